@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Stops the plan unless SDDC Manager has been backed up successfully during the last day
+data "vcf_backups" "recent" {
+  require_successful_within = "24h"
+}
+
+output "last_successful_backup" {
+  value = "${data.vcf_backups.recent.last_successful_backup_time} to ${data.vcf_backups.recent.target}"
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_ceip" "ceip" {}
+
+# Fail the plan if CEIP is enabled against the policy
+resource "terraform_data" "ceip_compliance" {
+  lifecycle {
+    precondition {
+      condition     = !data.vcf_ceip.ceip.is_enabled
+      error_message = "CEIP is ${data.vcf_ceip.ceip.status}, the policy requires it to be disabled."
+    }
+  }
+}
@@ -0,0 +1,17 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+data "vcf_credentials_expiration" "esxi_expiring" {
+  resource_type       = "ESXI"
+  expires_within_days = 14
+  refresh             = true
+}
+
+output "esxi_expiring_accounts" {
+  value = [for cred in data.vcf_credentials_expiration.esxi_expiring.credentials : "${cred.user_name}@${cred.resource_name}"]
+}
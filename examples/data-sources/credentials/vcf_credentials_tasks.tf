@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+    local = {
+      source = "hashicorp/local"
+    }
+  }
+}
+
+# Exports the password operations of the last quarter as compliance evidence
+data "vcf_credentials_tasks" "last_quarter" {
+  types = ["ROTATE", "UPDATE"]
+  since = timeadd(plantimestamp(), "-2160h")
+}
+
+resource "local_file" "password_audit" {
+  filename = "${path.module}/password_audit.json"
+  content  = jsonencode(data.vcf_credentials_tasks.last_quarter.tasks)
+}
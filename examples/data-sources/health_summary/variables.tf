@@ -0,0 +1,14 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN of an SDDC Manager instance"
+  default     = ""
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_health_summary" "sddc_manager" {
+}
+
+# Fails the plan while SDDC Manager is unhealthy
+resource "terraform_data" "healthy" {
+  lifecycle {
+    precondition {
+      condition     = data.vcf_health_summary.sddc_manager.is_healthy
+      error_message = "SDDC Manager is unhealthy: ${join("; ", data.vcf_health_summary.sddc_manager.issues)}"
+    }
+  }
+}
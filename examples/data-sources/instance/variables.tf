@@ -0,0 +1,24 @@
+variable "cloud_builder_username" {
+  description = "Username to authenticate to CloudBuilder"
+  default     = ""
+}
+
+variable "cloud_builder_password" {
+  description = "Password to authenticate to CloudBuilder"
+  default     = ""
+}
+
+variable "cloud_builder_host" {
+  description = "Fully qualified domain name or IP address of the CloudBuilder"
+  default     = ""
+}
+
+variable "sddc_manager_root_user_password" {
+  description = "Root user password for the SDDC Manager VM. Password needs to be a strong password with at least one alphabet and one special character and at least 8 characters in length"
+  default     = ""
+}
+
+variable "sddc_manager_secondary_user_password" {
+  description = "Second user (vcf) password for the SDDC Manager VM.  Password needs to be a strong password with at least one alphabet and one special character and at least 8 characters in length."
+  default     = ""
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  cloud_builder_host     = var.cloud_builder_host
+  cloud_builder_username = var.cloud_builder_username
+  cloud_builder_password = var.cloud_builder_password
+}
+
+data "vcf_cloud_builder_status" "status" {
+}
+
+output "bringup_status" {
+  value = data.vcf_cloud_builder_status.status.bringup_status
+}
+
+# Whether a failed bring-up can be retried instead of validating the spec again
+output "can_resume" {
+  value = data.vcf_cloud_builder_status.status.can_resume
+}
+
+output "validation_failed_checks" {
+  value = data.vcf_cloud_builder_status.status.validation_failed_checks
+}
@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+    local = {
+      source = "hashicorp/local"
+    }
+  }
+}
+
+provider "vcf" {
+  cloud_builder_host     = var.cloud_builder_host
+  cloud_builder_username = var.cloud_builder_username
+  cloud_builder_password = var.cloud_builder_password
+}
+
+# Renders the bring-up spec from an existing spec, with the passwords from variables, without executing the bring-up
+data "vcf_instance_spec" "sddc_1" {
+  spec_json = file("${path.module}/sddc-spec.json")
+
+  sddc_manager {
+    ip_address = "10.0.0.4"
+    hostname   = "sddc-manager"
+    root_user_credentials {
+      username = "root"
+      password = var.sddc_manager_root_user_password
+    }
+    second_user_credentials {
+      username = "vcf"
+      password = var.sddc_manager_secondary_user_password
+    }
+  }
+}
+
+# Archives the rendered spec for review
+resource "local_sensitive_file" "sddc_spec" {
+  content  = data.vcf_instance_spec.sddc_1.json
+  filename = "${path.module}/rendered-sddc-spec.json"
+}
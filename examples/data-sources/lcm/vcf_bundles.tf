@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Lists the vCenter bundles for the target version which are not staged yet
+data "vcf_bundles" "vcenter_pending" {
+  component_type  = "VCENTER"
+  version         = var.vcenter_target_version
+  download_status = ["PENDING", "FAILED"]
+}
+
+output "vcenter_bundles_to_download" {
+  value = [for bundle in data.vcf_bundles.vcenter_pending.bundles : bundle.id]
+}
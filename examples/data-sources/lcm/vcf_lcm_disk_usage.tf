@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_lcm_disk_usage" "usage" {
+  appliance_password = var.appliance_vcf_password
+}
+
+# Warns when the LCM repository has no room left to stage the next bundles
+check "lcm_repository_space" {
+  assert {
+    condition     = data.vcf_lcm_disk_usage.usage.used_percent < 80
+    error_message = "The LCM repository is ${data.vcf_lcm_disk_usage.usage.used_percent}% full, consider deleting bundles with vcf_bundle_cleanup"
+  }
+}
+
+output "bundle_gb" {
+  value = data.vcf_lcm_disk_usage.usage.bundle_bytes / 1073741824
+}
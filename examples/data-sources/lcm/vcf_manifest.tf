@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_manifest" "manifest" {
+}
+
+# Warns when SDDC Manager is more than one release behind the latest known release
+check "at_most_n_minus_1" {
+  assert {
+    condition     = data.vcf_manifest.manifest.releases_behind <= 1
+    error_message = "VCF ${data.vcf_manifest.manifest.version} is ${data.vcf_manifest.manifest.releases_behind} releases behind ${data.vcf_manifest.manifest.latest_version}"
+  }
+}
+
+output "upgrade_targets" {
+  value = data.vcf_manifest.manifest.upgrade_targets
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Lists the releases the management domain can be upgraded to
+data "vcf_releases" "management_targets" {
+  domain_id = var.management_domain_id
+}
+
+locals {
+  target_release = one([
+    for release in data.vcf_releases.management_targets.releases : release
+    if release.version == data.vcf_releases.management_targets.latest_version
+  ])
+}
+
+output "target_vcenter_version" {
+  value = one([for component in local.target_release.bom : component.version if component.name == "VCENTER"])
+}
@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_upgradables" "management" {
+  domain_id = var.management_domain_id
+}
+
+# Stages the bundles of the components which can be upgraded next
+resource "vcf_bundle_download" "next" {
+  for_each = {
+    for upgradable in data.vcf_upgradables.management.upgradables : upgradable.bundle_id => upgradable
+    if upgradable.status == "AVAILABLE" && length(upgradable.prerequisite_bundle_ids) == 0
+  }
+
+  bundle_id = each.key
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# The ESXi license keys with enough CPUs left for 4 hosts with 2 sockets each
+data "vcf_license_keys" "esxi" {
+  product_type  = "ESXI"
+  statuses      = ["ACTIVE", "NEVER_EXPIRES"]
+  min_remaining = 8
+}
+
+output "esxi_license_key_id" {
+  # The key with the most units left is the first one
+  value = data.vcf_license_keys.esxi.license_keys[0].id
+}
@@ -0,0 +1,20 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN or IP Address of an SDDC Manager instance"
+  default     = ""
+}
+
+
+variable "personality_name" {
+  description = "The name of the personality the cluster image is created from"
+  default     = ""
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Looks up a personality by name so that image-based clusters do not need to hardcode its ID
+data "vcf_personalities" "personality" {
+  name = var.personality_name
+}
+
+output "cluster_image_id" {
+  value = data.vcf_personalities.personality.personalities[0].id
+}
+
+output "esxi_version" {
+  value = data.vcf_personalities.personality.personalities[0].esxi_version
+}
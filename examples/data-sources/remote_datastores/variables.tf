@@ -0,0 +1,19 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "cluster_id" {
+  description = "ID of the cluster to mount the remote datastores on"
+  default     = ""
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_remote_datastores" "cluster" {
+  cluster_id = var.cluster_id
+}
+
+# The remote vSAN datastores with at least 1 TB free
+output "mountable_datastore_ids" {
+  value = [for datastore in data.vcf_remote_datastores.cluster.mountable_datastores : datastore.id if datastore.free_capacity_gb >= 1024]
+}
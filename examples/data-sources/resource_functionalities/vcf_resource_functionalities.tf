@@ -0,0 +1,45 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_domain" "wld" {
+  name = var.workload_domain_name
+}
+
+data "vcf_resource_functionalities" "wld" {
+  resource_type = "DOMAIN"
+  resource_ids  = [data.vcf_domain.wld.id]
+}
+
+locals {
+  disallowed = flatten([
+    for resource in data.vcf_resource_functionalities.wld.resources : [
+      for functionality in resource.functionalities : "${functionality.type}: ${functionality.error_message}"
+      if !functionality.is_allowed
+    ]
+  ])
+}
+
+# Fails the plan with the reasons SDDC Manager gives while operations are not allowed on the workload domain
+resource "terraform_data" "domain_operations_allowed" {
+  lifecycle {
+    precondition {
+      condition     = data.vcf_resource_functionalities.wld.is_allowed_globally
+      error_message = data.vcf_resource_functionalities.wld.global_error_message
+    }
+    precondition {
+      condition     = length(local.disallowed) == 0
+      error_message = "Operations not allowed on ${var.workload_domain_name}: ${join("; ", local.disallowed)}"
+    }
+  }
+}
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_resource_warnings" "major" {
+  severities = ["MAJOR"]
+}
+
+output "major_warnings" {
+  value = [for warning in data.vcf_resource_warnings.major.warnings : "${warning.resource_name}: ${warning.message}"]
+}
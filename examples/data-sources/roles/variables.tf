@@ -0,0 +1,29 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN or IP Address of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sso_domain" {
+  description = "The SSO domain of the groups"
+  default     = "rainpole.io"
+}
+
+variable "group_roles" {
+  description = "The names of the roles to assign to the groups of the SSO domain, by group name"
+  type        = map(string)
+  default = {
+    "gg-vcf-admins"    = "ADMIN"
+    "gg-vcf-operators" = "OPERATOR"
+    "gg-vcf-viewers"   = "VIEWER"
+  }
+}
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_roles" "all" {
+}
+
+# Assign the roles to the groups, the plan fails when a role does not exist
+resource "vcf_user" "groups" {
+  for_each = var.group_roles
+
+  name      = each.key
+  domain    = var.sso_domain
+  type      = "GROUP"
+  role_name = one([for role in data.vcf_roles.all.roles : role.name if role.name == each.value])
+}
+
+output "role_ids" {
+  value = { for role in data.vcf_roles.all.roles : role.name => role.id }
+}
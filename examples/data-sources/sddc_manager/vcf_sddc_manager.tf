@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_sddc_manager" "sddc_manager" {}
+
+locals {
+  vcf_major = data.vcf_sddc_manager.sddc_manager.major_version
+  vcf_minor = data.vcf_sddc_manager.sddc_manager.minor_version
+  # vSAN ESA is available from VCF 5.1
+  vsan_esa_supported = local.vcf_major > 5 || (local.vcf_major == 5 && local.vcf_minor >= 1)
+}
+
+output "vsan_esa_supported" {
+  value = local.vsan_esa_supported
+}
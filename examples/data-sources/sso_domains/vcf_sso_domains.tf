@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_sso_domains" "all" {
+}
+
+# The workload domains deployed with an isolated SSO domain
+output "isolated_domains" {
+  value = flatten([for sso in data.vcf_sso_domains.all.sso_domains : sso.domain_names if !sso.is_management_sso_domain])
+}
@@ -0,0 +1,19 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "workload_domain_name" {
+  description = "Name of the workload domain"
+  default     = ""
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_domain" "wld" {
+  name = var.workload_domain_name
+}
+
+data "vcf_tasks" "running" {
+  resource_id = data.vcf_domain.wld.id
+  statuses    = ["PENDING", "IN_PROGRESS"]
+}
+
+# Fails the plan while a task is running against the workload domain
+resource "terraform_data" "domain_idle" {
+  lifecycle {
+    precondition {
+      condition     = length(data.vcf_tasks.running.tasks) == 0
+      error_message = "Tasks are running against ${var.workload_domain_name}: ${join(", ", data.vcf_tasks.running.tasks[*].name)}"
+    }
+  }
+}
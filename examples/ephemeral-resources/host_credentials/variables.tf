@@ -0,0 +1,19 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN or IP Address of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "esxi_host_fqdn" {
+  description = "The fully qualified domain name of the ESXi host to bootstrap"
+  default     = ""
+}
@@ -0,0 +1,33 @@
+terraform {
+  required_version = ">= 1.10"
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+ephemeral "vcf_host_credentials" "esxi_root" {
+  fqdn = var.esxi_host_fqdn
+}
+
+resource "terraform_data" "bootstrap" {
+  input = var.esxi_host_fqdn
+
+  connection {
+    type     = "ssh"
+    host     = var.esxi_host_fqdn
+    user     = ephemeral.vcf_host_credentials.esxi_root.user_name
+    password = ephemeral.vcf_host_credentials.esxi_root.password
+  }
+
+  provisioner "remote-exec" {
+    inline = ["esxcli system hostname get"]
+  }
+}
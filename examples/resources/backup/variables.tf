@@ -0,0 +1,57 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN or IP Address of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "backup_user_name" {
+  description = "The user name of the backup server account"
+  default     = ""
+}
+
+variable "backup_password" {
+  description = "The password of the backup server account"
+  default     = ""
+  sensitive   = true
+}
+
+variable "backup_password_version" {
+  description = "The version of the backup server password, increase it after each rotation"
+  default     = 1
+}
+
+variable "backup_server" {
+  description = "The FQDN of the backup server"
+  default     = ""
+}
+
+variable "backup_ssh_fingerprint" {
+  description = "The SSH fingerprint of the backup server"
+  default     = ""
+}
+
+variable "backup_encryption_passphrase" {
+  description = "The passphrase used to encrypt the backup files"
+  default     = ""
+  sensitive   = true
+}
+
+variable "backup_encryption_passphrase_version" {
+  description = "The version of the backup encryption passphrase, increase it after each rotation"
+  default     = 1
+}
+
+variable "appliance_vcf_password" {
+  description = "Password of the vcf user of the SDDC Manager appliance"
+  sensitive   = true
+  default     = ""
+}
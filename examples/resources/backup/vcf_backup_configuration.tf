@@ -0,0 +1,45 @@
+terraform {
+  required_version = ">= 1.11"
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Backs up SDDC Manager and the NSX Managers of all domains every night to the SFTP server
+resource "vcf_backup_configuration" "sftp" {
+  server          = var.backup_server
+  directory_path  = "/backups/sddc-manager"
+  user_name       = var.backup_user_name
+  password        = var.backup_password
+  ssh_fingerprint = var.backup_ssh_fingerprint
+
+  # Bump the version to rotate the passphrase, it is never stored in the state
+  encryption_passphrase_wo         = var.backup_encryption_passphrase
+  encryption_passphrase_wo_version = var.backup_encryption_passphrase_version
+
+  schedule {
+    frequency                   = "WEEKLY"
+    days_of_week                = ["MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY", "SUNDAY"]
+    hour_of_day                 = 2
+    minute_of_hour              = 0
+    take_backup_on_state_change = true
+
+    # Keeps the last 20 backups and one backup per day during two weeks
+    retention_policy {
+      number_of_most_recent_backups   = 20
+      number_of_days_of_daily_backups = 14
+    }
+  }
+
+  nsx_backup {
+    directory_path = "/backups/nsx"
+  }
+}
@@ -0,0 +1,21 @@
+terraform {
+  required_version = ">= 1.11"
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Push the password again whenever it is rotated on the backup server by bumping the version
+resource "vcf_backup_credentials" "sftp" {
+  user_name           = var.backup_user_name
+  password_wo         = var.backup_password
+  password_wo_version = var.backup_password_version
+}
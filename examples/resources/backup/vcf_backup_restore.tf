@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+# Connects to the newly deployed SDDC Manager appliance which replaces the failed one
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Copies the backup from the SFTP server to the appliance and restores SDDC Manager from it
+resource "vcf_backup_restore" "sddc_manager" {
+  backup_file           = "/backups/sddc-manager/vcf-backup-sddc-manager-2024-06-01-02-00-00.tar.gz"
+  encryption_passphrase = var.backup_encryption_passphrase
+  appliance_password    = var.appliance_vcf_password
+
+  backup_server {
+    server          = var.backup_server
+    user_name       = var.backup_user_name
+    password        = var.backup_password
+    ssh_fingerprint = var.backup_ssh_fingerprint
+  }
+
+  # The restore of a large inventory can outlast the default of 4 hours
+  timeouts {
+    create = "8h"
+  }
+}
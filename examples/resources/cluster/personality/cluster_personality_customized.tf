@@ -0,0 +1,20 @@
+# Adds the vendor add-on and the firmware package of the hardware support manager to the image of the
+# seed cluster before the personality is extracted, so the personality contains the full host image
+resource "vcf_cluster_personality" "personality_customized" {
+  name       = "personality3"
+  cluster_id = var.cluster_id
+  domain_id  = var.domain_id
+  image_customization {
+    vcenter_username = var.vcenter_username
+    vcenter_password = var.vcenter_password
+    add_on {
+      name    = "DEL-ESXi"
+      version = "803.22348816-A04"
+    }
+    hardware_support {
+      manager = "com.dell.om"
+      package = "DELL-HSP"
+      version = "1.0"
+    }
+  }
+}
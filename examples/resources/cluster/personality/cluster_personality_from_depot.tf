@@ -0,0 +1,9 @@
+resource "vcf_cluster_personality" "personality_from_depot" {
+  name = "personality2"
+  image_depot {
+    zip_file           = var.image_zip_file
+    json_file          = var.image_json_file
+    info_json_file     = var.image_info_json_file
+    appliance_password = var.appliance_vcf_password
+  }
+}
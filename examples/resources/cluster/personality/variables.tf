@@ -22,3 +22,33 @@ variable "domain_id" {
   description = "Id of the domain in which the cluster is to be created"
   default     = ""
 }
+
+variable "image_zip_file" {
+  description = "Local path or URL of the offline depot ZIP file of a cluster image exported from vSphere Lifecycle Manager"
+  default     = ""
+}
+
+variable "image_json_file" {
+  description = "Local path or URL of the JSON specification of the exported cluster image"
+  default     = ""
+}
+
+variable "image_info_json_file" {
+  description = "Local path or URL of the JSON file with the information about the exported cluster image"
+  default     = ""
+}
+
+variable "appliance_vcf_password" {
+  description = "Password of the vcf user of the SDDC Manager appliance, used to copy the image files over SSH"
+  default     = ""
+}
+
+variable "vcenter_username" {
+  description = "User of the vCenter server of the domain allowed to change the image of the source cluster"
+  default     = ""
+}
+
+variable "vcenter_password" {
+  description = "Password of the vCenter server user"
+  default     = ""
+}
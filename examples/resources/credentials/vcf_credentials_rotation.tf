@@ -0,0 +1,48 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+    time = {
+      source = "hashicorp/time"
+    }
+  }
+}
+
+data "vcf_credentials" "esxi_creds" {
+  resource_type = "ESXI"
+  account_type  = "USER"
+}
+
+resource "time_rotating" "every_30_days" {
+  rotation_days = 30
+}
+
+resource "vcf_credentials_rotation" "esxi_rotation" {
+  dynamic "resource" {
+    for_each = data.vcf_credentials.esxi_creds.credentials
+    content {
+      resource_name = resource.value.resource[0].name
+      resource_type = resource.value.resource[0].type
+      credentials {
+        credential_type = resource.value.credential_type
+        user_name       = resource.value.user_name
+      }
+    }
+  }
+  rotate_on = time_rotating.every_30_days.id
+}
+
+resource "time_rotating" "quarterly" {
+  rotation_months = 3
+}
+
+# Rotates all NSX and vCenter accounts in all domains, two accounts per credentials task
+resource "vcf_credentials_rotation" "quarterly_campaign" {
+  selector {
+    resource_types = ["VCENTER", "NSXT_MANAGER"]
+  }
+  batch_size          = 2
+  continue_on_failure = true
+  rotate_on           = time_rotating.quarterly.id
+}
@@ -0,0 +1,13 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+# Retries the most recent failed credentials task, change recover_on to retry again
+resource "vcf_credentials_task_recovery" "retry_failed_rotation" {
+  action     = "RETRY"
+  recover_on = "2024-06-01"
+}
@@ -15,3 +15,15 @@ resource "vcf_credentials_update" "vc_0_update" {
     password        = var.esxi_host_1_pass
   }
 }
+
+# Requires Terraform 1.11 or later, the password is never stored in the state
+resource "vcf_credentials_update" "vc_0_update_wo" {
+  resource_name       = var.esxi_host_1
+  resource_type       = "ESXI"
+  password_wo_version = 1
+  credentials {
+    credential_type = "SSH"
+    user_name       = "root"
+    password_wo     = var.esxi_host_1_pass
+  }
+}
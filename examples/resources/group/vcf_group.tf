@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_group" "admins" {
+  name      = "gg-vcf-admins"
+  domain    = var.group_domain
+  role_name = "ADMIN"
+}
+
+resource "vcf_group" "operators" {
+  name      = "gg-vcf-operators"
+  domain    = var.group_domain
+  role_name = "OPERATOR"
+}
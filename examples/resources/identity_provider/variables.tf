@@ -0,0 +1,30 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "entra_tenant_id" {
+  description = "The ID of the Microsoft Entra ID tenant"
+  default     = ""
+}
+
+variable "entra_client_id" {
+  description = "The application ID of the vCenter application registered in Microsoft Entra ID"
+  default     = ""
+}
+
+variable "entra_client_secret" {
+  description = "The client secret of the vCenter application"
+  sensitive   = true
+  default     = ""
+}
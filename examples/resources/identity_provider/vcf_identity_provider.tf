@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_identity_provider" "entra" {
+  name               = "rainpole-entra"
+  type               = "Microsoft Entra ID"
+  client_id          = var.entra_client_id
+  client_secret      = var.entra_client_secret
+  discovery_endpoint = "https://login.microsoftonline.com/${var.entra_tenant_id}/v2.0/.well-known/openid-configuration"
+
+  directory {
+    name           = "rainpole"
+    default_domain = "rainpole.io"
+    domains        = ["rainpole.io"]
+  }
+}
@@ -0,0 +1,30 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "ldap_bind_username" {
+  description = "User name of the Active Directory user the identity provider binds with"
+  default     = "svc-vsphere-ad@rainpole.io"
+}
+
+variable "ldap_bind_password" {
+  description = "Password of the Active Directory bind user"
+  sensitive   = true
+  default     = ""
+}
+
+variable "ldap_certificate_file" {
+  description = "Path of the PEM encoded certificate of the domain controllers"
+  default     = "rainpole-root-ca.pem"
+}
@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_identity_source" "rainpole" {
+  name           = "rainpole.io"
+  domain_name    = "rainpole.io"
+  domain_alias   = "RAINPOLE"
+  type           = "ActiveDirectory"
+  username       = var.ldap_bind_username
+  password       = var.ldap_bind_password
+  users_base_dn  = "ou=Users,dc=rainpole,dc=io"
+  groups_base_dn = "ou=Groups,dc=rainpole,dc=io"
+  server_endpoints = [
+    "ldaps://dc01.rainpole.io:636",
+    "ldaps://dc02.rainpole.io:636",
+  ]
+  cert_chain = [file(var.ldap_certificate_file)]
+}
+
+# Grant the administrators of the domain access to SDDC Manager
+resource "vcf_user" "sddc_admins" {
+  name      = "gg-vcf-admins"
+  domain    = vcf_identity_source.rainpole.domain_name
+  type      = "GROUP"
+  role_name = "ADMIN"
+}
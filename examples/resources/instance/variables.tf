@@ -76,4 +76,9 @@ variable "vcenter_license_key" {
 variable "vsan_license_key" {
   description = "vSAN license key to be used"
   default     = ""
-}
\ No newline at end of file
+}
+
+variable "sso_admin_password" {
+  description = "Password of the SSO administrator set in the bring-up spec"
+  default     = ""
+}
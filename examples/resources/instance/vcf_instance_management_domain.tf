@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  cloud_builder_host     = var.cloud_builder_host
+  cloud_builder_username = var.cloud_builder_username
+  cloud_builder_password = var.cloud_builder_password
+}
+
+# Reads the management domain from the new SDDC Manager once the bring-up completes
+resource "vcf_instance" "sddc_with_management_domain" {
+  spec_json                = file("${path.module}/sddc-spec.json")
+  import_management_domain = true
+}
+
+# Manages the new instance with the endpoints exposed by the bring-up
+provider "vcf" {
+  alias                 = "sddc_manager"
+  sddc_manager_host     = vcf_instance.sddc_with_management_domain.sddc_manager_fqdn
+  sddc_manager_username = "administrator@vsphere.local"
+  sddc_manager_password = var.sso_admin_password
+}
+
+data "vcf_domain" "management" {
+  provider  = vcf.sddc_manager
+  domain_id = vcf_instance.sddc_with_management_domain.management_domain_id
+}
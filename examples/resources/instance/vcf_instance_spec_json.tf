@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  cloud_builder_host     = var.cloud_builder_host
+  cloud_builder_username = var.cloud_builder_username
+  cloud_builder_password = var.cloud_builder_password
+}
+
+# Brings up the instance from a validated Cloud Builder spec. The arguments set here take precedence over
+# the properties of the spec, the SDDC Manager credentials are kept out of the file.
+resource "vcf_instance" "sddc_from_spec" {
+  spec_json = file("${path.module}/sddc-spec.json")
+
+  sddc_manager {
+    ip_address = "10.0.0.4"
+    hostname   = "sddc-manager"
+    root_user_credentials {
+      username = "root"
+      password = var.sddc_manager_root_user_password
+    }
+    second_user_credentials {
+      username = "vcf"
+      password = var.sddc_manager_secondary_user_password
+    }
+  }
+}
@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+# The VCF Installer appliance is configured as Cloud Builder
+provider "vcf" {
+  cloud_builder_host     = var.cloud_builder_host
+  cloud_builder_username = var.cloud_builder_username
+  cloud_builder_password = var.cloud_builder_password
+}
+
+# Deploys a VCF 9 instance with the VCF Installer. The specs new in VCF 9, e.g. vcfOperationsSpec,
+# are read from the spec file and submitted as is.
+resource "vcf_instance" "sddc_vcf9" {
+  version   = "9.0.0.0"
+  spec_json = file("${path.module}/vcf9-spec.json")
+
+  sddc_manager {
+    ip_address = "10.0.0.4"
+    hostname   = "sddc-manager"
+    root_user_credentials {
+      username = "root"
+      password = var.sddc_manager_root_user_password
+    }
+    second_user_credentials {
+      username = "vcf"
+      password = var.sddc_manager_secondary_user_password
+    }
+  }
+}
@@ -0,0 +1,36 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN or IP Address of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "vcenter_target_version" {
+  description = "The vCenter version to stage the upgrade bundle for, e.g. 8.0.2.00100-22617221"
+  default     = ""
+}
+
+variable "appliance_vcf_password" {
+  description = "Password of the vcf user of the SDDC Manager appliance"
+  sensitive   = true
+  default     = ""
+}
+
+variable "vcenter_applied_version" {
+  description = "The vCenter version the domains already run, which bundles are no longer needed"
+  default     = ""
+}
+
+variable "appliance_root_password" {
+  description = "Password of the root user of the SDDC Manager appliance"
+  sensitive   = true
+  default     = ""
+}
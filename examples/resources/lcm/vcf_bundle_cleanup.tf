@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Lists the downloaded vCenter bundles of the release the domains have already been upgraded to
+data "vcf_bundles" "vcenter_applied" {
+  component_type  = "VCENTER"
+  version         = var.vcenter_applied_version
+  download_status = ["SUCCESSFUL"]
+}
+
+# Frees the space of the LCM repository used by these bundles
+resource "vcf_bundle_cleanup" "vcenter_applied" {
+  bundle_ids              = [for bundle in data.vcf_bundles.vcenter_applied.bundles : bundle.id]
+  appliance_password      = var.appliance_vcf_password
+  appliance_root_password = var.appliance_root_password
+}
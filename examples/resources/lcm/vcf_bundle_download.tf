@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Stages the vCenter upgrade bundle ahead of the maintenance window
+resource "vcf_bundle_download" "vcenter" {
+  component_type = "VCENTER"
+  version        = var.vcenter_target_version
+  image_type     = "PATCH"
+
+  timeouts {
+    create = "6h"
+  }
+}
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Uploads a bundle published on the internal web server of a dark site
+resource "vcf_bundle_upload" "vcenter" {
+  bundle_file        = "https://repo.vcf.internal/bundles/bundle-124941.tar"
+  manifest_file      = "https://repo.vcf.internal/bundles/bundle-124941.manifest"
+  signature_file     = "https://repo.vcf.internal/bundles/bundle-124941.manifest.sig"
+  appliance_password = var.appliance_vcf_password
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_license_key" "esxi" {
+  product_type = "ESXI"
+  key          = var.esxi_license_key
+  description  = "ESXi license key of the workload domains"
+}
+
+output "esxi_license_remaining" {
+  value = "${vcf_license_key.esxi.remaining} of ${vcf_license_key.esxi.total} ${vcf_license_key.esxi.license_unit} left"
+}
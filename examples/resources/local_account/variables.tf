@@ -0,0 +1,32 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "local_account_password" {
+  description = "The password of the admin@local account, e.g. read from a secrets manager"
+  sensitive   = true
+  ephemeral   = true
+}
+
+variable "local_account_old_password" {
+  description = "The current password of the admin@local account, empty if it is not configured yet"
+  sensitive   = true
+  ephemeral   = true
+  default     = null
+}
+
+variable "local_account_password_version" {
+  description = "The version of the password of the admin@local account, bump it to rotate the password"
+  default     = 1
+}
@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# To rotate the password, set the new one, pass the previous one and bump local_account_password_version
+resource "vcf_local_account" "admin" {
+  password_wo         = var.local_account_password
+  old_password_wo     = var.local_account_old_password
+  password_wo_version = var.local_account_password_version
+}
@@ -0,0 +1,25 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN or IP Address of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "depot_username" {
+  description = "Username used to authenticate against the offline depot"
+  default     = ""
+}
+
+variable "depot_password" {
+  description = "Password used to authenticate against the offline depot"
+  sensitive   = true
+  default     = ""
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Points SDDC Manager of an air-gapped site to the internal depot, signed by the internal CA
+resource "vcf_offline_depot" "depot" {
+  hostname    = "depot.vcf.internal"
+  port        = 8443
+  username    = var.depot_username
+  password    = var.depot_password
+  certificate = file("${path.module}/internal-ca.pem")
+}
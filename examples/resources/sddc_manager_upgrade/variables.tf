@@ -0,0 +1,25 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN or IP Address of an SDDC Manager instance"
+  default     = ""
+}
+
+
+variable "domain_id" {
+  description = "The ID of the workload domain to upgrade once SDDC Manager is upgraded"
+  default     = ""
+}
+
+variable "target_version" {
+  description = "The VCF release to upgrade SDDC Manager and the workload domain to, e.g. 5.2.1.0"
+  default     = ""
+}
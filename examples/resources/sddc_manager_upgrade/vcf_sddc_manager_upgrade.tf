@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# SDDC Manager has to run the target release before the domains are upgraded
+resource "vcf_sddc_manager_upgrade" "sddc_manager" {
+  target_version = var.target_version
+}
+
+resource "vcf_upgrade" "workload_domain" {
+  domain_id      = var.domain_id
+  target_version = vcf_sddc_manager_upgrade.sddc_manager.target_version
+}
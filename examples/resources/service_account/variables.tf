@@ -0,0 +1,23 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "pipelines" {
+  description = "The roles of the service accounts of the pipelines, by pipeline name"
+  type        = map(string)
+  default = {
+    "network"   = "OPERATOR"
+    "reporting" = "VIEWER"
+  }
+}
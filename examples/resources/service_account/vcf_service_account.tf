@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+    vault = {
+      source = "hashicorp/vault"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Each pipeline gets its own service account
+resource "vcf_service_account" "pipeline" {
+  for_each = var.pipelines
+
+  name      = "svc-${each.key}"
+  role_name = each.value
+}
+
+ephemeral "vcf_service_account_token" "pipeline" {
+  for_each = vcf_service_account.pipeline
+
+  service_account_id = each.value.id
+}
+
+# The API key is handed over with a write-only argument, it is never stored in the state
+resource "vault_kv_secret_v2" "pipeline" {
+  for_each = vcf_service_account.pipeline
+
+  mount                = "secret"
+  name                 = "vcf/${each.key}"
+  data_json_wo         = jsonencode({ api_key = ephemeral.vcf_service_account_token.pipeline[each.key].api_key })
+  data_json_wo_version = 1
+}
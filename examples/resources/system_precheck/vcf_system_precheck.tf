@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Checks the health of the domain on every pipeline run and blocks on failures and warnings
+resource "vcf_system_precheck" "domain" {
+  resource {
+    type = "DOMAIN"
+    id   = var.domain_id
+  }
+  fail_on = "WARNING"
+  run_on  = plantimestamp()
+}
+
+output "precheck_results" {
+  value = [for result in vcf_system_precheck.domain.results : "${result.name}: ${result.status}"]
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_tag_category" "tier" {
+  domain_id        = var.domain_id
+  name             = "tier"
+  description      = "Service tier of the workloads"
+  cardinality      = "SINGLE"
+  associable_types = ["ClusterComputeResource", "HostSystem"]
+}
+
+resource "vcf_tag" "gold" {
+  domain_id   = var.domain_id
+  category_id = vcf_tag_category.tier.id
+  name        = "gold"
+  description = "Production workloads"
+}
@@ -0,0 +1,24 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default     = ""
+}
+
+variable "sddc_manager_host" {
+  description = "FQDN of an SDDC Manager instance"
+  default     = ""
+}
+
+variable "domain_id" {
+  description = "ID of the domain of the vCenter Server the tags are created in"
+  default     = ""
+}
+
+variable "cluster_id" {
+  description = "ID of the cluster to tag"
+  default     = ""
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_tag_category" "tier" {
+  domain_id        = var.domain_id
+  name             = "tier"
+  cardinality      = "SINGLE"
+  associable_types = ["ClusterComputeResource"]
+}
+
+resource "vcf_tag" "gold" {
+  domain_id   = var.domain_id
+  category_id = vcf_tag_category.tier.id
+  name        = "gold"
+}
+
+resource "vcf_tag_assignment" "cluster" {
+  resource_type = "CLUSTER"
+  resource_id   = var.cluster_id
+  tag_ids       = [vcf_tag.gold.id]
+}
@@ -0,0 +1,35 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+# Upgrades the workload domain during the next maintenance window
+resource "vcf_upgrade" "workload_domain" {
+  domain_id      = var.domain_id
+  target_version = var.target_version
+
+  maintenance_window {
+    start = "2025-06-14T22:00:00Z"
+    end   = "2025-06-15T06:00:00Z"
+  }
+
+  vcenter_temporary_network {
+    ip_address  = "10.0.0.250"
+    subnet_mask = "255.255.255.0"
+    gateway     = "10.0.0.1"
+  }
+
+  timeouts {
+    create = "24h"
+  }
+}
+
@@ -0,0 +1,166 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/client/certificates"
+	"github.com/vmware/vcf-sdk-go/client/sddc_managers"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// ApiFeature is an API of SDDC Manager which changed across VCF versions, it is available from the version of VCF
+// which introduced it.
+type ApiFeature struct {
+	name  string
+	since *version.Version
+}
+
+var (
+	// ResourceCertificatesApi reads the certificates of the resources of a domain with their validity from
+	// /v1/domains/{id}/resource-certificates. The former /v1/domains/{id}/certificates of VCF 4.x is used before.
+	ResourceCertificatesApi = ApiFeature{name: "resource certificates", since: version.Must(version.NewVersion("5.0"))}
+	// PasswordExpirationApi checks the expiration of the passwords of the credentials with /v1/credentials/expirations.
+	PasswordExpirationApi = ApiFeature{name: "password expiration", since: version.Must(version.NewVersion("5.0"))}
+)
+
+// certificateExpiringDays is the number of days before the end of the validity of a certificate from which it is
+// reported as expiring, for the certificates of VCF 4.x which have no expiration status.
+const certificateExpiringDays = 30
+
+// VcfVersion returns the version of SDDC Manager without the build number, e.g. 5.2.1.0. It is read once for the
+// life of the client, the following calls return the same version or the same error. The concurrent first calls
+// wait for the single read, which is not cancelled with the context of the caller so that its outcome does not
+// depend on which caller made it.
+func (sddcManagerClient *SddcManagerClient) VcfVersion(ctx context.Context) (*version.Version, error) {
+	sddcManagerClient.versionOnce.Do(func() {
+		sddcManagerClient.vcfVersion, sddcManagerClient.vcfVersionErr = sddcManagerClient.readVcfVersion(
+			context.WithoutCancel(ctx))
+	})
+	return sddcManagerClient.vcfVersion, sddcManagerClient.vcfVersionErr
+}
+
+func (sddcManagerClient *SddcManagerClient) readVcfVersion(ctx context.Context) (*version.Version, error) {
+	result, err := sddcManagerClient.ApiClient.SDDCManagers.GetSDDCManagers(
+		sddc_managers.NewGetSDDCManagersParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil || len(result.Payload.Elements) == 0 {
+		return nil, fmt.Errorf("no SDDC Manager found")
+	}
+	// The version has the build number, e.g. 5.2.1.0-24307856
+	sddcManagerVersion, _, _ := strings.Cut(result.Payload.Elements[0].Version, "-")
+	parsedVersion, err := version.NewVersion(sddcManagerVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q of SDDC Manager: %w", result.Payload.Elements[0].Version, err)
+	}
+	return parsedVersion, nil
+}
+
+// Supports returns whether SDDC Manager has the API of the feature. The API is assumed to be available when the
+// version of SDDC Manager cannot be read, so that the call reports the actual error.
+func (sddcManagerClient *SddcManagerClient) Supports(ctx context.Context, feature ApiFeature) bool {
+	vcfVersion, err := sddcManagerClient.VcfVersion(ctx)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Cannot read the version of SDDC Manager, assuming the %s API is available: %s",
+			feature.name, err))
+		return true
+	}
+	return vcfVersion.GreaterThanOrEqual(feature.since)
+}
+
+// Require returns an error telling the VCF version required by the feature if SDDC Manager does not have its API.
+func (sddcManagerClient *SddcManagerClient) Require(ctx context.Context, feature ApiFeature) error {
+	if sddcManagerClient.Supports(ctx, feature) {
+		return nil
+	}
+	vcfVersion, _ := sddcManagerClient.VcfVersion(ctx)
+	return fmt.Errorf("the %s API requires VCF %s or later, SDDC Manager %s does not support it",
+		feature.name, feature.since, vcfVersion)
+}
+
+// GetCertificatesOfDomain reads the certificates of the resources of the domain with the API of the version of
+// SDDC Manager. The certificates of VCF 4.x are completed with the fields they lack, e.g. the expiration status.
+func (sddcManagerClient *SddcManagerClient) GetCertificatesOfDomain(ctx context.Context, domainId string) ([]*models.Certificate, error) {
+	apiClient := sddcManagerClient.ApiClient
+	if sddcManagerClient.Supports(ctx, ResourceCertificatesApi) {
+		params := certificates.NewGetCertificatesByDomainParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(domainId)
+		result, _, err := apiClient.Certificates.GetCertificatesByDomain(params)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil || result.Payload == nil {
+			return nil, nil
+		}
+		return result.Payload.Elements, nil
+	}
+
+	params := certificates.NewGetDomainCertificatesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(domainId)
+	result, err := apiClient.Certificates.GetDomainCertificates(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return nil, nil
+	}
+	for _, cert := range result.Payload.Elements {
+		completeLegacyCertificate(cert, time.Now())
+	}
+	return result.Payload.Elements, nil
+}
+
+// completeLegacyCertificate sets the fields a certificate of VCF 4.x lacks: the days to the end of its validity and
+// its expiration status are computed from its validity, the other fields are empty.
+func completeLegacyCertificate(cert *models.Certificate, now time.Time) {
+	for _, field := range []**string{
+		&cert.IssuedBy, &cert.IssuedTo, &cert.KeySize, &cert.NotAfter, &cert.NotBefore, &cert.PemEncoded,
+		&cert.PublicKey, &cert.PublicKeyAlgorithm, &cert.SerialNumber, &cert.SignatureAlgorithm, &cert.Subject,
+		&cert.Thumbprint, &cert.ThumbprintAlgorithm, &cert.Version,
+	} {
+		if *field == nil {
+			*field = new(string)
+		}
+	}
+	if cert.IsInstalled == nil {
+		isInstalled := true
+		cert.IsInstalled = &isInstalled
+	}
+
+	notAfter, err := time.Parse(time.RFC3339, *cert.NotAfter)
+	if cert.NumberOfDaysToExpire == nil {
+		days := int32(0)
+		if err == nil {
+			days = int32(notAfter.Sub(now).Hours() / 24)
+		}
+		cert.NumberOfDaysToExpire = &days
+	}
+	if cert.ExpirationStatus == nil {
+		status := "UNKNOWN"
+		if err == nil {
+			switch {
+			case !notAfter.After(now):
+				status = "EXPIRED"
+			case *cert.NumberOfDaysToExpire <= certificateExpiringDays:
+				status = "EXPIRING"
+			default:
+				status = "ACTIVE"
+			}
+		}
+		cert.ExpirationStatus = &status
+	}
+}
@@ -0,0 +1,53 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+func TestCompleteLegacyCertificate(t *testing.T) {
+	now := time.Date(2026, 10, 9, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		notAfter string
+		days     int32
+		status   string
+	}{
+		{notAfter: "2027-10-09T10:00:00Z", days: 365, status: "ACTIVE"},
+		{notAfter: "2026-11-08T10:00:00Z", days: 30, status: "EXPIRING"},
+		{notAfter: "2026-10-09T10:00:00Z", days: 0, status: "EXPIRED"},
+		{notAfter: "2025-10-09T10:00:00Z", days: -365, status: "EXPIRED"},
+		{notAfter: "not a date", days: 0, status: "UNKNOWN"},
+	}
+
+	for _, test := range tests {
+		notAfter := test.notAfter
+		cert := &models.Certificate{NotAfter: &notAfter}
+		completeLegacyCertificate(cert, now)
+		assert.Equal(t, test.days, *cert.NumberOfDaysToExpire, test.notAfter)
+		assert.Equal(t, test.status, *cert.ExpirationStatus, test.notAfter)
+		assert.True(t, *cert.IsInstalled)
+		assert.NotNil(t, cert.Thumbprint)
+	}
+
+	// The fields a certificate already has are kept
+	notAfter := "2025-10-09T10:00:00Z"
+	days := int32(12)
+	status := "ACTIVE"
+	cert := &models.Certificate{NotAfter: &notAfter, NumberOfDaysToExpire: &days, ExpirationStatus: &status}
+	completeLegacyCertificate(cert, now)
+	assert.Equal(t, int32(12), *cert.NumberOfDaysToExpire)
+	assert.Equal(t, "ACTIVE", *cert.ExpirationStatus)
+
+	// A certificate without a validity has an unknown expiration
+	cert = &models.Certificate{}
+	completeLegacyCertificate(cert, now)
+	assert.Equal(t, int32(0), *cert.NumberOfDaysToExpire)
+	assert.Equal(t, "UNKNOWN", *cert.ExpirationStatus)
+}
@@ -73,3 +73,9 @@ func (c *cloudBuilderCustomHttpTransport) RoundTrip(r *http.Request) (*http.Resp
 
 	return resp, nil
 }
+
+// NewSddcManagerClient constructs a client of the SDDC Manager instance deployed by the CloudBuilder appliance,
+// with the same TLS settings.
+func (cloudBuilderClient *CloudBuilderClient) NewSddcManagerClient(username, password, url string) *SddcManagerClient {
+	return NewSddcManagerClient(username, password, url, cloudBuilderClient.allowUnverifiedTls)
+}
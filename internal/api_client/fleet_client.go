@@ -0,0 +1,113 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// FleetClient is an API client of the fleet management of VCF 9, which inventories the VCF instances of a fleet and
+// runs operations on several instances at once. The fleet management authenticates with access tokens like SDDC
+// Manager. The SDK has no model for its API, the requests are therefore sent as JSON documents.
+//
+// The client holds its own access token and TLS settings, so that a provider configuration connected to the fleet
+// management and another one connected to SDDC Manager do not replace each other's token.
+type FleetClient struct {
+	username        string
+	password        string
+	fleetUrl        string
+	httpClient      *http.Client
+	taskWait        TaskWaitSettings
+	tokenMutex      sync.Mutex
+	accessToken     string
+	lastRefreshTime time.Time
+}
+
+// NewFleetClient constructs a client of the fleet management with the credentials of a user of the fleet.
+func NewFleetClient(username, password, url string, allowUnverifiedTls bool) *FleetClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: allowUnverifiedTls}
+	return &FleetClient{
+		username:   username,
+		password:   password,
+		fleetUrl:   url,
+		httpClient: &http.Client{Transport: transport},
+		taskWait:   TaskWaitSettings{PollInterval: defaultPollingInterval},
+	}
+}
+
+// WithTaskWaitSettings makes the client wait for the fleet operations with the poll interval of the settings. The
+// default poll interval is kept if the one of the settings is not positive.
+func (fleetClient *FleetClient) WithTaskWaitSettings(settings TaskWaitSettings) *FleetClient {
+	if settings.PollInterval <= 0 {
+		settings.PollInterval = defaultPollingInterval
+	}
+	fleetClient.taskWait = settings
+	return fleetClient
+}
+
+// PollInterval returns the time between two reads of the status of a fleet operation.
+func (fleetClient *FleetClient) PollInterval() time.Duration {
+	return fleetClient.taskWait.PollInterval
+}
+
+// Host returns the FQDN or IP address of the fleet management.
+func (fleetClient *FleetClient) Host() string {
+	return fleetClient.fleetUrl
+}
+
+// Connect requests an access token from the fleet management.
+func (fleetClient *FleetClient) Connect() error {
+	fleetClient.tokenMutex.Lock()
+	defer fleetClient.tokenMutex.Unlock()
+	return fleetClient.connect(context.Background())
+}
+
+func (fleetClient *FleetClient) connect(ctx context.Context) error {
+	tokenSpec := &models.TokenCreationSpec{
+		Username: fleetClient.username,
+		Password: fleetClient.password,
+	}
+	tokenPair := &models.TokenPair{}
+	if err := sendJSONRequest(ctx, fleetClient.httpClient, fleetClient.fleetUrl, "", http.MethodPost, "/v1/tokens",
+		tokenSpec, tokenPair); err != nil {
+		return err
+	}
+	if tokenPair.AccessToken == "" {
+		return errors.New("the fleet management did not return an access token")
+	}
+	fleetClient.accessToken = tokenPair.AccessToken
+	fleetClient.lastRefreshTime = time.Now()
+	return nil
+}
+
+// getAccessToken returns the access token of the client, refreshed every 20 minutes as SDDC Manager does, since a
+// fleet operation can take hours.
+func (fleetClient *FleetClient) getAccessToken(ctx context.Context) (string, error) {
+	fleetClient.tokenMutex.Lock()
+	defer fleetClient.tokenMutex.Unlock()
+	if fleetClient.accessToken == "" || time.Since(fleetClient.lastRefreshTime) > 20*time.Minute {
+		if err := fleetClient.connect(ctx); err != nil {
+			return "", err
+		}
+	}
+	return fleetClient.accessToken, nil
+}
+
+// Do sends a request with the JSON encoded body, if not nil, and decodes the response into result, if not nil.
+func (fleetClient *FleetClient) Do(ctx context.Context, method, path string, body, result interface{}) error {
+	accessToken, err := fleetClient.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	return sendJSONRequest(ctx, fleetClient.httpClient, fleetClient.fleetUrl, accessToken, method, path, body, result)
+}
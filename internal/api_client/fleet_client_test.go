@@ -0,0 +1,52 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// newTokenServer returns a server which issues the access token and records the token of the other requests.
+func newTokenServer(t *testing.T, accessToken string, authorizations *[]string) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/tokens" {
+			assert.Empty(t, r.Header.Get("Authorization"))
+			_ = json.NewEncoder(w).Encode(&models.TokenPair{AccessToken: accessToken})
+			return
+		}
+		*authorizations = append(*authorizations, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("{}"))
+	}))
+}
+
+func TestFleetClientAccessToken(t *testing.T) {
+	var sddcManagerAuthorizations, fleetAuthorizations []string
+	sddcManager := newTokenServer(t, "sddc-manager-token", &sddcManagerAuthorizations)
+	defer sddcManager.Close()
+	fleet := newTokenServer(t, "fleet-token", &fleetAuthorizations)
+	defer fleet.Close()
+
+	sddcManagerClient := NewSddcManagerClient("admin@local", "VMware123!VMware123!",
+		strings.TrimPrefix(sddcManager.URL, "https://"), true)
+	assert.NoError(t, sddcManagerClient.Connect())
+	fleetClient := NewFleetClient("admin@local", "VMware123!VMware123!", strings.TrimPrefix(fleet.URL, "https://"), true)
+	assert.NoError(t, fleetClient.Connect())
+
+	// Connecting to the fleet management keeps the token of SDDC Manager
+	assert.Equal(t, "sddc-manager-token", *accessToken)
+	assert.NoError(t, fleetClient.Do(context.Background(), http.MethodGet, "/v1/fleet/instances", nil, nil))
+	assert.Equal(t, []string{"Bearer fleet-token"}, fleetAuthorizations)
+	assert.NoError(t, sendJSON(context.Background(), sddcManagerClient, http.MethodGet, "/v1/domains", nil, nil))
+	assert.Equal(t, []string{"Bearer sddc-manager-token"}, sddcManagerAuthorizations)
+}
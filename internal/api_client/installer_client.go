@@ -0,0 +1,120 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// InstallerClient is an API client of the VCF Installer appliance, which replaces the CloudBuilder appliance from VCF 9.
+// The installer authenticates with access tokens like SDDC Manager. Its deployment spec has properties the SDK has
+// no model for, the requests are therefore sent as JSON documents.
+type InstallerClient struct {
+	sddcManagerClient *SddcManagerClient
+}
+
+// NewInstallerClient constructs a client of the VCF Installer appliance configured as CloudBuilder, with the same
+// credentials and TLS settings.
+func (cloudBuilderClient *CloudBuilderClient) NewInstallerClient() *InstallerClient {
+	return &InstallerClient{
+		sddcManagerClient: NewSddcManagerClient(cloudBuilderClient.username, cloudBuilderClient.password,
+			cloudBuilderClient.cloudBuilderUrl, cloudBuilderClient.allowUnverifiedTls),
+	}
+}
+
+// Connect requests an access token from the VCF Installer appliance.
+func (installerClient *InstallerClient) Connect() error {
+	return installerClient.sddcManagerClient.Connect()
+}
+
+// Do sends a request with the JSON encoded body, if not nil, and decodes the response into result, if not nil.
+func (installerClient *InstallerClient) Do(ctx context.Context, method, path string, body, result interface{}) error {
+	return sendJSON(ctx, installerClient.sddcManagerClient, method, path, body, result)
+}
+
+// ApiError is the error of a request sent as a JSON document which did not succeed.
+type ApiError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("%s %s returned %s: %s", e.Method, e.Path, e.Status, e.Body)
+}
+
+// IsNotFound returns whether the error is the response of a request for a resource which does not exist.
+func IsNotFound(err error) bool {
+	var apiError *ApiError
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
+
+// sendJSON sends a request with the JSON encoded body, if not nil, to the appliance of the client authenticated with
+// access tokens and decodes the response into result, if not nil.
+func sendJSON(ctx context.Context, client *SddcManagerClient, method, path string, body, result interface{}) error {
+	// Refresh the access token as SDDC Manager does, a deployment takes hours
+	if client.accessToken == nil || time.Since(client.lastRefreshTime) > 20*time.Minute {
+		if err := client.Connect(); err != nil {
+			return err
+		}
+	}
+
+	return sendJSONRequest(ctx, http.DefaultClient, client.sddcManagerUrl, *client.accessToken, method, path, body, result)
+}
+
+// sendJSONRequest sends a request with the JSON encoded body, if not nil, to the appliance at the URL, with the access
+// token if not empty, and decodes the response into result, if not nil.
+func sendJSONRequest(ctx context.Context, httpClient *http.Client, url, accessToken, method, path string,
+	body, result interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(content)
+	}
+	if !strings.HasPrefix(url, "https://") {
+		url = "https://" + url
+	}
+	request, err := http.NewRequestWithContext(ctx, method, url+path, payload)
+	if err != nil {
+		return err
+	}
+	if accessToken != "" {
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return &ApiError{Method: method, Path: path, StatusCode: response.StatusCode, Status: response.Status,
+			Body: string(content)}
+	}
+	if result == nil || len(content) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(content, result)
+}
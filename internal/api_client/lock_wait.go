@@ -0,0 +1,129 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// resourceLockedMessage matches the messages of the errors with which SDDC Manager rejects a change while another
+// workflow holds the lock of a resource the change needs, e.g. "Unable to acquire resource level lock(s)". The
+// messages of other locks, e.g. of a locked user account, do not match.
+var resourceLockedMessage = regexp.MustCompile(
+	`(?i)\bunable to acquire (resource level )?locks?\b|\bresource level locks?\b|` +
+		`\banother (task|operation|workflow) is (running|in progress)\b`)
+
+// resourceLockedErrorCodes are the error codes of these errors.
+var resourceLockedErrorCodes = map[string]bool{
+	"RESOURCE_LOCKED":  true,
+	"RESOURCES_LOCKED": true,
+	"DOMAIN_LOCKED":    true,
+	"CLUSTER_LOCKED":   true,
+	"HOST_LOCKED":      true,
+}
+
+type operationContextKey struct{}
+
+// WithOperationContext returns a context whose API calls wait for the locks held by other workflows until the
+// context of the operation is done rather than until the API call times out. The wait is limited by the lock
+// maximum wait of the client.
+func WithOperationContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, ctx)
+}
+
+// roundTripWaitingForLocks sends the request. A change SDDC Manager rejects because another workflow holds a lock
+// is sent again every poll interval, up to the lock maximum wait of the client. Changes with a streamed body, e.g.
+// uploads, cannot be sent again and fail right away.
+func (c *sddcManagerCustomHttpTransport) roundTripWaitingForLocks(r *http.Request) (*http.Response, error) {
+	resp, err := c.send(r)
+	settings := c.sddcManagerClient.taskWait
+	if err != nil || settings.LockMaxWait <= 0 || r.Method == http.MethodGet || !isResourceLocked(resp) {
+		return resp, err
+	}
+	if r.Body != nil && r.Body != http.NoBody && r.GetBody == nil {
+		return resp, nil
+	}
+
+	operationCtx := r.Context()
+	if ctx, ok := r.Context().Value(operationContextKey{}).(context.Context); ok {
+		operationCtx = ctx
+	}
+	deadline := time.Now().Add(settings.LockMaxWait)
+	for time.Now().Add(settings.PollInterval).Before(deadline) {
+		_ = resp.Body.Close()
+		tflog.Warn(operationCtx, fmt.Sprintf("%s %s is rejected because another workflow holds a lock, retrying in %s",
+			r.Method, r.URL.Path, settings.PollInterval))
+		select {
+		case <-time.After(settings.PollInterval):
+		case <-operationCtx.Done():
+			return nil, operationCtx.Err()
+		}
+
+		resp, err = c.resend(operationCtx, r)
+		if err != nil || !isResourceLocked(resp) {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// resend sends a copy of the request with the context of the operation, the API call timeout applies to the copy.
+// The body of the response is read before the timeout is cancelled.
+func (c *sddcManagerCustomHttpTransport) resend(ctx context.Context, r *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, constants.DefaultVcfApiCallTimeout)
+	defer cancel()
+
+	retry := r.Clone(ctx)
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	resp, err := c.send(retry)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// isResourceLocked returns whether the response is an error caused by a lock held by another workflow, the body of
+// the response is kept.
+func isResourceLocked(resp *http.Response) bool {
+	if resp.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	apiError := &models.Error{}
+	if json.Unmarshal(body, apiError) != nil {
+		return false
+	}
+	return resourceLockedErrorCodes[apiError.ErrorCode] || resourceLockedMessage.MatchString(apiError.Message)
+}
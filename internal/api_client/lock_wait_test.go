@@ -0,0 +1,103 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsResourceLocked(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		locked     bool
+	}{
+		{
+			name:       "resource lock code",
+			statusCode: http.StatusConflict,
+			body:       `{"errorCode": "RESOURCE_LOCKED", "message": "The domain is used by another workflow"}`,
+			locked:     true,
+		},
+		{
+			name:       "domain lock code",
+			statusCode: http.StatusConflict,
+			body:       `{"errorCode": "DOMAIN_LOCKED"}`,
+			locked:     true,
+		},
+		{
+			name:       "resource lock message",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"errorCode": "VCF_ERROR", "message": "Unable to acquire resource level lock(s)"}`,
+			locked:     true,
+		},
+		{
+			name:       "task in progress message",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errorCode": "VCF_ERROR", "message": "Another task is in progress on the cluster"}`,
+			locked:     true,
+		},
+		{
+			name:       "operation running message",
+			statusCode: http.StatusBadRequest,
+			body:       `{"message": "Another operation is running"}`,
+			locked:     true,
+		},
+		{
+			name:       "locked user account message",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"errorCode": "AUTHENTICATION_FAILED", "message": "User account is locked"}`,
+		},
+		{
+			name:       "locked password code",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errorCode": "PASSWORD_LOCKED", "message": "The password of the account is locked"}`,
+		},
+		{
+			name:       "account lockout code",
+			statusCode: http.StatusForbidden,
+			body:       `{"errorCode": "ACCOUNT_LOCKOUT", "message": "The account is locked out after failed logins"}`,
+		},
+		{
+			name:       "lockdown mode message",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errorCode": "HOST_VALIDATION_FAILED", "message": "The host is in lockdown mode"}`,
+		},
+		{
+			name:       "other error",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errorCode": "INVALID_SPEC", "message": "The name is required"}`,
+		},
+		{
+			name:       "not an error",
+			statusCode: http.StatusOK,
+			body:       `{"errorCode": "RESOURCE_LOCKED"}`,
+		},
+		{
+			name:       "not JSON",
+			statusCode: http.StatusConflict,
+			body:       `resource level lock`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: test.statusCode, Body: io.NopCloser(strings.NewReader(test.body))}
+			assert.Equal(t, test.locked, isResourceLocked(resp))
+
+			// The body is kept for the caller
+			if test.statusCode >= http.StatusBadRequest {
+				body, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.body, string(body))
+			}
+		})
+	}
+}
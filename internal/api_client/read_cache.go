@@ -0,0 +1,122 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// cacheablePath matches the inventory lookups which are served from the read cache: the collections of domains,
+// clusters, hosts, network pools and the other inventory objects, their items and the networks of a network pool.
+// The validations, queries and tasks they poll have longer paths and are never cached.
+var cacheablePath = regexp.MustCompile(
+	`^/v1/(domains|clusters|hosts|network-pools|sddc-managers|vcenters|nsxt-clusters|edge-clusters)(/[^/]+)?(/networks)?$`)
+
+// readCache memoizes the inventory lookups of a provider instance, which lives for a single plan, refresh or apply,
+// so that the data sources and resources reading the same domain do not repeat the same calls. Identical concurrent
+// lookups share a single call. Any other request, e.g. a change or the poll of a task, empties the cache since the
+// inventory may be changing.
+type readCache struct {
+	mutex      sync.Mutex
+	entries    map[string]*cachedResponse
+	generation uint64
+}
+
+// cachedResponse is the response of a lookup, done is closed once it is received.
+type cachedResponse struct {
+	done       chan struct{}
+	statusCode int
+	header     http.Header
+	body       []byte
+	err        error
+}
+
+func newReadCache() *readCache {
+	return &readCache{entries: make(map[string]*cachedResponse)}
+}
+
+// roundTrip sends the request with the transport unless the response of an identical lookup is cached.
+func (c *readCache) roundTrip(r *http.Request, transport http.RoundTripper) (*http.Response, error) {
+	if r.Method != http.MethodGet || !cacheablePath.MatchString(strings.TrimSuffix(r.URL.Path, "/")) {
+		c.invalidate()
+		return transport.RoundTrip(r)
+	}
+
+	key := r.URL.String()
+	c.mutex.Lock()
+	entry, found := c.entries[key]
+	if !found {
+		entry = &cachedResponse{done: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	generation := c.generation
+	c.mutex.Unlock()
+
+	if found {
+		select {
+		case <-entry.done:
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+		if entry.err == nil {
+			return entry.response(r), nil
+		}
+		// The lookup this one waited for failed, e.g. because its context was cancelled
+		return transport.RoundTrip(r)
+	}
+
+	resp, err := transport.RoundTrip(r)
+	if err == nil {
+		entry.statusCode = resp.StatusCode
+		entry.header = resp.Header.Clone()
+		entry.body, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+	entry.err = err
+	close(entry.done)
+
+	// Only the successful lookups which did not overlap a change are kept
+	c.mutex.Lock()
+	if err != nil || resp.StatusCode != http.StatusOK || generation != c.generation {
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+	}
+	c.mutex.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return entry.response(r), nil
+}
+
+// invalidate empties the cache, the lookups in flight are not kept once they complete.
+func (c *readCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.generation++
+	clear(c.entries)
+}
+
+// response returns a copy of the cached response for the request.
+func (e *cachedResponse) response(r *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.statusCode, http.StatusText(e.statusCode)),
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       r,
+	}
+}
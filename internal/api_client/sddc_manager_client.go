@@ -12,10 +12,12 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	openapiclient "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	vcfclient "github.com/vmware/vcf-sdk-go/client"
 	"github.com/vmware/vcf-sdk-go/client/tasks"
@@ -37,6 +39,25 @@ type SddcManagerClient struct {
 	lastRefreshTime    time.Time
 	isRefreshing       bool
 	getTaskRetries     int
+	taskAutoRetry      int
+	taskWait           TaskWaitSettings
+	readCache          *readCache
+	versionOnce        sync.Once
+	vcfVersion         *version.Version
+	vcfVersionErr      error
+}
+
+// TaskWaitSettings configure how the client waits for the tasks of SDDC Manager.
+type TaskWaitSettings struct {
+	// PollInterval is the time between two reads of the status of a task
+	PollInterval time.Duration
+	// MaxWait is the time after which the wait for a task stops while the task keeps running, no limit if zero
+	MaxWait time.Duration
+	// FailOnWarning makes the tasks which complete with warnings fail
+	FailOnWarning bool
+	// LockMaxWait is the time the changes rejected because another workflow holds a lock are sent again every
+	// PollInterval, they are not sent again if zero
+	LockMaxWait time.Duration
 }
 
 // NewSddcManagerClient constructs new Client instance with vcf credentials.
@@ -49,7 +70,47 @@ func NewSddcManagerClient(username, password, url string, allowUnverifiedTls boo
 		lastRefreshTime:    time.Now(),
 		isRefreshing:       false,
 		getTaskRetries:     0,
+		taskWait:           TaskWaitSettings{PollInterval: defaultPollingInterval},
+		readCache:          newReadCache(),
+	}
+}
+
+// WithTaskAutoRetry makes the client retry a failed task which can be retried up to count times before
+// reporting the failure.
+func (sddcManagerClient *SddcManagerClient) WithTaskAutoRetry(count int) *SddcManagerClient {
+	sddcManagerClient.taskAutoRetry = count
+	return sddcManagerClient
+}
+
+// WithTaskWaitSettings makes the client wait for the tasks with the given settings. The default poll interval is
+// kept if the one of the settings is not positive.
+func (sddcManagerClient *SddcManagerClient) WithTaskWaitSettings(settings TaskWaitSettings) *SddcManagerClient {
+	if settings.PollInterval <= 0 {
+		settings.PollInterval = defaultPollingInterval
 	}
+	sddcManagerClient.taskWait = settings
+	return sddcManagerClient
+}
+
+// PollInterval returns the time between two reads of the status of a task or a validation.
+func (sddcManagerClient *SddcManagerClient) PollInterval() time.Duration {
+	return sddcManagerClient.taskWait.PollInterval
+}
+
+// InvalidateReadCache empties the read cache, so that the next lookups see the changes made by SDDC Manager since
+// the inventory was last read, e.g. when waiting for an object to change status.
+func (sddcManagerClient *SddcManagerClient) InvalidateReadCache() {
+	sddcManagerClient.readCache.invalidate()
+}
+
+// NewTaskTracker returns a tracker of the task which applies the task settings of the client.
+func (sddcManagerClient *SddcManagerClient) NewTaskTracker(ctx context.Context, taskId string) *TaskTracker {
+	tracker := NewTaskTrackerWithCustomPollingInterval(ctx, sddcManagerClient.ApiClient, taskId,
+		sddcManagerClient.taskWait.PollInterval).
+		WithAutoRetry(sddcManagerClient.taskAutoRetry)
+	tracker.maxWait = sddcManagerClient.taskWait.MaxWait
+	tracker.failOnWarning = sddcManagerClient.taskWait.FailOnWarning
+	return tracker
 }
 
 var accessToken *string
@@ -57,6 +118,19 @@ var accessToken *string
 const maxGetTaskRetries int = 10
 const maxTaskRetries int = 6
 
+// shortTaskMaxWait is the time WaitForTask waits for a task unless the provider sets a maximum wait.
+const shortTaskMaxWait = 10 * defaultPollingInterval
+
+// Host returns the FQDN or IP address of the SDDC Manager instance.
+func (sddcManagerClient *SddcManagerClient) Host() string {
+	return sddcManagerClient.sddcManagerUrl
+}
+
+// AllowsUnverifiedTls returns whether the provider accepts the certificates of SDDC Manager which cannot be verified.
+func (sddcManagerClient *SddcManagerClient) AllowsUnverifiedTls() bool {
+	return sddcManagerClient.allowUnverifiedTls
+}
+
 func (sddcManagerClient *SddcManagerClient) newTransport() *sddcManagerCustomHttpTransport {
 	return &sddcManagerCustomHttpTransport{
 		originalTransport: http.DefaultTransport,
@@ -70,6 +144,10 @@ type sddcManagerCustomHttpTransport struct {
 }
 
 func (c *sddcManagerCustomHttpTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return c.roundTripWaitingForLocks(r)
+}
+
+func (c *sddcManagerCustomHttpTransport) send(r *http.Request) (*http.Response, error) {
 	// Refresh the access token every 20 minutes so that SDK operations won't start to
 	// fail with 401, 403 because of token expiration, during long-running tasks
 	if time.Since(c.sddcManagerClient.lastRefreshTime) > 20*time.Minute &&
@@ -81,12 +159,12 @@ func (c *sddcManagerCustomHttpTransport) RoundTrip(r *http.Request) (*http.Respo
 	}
 
 	if accessToken != nil {
-		r.Header.Add("Authorization", fmt.Sprintf("Bearer %s", *accessToken))
+		r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", *accessToken))
 	}
 
-	r.Header.Add("Content-Type", "application/json")
+	r.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.originalTransport.RoundTrip(r)
+	resp, err := c.sddcManagerClient.readCache.roundTrip(r, c.originalTransport)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +197,7 @@ func (sddcManagerClient *SddcManagerClient) Connect() error {
 
 	ok, _, err := vcfClient.Tokens.CreateToken(params)
 	if err != nil {
+		sddcManagerClient.isRefreshing = false
 		return err
 	}
 
@@ -130,29 +209,38 @@ func (sddcManagerClient *SddcManagerClient) Connect() error {
 	return nil
 }
 
-// WaitForTask Wait for a task to complete (waits for up to a minute).
+// WaitForTask Wait for a short task to complete (waits for up to shortTaskMaxWait or the maximum wait of the provider).
 func (sddcManagerClient *SddcManagerClient) WaitForTask(ctx context.Context, taskId string) error {
-	// Fetch task status 10 times with a delay of 20 seconds each time
-	taskStatusRetry := 10
+	maxWait := sddcManagerClient.taskWait.MaxWait
+	if maxWait <= 0 {
+		maxWait = shortTaskMaxWait
+	}
+	start := time.Now()
+	stages := newTaskStageReporter()
 
-	for taskStatusRetry > 0 {
+	for time.Since(start) < maxWait {
 		task, err := sddcManagerClient.getTask(ctx, taskId)
 		if err != nil {
 			log.Println("error = ", err)
 			return err
 		}
+		stages.report(ctx, task)
 		waitStatuses := []string{"in progress", "pending", "in_progress"}
 		if slices.Contains(waitStatuses, strings.ToLower(task.Status)) {
-			time.Sleep(20 * time.Second)
-			taskStatusRetry--
+			if err := sleepOrInterrupt(ctx, sddcManagerClient.ApiClient, task, sddcManagerClient.taskWait.PollInterval); err != nil {
+				return err
+			}
 			continue
 		}
 
 		if task.Status == "Failed" || task.Status == "Cancelled" {
-			errorMsg := fmt.Sprintf("Task with ID = %s is in state %s", taskId, task.Status)
+			errorMsg := fmt.Sprintf("Task with ID = %s is in state %s%s", taskId, task.Status, describeFailedSubTasks(task))
 			log.Println(errorMsg)
 			return errors.New(errorMsg)
 		}
+		if sddcManagerClient.taskWait.FailOnWarning && hasWarnings(task.Status) {
+			return fmt.Errorf("Task with ID = %s completed with warnings in state %s", taskId, task.Status)
+		}
 
 		log.Printf("Task with ID = %s is in state %s, completed at %s", taskId, task.Status, task.CompletionTimestamp)
 		return nil
@@ -162,26 +250,45 @@ func (sddcManagerClient *SddcManagerClient) WaitForTask(ctx context.Context, tas
 }
 
 // WaitForTaskComplete Wait for task till it completes (either succeeds or fails).
+// When the context is done the task is cancelled if possible and a TaskInterruptedError is returned.
+// A failed task is retried up to the task_auto_retry count of the provider if SDDC Manager reports it as retryable,
+// or at least maxTaskRetries times regardless if retry is set.
 func (sddcManagerClient *SddcManagerClient) WaitForTaskComplete(ctx context.Context, taskId string, retry bool) error {
 	log.Printf("Getting status of task %s", taskId)
 	currentTaskRetries := 0
+	start := time.Now()
+	stages := newTaskStageReporter()
 	for {
 		task, err := sddcManagerClient.getTask(ctx, taskId)
 		if err != nil {
 			return err
 		}
+		stages.report(ctx, task)
 
 		if task.Status == "In Progress" || task.Status == "Pending" || task.Status == "IN_PROGRESS" {
-			time.Sleep(20 * time.Second)
+			if maxWait := sddcManagerClient.taskWait.MaxWait; maxWait > 0 && time.Since(start) >= maxWait {
+				return fmt.Errorf("stopped waiting for task %s after %s, the task is still running in SDDC Manager",
+					taskId, maxWait)
+			}
+			if err := sleepOrInterrupt(ctx, sddcManagerClient.ApiClient, task, sddcManagerClient.taskWait.PollInterval); err != nil {
+				return err
+			}
 			continue
 		}
 
 		if task.Status == "Failed" || task.Status == "Cancelled" {
-			errorMsg := fmt.Sprintf("Task with ID = %s , Name: %q Type: %q is in state %s", taskId, task.Name, task.Type, task.Status)
+			errorMsg := fmt.Sprintf("Task with ID = %s , Name: %q Type: %q is in state %s%s", taskId, task.Name, task.Type,
+				task.Status, describeFailedSubTasks(task))
 			tflog.Error(ctx, errorMsg)
 
-			if retry && currentTaskRetries < maxTaskRetries {
+			// Tasks waited for with retry are retried regardless of whether SDDC Manager reports them as retryable
+			maxRetries := sddcManagerClient.taskAutoRetry
+			if retry {
+				maxRetries = max(maxRetries, maxTaskRetries)
+			}
+			if (retry || (task.Status == "Failed" && task.IsRetryable)) && currentTaskRetries < maxRetries {
 				currentTaskRetries++
+				tflog.Info(ctx, fmt.Sprintf("Retrying task %s, attempt %d of %d", taskId, currentTaskRetries, maxRetries))
 				err := sddcManagerClient.retryTask(ctx, taskId)
 				if err != nil {
 					tflog.Error(ctx, fmt.Sprintf("Task %q %q failed after %d retries",
@@ -191,10 +298,19 @@ func (sddcManagerClient *SddcManagerClient) WaitForTaskComplete(ctx context.Cont
 			} else {
 				return errors.New(errorMsg)
 			}
-			time.Sleep(20 * time.Second)
+			if err := sleepOrInterrupt(ctx, sddcManagerClient.ApiClient, task, sddcManagerClient.taskWait.PollInterval); err != nil {
+				return err
+			}
 			continue
 		}
 
+		if sddcManagerClient.taskWait.FailOnWarning && hasWarnings(task.Status) {
+			errorMsg := fmt.Sprintf("Task with ID = %s , Name: %q Type: %q completed with warnings in state %s",
+				taskId, task.Name, task.Type, task.Status)
+			tflog.Error(ctx, errorMsg)
+			return errors.New(errorMsg)
+		}
+
 		log.Printf("Task with ID = %s is in state %s, completed at %s", taskId, task.Status, task.CompletionTimestamp)
 		return nil
 	}
@@ -216,6 +332,62 @@ func (sddcManagerClient *SddcManagerClient) GetResourceIdAssociatedWithTask(ctx
 	return "", fmt.Errorf("task %q did not contain resources of type %q", taskId, resourceType)
 }
 
+// FindRetryableTask returns the last task of a resource if it failed and SDDC Manager can retry it, nil otherwise.
+// SDDC Manager keeps the progress of such a task, retrying it resumes the workflow from its failed subtask.
+func (sddcManagerClient *SddcManagerClient) FindRetryableTask(ctx context.Context, resourceType, resourceId string) (*models.Task, error) {
+	lastTask, err := sddcManagerClient.findLastTask(ctx, resourceType, resourceId)
+	if err != nil || lastTask == nil {
+		return nil, err
+	}
+	if lastTask.Status != statusFailed || !lastTask.IsRetryable {
+		return nil, nil
+	}
+	return lastTask, nil
+}
+
+// FindResumableTask returns the last task of a resource if it is still running, e.g. because a previous apply
+// stopped waiting for it, or if it is retryable, nil otherwise. WaitForTaskComplete with retry then waits for the
+// running task again or retries the failed one.
+func (sddcManagerClient *SddcManagerClient) FindResumableTask(ctx context.Context, resourceType, resourceId string) (*models.Task, error) {
+	lastTask, err := sddcManagerClient.findLastTask(ctx, resourceType, resourceId)
+	if err != nil || lastTask == nil {
+		return nil, err
+	}
+	if !isRunning(lastTask.Status) && (lastTask.Status != statusFailed || !lastTask.IsRetryable) {
+		return nil, nil
+	}
+	return lastTask, nil
+}
+
+// findLastTask returns the last task of a resource, nil if there is none.
+func (sddcManagerClient *SddcManagerClient) findLastTask(ctx context.Context, resourceType, resourceId string) (*models.Task, error) {
+	orderBy := "creationTimestamp"
+	orderDirection := "DESC"
+	getTasksParams := tasks.NewGetTasksParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithResourceType(&resourceType).
+		WithResourceID(&resourceId).
+		WithOrderBy(&orderBy).
+		WithOrderDirection(&orderDirection)
+
+	getTasksResult, err := sddcManagerClient.ApiClient.Tasks.GetTasks(getTasksParams)
+	if err != nil {
+		return nil, err
+	}
+	if getTasksResult.Payload == nil || len(getTasksResult.Payload.Elements) == 0 {
+		return nil, nil
+	}
+
+	// A later task of the resource, e.g. a successful retry, supersedes the failed one
+	lastTask := getTasksResult.Payload.Elements[0]
+	for _, task := range getTasksResult.Payload.Elements[1:] {
+		if task.CreationTimestamp > lastTask.CreationTimestamp {
+			lastTask = task
+		}
+	}
+	return lastTask, nil
+}
+
 func (sddcManagerClient *SddcManagerClient) getTask(ctx context.Context, taskId string) (*models.Task, error) {
 	apiClient := sddcManagerClient.ApiClient
 	getTaskParams := tasks.NewGetTaskParamsWithTimeout(constants.DefaultVcfApiCallTimeout).
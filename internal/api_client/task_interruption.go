@@ -0,0 +1,73 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	vcfclient "github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/tasks"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// TaskInterruptedError is returned when the wait for a task stops because the context is done. When the apply is
+// interrupted the task is cancelled if SDDC Manager allows it. When the timeout of the resource is reached, or the
+// task cannot be cancelled, the task keeps running and the next apply can wait for it again with its ID.
+type TaskInterruptedError struct {
+	TaskID    string
+	Cancelled bool
+	Err       error
+}
+
+func (e *TaskInterruptedError) Error() string {
+	if e.Cancelled {
+		return fmt.Sprintf("task %s was cancelled: %s", e.TaskID, e.Err)
+	}
+	return fmt.Sprintf("stopped waiting for task %s, the task is still running in SDDC Manager: %s", e.TaskID, e.Err)
+}
+
+func (e *TaskInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// sleepOrInterrupt waits for the given duration, or until the context is done. In the latter case a
+// TaskInterruptedError is returned, after cancelling the task if the context was cancelled.
+func sleepOrInterrupt(ctx context.Context, client *vcfclient.VcfClient, task *models.Task, duration time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return interruptTask(ctx, client, task)
+	case <-time.After(duration):
+		return nil
+	}
+}
+
+// interruptTask cancels the task if the apply was interrupted and SDDC Manager allows it. A task whose wait reached
+// its deadline is left running, a long workflow such as the creation of a domain may outlast the timeout of the
+// resource. The context of the wait is already done, so the cancellation is sent with a context of its own.
+func interruptTask(ctx context.Context, client *vcfclient.VcfClient, task *models.Task) error {
+	interrupted := &TaskInterruptedError{TaskID: task.ID, Err: ctx.Err()}
+	if errors.Is(ctx.Err(), context.Canceled) && task.IsCancellable {
+		cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constants.DefaultVcfApiCallTimeout)
+		defer cancel()
+
+		cancelTaskParams := tasks.NewCancelTaskParamsWithContext(cancelCtx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout)
+		cancelTaskParams.ID = task.ID
+		if _, err := client.Tasks.CancelTask(cancelTaskParams); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Failed to cancel task %s: %s", task.ID, err))
+		} else {
+			interrupted.Cancelled = true
+		}
+	}
+
+	tflog.Warn(ctx, interrupted.Error())
+	return interrupted
+}
@@ -0,0 +1,66 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package api_client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// taskStageReporter logs the transitions of the subtasks, the stages of a task, e.g. "Deploy NSX Manager",
+// with the time elapsed since the wait for the task started.
+type taskStageReporter struct {
+	start time.Time
+	// statuses are the last known statuses of the subtasks, by position in the task
+	statuses map[int]string
+	// stageStarts are the times the subtasks were seen starting to run
+	stageStarts map[int]time.Time
+}
+
+func newTaskStageReporter() *taskStageReporter {
+	return &taskStageReporter{
+		start:       time.Now(),
+		statuses:    make(map[int]string),
+		stageStarts: make(map[int]time.Time),
+	}
+}
+
+// report logs the subtasks which status changed since the last report.
+func (r *taskStageReporter) report(ctx context.Context, task *models.Task) {
+	now := time.Now()
+	for i, subTask := range task.SubTasks {
+		if subTask == nil || subTask.Status == statusNotApplicable || subTask.Status == r.statuses[i] {
+			continue
+		}
+		previousStatus := r.statuses[i]
+		r.statuses[i] = subTask.Status
+
+		description := subTask.Description
+		if len(description) == 0 {
+			description = subTask.Name
+		}
+		elapsed := now.Sub(r.start).Round(time.Second)
+
+		if isRunning(subTask.Status) {
+			// A retried stage starts over
+			if !isRunning(previousStatus) {
+				r.stageStarts[i] = now
+			}
+			tflog.Info(ctx, fmt.Sprintf("[%s] %s (elapsed %s)", subTask.Status, description, elapsed))
+			continue
+		}
+
+		if stageStart, ok := r.stageStarts[i]; ok {
+			tflog.Info(ctx, fmt.Sprintf("[%s] %s after %s (elapsed %s)", subTask.Status, description,
+				now.Sub(stageStart).Round(time.Second), elapsed))
+		} else {
+			tflog.Info(ctx, fmt.Sprintf("[%s] %s (elapsed %s)", subTask.Status, description, elapsed))
+		}
+	}
+}
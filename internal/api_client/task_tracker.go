@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -25,6 +26,7 @@ const (
 	statusInProgress          = "In Progress"
 	statusInProgressUppercase = "IN_PROGRESS"
 	statusPending             = "Pending"
+	statusPendingUppercase    = "PENDING"
 	statusFailed              = "Failed"
 	statusCancelled           = "Cancelled"
 	statusNotApplicable       = "NOT_APPLICABLE"
@@ -36,6 +38,18 @@ type TaskTracker struct {
 	taskId          string
 	pollingInterval time.Duration
 	completedTasks  map[string]bool
+	stages          *taskStageReporter
+	// progressDescription is set when the progress of the task is logged
+	progressDescription string
+	progress            int
+	// autoRetry is the number of times a failed task which can be retried is retried
+	autoRetry int
+	retries   int
+	// lastTask is the last known state of the task, used to cancel it when the context is done
+	lastTask *models.Task
+	// maxWait is the time after which the tracker stops waiting, no limit if zero
+	maxWait       time.Duration
+	failOnWarning bool
 }
 
 func NewTaskTracker(ctx context.Context, client *client.VcfClient, taskId string) *TaskTracker {
@@ -45,6 +59,7 @@ func NewTaskTracker(ctx context.Context, client *client.VcfClient, taskId string
 		taskId:          taskId,
 		pollingInterval: defaultPollingInterval,
 		completedTasks:  make(map[string]bool),
+		stages:          newTaskStageReporter(),
 	}
 }
 
@@ -54,31 +69,75 @@ func NewTaskTrackerWithCustomPollingInterval(ctx context.Context, client *client
 	return tracker
 }
 
+// WithProgressReporting makes the tracker log the progress of the task whenever it changes.
+func (t *TaskTracker) WithProgressReporting(description string) *TaskTracker {
+	t.progressDescription = description
+	t.progress = -1
+	return t
+}
+
+// WithAutoRetry makes the tracker retry the task up to count times when it fails and can be retried.
+func (t *TaskTracker) WithAutoRetry(count int) *TaskTracker {
+	t.autoRetry = count
+	return t
+}
+
+// Progress returns the last known progress of the task in percent.
+func (t *TaskTracker) Progress() int {
+	return max(t.progress, 0)
+}
+
 func (t *TaskTracker) WaitForTask() error {
 	ticker := time.NewTicker(t.pollingInterval)
 	defer ticker.Stop()
+	start := time.Now()
 
 	for {
 		select {
 		case <-t.ctx.Done():
+			if t.lastTask != nil {
+				return fmt.Errorf("stopped waiting at %d%%: %w", t.Progress(), interruptTask(t.ctx, t.client, t.lastTask))
+			}
+			return fmt.Errorf("stopped waiting for task %s at %d%%: %w", t.taskId, t.Progress(), t.ctx.Err())
 		case <-ticker.C:
 			task, err := t.getTask()
 			if err != nil {
 				return err
 			}
+			t.lastTask = task
 
 			t.logTask(task)
+			t.logProgress(task)
 
 			switch task.Status {
 			case statusInProgress, statusInProgressUppercase, statusPending:
+				if t.maxWait > 0 && time.Since(start) >= t.maxWait {
+					return fmt.Errorf("stopped waiting for task %s at %d%% after %s, the task is still running in SDDC Manager",
+						t.taskId, t.Progress(), t.maxWait)
+				}
 				continue
 			case statusFailed, statusCancelled:
-				errorMsg := fmt.Sprintf("Task with ID = %s , Name: %q Type: %q is in state %s",
-					task.ID, task.Name, task.Type, task.Status)
+				errorMsg := fmt.Sprintf("Task with ID = %s , Name: %q Type: %q is in state %s%s",
+					task.ID, task.Name, task.Type, task.Status, describeFailedSubTasks(task))
 				tflog.Error(t.ctx, errorMsg)
 
+				if task.Status == statusFailed && task.IsRetryable && t.retries < t.autoRetry {
+					t.retries++
+					tflog.Info(t.ctx, fmt.Sprintf("Retrying task %s, attempt %d of %d", t.taskId, t.retries, t.autoRetry))
+					if err := t.retryTask(); err != nil {
+						return err
+					}
+					continue
+				}
+
 				return errors.New(errorMsg)
 			default:
+				if t.failOnWarning && hasWarnings(task.Status) {
+					errorMsg := fmt.Sprintf("Task with ID = %s , Name: %q Type: %q completed with warnings in state %s",
+						task.ID, task.Name, task.Type, task.Status)
+					tflog.Error(t.ctx, errorMsg)
+					return errors.New(errorMsg)
+				}
 				tflog.Info(t.ctx, fmt.Sprintf("Task with ID = %s is in state %s, completed at %s",
 					task.ID, task.Status, task.CompletionTimestamp))
 				return nil
@@ -87,6 +146,103 @@ func (t *TaskTracker) WaitForTask() error {
 	}
 }
 
+func (t *TaskTracker) logProgress(task *models.Task) {
+	if len(t.progressDescription) == 0 {
+		return
+	}
+	if progress := TaskProgress(task); progress != t.progress {
+		t.progress = progress
+		tflog.Info(t.ctx, fmt.Sprintf("%s: %d%% complete", t.progressDescription, progress))
+	}
+}
+
+// TaskProgress estimates the progress of the task in percent from its completed subtasks, as the
+// API does not report it.
+func TaskProgress(task *models.Task) int {
+	if !isRunning(task.Status) && task.Status != statusFailed && task.Status != statusCancelled {
+		return 100
+	}
+	if len(task.SubTasks) == 0 {
+		return 0
+	}
+
+	completed := 0
+	for _, subTask := range task.SubTasks {
+		if subTask != nil && !isRunning(subTask.Status) {
+			completed++
+		}
+	}
+
+	return completed * 100 / len(task.SubTasks)
+}
+
+// FailedSubTasks returns the failed subtasks of the task, including the nested ones, in the order of the task.
+func FailedSubTasks(task *models.Task) []*models.SubTask {
+	return appendFailedSubTasks(make([]*models.SubTask, 0), task.SubTasks)
+}
+
+func appendFailedSubTasks(failed []*models.SubTask, subTasks []*models.SubTask) []*models.SubTask {
+	for _, subTask := range subTasks {
+		if subTask == nil {
+			continue
+		}
+		if strings.EqualFold(subTask.Status, statusFailed) {
+			failed = append(failed, subTask)
+		}
+		failed = appendFailedSubTasks(failed, subTask.SubTasks)
+	}
+
+	return failed
+}
+
+// ErrorMessages returns the messages of the errors, skipping the empty ones.
+func ErrorMessages(errors []*models.Error) []string {
+	messages := make([]string, 0, len(errors))
+	for _, taskError := range errors {
+		if taskError != nil && len(taskError.Message) > 0 {
+			messages = append(messages, taskError.Message)
+		}
+	}
+
+	return messages
+}
+
+// describeFailedSubTasks describes the failed subtasks of the task with their errors, to complete the error
+// message of the failed task. Empty if no subtask failed.
+func describeFailedSubTasks(task *models.Task) string {
+	descriptions := make([]string, 0)
+	for _, subTask := range FailedSubTasks(task) {
+		name := subTask.Description
+		if len(name) == 0 {
+			name = subTask.Name
+		}
+		description := fmt.Sprintf("%q", name)
+		if messages := ErrorMessages(subTask.Errors); len(messages) > 0 {
+			description += ": " + strings.Join(messages, ", ")
+		}
+		descriptions = append(descriptions, description)
+	}
+	if len(descriptions) == 0 {
+		return ""
+	}
+
+	return ". Failed subtasks: " + strings.Join(descriptions, "; ")
+}
+
+func isRunning(status string) bool {
+	switch status {
+	case statusInProgress, statusInProgressUppercase, statusPending, statusPendingUppercase:
+		return true
+	}
+
+	return false
+}
+
+// hasWarnings tells whether the task completed with warnings, e.g. in state COMPLETED_WITH_WARNING.
+func hasWarnings(status string) bool {
+	return strings.Contains(strings.ToUpper(status), "WARNING")
+}
+
 func (t *TaskTracker) getTask() (*models.Task, error) {
 	getTaskParams := tasks.NewGetTaskParamsWithTimeout(constants.DefaultVcfApiCallTimeout).
 		WithContext(t.ctx)
@@ -101,6 +257,16 @@ func (t *TaskTracker) getTask() (*models.Task, error) {
 	return getTaskResult.Payload, nil
 }
 
+func (t *TaskTracker) retryTask() error {
+	retryTaskParams := tasks.NewRetryTaskParamsWithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithContext(t.ctx)
+	retryTaskParams.ID = t.taskId
+
+	_, err := t.client.Tasks.RetryTask(retryTaskParams)
+
+	return err
+}
+
 func (t *TaskTracker) logTask(task *models.Task) {
 	if task.SubTasks == nil {
 		messagePack := task.LocalizableDescriptionPack
@@ -108,17 +274,7 @@ func (t *TaskTracker) logTask(task *models.Task) {
 			t.log(messagePack.Message, task.Status)
 		}
 	} else {
-		for _, subtask := range task.SubTasks {
-			t.logSubTask(subtask)
-		}
-	}
-}
-
-func (t *TaskTracker) logSubTask(task *models.SubTask) {
-	if task.Status != statusInProgressUppercase && task.Status != statusPending && task.Status != statusNotApplicable {
-		if t.shouldLog(task.Description) {
-			t.log(task.Description, task.Status)
-		}
+		t.stages.report(t.ctx, task)
 	}
 }
 
@@ -0,0 +1,192 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/tasks"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+const (
+	BackupStatusSuccessful = "SUCCESSFUL"
+	BackupStatusFailed     = "FAILED"
+	BackupStatusInProgress = "IN_PROGRESS"
+)
+
+// BackupStatuses returns the statuses of the backups, normalized from the statuses of their tasks.
+func BackupStatuses() []string {
+	return []string{BackupStatusInProgress, BackupStatusSuccessful, BackupStatusFailed}
+}
+
+// GetBackupTasks returns the tasks of the most recent backups, newest first, reading at most limit tasks
+// if limit is positive. SDDC Manager has no API listing the backups, they are only known by their tasks.
+func GetBackupTasks(ctx context.Context, limit int, apiClient *client.VcfClient) ([]*models.Task, error) {
+	taskName := "backup"
+	orderBy := "creationTimestamp"
+	orderDirection := "DESC"
+	params := tasks.NewGetTasksParamsWithContext(ctx).
+		WithTimeout(apiCallTimeout(ctx)).
+		WithTaskName(&taskName).
+		WithOrderBy(&orderBy).
+		WithOrderDirection(&orderDirection)
+	if limit > 0 {
+		limit32 := int32(limit)
+		params.WithLimit(&limit32)
+	}
+
+	result, err := apiClient.Tasks.GetTasks(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return []*models.Task{}, nil
+	}
+
+	backupTasks := make([]*models.Task, 0)
+	for _, task := range result.Payload.Elements {
+		if isBackupTask(task) {
+			backupTasks = append(backupTasks, task)
+		}
+	}
+	slices.SortStableFunc(backupTasks, func(a, b *models.Task) int {
+		return strings.Compare(b.CreationTimestamp, a.CreationTimestamp)
+	})
+
+	return backupTasks, nil
+}
+
+// isBackupTask tells the backup tasks apart from the tasks which change the backup configuration
+// or restore a backup, which names contain "backup" too.
+func isBackupTask(task *models.Task) bool {
+	if task == nil {
+		return false
+	}
+	name := strings.ToLower(task.Name)
+
+	return strings.Contains(name, "backup") && !strings.Contains(name, "configur") &&
+		!strings.Contains(name, "restore") && !strings.Contains(name, "credential")
+}
+
+// BackupStatus normalizes the status of a backup task, which may be upper or title case.
+func BackupStatus(task *models.Task) string {
+	status := strings.ToUpper(strings.ReplaceAll(task.Status, " ", "_"))
+	switch status {
+	case BackupStatusSuccessful, "COMPLETED_WITH_WARNING":
+		return BackupStatusSuccessful
+	case BackupStatusFailed, "CANCELLED":
+		return BackupStatusFailed
+	default:
+		return BackupStatusInProgress
+	}
+}
+
+// LastSuccessfulBackup returns the most recent successful backup task, nil if there is none.
+// The tasks are expected newest first.
+func LastSuccessfulBackup(backupTasks []*models.Task) *models.Task {
+	for _, task := range backupTasks {
+		if BackupStatus(task) == BackupStatusSuccessful {
+			return task
+		}
+	}
+
+	return nil
+}
+
+// CheckRecentBackup returns an error unless a backup succeeded within the given duration before now.
+func CheckRecentBackup(backupTasks []*models.Task, within time.Duration, now time.Time) error {
+	last := LastSuccessfulBackup(backupTasks)
+	if last == nil {
+		return fmt.Errorf("no successful backup found")
+	}
+
+	timestamp := last.CompletionTimestamp
+	if len(timestamp) == 0 {
+		timestamp = last.CreationTimestamp
+	}
+	completed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return fmt.Errorf("invalid completion time %q of backup %s: %w", timestamp, last.ID, err)
+	}
+	if now.Sub(completed) > within {
+		return fmt.Errorf("the last successful backup %s completed at %s, more than %s ago", last.ID, timestamp, within)
+	}
+
+	return nil
+}
+
+// FilterBackupTasks returns the backup tasks with one of the given statuses. No status matches any task.
+func FilterBackupTasks(backupTasks []*models.Task, statuses []string) []*models.Task {
+	if len(statuses) == 0 {
+		return backupTasks
+	}
+
+	result := make([]*models.Task, 0)
+	for _, task := range backupTasks {
+		if slices.Contains(statuses, BackupStatus(task)) {
+			result = append(result, task)
+		}
+	}
+
+	return result
+}
+
+// FlattenBackupTasks converts the backup tasks to the format of the vcf_backups data source.
+func FlattenBackupTasks(backupTasks []*models.Task, target string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(backupTasks))
+	for _, task := range backupTasks {
+		resourceTypes := make([]string, 0)
+		for _, resource := range task.Resources {
+			if resource != nil && resource.Type != nil && !slices.Contains(resourceTypes, *resource.Type) {
+				resourceTypes = append(resourceTypes, *resource.Type)
+			}
+		}
+		errors := make([]string, 0)
+		for _, taskError := range task.Errors {
+			if taskError != nil && len(taskError.Message) > 0 {
+				errors = append(errors, taskError.Message)
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":                   task.ID,
+			"name":                 task.Name,
+			"status":               BackupStatus(task),
+			"creation_timestamp":   task.CreationTimestamp,
+			"completion_timestamp": task.CompletionTimestamp,
+			"resource_types":       resourceTypes,
+			"target":               target,
+			"errors":               errors,
+		})
+	}
+
+	return result
+}
+
+// BackupTarget describes the backup location as protocol://server:port/directory, empty if there is none.
+func BackupTarget(location *models.BackupLocation) string {
+	if location == nil || location.Server == nil {
+		return ""
+	}
+
+	target := strings.ToLower(ProtocolSftp) + "://" + *location.Server
+	if location.Protocol != nil {
+		target = strings.ToLower(*location.Protocol) + "://" + *location.Server
+	}
+	if location.Port != nil {
+		target += fmt.Sprintf(":%d", *location.Port)
+	}
+	if location.DirectoryPath != nil {
+		target += "/" + strings.TrimPrefix(*location.DirectoryPath, "/")
+	}
+
+	return target
+}
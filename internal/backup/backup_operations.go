@@ -0,0 +1,179 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/backup_restore"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	ResourceTypeSddcManager = "SDDC_MANAGER"
+
+	ProtocolSftp = "SFTP"
+
+	FrequencyWeekly = "WEEKLY"
+	FrequencyHourly = "HOURLY"
+)
+
+// The backup and restore tasks of large inventories outlast the other SDDC Manager tasks, so the backup
+// resources have their own default timeouts.
+const (
+	DefaultConfigurationTimeout = 30 * time.Minute
+	DefaultRestoreTimeout       = 4 * time.Hour
+	DefaultReadTimeout          = 20 * time.Minute
+)
+
+// apiCallTimeout returns the timeout of a single API call made before the deadline of the context: the time
+// left until the deadline if it is longer than the default API call timeout.
+func apiCallTimeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining > constants.DefaultVcfApiCallTimeout {
+			return remaining
+		}
+	}
+
+	return constants.DefaultVcfApiCallTimeout
+}
+
+// DaysOfWeek returns the days accepted in the backup schedules.
+func DaysOfWeek() []string {
+	return []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
+}
+
+func GetBackupConfiguration(ctx context.Context, apiClient *client.VcfClient) (*models.BackupConfiguration, error) {
+	params := backup_restore.NewGetBackupConfigurationParamsWithContext(ctx).
+		WithTimeout(apiCallTimeout(ctx))
+
+	result, err := apiClient.BackupRestore.GetBackupConfiguration(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// GetBackupLocation returns the external (SFTP) backup location configured in SDDC Manager
+// or nil if file-based backups are not configured.
+func GetBackupLocation(ctx context.Context, apiClient *client.VcfClient) (*models.BackupLocation, error) {
+	backupConfiguration, err := GetBackupConfiguration(ctx, apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if backupConfiguration == nil || len(backupConfiguration.BackupLocations) == 0 {
+		return nil, nil
+	}
+
+	return backupConfiguration.BackupLocations[0], nil
+}
+
+// UpdateBackupLocationCredentials changes the user used by SDDC Manager and NSX Manager to upload
+// file-based backups to the backup server. SDDC Manager connects to the backup server with the
+// new credentials before it applies them, so the returned error tells whether the server rejected them.
+func UpdateBackupLocationCredentials(ctx context.Context, userName, password string, sddcClient *api_client.SddcManagerClient) error {
+	location, err := GetBackupLocation(ctx, sddcClient.ApiClient)
+	if err != nil {
+		return err
+	}
+	if location == nil {
+		return errors.New("no backup server is configured in SDDC Manager")
+	}
+
+	server := ""
+	if location.Server != nil {
+		server = *location.Server
+	}
+
+	location.Username = &userName
+	location.Password = password
+
+	err = UpdateBackupConfiguration(ctx, &models.BackupConfigurationSpec{
+		BackupLocations: []*models.BackupLocation{location},
+	}, sddcClient)
+	if err != nil {
+		return fmt.Errorf("backup server %s rejected the credentials of %s: %w", server, userName, err)
+	}
+
+	return nil
+}
+
+// SetBackupConfiguration configures the backup server, the encryption passphrase and the schedules
+// of the file-based backups and waits for SDDC Manager to apply them.
+func SetBackupConfiguration(ctx context.Context, spec *models.BackupConfigurationSpec, sddcClient *api_client.SddcManagerClient) error {
+	params := backup_restore.NewSetBackupConfigurationParamsWithContext(ctx).
+		WithTimeout(apiCallTimeout(ctx)).
+		WithBackupConfigurationSpec(spec)
+
+	ok, accepted, err := sddcClient.ApiClient.BackupRestore.SetBackupConfiguration(params)
+	if err != nil {
+		return err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+
+	return waitForBackupConfigurationTask(ctx, task, sddcClient)
+}
+
+// UpdateBackupConfiguration changes the parts of the backup configuration set in the spec
+// and waits for SDDC Manager to apply them.
+func UpdateBackupConfiguration(ctx context.Context, spec *models.BackupConfigurationSpec, sddcClient *api_client.SddcManagerClient) error {
+	params := backup_restore.NewUpdateBackupConfigurationParamsWithContext(ctx).
+		WithTimeout(apiCallTimeout(ctx)).
+		WithBackupConfigurationSpec(spec)
+
+	ok, accepted, err := sddcClient.ApiClient.BackupRestore.UpdateBackupConfiguration(params)
+	if err != nil {
+		return err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+
+	return waitForBackupConfigurationTask(ctx, task, sddcClient)
+}
+
+func waitForBackupConfigurationTask(ctx context.Context, task *models.Task, sddcClient *api_client.SddcManagerClient) error {
+	if task == nil {
+		return nil
+	}
+
+	return sddcClient.WaitForTaskComplete(ctx, task.ID, false)
+}
+
+// GetSddcManagerBackupSchedule returns the schedule of the SDDC Manager backups or nil if none is configured.
+func GetSddcManagerBackupSchedule(backupConfiguration *models.BackupConfiguration) *models.BackupSchedule {
+	if backupConfiguration == nil {
+		return nil
+	}
+
+	index := slices.IndexFunc(backupConfiguration.BackupSchedules, func(schedule *models.BackupSchedule) bool {
+		return schedule != nil && schedule.ResourceType != nil && *schedule.ResourceType == ResourceTypeSddcManager
+	})
+	if index < 0 {
+		return nil
+	}
+
+	return backupConfiguration.BackupSchedules[index]
+}
@@ -0,0 +1,187 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/nsxt_clusters"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+)
+
+const (
+	nsxAdminUser          = "admin"
+	nsxBackupConfigPath   = "/api/v1/cluster/backups/config"
+	nsxSecondsBetweenRuns = 3600
+)
+
+// NsxBackupSettings is the backup configuration of SDDC Manager applied to the NSX Manager clusters.
+type NsxBackupSettings struct {
+	Location *models.BackupLocation
+	// DirectoryPath overrides the directory of the location for the NSX backups if not empty
+	DirectoryPath string
+	// Passphrase is omitted if empty, NSX Manager keeps its current passphrase then
+	Passphrase string
+	// Schedule is the SDDC Manager backup schedule, the scheduled NSX backups are disabled if nil
+	Schedule *models.BackupSchedule
+}
+
+// GetNsxClusters returns the NSX Manager clusters of the given domains, of all domains if none is given.
+func GetNsxClusters(ctx context.Context, domainIds []string, apiClient *client.VcfClient) ([]*models.NsxTCluster, error) {
+	params := nsxt_clusters.NewGetNsxClustersParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	result, err := apiClient.NSXTClusters.GetNsxClusters(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return []*models.NsxTCluster{}, nil
+	}
+
+	clusters := make([]*models.NsxTCluster, 0)
+	for _, cluster := range result.Payload.Elements {
+		if cluster == nil || len(cluster.VipFqdn) == 0 {
+			continue
+		}
+		if len(domainIds) == 0 || slices.ContainsFunc(cluster.Domains, func(domain *models.DomainReference) bool {
+			return domain != nil && domain.ID != nil && slices.Contains(domainIds, *domain.ID)
+		}) {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	return clusters, nil
+}
+
+// SyncNsxBackupConfiguration applies the backup settings to each NSX Manager cluster with the admin
+// credentials SDDC Manager manages for it, and returns the FQDNs of the updated clusters.
+func SyncNsxBackupConfiguration(ctx context.Context, settings NsxBackupSettings, clusters []*models.NsxTCluster,
+	apiClient *client.VcfClient) ([]string, error) {
+	config, err := toNsxBackupConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	synchronized := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		credential, err := credentials.GetResourceCredential(ctx, apiClient, credentials.ResourceTypeNsxtManager,
+			cluster.VipFqdn, nsxAdminUser, "API")
+		if err != nil {
+			return synchronized, err
+		}
+
+		log.Printf("[DEBUG] Applying the backup configuration to NSX Manager %s", cluster.VipFqdn)
+		if err := putNsxBackupConfig(ctx, cluster.VipFqdn, credential.Password, payload); err != nil {
+			return synchronized, fmt.Errorf("cannot configure the backups of NSX Manager %s: %w", cluster.VipFqdn, err)
+		}
+		synchronized = append(synchronized, cluster.VipFqdn)
+	}
+
+	return synchronized, nil
+}
+
+func putNsxBackupConfig(ctx context.Context, host, password string, payload []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, "https://"+host+nsxBackupConfigPath, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(nsxAdminUser, password)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		content, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("PUT %s returned %s: %s", nsxBackupConfigPath, response.Status, string(content))
+	}
+
+	return nil
+}
+
+// toNsxBackupConfig converts the settings to the BackupConfiguration of the NSX Manager API.
+func toNsxBackupConfig(settings NsxBackupSettings) (map[string]interface{}, error) {
+	location := settings.Location
+	if location == nil || location.Server == nil || location.Username == nil {
+		return nil, fmt.Errorf("no backup server is configured")
+	}
+
+	directoryPath := settings.DirectoryPath
+	if len(directoryPath) == 0 && location.DirectoryPath != nil {
+		directoryPath = *location.DirectoryPath
+	}
+	port := int32(22)
+	if location.Port != nil {
+		port = *location.Port
+	}
+
+	config := map[string]interface{}{
+		"backup_enabled": settings.Schedule != nil && settings.Schedule.TakeScheduledBackups,
+		"remote_file_server": map[string]interface{}{
+			"server":         *location.Server,
+			"port":           port,
+			"directory_path": directoryPath,
+			"protocol": map[string]interface{}{
+				"protocol_name":   "sftp",
+				"ssh_fingerprint": location.SSHFingerprint,
+				"authentication_scheme": map[string]interface{}{
+					"scheme_name": "PASSWORD",
+					"username":    *location.Username,
+					"password":    location.Password,
+				},
+			},
+		},
+	}
+	if len(settings.Passphrase) > 0 {
+		config["passphrase"] = settings.Passphrase
+	}
+
+	if schedule := settings.Schedule; schedule != nil && schedule.Frequency != nil {
+		switch *schedule.Frequency {
+		case FrequencyHourly:
+			config["backup_schedule"] = map[string]interface{}{
+				"resource_type":           "IntervalBackupSchedule",
+				"seconds_between_backups": nsxSecondsBetweenRuns,
+			}
+		default:
+			// NSX Manager numbers the days of the week from Sunday
+			days := make([]int, 0, len(schedule.DaysOfWeek))
+			for _, day := range schedule.DaysOfWeek {
+				if index := slices.Index(DaysOfWeek(), strings.ToUpper(day)); index >= 0 {
+					days = append(days, index)
+				}
+			}
+			slices.Sort(days)
+			config["backup_schedule"] = map[string]interface{}{
+				"resource_type":  "WeeklyBackupSchedule",
+				"days_of_week":   days,
+				"hour_of_day":    schedule.HourOfDay,
+				"minute_of_hour": schedule.MinuteOfHour,
+			}
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,193 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client/backup_restore"
+	"github.com/vmware/vcf-sdk-go/models"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+const restorePollInterval = 30 * time.Second
+
+// BackupServer holds the connection to the SFTP server the backup files are read from.
+type BackupServer struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// SshFingerprint is the expected SHA256 or MD5 fingerprint of the server key, any key is accepted if empty
+	SshFingerprint string
+}
+
+// FetchBackupFile copies the backup file from the backup server to the remote directory of the SDDC Manager
+// appliance. It returns the path of the copy and the fingerprint of the key the backup server presented,
+// so that a key accepted on first use can be pinned afterwards.
+func FetchBackupFile(ctx context.Context, server BackupServer, backupFile string, connection lcm.ApplianceConnection,
+	remoteDirectory string, sddcClient *api_client.SddcManagerClient) (string, string, error) {
+	var fingerprint string
+	config := &ssh.ClientConfig{
+		User: server.Username,
+		Auth: []ssh.AuthMethod{ssh.Password(server.Password)},
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			fingerprint = ssh.FingerprintSHA256(key)
+			if len(server.SshFingerprint) == 0 || server.SshFingerprint == fingerprint ||
+				strings.EqualFold(server.SshFingerprint, ssh.FingerprintLegacyMD5(key)) {
+				return nil
+			}
+			return fmt.Errorf("the fingerprint %s of the backup server does not match %s", fingerprint, server.SshFingerprint)
+		},
+		Timeout: constants.DefaultVcfApiCallTimeout,
+	}
+
+	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(server.Host, strconv.Itoa(server.Port)), config)
+	if err != nil {
+		return "", fingerprint, fmt.Errorf("cannot connect to the backup server %s: %w", server.Host, err)
+	}
+	defer sshClient.Close()
+
+	size := int64(-1)
+	if output, err := runBackupServerCommand(sshClient, "stat -c %s "+shellQuote(backupFile)); err == nil {
+		if parsed, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64); err == nil {
+			size = parsed
+		}
+	}
+
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return "", fingerprint, err
+	}
+	defer session.Close()
+
+	reader, err := session.StdoutPipe()
+	if err != nil {
+		return "", fingerprint, err
+	}
+	if err := session.Start("cat " + shellQuote(backupFile)); err != nil {
+		return "", fingerprint, fmt.Errorf("cannot read %s on the backup server %s: %w", backupFile, server.Host, err)
+	}
+
+	remotePath, err := lcm.CopyStreamToAppliance(ctx, sddcClient, connection, reader, path.Base(backupFile), size, remoteDirectory)
+	if err != nil {
+		return "", fingerprint, err
+	}
+	if err := session.Wait(); err != nil {
+		return "", fingerprint, fmt.Errorf("cannot read %s on the backup server %s: %w", backupFile, server.Host, err)
+	}
+
+	return remotePath, fingerprint, nil
+}
+
+func runBackupServerCommand(sshClient *ssh.Client, command string) (string, error) {
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	output, err := session.Output(command)
+	return string(output), err
+}
+
+// StartRestore restores SDDC Manager from the backup file, a path on the SDDC Manager appliance,
+// and returns the ID of the restore task.
+func StartRestore(ctx context.Context, backupFile, passphrase string, sddcClient *api_client.SddcManagerClient) (string, error) {
+	resourceType := ResourceTypeSddcManager
+	params := backup_restore.NewStartRestoreParamsWithContext(ctx).
+		WithTimeout(apiCallTimeout(ctx)).
+		WithRestoreSpec(&models.RestoreSpec{
+			BackupFile: &backupFile,
+			Elements:   []*models.BackupResource{{ResourceType: &resourceType}},
+			Encryption: &models.Encryption{Passphrase: &passphrase},
+		})
+
+	ok, accepted, err := sddcClient.ApiClient.BackupRestore.StartRestore(params)
+	if err != nil {
+		return "", err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+	if task == nil {
+		return "", fmt.Errorf("restore from %s did not return a task", backupFile)
+	}
+
+	return task.ID, nil
+}
+
+// GetRestoreTask returns the restore task with the given ID.
+func GetRestoreTask(ctx context.Context, taskId string, sddcClient *api_client.SddcManagerClient) (*models.Task, error) {
+	params := backup_restore.NewGetRestoreTaskParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(taskId)
+
+	result, err := sddcClient.ApiClient.BackupRestore.GetRestoreTask(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// WaitForRestore polls the restore task until it completes. The services of SDDC Manager restart during the
+// restore, so errors are tolerated and the session is established again until the task can be read.
+func WaitForRestore(ctx context.Context, taskId string, sddcClient *api_client.SddcManagerClient) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for the restore task %s: %w", taskId, ctx.Err())
+		case <-time.After(restorePollInterval):
+		}
+
+		task, err := GetRestoreTask(ctx, taskId, sddcClient)
+		if err != nil {
+			log.Printf("[DEBUG] SDDC Manager is not available during the restore: %s", err)
+			if err := sddcClient.Connect(); err != nil {
+				log.Printf("[DEBUG] Cannot connect to SDDC Manager yet: %s", err)
+			}
+			continue
+		}
+
+		log.Printf("[INFO] Restore task %s is in state %s", taskId, task.Status)
+		switch BackupStatus(task) {
+		case BackupStatusSuccessful:
+			return nil
+		case BackupStatusFailed:
+			return fmt.Errorf("restore task %s is in state %s: %s", taskId, task.Status, taskErrors(task.Errors))
+		}
+	}
+}
+
+func taskErrors(errors []*models.Error) string {
+	messages := make([]string, 0, len(errors))
+	for _, taskError := range errors {
+		if taskError != nil && len(taskError.Message) > 0 {
+			messages = append(messages, taskError.Message)
+		}
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
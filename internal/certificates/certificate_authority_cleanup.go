@@ -0,0 +1,53 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package certificates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	vcfclient "github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/certificates"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// SweepCertificateAuthorities removes the configuration of the certificate authorities which OpenSSL common name
+// or Microsoft server URL is among the given ones, e.g. the ones left behind by failed acceptance tests.
+// The certificates already issued by the certificate authorities are kept.
+func SweepCertificateAuthorities(ctx context.Context, client *vcfclient.VcfClient, identifiers []string) error {
+	params := certificates.NewGetCertificateAuthoritiesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	caResponse, err := client.Certificates.GetCertificateAuthorities(params)
+	if err != nil {
+		return err
+	}
+	if caResponse.Payload == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, ca := range caResponse.Payload.Elements {
+		if ca == nil || ca.ID == nil {
+			continue
+		}
+		if !slices.Contains(identifiers, ca.CommonName) && !slices.Contains(identifiers, ca.ServerURL) {
+			continue
+		}
+		removeParams := certificates.NewRemoveCertificateAuthorityParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(*ca.ID)
+
+		log.Printf("Removing the %s certificate authority", *ca.ID)
+		if _, _, err := client.Certificates.RemoveCertificateAuthority(removeParams); err != nil {
+			errs = append(errs, fmt.Errorf("cannot remove the %s certificate authority: %w", *ca.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
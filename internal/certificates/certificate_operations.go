@@ -10,16 +10,19 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	vcfclient "github.com/vmware/vcf-sdk-go/client"
 	"github.com/vmware/vcf-sdk-go/client/certificates"
+	"github.com/vmware/vcf-sdk-go/client/domains"
 	"github.com/vmware/vcf-sdk-go/models"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -128,23 +131,55 @@ func GenerateCertificateForResource(ctx context.Context, client *api_client.Sddc
 	return nil
 }
 
-func ReadCertificate(ctx context.Context, client *vcfclient.VcfClient,
-	domainId, resourceFqdn string) (*models.Certificate, error) {
-	viewCertificatesParams := certificates.NewGetCertificatesByDomainParamsWithContext(ctx).
-		WithTimeout(constants.DefaultVcfApiCallTimeout)
-	viewCertificatesParams.ID = domainId
-
-	certificatesResponse, _, err := client.Certificates.GetCertificatesByDomain(viewCertificatesParams)
+// ReadCertificates returns the certificates of the resources of a domain.
+// ReadCertificates reads the certificates of the resources of the domain with the API of the VCF version of SDDC Manager.
+func ReadCertificates(ctx context.Context, client *api_client.SddcManagerClient, domainId string) ([]*models.Certificate, error) {
+	domainCertificates, err := client.GetCertificatesOfDomain(ctx, domainId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get certificates by domain: %w", err)
 	}
+	return domainCertificates, nil
+}
+
+// ReadAllCertificates reads the certificates of the resources of all the domains, the domains are read in parallel.
+// The certificates are ordered by domain ID.
+func ReadAllCertificates(ctx context.Context, client *api_client.SddcManagerClient) ([]*models.Certificate, error) {
+	domainsResponse, err := client.ApiClient.Domains.GetDomains(
+		domains.NewGetDomainsParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains: %w", err)
+	}
+
+	var domainIds []string
+	if domainsResponse.Payload != nil {
+		for _, domain := range domainsResponse.Payload.Elements {
+			domainIds = append(domainIds, domain.ID)
+		}
+	}
+	slices.Sort(domainIds)
+
+	certificatesOfDomains, err := utils.ParallelMap(ctx, domainIds, constants.MaxParallelApiCalls,
+		func(ctx context.Context, domainId string) ([]*models.Certificate, error) {
+			return ReadCertificates(ctx, client, domainId)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(certificatesOfDomains...), nil
+}
+
+func ReadCertificate(ctx context.Context, client *api_client.SddcManagerClient,
+	domainId, resourceFqdn string) (*models.Certificate, error) {
+	allCertsForDomain, err := ReadCertificates(ctx, client, domainId)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if any certificates are found
-	if certificatesResponse.Payload == nil || len(certificatesResponse.Payload.Elements) == 0 {
+	if len(allCertsForDomain) == 0 {
 		return nil, fmt.Errorf("no certificates found for domain ID %s", domainId)
 	}
 
-	allCertsForDomain := certificatesResponse.Payload.Elements
 	for _, cert := range allCertsForDomain {
 		if cert.IssuedTo != nil && *cert.IssuedTo == resourceFqdn {
 			return cert, nil
@@ -5,6 +5,8 @@
 package certificates
 
 import (
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vcf-sdk-go/models"
 )
@@ -82,3 +84,16 @@ func FlattenResource(resource *models.Resource) map[string]interface{} {
 
 	return result
 }
+
+// UpgradeCsrIdV0 converts a CSR ID of the format used before version 0.10.0 of the provider,
+// csr:<domain ID>:<resource type>:<task ID>, to the current one which includes the FQDN of the resource.
+// IDs of any other format are returned as they are.
+func UpgradeCsrIdV0(csrId, resourceFqdn string) string {
+	csrIdComponents := strings.Split(csrId, ":")
+	if len(csrIdComponents) != 4 || len(resourceFqdn) == 0 {
+		return csrId
+	}
+
+	return strings.Join([]string{csrIdComponents[0], csrIdComponents[1], csrIdComponents[2], resourceFqdn,
+		csrIdComponents[3]}, ":")
+}
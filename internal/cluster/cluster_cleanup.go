@@ -0,0 +1,129 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/vmware/vcf-sdk-go/client/clusters"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// hostUnassignedStatuses are the statuses of the commissioned hosts which are not part of a cluster.
+var hostUnassignedStatuses = []string{"UNASSIGNED_USEABLE", "UNASSIGNED_UNUSEABLE"}
+
+// DeleteCluster marks the cluster for deletion and deletes it. The hosts of the cluster are left unassigned.
+func DeleteCluster(ctx context.Context, vcfClient *api_client.SddcManagerClient, clusterId string) error {
+	clusterUpdateParams := clusters.NewUpdateClusterParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	clusterUpdateParams.ID = clusterId
+	clusterUpdateSpec, _ := CreateClusterUpdateSpec(nil, true)
+	clusterUpdateParams.SetClusterUpdateSpec(clusterUpdateSpec)
+
+	apiClient := vcfClient.ApiClient
+	log.Printf("Marking Cluster %s for deletion", clusterId)
+	acceptedUpdateTask, acceptedUpdateTask2, err := apiClient.Clusters.UpdateCluster(clusterUpdateParams)
+	if err != nil {
+		return err
+	}
+	var taskId string
+	if acceptedUpdateTask != nil {
+		taskId = acceptedUpdateTask.Payload.ID
+	}
+	if acceptedUpdateTask2 != nil {
+		taskId = acceptedUpdateTask2.Payload.ID
+	}
+	if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+		return err
+	}
+
+	clusterDeleteParams := clusters.NewDeleteClusterParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	clusterDeleteParams.ID = clusterId
+
+	log.Printf("Deleting Cluster %s", clusterId)
+	_, acceptedDeleteTask, err := apiClient.Clusters.DeleteCluster(clusterDeleteParams)
+	if err != nil {
+		return err
+	}
+	if acceptedDeleteTask != nil {
+		taskId = acceptedDeleteTask.Payload.ID
+	}
+
+	return vcfClient.WaitForTaskComplete(ctx, taskId, true)
+}
+
+// SweepClusters deletes the clusters with the given names, e.g. the ones left behind by failed acceptance tests.
+// The default cluster of a domain is only deleted with its domain, so the domains are to be swept first.
+// A failure does not stop the deletion of the other clusters.
+func SweepClusters(ctx context.Context, vcfClient *api_client.SddcManagerClient, names []string) error {
+	params := clusters.NewGetClustersParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	clustersResponse, err := vcfClient.ApiClient.Clusters.GetClusters(params)
+	if err != nil {
+		return err
+	}
+	if clustersResponse.Payload == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, clusterElement := range clustersResponse.Payload.Elements {
+		if clusterElement == nil || clusterElement.IsDefault || !slices.Contains(names, clusterElement.Name) {
+			continue
+		}
+		if err := DeleteCluster(ctx, vcfClient, clusterElement.ID); err != nil {
+			errs = append(errs, fmt.Errorf("cannot delete cluster %s: %w", clusterElement.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SweepHosts decommissions the hosts with the given FQDNs which are not part of a cluster. The clusters and the
+// domains are to be swept first for the hosts to be released.
+func SweepHosts(ctx context.Context, vcfClient *api_client.SddcManagerClient, fqdns []string) error {
+	params := hosts.NewGetHostsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	hostsResponse, err := vcfClient.ApiClient.Hosts.GetHosts(params)
+	if err != nil {
+		return err
+	}
+	if hostsResponse.Payload == nil {
+		return nil
+	}
+
+	decommissionSpecs := make([]*models.HostDecommissionSpec, 0)
+	for _, host := range hostsResponse.Payload.Elements {
+		if host == nil || !slices.Contains(fqdns, host.Fqdn) || !slices.Contains(hostUnassignedStatuses, host.Status) {
+			continue
+		}
+		fqdn := host.Fqdn
+		decommissionSpecs = append(decommissionSpecs, &models.HostDecommissionSpec{Fqdn: &fqdn})
+	}
+	if len(decommissionSpecs) == 0 {
+		return nil
+	}
+
+	decommissionParams := hosts.NewDecommissionHostsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	decommissionParams.HostDecommissionSpecs = decommissionSpecs
+
+	log.Printf("Decommissioning %d hosts", len(decommissionSpecs))
+	_, accepted, err := vcfClient.ApiClient.Hosts.DecommissionHosts(decommissionParams)
+	if err != nil {
+		return err
+	}
+
+	return vcfClient.WaitForTaskComplete(ctx, accepted.Payload.ID, false)
+}
@@ -7,6 +7,7 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -24,6 +25,12 @@ import (
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
+// ExcludableBlocks returns the computed blocks of a cluster which can be left out of the state, they hold the
+// details of every host and vSphere Distributed Switch of the cluster.
+func ExcludableBlocks() []string {
+	return []string{"host", "vds"}
+}
+
 func CreateClusterUpdateSpec(data *schema.ResourceData, markForDeletion bool) (*models.ClusterUpdateSpec, error) {
 	result := new(models.ClusterUpdateSpec)
 	if markForDeletion {
@@ -169,6 +176,8 @@ func TryConvertResourceDataToClusterSpec(data *schema.ResourceData) (*models.Clu
 	intermediaryMap["high_availability_enabled"] = data.Get("high_availability_enabled")
 	intermediaryMap["geneve_vlan_id"] = data.Get("geneve_vlan_id")
 	intermediaryMap["ip_address_pool"] = data.Get("ip_address_pool")
+	intermediaryMap["uplink_profile"] = data.Get("uplink_profile")
+	intermediaryMap["network_profile"] = data.Get("network_profile")
 	intermediaryMap["host"] = data.Get("host")
 	intermediaryMap["vds"] = data.Get("vds")
 	intermediaryMap["vsan_datastore"] = data.Get("vsan_datastore")
@@ -222,13 +231,46 @@ func TryConvertToClusterSpec(object map[string]interface{}) (*models.ClusterSpec
 	}
 
 	if ipAddressPoolRaw, ok := object["ip_address_pool"]; ok && !validationUtils.IsEmpty(ipAddressPoolRaw) {
-		ipAddressPoolList := ipAddressPoolRaw.([]interface{})
-		if !validationUtils.IsEmpty(ipAddressPoolList[0]) {
-			ipAddressPoolSpec, err := network.GetIpAddressPoolSpecFromSchema(ipAddressPoolList[0].(map[string]interface{}))
+		var ipAddressPoolSpecs []*models.IPAddressPoolSpec
+		for _, ipAddressPoolEntry := range ipAddressPoolRaw.([]interface{}) {
+			if validationUtils.IsEmpty(ipAddressPoolEntry) {
+				continue
+			}
+			ipAddressPoolSpec, err := network.GetIpAddressPoolSpecFromSchema(ipAddressPoolEntry.(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			ipAddressPoolSpecs = append(ipAddressPoolSpecs, ipAddressPoolSpec)
+		}
+		// A single pool without network profiles keeps using the original (single pool) field
+		// so that older VCF versions continue to work.
+		if len(ipAddressPoolSpecs) == 1 && validationUtils.IsEmpty(object["network_profile"]) {
+			result.NetworkSpec.NsxClusterSpec.NsxTClusterSpec.IPAddressPoolSpec = ipAddressPoolSpecs[0]
+		} else {
+			result.NetworkSpec.NsxClusterSpec.NsxTClusterSpec.IPAddressPoolsSpec = ipAddressPoolSpecs
+		}
+	}
+
+	if uplinkProfilesRaw, ok := object["uplink_profile"]; ok && !validationUtils.IsEmpty(uplinkProfilesRaw) {
+		result.NetworkSpec.NsxClusterSpec.NsxTClusterSpec.UplinkProfiles = []*models.UplinkProfile{}
+		for _, uplinkProfileEntry := range uplinkProfilesRaw.([]interface{}) {
+			uplinkProfile, err := network.TryConvertToUplinkProfile(uplinkProfileEntry.(map[string]interface{}))
 			if err != nil {
 				return nil, err
 			}
-			result.NetworkSpec.NsxClusterSpec.NsxTClusterSpec.IPAddressPoolSpec = ipAddressPoolSpec
+			result.NetworkSpec.NsxClusterSpec.NsxTClusterSpec.UplinkProfiles = append(
+				result.NetworkSpec.NsxClusterSpec.NsxTClusterSpec.UplinkProfiles, uplinkProfile)
+		}
+	}
+
+	if networkProfilesRaw, ok := object["network_profile"]; ok && !validationUtils.IsEmpty(networkProfilesRaw) {
+		result.NetworkSpec.NetworkProfiles = []*models.NetworkProfile{}
+		for _, networkProfileEntry := range networkProfilesRaw.([]interface{}) {
+			networkProfile, err := network.TryConvertToNetworkProfile(networkProfileEntry.(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			result.NetworkSpec.NetworkProfiles = append(result.NetworkSpec.NetworkProfiles, networkProfile)
 		}
 	}
 
@@ -249,6 +291,9 @@ func TryConvertToClusterSpec(object map[string]interface{}) (*models.ClusterSpec
 	} else {
 		return nil, fmt.Errorf("cannot convert to ClusterSpec, hosts list is not set")
 	}
+	if err := validateHostNetworkProfiles(name, result.HostSpecs, result.NetworkSpec.NetworkProfiles); err != nil {
+		return nil, err
+	}
 
 	if vdsRaw, ok := object["vds"]; ok {
 		vdsList := vdsRaw.([]interface{})
@@ -282,6 +327,25 @@ func TryConvertToClusterSpec(object map[string]interface{}) (*models.ClusterSpec
 	return result, nil
 }
 
+// validateHostNetworkProfiles checks that the network profile of every host is one of the network profiles of the
+// cluster, SDDC Manager otherwise fails the cluster creation after the hosts are validated.
+func validateHostNetworkProfiles(clusterName string, hostSpecs []*models.HostSpec, networkProfiles []*models.NetworkProfile) error {
+	profileNames := make(map[string]bool, len(networkProfiles))
+	for _, networkProfile := range networkProfiles {
+		profileNames[*networkProfile.Name] = true
+	}
+	for _, hostSpec := range hostSpecs {
+		if hostSpec.HostNetworkSpec == nil || len(hostSpec.HostNetworkSpec.NetworkProfileName) == 0 {
+			continue
+		}
+		if !profileNames[hostSpec.HostNetworkSpec.NetworkProfileName] {
+			return fmt.Errorf("cannot convert to ClusterSpec, host %s uses network profile %q which is not a "+
+				"network_profile of cluster %q", *hostSpec.ID, hostSpec.HostNetworkSpec.NetworkProfileName, clusterName)
+		}
+	}
+	return nil
+}
+
 func tryConvertToClusterDatastoreSpec(object map[string]interface{}, clusterName string) (*models.DatastoreSpec, error) {
 	result := &models.DatastoreSpec{}
 	atLeastOneTypeOfDatastoreConfigured := false
@@ -359,7 +423,9 @@ func tryConvertToClusterDatastoreSpec(object map[string]interface{}, clusterName
 	return result, nil
 }
 
-func FlattenCluster(ctx context.Context, clusterObj *models.Cluster, apiClient *client.VcfClient) (*map[string]interface{}, error) {
+// FlattenCluster flattens the cluster, the excluded blocks among ExcludableBlocks are left empty.
+func FlattenCluster(ctx context.Context, clusterObj *models.Cluster, apiClient *client.VcfClient,
+	exclude ...string) (*map[string]interface{}, error) {
 	result := make(map[string]interface{})
 	if clusterObj == nil {
 		return &result, nil
@@ -372,20 +438,24 @@ func FlattenCluster(ctx context.Context, clusterObj *models.Cluster, apiClient *
 	result["is_default"] = clusterObj.IsDefault
 	result["is_stretched"] = clusterObj.IsStretched
 
-	flattenedVdsSpecs := getFlattenedVdsSpecsForRefs(clusterObj.VdsSpecs)
-	result["vds"] = flattenedVdsSpecs
+	if !slices.Contains(exclude, "vds") {
+		result["vds"] = getFlattenedVdsSpecsForRefs(clusterObj.VdsSpecs)
+	}
 
-	flattenedHostSpecs, err := getFlattenedHostSpecsForRefs(ctx, clusterObj.Hosts, apiClient)
-	if err != nil {
-		return nil, err
+	if !slices.Contains(exclude, "host") {
+		flattenedHostSpecs, err := getFlattenedHostSpecsForRefs(ctx, clusterObj.Hosts, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		result["host"] = flattenedHostSpecs
 	}
-	result["host"] = flattenedHostSpecs
 
 	return &result, nil
 }
 
+// ImportCluster reads the cluster into the data, the excluded blocks among ExcludableBlocks are left empty.
 func ImportCluster(ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient,
-	clusterId string) ([]*schema.ResourceData, error) {
+	clusterId string, exclude ...string) ([]*schema.ResourceData, error) {
 	getClusterParams := clusters.NewGetClusterParamsWithContext(ctx).
 		WithTimeout(constants.DefaultVcfApiCallTimeout)
 	getClusterParams.ID = clusterId
@@ -401,14 +471,17 @@ func ImportCluster(ctx context.Context, data *schema.ResourceData, apiClient *cl
 	_ = data.Set("primary_datastore_type", clusterObj.PrimaryDatastoreType)
 	_ = data.Set("is_default", clusterObj.IsDefault)
 	_ = data.Set("is_stretched", clusterObj.IsStretched)
-	flattenedVdsSpecs := getFlattenedVdsSpecsForRefs(clusterObj.VdsSpecs)
-	_ = data.Set("vds", flattenedVdsSpecs)
+	if !slices.Contains(exclude, "vds") {
+		_ = data.Set("vds", getFlattenedVdsSpecsForRefs(clusterObj.VdsSpecs))
+	}
 
-	flattenedHostSpecs, err := getFlattenedHostSpecsForRefs(ctx, clusterObj.Hosts, apiClient)
-	if err != nil {
-		return nil, err
+	if !slices.Contains(exclude, "host") {
+		flattenedHostSpecs, err := getFlattenedHostSpecsForRefs(ctx, clusterObj.Hosts, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		_ = data.Set("host", flattenedHostSpecs)
 	}
-	_ = data.Set("host", flattenedHostSpecs)
 
 	//get all domains and find our cluster to set the "domain_id" attribute, because
 	// cluster API doesn't provide parent domain ID.
@@ -435,23 +508,21 @@ func ImportCluster(ctx context.Context, data *schema.ResourceData, apiClient *cl
 // to get some useful info about the hosts in the cluster.
 func getFlattenedHostSpecsForRefs(ctx context.Context, hostRefs []*models.HostReference,
 	apiClient *client.VcfClient) ([]map[string]interface{}, error) {
-	flattenedHostSpecs := *new([]map[string]interface{})
 	// Sort for reproducibility
 	sort.SliceStable(hostRefs, func(i, j int) bool {
 		return hostRefs[i].ID < hostRefs[j].ID
 	})
-	for _, hostRef := range hostRefs {
-		getHostParams := hosts.NewGetHostParamsWithContext(ctx).
-			WithTimeout(constants.DefaultVcfApiCallTimeout)
-		getHostParams.ID = hostRef.ID
-		getHostResult, err := apiClient.Hosts.GetHost(getHostParams)
-		if err != nil {
-			return nil, err
-		}
-		hostObj := getHostResult.Payload
-		flattenedHostSpecs = append(flattenedHostSpecs, *FlattenHost(hostObj))
-	}
-	return flattenedHostSpecs, nil
+	return resource_utils.ParallelMap(ctx, hostRefs, constants.MaxParallelApiCalls,
+		func(ctx context.Context, hostRef *models.HostReference) (map[string]interface{}, error) {
+			getHostParams := hosts.NewGetHostParamsWithContext(ctx).
+				WithTimeout(constants.DefaultVcfApiCallTimeout)
+			getHostParams.ID = hostRef.ID
+			getHostResult, err := apiClient.Hosts.GetHost(getHostParams)
+			if err != nil {
+				return nil, err
+			}
+			return *FlattenHost(getHostResult.Payload), nil
+		})
 }
 
 func getFlattenedVdsSpecsForRefs(vdsSpecs []*models.VdsSpec) []map[string]interface{} {
@@ -0,0 +1,119 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testIpAddressPool(name, cidr, gateway string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name,
+		"subnet": []interface{}{map[string]interface{}{
+			"cidr":                  cidr,
+			"gateway":               gateway,
+			"ip_address_pool_range": []interface{}{},
+		}},
+	}
+}
+
+func testNetworkProfile(name, ipAddressPoolName string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name,
+		"nsx_host_switch_config": []interface{}{map[string]interface{}{
+			"vds_name":             "sfo-w01-cl01-vds01",
+			"uplink_profile_name":  "sfo-w01-cl01-uplink-profile",
+			"ip_address_pool_name": ipAddressPoolName,
+		}},
+	}
+}
+
+func testHost(id, networkProfileName string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                   id,
+		"network_profile_name": networkProfileName,
+	}
+}
+
+func testClusterObject(hosts []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name": "sfo-w01-cl01",
+		"host": hosts,
+		"vds": []interface{}{map[string]interface{}{
+			"name": "sfo-w01-cl01-vds01",
+		}},
+		"vsan_datastore": []interface{}{map[string]interface{}{
+			"datastore_name": "sfo-w01-cl01-ds-vsan01",
+			"license_key":    "",
+		}},
+	}
+}
+
+func TestTryConvertToClusterSpecSingleIpAddressPool(t *testing.T) {
+	object := testClusterObject([]interface{}{testHost("host-1", "")})
+	object["ip_address_pool"] = []interface{}{testIpAddressPool("sfo-w01-cl01-tep01", "172.16.14.0/24", "172.16.14.1")}
+
+	clusterSpec, err := TryConvertToClusterSpec(object)
+	assert.NoError(t, err)
+
+	// A single pool without network profiles is sent in the field older VCF versions know
+	nsxtClusterSpec := clusterSpec.NetworkSpec.NsxClusterSpec.NsxTClusterSpec
+	assert.Equal(t, "sfo-w01-cl01-tep01", *nsxtClusterSpec.IPAddressPoolSpec.Name)
+	assert.Empty(t, nsxtClusterSpec.IPAddressPoolsSpec)
+	assert.Empty(t, clusterSpec.NetworkSpec.NetworkProfiles)
+}
+
+func TestTryConvertToClusterSpecNetworkProfiles(t *testing.T) {
+	object := testClusterObject([]interface{}{
+		testHost("host-1", "sfo-w01-cl01-rack01"),
+		testHost("host-2", "sfo-w01-cl01-rack02"),
+	})
+	object["ip_address_pool"] = []interface{}{
+		testIpAddressPool("sfo-w01-cl01-tep01", "172.16.14.0/24", "172.16.14.1"),
+		testIpAddressPool("sfo-w01-cl01-tep02", "172.16.15.0/24", "172.16.15.1"),
+	}
+	object["uplink_profile"] = []interface{}{map[string]interface{}{
+		"name":           "sfo-w01-cl01-uplink-profile",
+		"transport_vlan": 1614,
+	}}
+	object["network_profile"] = []interface{}{
+		testNetworkProfile("sfo-w01-cl01-rack01", "sfo-w01-cl01-tep01"),
+		testNetworkProfile("sfo-w01-cl01-rack02", "sfo-w01-cl01-tep02"),
+	}
+
+	clusterSpec, err := TryConvertToClusterSpec(object)
+	assert.NoError(t, err)
+
+	// The pools of the network profiles are sent together
+	nsxtClusterSpec := clusterSpec.NetworkSpec.NsxClusterSpec.NsxTClusterSpec
+	assert.Nil(t, nsxtClusterSpec.IPAddressPoolSpec)
+	assert.Len(t, nsxtClusterSpec.IPAddressPoolsSpec, 2)
+	assert.Equal(t, "sfo-w01-cl01-tep02", *nsxtClusterSpec.IPAddressPoolsSpec[1].Name)
+	assert.Len(t, nsxtClusterSpec.UplinkProfiles, 1)
+	assert.Equal(t, int32(1614), nsxtClusterSpec.UplinkProfiles[0].TransportVlan)
+	assert.Len(t, clusterSpec.NetworkSpec.NetworkProfiles, 2)
+	assert.Equal(t, "sfo-w01-cl01-tep02",
+		clusterSpec.NetworkSpec.NetworkProfiles[1].NSXTHostSwitchConfigs[0].IPAddressPoolName)
+	assert.Equal(t, "sfo-w01-cl01-rack02", clusterSpec.HostSpecs[1].HostNetworkSpec.NetworkProfileName)
+}
+
+func TestTryConvertToClusterSpecUnknownNetworkProfile(t *testing.T) {
+	object := testClusterObject([]interface{}{
+		testHost("host-1", "sfo-w01-cl01-rack01"),
+		testHost("host-2", "sfo-w01-cl01-rack03"),
+	})
+	object["network_profile"] = []interface{}{testNetworkProfile("sfo-w01-cl01-rack01", "")}
+
+	_, err := TryConvertToClusterSpec(object)
+	assert.EqualError(t, err, `cannot convert to ClusterSpec, host host-2 uses network profile "sfo-w01-cl01-rack03" `+
+		`which is not a network_profile of cluster "sfo-w01-cl01"`)
+
+	// A host cannot use a network profile when the cluster has none
+	delete(object, "network_profile")
+	_, err = TryConvertToClusterSpec(object)
+	assert.ErrorContains(t, err, `host host-1 uses network profile "sfo-w01-cl01-rack01"`)
+}
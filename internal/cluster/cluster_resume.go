@@ -0,0 +1,70 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/client/clusters"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// ResumeClusterCreation resumes the creation of a cluster which failed in a previous apply, or which was still running
+// when the previous apply stopped waiting for it. SDDC Manager keeps such a cluster in its domain along with its
+// creation task: the running task is waited for again and the failed task is retried from the failed stage, instead
+// of adding the cluster again. Returns the ID of the cluster once it is created, or an empty ID if there is no
+// creation to resume.
+func ResumeClusterCreation(ctx context.Context, vcfClient *api_client.SddcManagerClient, domainId, name string) (string, error) {
+	if domainId == "" {
+		return "", nil
+	}
+	getDomainParams := domains.NewGetDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(domainId)
+	domainResult, err := vcfClient.ApiClient.Domains.GetDomain(getDomainParams)
+	if err != nil {
+		return "", err
+	}
+
+	for _, clusterRef := range domainResult.Payload.Clusters {
+		if clusterRef.ID == nil {
+			continue
+		}
+		getClusterParams := clusters.NewGetClusterParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(*clusterRef.ID)
+		clusterResult, err := vcfClient.ApiClient.Clusters.GetCluster(getClusterParams)
+		if err != nil {
+			return "", err
+		}
+		clusterObj := clusterResult.Payload
+		if clusterObj.Name != name {
+			continue
+		}
+		task, err := vcfClient.FindResumableTask(ctx, "CLUSTER", clusterObj.ID)
+		if err != nil {
+			return "", err
+		}
+		if task == nil {
+			return "", nil
+		}
+
+		tflog.Info(ctx, "Resuming the creation of the cluster from its previous task", map[string]interface{}{
+			"cluster_id": clusterObj.ID,
+			"task_id":    task.ID,
+			"status":     task.Status,
+		})
+		if err = vcfClient.WaitForTaskComplete(ctx, task.ID, true); err != nil {
+			return "", err
+		}
+		return clusterObj.ID, nil
+	}
+
+	return "", nil
+}
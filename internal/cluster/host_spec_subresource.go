@@ -83,6 +83,11 @@ func HostSpecSchema() *schema.Resource {
 				Description: "vmnic configuration for the ESXi host",
 				Elem:        network.VMNicSchema(),
 			},
+			"network_profile_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the cluster network profile to apply to the ESXi host, one of the network_profile of the cluster",
+			},
 		},
 	}
 }
@@ -164,6 +169,12 @@ func TryConvertToHostSpec(object map[string]interface{}) (*models.HostSpec, erro
 			return nil, fmt.Errorf("cannot convert to HostSpec, vmnic list is empty")
 		}
 	}
+	if networkProfileName, ok := object["network_profile_name"]; ok && !validationutils.IsEmpty(networkProfileName) {
+		if result.HostNetworkSpec == nil {
+			result.HostNetworkSpec = &models.HostNetworkSpec{}
+		}
+		result.HostNetworkSpec.NetworkProfileName = networkProfileName.(string)
+	}
 
 	return result, nil
 }
@@ -8,6 +8,9 @@ import "time"
 
 const (
 	DefaultVcfApiCallTimeout = 2 * time.Minute
+	// MaxParallelApiCalls is the number of API calls the list data sources make at a time to read the objects they
+	// list, e.g. the hosts of a cluster or the certificates of every domain.
+	MaxParallelApiCalls = 8
 
 	// VcfTestUrl URL of a VCF instance, used for acceptance tests.
 	VcfTestUrl = "VCF_TEST_URL"
@@ -27,6 +30,9 @@ const (
 	// to be used in acceptance tests.
 	VcfTestAllowUnverifiedTls = "VCF_TEST_ALLOW_UNVERIFIED_TLS"
 
+	// VcfSimulator connects the provider to the built-in SDDC Manager simulator instead of a VCF instance.
+	VcfSimulator = "VCF_SIMULATOR"
+
 	// VcfTestHost1Fqdn the FQDN of the first ESXi host, that has not been commissioned
 	// with the SDDC Manager.
 	VcfTestHost1Fqdn = "VCF_TEST_HOST1_FQDN"
@@ -184,6 +190,99 @@ const (
 
 	// VcfTestNsxManagerFqdn the FQDN of the NSX manager.
 	VcfTestNsxManagerFqdn = "VCF_TEST_NSX_MANAGER_FQDN"
+
+	// VcfTestBackupUsername the user name of the backup server account.
+	VcfTestBackupUsername = "VCF_TEST_BACKUP_USERNAME"
+
+	// VcfTestBackupPassword the password of the backup server account.
+	VcfTestBackupPassword = "VCF_TEST_BACKUP_PASSWORD"
+
+	// VcfTestBackupServer the FQDN of the backup server.
+	VcfTestBackupServer = "VCF_TEST_BACKUP_SERVER"
+
+	// VcfTestBackupDirectory the directory of the backup server where the backup files are saved.
+	VcfTestBackupDirectory = "VCF_TEST_BACKUP_DIRECTORY"
+
+	// VcfTestBackupSshFingerprint the SSH fingerprint of the backup server.
+	VcfTestBackupSshFingerprint = "VCF_TEST_BACKUP_SSH_FINGERPRINT"
+
+	// VcfTestBackupPassphrase the passphrase used to encrypt the backup files.
+	VcfTestBackupPassphrase = "VCF_TEST_BACKUP_PASSPHRASE"
+
+	// VcfTestBackupFile the path on the backup server of the backup file restored in the restore acceptance test.
+	VcfTestBackupFile = "VCF_TEST_BACKUP_FILE"
+
+	// VcfTestBundleId the ID of an LCM bundle which can be downloaded.
+	VcfTestBundleId = "VCF_TEST_BUNDLE_ID"
+
+	// VcfTestUpgradeTargetVersion the VCF release the workload domain is upgraded to in the upgrade acceptance test.
+	VcfTestUpgradeTargetVersion = "VCF_TEST_UPGRADE_TARGET_VERSION"
+
+	// VcfTestOfflineDepotHostname the FQDN of the offline depot used in the offline depot acceptance test.
+	VcfTestOfflineDepotHostname = "VCF_TEST_OFFLINE_DEPOT_HOSTNAME"
+
+	// VcfTestOfflineDepotUsername the username of the offline depot used in the offline depot acceptance test.
+	VcfTestOfflineDepotUsername = "VCF_TEST_OFFLINE_DEPOT_USERNAME"
+
+	// VcfTestOfflineDepotPassword the password of the offline depot used in the offline depot acceptance test.
+	VcfTestOfflineDepotPassword = "VCF_TEST_OFFLINE_DEPOT_PASSWORD"
+
+	// VcfTestBundleFile the local path of the bundle tarball uploaded in the bundle upload acceptance test.
+	VcfTestBundleFile = "VCF_TEST_BUNDLE_FILE"
+
+	// VcfTestBundleManifestFile the local path of the manifest of the bundle uploaded in the bundle upload acceptance test.
+	VcfTestBundleManifestFile = "VCF_TEST_BUNDLE_MANIFEST_FILE"
+
+	// VcfTestApplianceVcfPassword the password of the vcf user of the SDDC Manager appliance.
+	VcfTestApplianceVcfPassword = "VCF_TEST_APPLIANCE_VCF_PASSWORD"
+
+	// VcfTestApplianceRootPassword the password of the root user of the SDDC Manager appliance.
+	VcfTestApplianceRootPassword = "VCF_TEST_APPLIANCE_ROOT_PASSWORD"
+
+	// VcfTestCleanupBundleId the ID of a downloaded LCM bundle deleted in the bundle cleanup acceptance test.
+	VcfTestCleanupBundleId = "VCF_TEST_CLEANUP_BUNDLE_ID"
+
+	// VcfTestPersonalityZipFile the local path of the offline depot ZIP file of an exported cluster image.
+	VcfTestPersonalityZipFile = "VCF_TEST_PERSONALITY_ZIP_FILE"
+
+	// VcfTestPersonalityJsonFile the local path of the JSON specification of an exported cluster image.
+	VcfTestPersonalityJsonFile = "VCF_TEST_PERSONALITY_JSON_FILE"
+
+	// VcfTestPersonalityInfoJsonFile the local path of the info JSON file of an exported cluster image.
+	VcfTestPersonalityInfoJsonFile = "VCF_TEST_PERSONALITY_INFO_JSON_FILE"
+
+	// VcfTestLocalAccountPassword the current password of the admin@local account of SDDC Manager.
+	VcfTestLocalAccountPassword = "VCF_TEST_LOCAL_ACCOUNT_PASSWORD"
+
+	// VcfTestOidcDiscoveryEndpoint the OpenID Connect metadata URL of the Microsoft ADFS server of the identity provider acceptance test.
+	VcfTestOidcDiscoveryEndpoint = "VCF_TEST_OIDC_DISCOVERY_ENDPOINT"
+
+	// VcfTestOidcClientId the identifier of the client application registered in the Microsoft ADFS server.
+	VcfTestOidcClientId = "VCF_TEST_OIDC_CLIENT_ID"
+
+	// VcfTestOidcClientSecret the secret of the client application registered in the Microsoft ADFS server.
+	VcfTestOidcClientSecret = "VCF_TEST_OIDC_CLIENT_SECRET"
+
+	// VcfTestLdapDomain the Active Directory domain added as identity source in the identity source acceptance test.
+	VcfTestLdapDomain = "VCF_TEST_LDAP_DOMAIN"
+
+	// VcfTestLdapServerEndpoint the LDAP or LDAPS URL of a domain controller of the Active Directory domain.
+	VcfTestLdapServerEndpoint = "VCF_TEST_LDAP_SERVER_ENDPOINT"
+
+	// VcfTestLdapCertificate the PEM encoded certificate of the domain controller, required for an LDAPS URL.
+	VcfTestLdapCertificate = "VCF_TEST_LDAP_CERTIFICATE"
+
+	// VcfTestLdapUsername the user name of the bind user of the Active Directory domain.
+	VcfTestLdapUsername = "VCF_TEST_LDAP_USERNAME"
+
+	// VcfTestLdapPassword the password of the bind user of the Active Directory domain.
+	VcfTestLdapPassword = "VCF_TEST_LDAP_PASSWORD"
+
+	// VcfTestLdapBaseDn the base distinguished name of the users and groups of the Active Directory domain.
+	VcfTestLdapBaseDn = "VCF_TEST_LDAP_BASE_DN"
+
+	// VcfTestLicenseKey an ESXi license key not yet added to SDDC Manager, used in the license key acceptance test.
+	VcfTestLicenseKey = "VCF_TEST_LICENSE_KEY"
 )
 
 func GetIso3166CountryCodes() []string {
@@ -32,6 +32,7 @@ const (
 	ConfigAutoRotate = "UPDATE_AUTO_ROTATE_POLICY"
 	Rotate           = "ROTATE"
 	Update           = "UPDATE"
+	Remediate        = "REMEDIATE"
 )
 
 const (
@@ -69,3 +70,7 @@ func AllResourceTypes() []string {
 		ResourceTypeVrops,
 	}
 }
+
+func AllOperationTypes() []string {
+	return []string{Update, Rotate, Remediate, ConfigAutoRotate}
+}
@@ -23,8 +23,9 @@ func CredentialDataSubresource() *schema.Resource {
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Description: "The password of the account to which the credential belong",
+				Description: "The password of the account to which the credential belong. Only set when include_passwords is true",
 				Computed:    true,
+				Sensitive:   true,
 			},
 			"account_type": {
 				Type:        schema.TypeString,
@@ -56,6 +57,35 @@ func CredentialDataSubresource() *schema.Resource {
 				Computed:    true,
 				Description: "The last time the credentials are changed",
 			},
+			"expiry": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The password expiration details",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"expiry_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date on which the password expires",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expiration status of the password. One among ACTIVE, EXPIRING, EXPIRED, UNKNOWN",
+						},
+						"last_checked_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The last time the expiration of the password was checked",
+						},
+						"connectivity_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The connectivity status of the resource during the last check. One among ACTIVE, ERROR, UNKNOWN",
+						},
+					},
+				},
+			},
 			"resource": {
 				Type:     schema.TypeList,
 				Computed: true,
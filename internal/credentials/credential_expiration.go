@@ -0,0 +1,125 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client/credentials"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	ExpiryStatusActive   = "ACTIVE"
+	ExpiryStatusExpiring = "EXPIRING"
+	ExpiryStatusExpired  = "EXPIRED"
+	ExpiryStatusUnknown  = "UNKNOWN"
+)
+
+func AllExpiryStatuses() []string {
+	return []string{ExpiryStatusActive, ExpiryStatusExpiring, ExpiryStatusExpired, ExpiryStatusUnknown}
+}
+
+// RefreshPasswordExpiration triggers a password expiration check in SDDC Manager for all credentials
+// of the given resource type (and domain) and waits for it to complete.
+func RefreshPasswordExpiration(ctx context.Context, resourceType, domainName string, sddcClient *api_client.SddcManagerClient) error {
+	if err := sddcClient.Require(ctx, api_client.PasswordExpirationApi); err != nil {
+		return err
+	}
+
+	params := credentials.NewGetPasswordExpirationParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithCredentialsExpirationSpec(&models.CredentialsExpirationSpec{
+			ResourceType: &resourceType,
+			DomainName:   domainName,
+		})
+
+	_, accepted, err := sddcClient.ApiClient.Credentials.GetPasswordExpiration(params)
+	if err != nil {
+		return err
+	}
+
+	// The check has completed synchronously
+	if accepted == nil {
+		return nil
+	}
+	if accepted.Payload == nil {
+		return errors.New("password expiration check did not return a task")
+	}
+
+	return sddcClient.WaitForTask(ctx, accepted.Payload.ID)
+}
+
+// FilterExpiringCredentials returns the credentials which expiry status is one of the given statuses
+// or which expire within the given number of days. A non-positive withinDays disables the date check.
+func FilterExpiringCredentials(creds []*models.Credential, statuses []string, withinDays int, now time.Time) []*models.Credential {
+	result := make([]*models.Credential, 0)
+	deadline := now.AddDate(0, 0, withinDays)
+
+	for _, cred := range creds {
+		if cred.Expiry == nil {
+			continue
+		}
+
+		if slices.Contains(statuses, cred.Expiry.Status) {
+			result = append(result, cred)
+			continue
+		}
+
+		if withinDays > 0 {
+			if expiryDate, ok := parseTimestamp(cred.Expiry.ExpiryDate); ok && expiryDate.Before(deadline) {
+				result = append(result, cred)
+			}
+		}
+	}
+
+	return result
+}
+
+func FlattenCredentialExpirations(creds []*models.Credential) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(creds))
+
+	for _, cred := range creds {
+		entry := map[string]interface{}{
+			"id":                cred.ID,
+			"user_name":         cred.Username,
+			"account_type":      cred.AccountType,
+			"credential_type":   cred.CredentialType,
+			"modification_time": cred.ModificationTimestamp,
+		}
+		if cred.Resource != nil {
+			entry["resource_id"] = cred.Resource.ResourceID
+			entry["resource_name"] = cred.Resource.ResourceName
+			entry["resource_type"] = cred.Resource.ResourceType
+			entry["domain_name"] = cred.Resource.DomainName
+		}
+		if cred.Expiry != nil {
+			entry["expiry_date"] = cred.Expiry.ExpiryDate
+			entry["expiry_status"] = cred.Expiry.Status
+			entry["last_checked_date"] = cred.Expiry.LastCheckedDate
+			entry["connectivity_status"] = cred.Expiry.ConnectivityStatus
+		}
+
+		result = append(result, entry)
+	}
+
+	return result
+}
+
+func parseTimestamp(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -6,22 +6,22 @@ package credentials
 
 import (
 	"context"
-	md52 "crypto/md5"
-	"encoding/hex"
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"strconv"
 	"strings"
 
 	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vcf-sdk-go/client"
 	"github.com/vmware/vcf-sdk-go/client/credentials"
 	"github.com/vmware/vcf-sdk-go/models"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 )
 
 func ReadCredentials(ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient) ([]*models.Credential, error) {
@@ -73,7 +73,34 @@ func ReadCredentials(ctx context.Context, data *schema.ResourceData, apiClient *
 	return result, nil
 }
 
-func FlattenCredentials(creds []*models.Credential) []map[string]interface{} {
+// GetResourceCredential returns the credential of the given account on a resource, including its password.
+func GetResourceCredential(ctx context.Context, apiClient *client.VcfClient, resourceType, resourceName, userName,
+	credentialType string) (*models.Credential, error) {
+	params := credentials.NewGetCredentialsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithResourceType(&resourceType).
+		WithResourceName(&resourceName)
+
+	creds, err := apiClient.Credentials.GetCredentials(params)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cred := range creds.Payload.Elements {
+		if cred.Username == nil || *cred.Username != userName {
+			continue
+		}
+		if cred.CredentialType != nil && strings.EqualFold(*cred.CredentialType, credentialType) {
+			return cred, nil
+		}
+	}
+
+	return nil, fmt.Errorf("credential %s of type %s not found for %s %q", userName, credentialType, resourceType, resourceName)
+}
+
+// FlattenCredentials converts the credentials to the data source representation.
+// The passwords are only included when includePasswords is set.
+func FlattenCredentials(creds []*models.Credential, includePasswords bool) []map[string]interface{} {
 	if creds == nil {
 		return []map[string]interface{}{}
 	}
@@ -88,7 +115,6 @@ func FlattenCredentials(creds []*models.Credential) []map[string]interface{} {
 			"credential_type":   entry.CredentialType,
 			"modification_time": entry.ModificationTimestamp,
 			"user_name":         entry.Username,
-			"password":          entry.Password,
 			"resource": []map[string]string{{
 				"id":     *entry.Resource.ResourceID,
 				"domain": *entry.Resource.DomainName,
@@ -98,11 +124,24 @@ func FlattenCredentials(creds []*models.Credential) []map[string]interface{} {
 			}},
 		}
 
+		if includePasswords {
+			entryMap["password"] = entry.Password
+		}
+
 		if entry.AutoRotatePolicy != nil {
 			entryMap["auto_rotate_frequency_days"] = entry.AutoRotatePolicy.FrequencyInDays
 			entryMap["auto_rotate_next_schedule"] = entry.AutoRotatePolicy.NextSchedule
 		}
 
+		if entry.Expiry != nil {
+			entryMap["expiry"] = []map[string]string{{
+				"expiry_date":         entry.Expiry.ExpiryDate,
+				"status":              entry.Expiry.Status,
+				"last_checked_date":   entry.Expiry.LastCheckedDate,
+				"connectivity_status": entry.Expiry.ConnectivityStatus,
+			}}
+		}
+
 		credsArray = append(credsArray, entryMap)
 	}
 
@@ -132,24 +171,39 @@ func CreateAutoRotatePolicy(ctx context.Context, data *schema.ResourceData, meta
 	}
 
 	sddcClient := meta.(*api_client.SddcManagerClient)
-	return executeCredentialsUpdate(ctx, credentialsUpdateSpec, sddcClient)
+	_, err = executeCredentialsUpdate(ctx, credentialsUpdateSpec, sddcClient)
+	return err
 
 }
 
 func RotatePasswords(ctx context.Context, data *schema.ResourceData, meta interface{}) error {
-	return mutatePassword(ctx, data, meta, Rotate)
+	return mutatePassword(ctx, data, meta, data.Get("credentials").([]interface{}), Rotate)
 }
 
 func UpdatePasswords(ctx context.Context, data *schema.ResourceData, meta interface{}) error {
-	return mutatePassword(ctx, data, meta, Update)
+	creds := data.Get("credentials").([]interface{})
+	for i, listEntry := range creds {
+		entry := listEntry.(map[string]interface{})
+		password, _ := entry["password"].(string)
+
+		// Write-only values are never stored in the state, so they have to be read from the configuration
+		passwordWo, diags := data.GetRawConfigAt(cty.GetAttrPath("credentials").IndexInt(i).GetAttr("password_wo"))
+		if diags.HasError() {
+			return fmt.Errorf("cannot read password_wo of credential %q: %v", entry["user_name"], diags)
+		}
+		// The plan validation makes sure that exactly one of password or password_wo is set
+		if len(password) == 0 && passwordWo.IsKnown() && !passwordWo.IsNull() {
+			entry["password"] = passwordWo.AsString()
+		}
+	}
+
+	return mutatePassword(ctx, data, meta, creds, Update)
 }
 
-func mutatePassword(ctx context.Context, data *schema.ResourceData, meta interface{}, operationName string) error {
+func mutatePassword(ctx context.Context, data *schema.ResourceData, meta interface{}, creds []interface{}, operationName string) error {
 	resourceName := data.Get("resource_name").(string)
 	resourceType := data.Get("resource_type").(string)
 
-	creds := data.Get("credentials").([]interface{})
-
 	credentialsUpdateSpec := makeCredentialsChangeSpec(resourceType, resourceName, creds, operationName)
 
 	if err := credentialsUpdateSpec.Validate(strfmt.Default); err != nil {
@@ -158,7 +212,213 @@ func mutatePassword(ctx context.Context, data *schema.ResourceData, meta interfa
 
 	sddcClient := meta.(*api_client.SddcManagerClient)
 
-	return executeCredentialsUpdate(ctx, credentialsUpdateSpec, sddcClient)
+	_, err := executeCredentialsUpdate(ctx, credentialsUpdateSpec, sddcClient)
+	return err
+}
+
+// RotationOptions controls how RotateResourceCredentials splits and executes the rotation.
+type RotationOptions struct {
+	// ContinueOnFailure excludes the accounts which fail and rotates the remaining accounts in a new task
+	ContinueOnFailure bool
+	// BatchSize limits the number of accounts rotated by a single credentials task, 0 means no limit
+	BatchSize int
+}
+
+// BatchError is returned when a batch of a rotation fails, the passwords of the batches before it were rotated.
+type BatchError struct {
+	// Batch is the number of the failed batch, from 1
+	Batch   int
+	Batches int
+	cause   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch %d of %d failed to rotate: %s", e.Batch, e.Batches, e.cause)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.cause
+}
+
+// RotateResourceCredentials rotates the passwords of the given accounts on one or more resources
+// and returns the IDs of the completed credentials tasks.
+// The accounts are rotated in a single task unless a batch size is set, in which case the batches are
+// rotated one after the other, since SDDC Manager runs only one credentials operation at a time.
+// If ContinueOnFailure is set, the failed accounts are returned alongside the IDs of the successful tasks.
+// If one of several batches fails, a BatchError is returned along with the IDs of the tasks of the batches rotated
+// before it and their failed accounts.
+func RotateResourceCredentials(ctx context.Context, resources []interface{}, options RotationOptions,
+	sddcClient *api_client.SddcManagerClient) ([]string, []FailedAccount, error) {
+	operation := Rotate
+	credentialsUpdateSpec := &models.CredentialsUpdateSpec{
+		Elements:      make([]*models.ResourceCredentials, 0, len(resources)),
+		OperationType: &operation,
+	}
+
+	for _, resourceRaw := range resources {
+		resource := resourceRaw.(map[string]interface{})
+		resourceSpec := makeCredentialsChangeSpec(resource["resource_type"].(string), resource["resource_name"].(string),
+			resource["credentials"].([]interface{}), operation)
+		credentialsUpdateSpec.Elements = append(credentialsUpdateSpec.Elements, resourceSpec.Elements...)
+	}
+
+	if err := credentialsUpdateSpec.Validate(strfmt.Default); err != nil {
+		return nil, nil, err
+	}
+
+	taskIds := make([]string, 0)
+	failedAccounts := make([]FailedAccount, 0)
+	batches := splitIntoBatches(credentialsUpdateSpec.Elements, options.BatchSize)
+	for i, batch := range batches {
+		log.Printf("[DEBUG] Rotating credentials batch %d of %d with %d accounts", i+1, len(batches), countAccounts(batch))
+		batchSpec := &models.CredentialsUpdateSpec{
+			Elements:      batch,
+			OperationType: &operation,
+		}
+
+		taskId, batchFailedAccounts, err := rotateBatch(ctx, batchSpec, options.ContinueOnFailure, sddcClient)
+		if err != nil && len(batches) == 1 {
+			return nil, nil, err
+		}
+		if err != nil {
+			return taskIds, failedAccounts, &BatchError{Batch: i + 1, Batches: len(batches), cause: err}
+		}
+		if len(taskId) > 0 {
+			taskIds = append(taskIds, taskId)
+		}
+		failedAccounts = append(failedAccounts, batchFailedAccounts...)
+	}
+
+	if len(taskIds) == 0 {
+		return nil, nil, fmt.Errorf("the passwords of all accounts failed to rotate: %w", &TaskError{
+			FailedAccounts: failedAccounts,
+			cause:          errors.New("no credentials task succeeded"),
+		})
+	}
+
+	return taskIds, failedAccounts, nil
+}
+
+// rotateBatch executes a single credentials task and, if continueOnFailure is set, retries it
+// without the failed accounts. An empty task ID is returned if all accounts of the batch failed.
+// SDDC Manager rejects the credentials operations while a failed credentials task can still be retried, the failed
+// task is therefore cancelled before the remaining accounts are rotated.
+func rotateBatch(ctx context.Context, credentialsUpdateSpec *models.CredentialsUpdateSpec, continueOnFailure bool,
+	sddcClient *api_client.SddcManagerClient) (string, []FailedAccount, error) {
+	failedAccounts := make([]FailedAccount, 0)
+	for {
+		taskId, err := executeCredentialsUpdate(ctx, credentialsUpdateSpec, sddcClient)
+		if err == nil {
+			return taskId, failedAccounts, nil
+		}
+
+		var taskErr *TaskError
+		if !continueOnFailure || !errors.As(err, &taskErr) {
+			return "", nil, err
+		}
+
+		accountsCount := countAccounts(credentialsUpdateSpec.Elements)
+		remaining := excludeFailedAccounts(credentialsUpdateSpec.Elements, taskErr.FailedAccounts)
+		// The failed accounts could not be matched to the requested ones, retrying would fail the same way
+		if len(remaining) > 0 && countAccounts(remaining) == accountsCount {
+			return "", nil, err
+		}
+
+		log.Printf("[WARN] Credentials task %s failed, continuing with the remaining accounts: %s", taskId, err)
+		if cancelErr := CancelTask(ctx, taskId, sddcClient.ApiClient); cancelErr != nil {
+			return "", nil, errors.Join(err, fmt.Errorf("cannot cancel the failed credentials task %s: %w", taskId,
+				cancelErr))
+		}
+		failedAccounts = append(failedAccounts, taskErr.FailedAccounts...)
+		credentialsUpdateSpec.Elements = remaining
+		if len(credentialsUpdateSpec.Elements) == 0 {
+			return "", failedAccounts, nil
+		}
+	}
+}
+
+// splitIntoBatches splits the resource credentials into batches of at most batchSize accounts.
+// The accounts of a resource are split across batches if necessary.
+func splitIntoBatches(elements []*models.ResourceCredentials, batchSize int) [][]*models.ResourceCredentials {
+	if batchSize <= 0 {
+		return [][]*models.ResourceCredentials{elements}
+	}
+
+	batches := make([][]*models.ResourceCredentials, 0)
+	batch := make([]*models.ResourceCredentials, 0)
+	batchAccounts := 0
+	for _, element := range elements {
+		remaining := element.Credentials
+		for len(remaining) > 0 {
+			count := min(batchSize-batchAccounts, len(remaining))
+			batch = append(batch, &models.ResourceCredentials{
+				ResourceID:   element.ResourceID,
+				ResourceName: element.ResourceName,
+				ResourceType: element.ResourceType,
+				Credentials:  remaining[:count],
+			})
+			remaining = remaining[count:]
+			batchAccounts += count
+
+			if batchAccounts == batchSize {
+				batches = append(batches, batch)
+				batch = make([]*models.ResourceCredentials, 0)
+				batchAccounts = 0
+			}
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+// excludeFailedAccounts returns the resource credentials without the failed accounts, the resources left without
+// accounts are left out.
+func excludeFailedAccounts(elements []*models.ResourceCredentials, failedAccounts []FailedAccount) []*models.ResourceCredentials {
+	result := make([]*models.ResourceCredentials, 0, len(elements))
+	for _, element := range elements {
+		remaining := make([]*models.BaseCredential, 0, len(element.Credentials))
+		for _, credential := range element.Credentials {
+			if !isFailedAccount(element.ResourceName, credential, failedAccounts) {
+				remaining = append(remaining, credential)
+			}
+		}
+
+		if len(remaining) > 0 {
+			result = append(result, &models.ResourceCredentials{
+				ResourceID:   element.ResourceID,
+				ResourceName: element.ResourceName,
+				ResourceType: element.ResourceType,
+				Credentials:  remaining,
+			})
+		}
+	}
+
+	return result
+}
+
+func countAccounts(elements []*models.ResourceCredentials) int {
+	count := 0
+	for _, element := range elements {
+		count += len(element.Credentials)
+	}
+
+	return count
+}
+
+// isFailedAccount returns whether the account of the resource is one of the failed accounts, a failed account without
+// a credential type matches the accounts of the user name of any type.
+func isFailedAccount(resourceName string, credential *models.BaseCredential, failedAccounts []FailedAccount) bool {
+	for _, account := range failedAccounts {
+		if account.ResourceName == resourceName && account.UserName == *credential.Username &&
+			(len(account.CredentialType) == 0 || strings.EqualFold(account.CredentialType, credential.CredentialType)) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func RemoveAutoRotatePolicy(ctx context.Context, data *schema.ResourceData, meta interface{}) error {
@@ -177,7 +437,8 @@ func RemoveAutoRotatePolicy(ctx context.Context, data *schema.ResourceData, meta
 	}
 
 	sddcClient := meta.(*api_client.SddcManagerClient)
-	return executeCredentialsUpdate(ctx, credentialsUpdateSpec, sddcClient)
+	_, err = executeCredentialsUpdate(ctx, credentialsUpdateSpec, sddcClient)
+	return err
 }
 
 func makeAutoRotatePolicySpec(autoRotateEnabled bool, autoRotateDays int32, resourceName string, resourceId string, resourceType string, userName string) (*models.CredentialsUpdateSpec, error) {
@@ -206,29 +467,33 @@ func makeAutoRotatePolicySpec(autoRotateEnabled bool, autoRotateDays int32, reso
 	}, nil
 }
 
-func executeCredentialsUpdate(ctx context.Context, updateSpec *models.CredentialsUpdateSpec, sddcClient *api_client.SddcManagerClient) error {
+func executeCredentialsUpdate(ctx context.Context, updateSpec *models.CredentialsUpdateSpec, sddcClient *api_client.SddcManagerClient) (string, error) {
 	param := credentials.NewUpdateOrRotatePasswordsParamsWithContext(ctx)
 	param.WithCredentialsUpdateSpec(updateSpec)
 
 	apiClient := sddcClient.ApiClient
 	ok, accepted, err := apiClient.Credentials.UpdateOrRotatePasswords(param)
 	if err != nil {
-		return err
+		return "", describeBlockingTask(ctx, err, apiClient)
 	}
 
 	if ok != nil && !ok.IsSuccess() {
-		return errors.New(ok.Error())
+		return "", errors.New(ok.Error())
 	}
 
 	if accepted != nil && !accepted.IsSuccess() {
-		return errors.New(accepted.Error())
+		return "", errors.New(accepted.Error())
 	}
 
 	if err := sddcClient.WaitForTask(ctx, accepted.Payload.ID); err != nil {
-		return err
+		failedAccounts, failedAccountsErr := GetFailedAccounts(ctx, accepted.Payload.ID, sddcClient)
+		if failedAccountsErr != nil || len(failedAccounts) == 0 {
+			return accepted.Payload.ID, err
+		}
+		return accepted.Payload.ID, &TaskError{TaskID: accepted.Payload.ID, FailedAccounts: failedAccounts, cause: err}
 	}
 
-	return nil
+	return accepted.Payload.ID, nil
 }
 
 func CreatePasswordChangeID(data *schema.ResourceData, operation string) (string, error) {
@@ -246,18 +511,7 @@ func CreatePasswordChangeID(data *schema.ResourceData, operation string) (string
 		params = append(params, fmt.Sprintf("credential:%s|%s", credentialType, username))
 	}
 
-	return HashFields(params)
-}
-
-func HashFields(fields []string) (string, error) {
-	md5 := md52.New()
-	_, err := io.WriteString(md5, strings.Join(fields, ""))
-
-	if err != nil {
-		return "", err
-	}
-
-	return hex.EncodeToString(md5.Sum(nil)), nil
+	return utils.HashFields(params)
 }
 
 func makeCredentialsChangeSpec(resourceType string, resourceName string, credentialsList []interface{}, operation string) *models.CredentialsUpdateSpec {
@@ -0,0 +1,201 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/simulator"
+)
+
+func testResourceCredentials(resourceName string, userNames ...string) *models.ResourceCredentials {
+	resourceType := ResourceTypeEsxi
+	element := &models.ResourceCredentials{ResourceName: resourceName, ResourceType: &resourceType}
+	for _, userName := range userNames {
+		element.Credentials = append(element.Credentials, &models.BaseCredential{
+			CredentialType: "SSH",
+			Username:       &userName,
+		})
+	}
+	return element
+}
+
+// batchAccounts returns the accounts of each batch as resource/user.
+func batchAccounts(batches [][]*models.ResourceCredentials) [][]string {
+	result := make([][]string, 0, len(batches))
+	for _, batch := range batches {
+		accounts := make([]string, 0)
+		for _, element := range batch {
+			for _, credential := range element.Credentials {
+				accounts = append(accounts, element.ResourceName+"/"+*credential.Username)
+			}
+		}
+		result = append(result, accounts)
+	}
+	return result
+}
+
+func TestSplitIntoBatches(t *testing.T) {
+	elements := []*models.ResourceCredentials{
+		testResourceCredentials("esx01", "root", "svc-vcf"),
+		testResourceCredentials("esx02", "root", "svc-vcf", "audit"),
+		testResourceCredentials("esx03", "root"),
+	}
+
+	// No batch size rotates all the accounts at once
+	assert.Equal(t, [][]string{{"esx01/root", "esx01/svc-vcf", "esx02/root", "esx02/svc-vcf", "esx02/audit", "esx03/root"}},
+		batchAccounts(splitIntoBatches(elements, 0)))
+
+	// The accounts of a resource are split across batches
+	assert.Equal(t, [][]string{
+		{"esx01/root", "esx01/svc-vcf", "esx02/root", "esx02/svc-vcf"},
+		{"esx02/audit", "esx03/root"},
+	}, batchAccounts(splitIntoBatches(elements, 4)))
+	assert.Equal(t, [][]string{
+		{"esx01/root", "esx01/svc-vcf"},
+		{"esx02/root", "esx02/svc-vcf"},
+		{"esx02/audit", "esx03/root"},
+	}, batchAccounts(splitIntoBatches(elements, 2)))
+	assert.Len(t, splitIntoBatches(elements, 1), 6)
+	assert.Len(t, splitIntoBatches(elements, 10), 1)
+}
+
+func testRotationResources(resourceNames ...string) []interface{} {
+	resources := make([]interface{}, 0, len(resourceNames))
+	for _, resourceName := range resourceNames {
+		resources = append(resources, map[string]interface{}{
+			"resource_name": resourceName,
+			"resource_type": ResourceTypeEsxi,
+			"credentials": []interface{}{
+				map[string]interface{}{"credential_type": "SSH", "user_name": "root"},
+			},
+		})
+	}
+	return resources
+}
+
+func testSimulatorClient(t *testing.T, sddcManager *simulator.Simulator) *api_client.SddcManagerClient {
+	client := api_client.NewSddcManagerClient("admin@local", "VMware123!VMware123!", sddcManager.Host(), true).
+		WithTaskWaitSettings(api_client.TaskWaitSettings{PollInterval: 10 * time.Millisecond})
+	if err := client.Connect(); err != nil {
+		t.Fatalf("cannot connect to the simulator: %v", err)
+	}
+	return client
+}
+
+func TestRotateResourceCredentialsBatchFailure(t *testing.T) {
+	sddcManager := simulator.New()
+	defer sddcManager.Close()
+	client := testSimulatorClient(t, sddcManager)
+	sddcManager.FailCredentialOperation("esx03", "root", "the host is not reachable")
+
+	// The tasks of the batches rotated before the failed one are returned with the error
+	taskIds, failedAccounts, err := RotateResourceCredentials(context.Background(),
+		testRotationResources("esx01", "esx02", "esx03", "esx04"), RotationOptions{BatchSize: 1}, client)
+	assert.Len(t, taskIds, 2)
+	assert.Empty(t, failedAccounts)
+	var batchErr *BatchError
+	assert.True(t, errors.As(err, &batchErr), "%v", err)
+	assert.Equal(t, 3, batchErr.Batch)
+	assert.Equal(t, 4, batchErr.Batches)
+	var taskErr *TaskError
+	assert.True(t, errors.As(err, &taskErr))
+	assert.Len(t, taskErr.FailedAccounts, 1)
+	assert.Equal(t, "esx03", taskErr.FailedAccounts[0].ResourceName)
+	assert.Contains(t, taskErr.FailedAccounts[0].Message, "the host is not reachable")
+
+	// The failed task blocks the next operations until it is cancelled
+	_, _, err = RotateResourceCredentials(context.Background(), testRotationResources("esx04"), RotationOptions{}, client)
+	assert.ErrorContains(t, err, "cancel or retry it")
+	assert.NoError(t, CancelTask(context.Background(), taskErr.TaskID, client.ApiClient))
+
+	// A single batch fails without any rotated task
+	taskIds, _, err = RotateResourceCredentials(context.Background(), testRotationResources("esx03"),
+		RotationOptions{}, client)
+	assert.Empty(t, taskIds)
+	assert.False(t, errors.As(err, &batchErr))
+	assert.True(t, errors.As(err, &taskErr))
+}
+
+func TestRotateResourceCredentialsContinueOnFailure(t *testing.T) {
+	sddcManager := simulator.New()
+	defer sddcManager.Close()
+	client := testSimulatorClient(t, sddcManager)
+	sddcManager.FailCredentialOperation("esx02", "root", "the host is not reachable")
+
+	// The failed task is cancelled before the remaining accounts are rotated, in the batch and in the next ones
+	taskIds, failedAccounts, err := RotateResourceCredentials(context.Background(),
+		testRotationResources("esx01", "esx02", "esx03"), RotationOptions{ContinueOnFailure: true, BatchSize: 2}, client)
+	assert.NoError(t, err)
+	assert.Len(t, taskIds, 2)
+	assert.Equal(t, []FailedAccount{{ResourceName: "esx02", UserName: "root", CredentialType: "SSH",
+		Message: "the host is not reachable"}}, failedAccounts)
+	assert.Equal(t, 1, sddcManager.RequestCount("DELETE", "/v1/credentials/tasks/"+failedTaskId(t, client)))
+
+	// The batch of a single failed account is skipped
+	sddcManager.FailCredentialOperation("esx03", "root", "the host is not reachable")
+	taskIds, failedAccounts, err = RotateResourceCredentials(context.Background(),
+		testRotationResources("esx03", "esx01"), RotationOptions{ContinueOnFailure: true, BatchSize: 1}, client)
+	assert.NoError(t, err)
+	assert.Len(t, taskIds, 1)
+	assert.Len(t, failedAccounts, 1)
+}
+
+// failedTaskId returns the ID of the first credentials task which failed, then was cancelled.
+func failedTaskId(t *testing.T, client *api_client.SddcManagerClient) string {
+	tasks, err := GetCredentialsTasks(context.Background(), 0, client.ApiClient)
+	assert.NoError(t, err)
+	for i := len(tasks) - 1; i >= 0; i-- {
+		if tasks[i].Status == TaskStatusCancelled {
+			return tasks[i].ID
+		}
+	}
+	return ""
+}
+
+func TestExcludeFailedAccounts(t *testing.T) {
+	elements := []*models.ResourceCredentials{
+		testResourceCredentials("esx01", "root", "svc-vcf"),
+		testResourceCredentials("esx02", "root"),
+	}
+	failedAccounts := []FailedAccount{
+		{ResourceName: "esx01", UserName: "svc-vcf", CredentialType: "ssh"},
+		{ResourceName: "esx02", UserName: "root"},
+	}
+
+	remaining := excludeFailedAccounts(elements, failedAccounts)
+	assert.Equal(t, [][]string{{"esx01/root"}}, batchAccounts([][]*models.ResourceCredentials{remaining}))
+	// The resource credentials are not changed
+	assert.Equal(t, 3, countAccounts(elements))
+
+	assert.Empty(t, excludeFailedAccounts(elements, append(failedAccounts, FailedAccount{ResourceName: "esx01",
+		UserName: "root"})))
+	assert.Equal(t, 3, countAccounts(excludeFailedAccounts(elements, nil)))
+}
+
+func TestIsFailedAccount(t *testing.T) {
+	userName := "root"
+	credential := &models.BaseCredential{CredentialType: "SSH", Username: &userName}
+
+	assert.True(t, isFailedAccount("esx01", credential, []FailedAccount{{ResourceName: "esx01", UserName: "root",
+		CredentialType: "SSH"}}))
+	// The credential types are compared without case, a failed account without type matches any type
+	assert.True(t, isFailedAccount("esx01", credential, []FailedAccount{{ResourceName: "esx01", UserName: "root",
+		CredentialType: "ssh"}}))
+	assert.True(t, isFailedAccount("esx01", credential, []FailedAccount{{ResourceName: "esx01", UserName: "root"}}))
+
+	assert.False(t, isFailedAccount("esx01", credential, []FailedAccount{{ResourceName: "esx01", UserName: "root",
+		CredentialType: "API"}}))
+	assert.False(t, isFailedAccount("esx01", credential, []FailedAccount{{ResourceName: "esx02", UserName: "root"}}))
+	assert.False(t, isFailedAccount("esx01", credential, []FailedAccount{{ResourceName: "esx01", UserName: "admin"}}))
+	assert.False(t, isFailedAccount("esx01", credential, nil))
+}
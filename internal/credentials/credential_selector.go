@@ -0,0 +1,93 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package credentials
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/credentials"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const credentialsPageSize = 100
+
+// SelectResourceCredentials looks up all accounts of the given resource types, optionally restricted
+// to some domains and account types, and returns them grouped by resource in the format of the
+// "resource" block of the vcf_credentials_rotation resource.
+func SelectResourceCredentials(ctx context.Context, apiClient *client.VcfClient, resourceTypes, domainNames,
+	accountTypes []string) ([]interface{}, error) {
+	resources := make([]interface{}, 0)
+	resourceIndexes := make(map[string]int)
+
+	for _, resourceType := range resourceTypes {
+		creds, err := getAllCredentials(ctx, apiClient, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cred := range creds {
+			if cred.Resource == nil || cred.Resource.ResourceName == nil || cred.Username == nil || cred.CredentialType == nil {
+				continue
+			}
+			if len(domainNames) > 0 && (cred.Resource.DomainName == nil || !slices.Contains(domainNames, *cred.Resource.DomainName)) {
+				continue
+			}
+			if len(accountTypes) > 0 && (cred.AccountType == nil || !slices.Contains(accountTypes, *cred.AccountType)) {
+				continue
+			}
+
+			key := resourceType + "/" + *cred.Resource.ResourceName
+			index, ok := resourceIndexes[key]
+			if !ok {
+				index = len(resources)
+				resourceIndexes[key] = index
+				resources = append(resources, map[string]interface{}{
+					"resource_name": *cred.Resource.ResourceName,
+					"resource_type": resourceType,
+					"credentials":   make([]interface{}, 0),
+				})
+			}
+
+			resource := resources[index].(map[string]interface{})
+			resource["credentials"] = append(resource["credentials"].([]interface{}), map[string]interface{}{
+				"credential_type": *cred.CredentialType,
+				"user_name":       *cred.Username,
+			})
+		}
+	}
+
+	return resources, nil
+}
+
+func getAllCredentials(ctx context.Context, apiClient *client.VcfClient, resourceType string) ([]*models.Credential, error) {
+	result := make([]*models.Credential, 0)
+	pageSize := strconv.Itoa(credentialsPageSize)
+
+	for page := 0; ; page++ {
+		pageNumber := strconv.Itoa(page)
+		params := credentials.NewGetCredentialsParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithResourceType(&resourceType).
+			WithPageNumber(&pageNumber).
+			WithPageSize(&pageSize)
+
+		creds, err := apiClient.Credentials.GetCredentials(params)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, creds.Payload.Elements...)
+
+		metadata := creds.Payload.PageMetadata
+		if metadata == nil || int32(len(result)) >= metadata.TotalElements || len(creds.Payload.Elements) == 0 {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,410 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/credentials"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	TaskStatusPending      = "PENDING"
+	TaskStatusInProgress   = "IN_PROGRESS"
+	TaskStatusSuccessful   = "SUCCESSFUL"
+	TaskStatusFailed       = "FAILED"
+	TaskStatusCancelled    = "USER_CANCELLED"
+	TaskStatusInconsistent = "INCONSISTENT"
+)
+
+func AllTaskStatuses() []string {
+	return []string{TaskStatusPending, TaskStatusInProgress, TaskStatusSuccessful, TaskStatusFailed, TaskStatusCancelled,
+		TaskStatusInconsistent}
+}
+
+const (
+	TaskActionCancel = "CANCEL"
+	TaskActionRetry  = "RETRY"
+)
+
+// credentialsTasksLimit is the number of recent credentials tasks inspected for one blocking further operations
+const credentialsTasksLimit = 50
+
+// FailedAccount describes an account which password could not be changed by a credentials task.
+type FailedAccount struct {
+	ResourceName   string
+	UserName       string
+	CredentialType string
+	Message        string
+}
+
+// TaskError is returned when a credentials task fails and carries the accounts that caused the failure.
+type TaskError struct {
+	TaskID         string
+	FailedAccounts []FailedAccount
+	cause          error
+}
+
+func (e *TaskError) Error() string {
+	details := make([]string, 0, len(e.FailedAccounts))
+	for _, account := range e.FailedAccounts {
+		details = append(details, account.String())
+	}
+
+	return fmt.Sprintf("%s, failed accounts: %s", e.cause, strings.Join(details, "; "))
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.cause
+}
+
+func (account FailedAccount) String() string {
+	return fmt.Sprintf("%s/%s (%s): %s", account.ResourceName, account.UserName, account.CredentialType, account.Message)
+}
+
+// GetFailedAccounts returns the accounts which sub-tasks have failed in the given credentials task.
+func GetFailedAccounts(ctx context.Context, taskId string, sddcClient *api_client.SddcManagerClient) ([]FailedAccount, error) {
+	params := credentials.NewGetCredentialsTaskParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(taskId)
+
+	result, err := sddcClient.ApiClient.Credentials.GetCredentialsTask(params)
+	if err != nil {
+		return nil, err
+	}
+
+	failedAccounts := make([]FailedAccount, 0)
+	if result.Payload != nil {
+		collectFailedAccounts(result.Payload.SubTasks, &failedAccounts)
+	}
+
+	return failedAccounts, nil
+}
+
+func collectFailedAccounts(subTasks []*models.CredentialsSubTask, failedAccounts *[]FailedAccount) {
+	for _, subTask := range subTasks {
+		if subTask == nil {
+			continue
+		}
+
+		// Failures are reported on the innermost sub-tasks, the parents only mirror their status
+		if len(subTask.DependentSubTasks) > 0 {
+			collectFailedAccounts(subTask.DependentSubTasks, failedAccounts)
+			continue
+		}
+
+		if subTask.Status != TaskStatusFailed {
+			continue
+		}
+
+		*failedAccounts = append(*failedAccounts, FailedAccount{
+			ResourceName:   subTask.ResourceName,
+			UserName:       subTask.Username,
+			CredentialType: subTask.CredentialType,
+			Message:        errorMessages(subTask.Errors),
+		})
+	}
+}
+
+func errorMessages(taskErrors []*models.Error) string {
+	messages := make([]string, 0, len(taskErrors))
+	for _, taskError := range taskErrors {
+		if taskError == nil {
+			continue
+		}
+		if len(taskError.Message) > 0 {
+			messages = append(messages, taskError.Message)
+		}
+		if len(taskError.NestedErrors) > 0 {
+			messages = append(messages, errorMessages(taskError.NestedErrors))
+		}
+	}
+
+	if len(messages) == 0 {
+		return "unknown error"
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+// GetCredentialsTask returns the credentials task with the given ID.
+func GetCredentialsTask(ctx context.Context, taskId string, apiClient *client.VcfClient) (*models.CredentialsTask, error) {
+	params := credentials.NewGetCredentialsTaskParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(taskId)
+
+	result, err := apiClient.Credentials.GetCredentialsTask(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// FindBlockingTask returns the most recent credentials task which has not completed successfully and
+// prevents SDDC Manager from accepting further credentials operations, or nil if there is none.
+func FindBlockingTask(ctx context.Context, apiClient *client.VcfClient) (*models.CredentialsTask, error) {
+	tasks, err := GetCredentialsTasks(ctx, credentialsTasksLimit, apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	blockingStatuses := []string{TaskStatusPending, TaskStatusInProgress, TaskStatusFailed, TaskStatusInconsistent}
+	var blockingTask *models.CredentialsTask
+	for _, task := range tasks {
+		if task == nil || !slices.Contains(blockingStatuses, task.Status) {
+			continue
+		}
+		// The timestamps are in ISO 8601 format, so they can be compared as strings
+		if blockingTask == nil || task.CreationTimestamp > blockingTask.CreationTimestamp {
+			blockingTask = task
+		}
+	}
+
+	return blockingTask, nil
+}
+
+// CancelTask cancels a credentials task which is stuck or has failed.
+func CancelTask(ctx context.Context, taskId string, apiClient *client.VcfClient) error {
+	params := credentials.NewCancelCredentialsTaskParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(taskId)
+
+	_, _, err := apiClient.Credentials.CancelCredentialsTask(params)
+	return err
+}
+
+// RetryTask retries a failed credentials task and waits for it to complete.
+// SDDC Manager expects the specification of the original operation, which is reconstructed from
+// the sub-tasks. Tasks that set passwords to known values cannot be retried since the passwords
+// are not returned by the API.
+func RetryTask(ctx context.Context, taskId string, sddcClient *api_client.SddcManagerClient) error {
+	task, err := GetCredentialsTask(ctx, taskId, sddcClient.ApiClient)
+	if err != nil {
+		return err
+	}
+	if task.Type != Rotate {
+		return fmt.Errorf("credentials task %s of type %s cannot be retried, only %s tasks can. Cancel it instead",
+			taskId, task.Type, Rotate)
+	}
+
+	operation := task.Type
+	spec := &models.CredentialsUpdateSpec{
+		Elements:      makeRetryElements(task.SubTasks),
+		OperationType: &operation,
+	}
+	if len(spec.Elements) == 0 {
+		return fmt.Errorf("credentials task %s has no accounts to retry", taskId)
+	}
+
+	params := credentials.NewRetryCredentialsTaskParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(taskId).
+		WithCredentialsUpdateSpec(spec)
+
+	ok, accepted, err := sddcClient.ApiClient.Credentials.RetryCredentialsTask(params)
+	if err != nil {
+		return err
+	}
+
+	retriedTaskId := taskId
+	if accepted != nil && accepted.Payload != nil && len(accepted.Payload.ID) > 0 {
+		retriedTaskId = accepted.Payload.ID
+	} else if ok != nil && ok.Payload != nil && len(ok.Payload.ID) > 0 {
+		retriedTaskId = ok.Payload.ID
+	}
+
+	if err := sddcClient.WaitForTask(ctx, retriedTaskId); err != nil {
+		failedAccounts, failedAccountsErr := GetFailedAccounts(ctx, retriedTaskId, sddcClient)
+		if failedAccountsErr != nil || len(failedAccounts) == 0 {
+			return err
+		}
+		return &TaskError{TaskID: retriedTaskId, FailedAccounts: failedAccounts, cause: err}
+	}
+
+	return nil
+}
+
+func makeRetryElements(subTasks []*models.CredentialsSubTask) []*models.ResourceCredentials {
+	elements := make([]*models.ResourceCredentials, 0)
+	elementIndexes := make(map[string]int)
+
+	var collect func(subTasks []*models.CredentialsSubTask)
+	collect = func(subTasks []*models.CredentialsSubTask) {
+		for _, subTask := range subTasks {
+			if subTask == nil {
+				continue
+			}
+			if len(subTask.DependentSubTasks) > 0 {
+				collect(subTask.DependentSubTasks)
+				continue
+			}
+			if len(subTask.ResourceName) == 0 || len(subTask.Username) == 0 {
+				continue
+			}
+
+			key := subTask.EntityType + "/" + subTask.ResourceName
+			index, ok := elementIndexes[key]
+			if !ok {
+				resourceType := subTask.EntityType
+				index = len(elements)
+				elementIndexes[key] = index
+				elements = append(elements, &models.ResourceCredentials{
+					ResourceName: subTask.ResourceName,
+					ResourceType: &resourceType,
+				})
+			}
+
+			userName := subTask.Username
+			elements[index].Credentials = append(elements[index].Credentials, &models.BaseCredential{
+				CredentialType: subTask.CredentialType,
+				Username:       &userName,
+			})
+		}
+	}
+	collect(subTasks)
+
+	return elements
+}
+
+// describeBlockingTask extends the error of a rejected credentials operation with the task that blocks it, if any.
+func describeBlockingTask(ctx context.Context, err error, apiClient *client.VcfClient) error {
+	blockingTask, lookupErr := FindBlockingTask(ctx, apiClient)
+	if lookupErr != nil || blockingTask == nil {
+		return err
+	}
+
+	return errors.Join(err, fmt.Errorf("credentials task %s (%s) is in state %s and may block further credentials "+
+		"operations, cancel or retry it with the vcf_credentials_task_recovery resource",
+		blockingTask.ID, blockingTask.Type, blockingTask.Status))
+}
+
+// GetCredentialsTasks returns the most recent credentials tasks, at most limit of them if limit is positive.
+func GetCredentialsTasks(ctx context.Context, limit int, apiClient *client.VcfClient) ([]*models.CredentialsTask, error) {
+	params := credentials.NewGetCredentialsTasksParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	if limit > 0 {
+		limit32 := int32(limit)
+		params.WithLimit(&limit32)
+	}
+
+	result, err := apiClient.Credentials.GetCredentialsTasks(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return []*models.CredentialsTask{}, nil
+	}
+
+	return result.Payload.Elements, nil
+}
+
+// FilterCredentialsTasks returns the tasks matching all given filters. Empty filters match any task.
+// The tasks created before since are skipped unless since is the zero time.
+func FilterCredentialsTasks(tasks []*models.CredentialsTask, types, statuses []string, resourceName string,
+	since time.Time) []*models.CredentialsTask {
+	result := make([]*models.CredentialsTask, 0)
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+		if len(types) > 0 && !slices.Contains(types, task.Type) {
+			continue
+		}
+		if len(statuses) > 0 && !slices.Contains(statuses, task.Status) {
+			continue
+		}
+		if !since.IsZero() {
+			if created, ok := parseTimestamp(task.CreationTimestamp); !ok || created.Before(since) {
+				continue
+			}
+		}
+		if len(resourceName) > 0 && !hasResourceSubTask(task.SubTasks, resourceName) {
+			continue
+		}
+		result = append(result, task)
+	}
+
+	return result
+}
+
+func hasResourceSubTask(subTasks []*models.CredentialsSubTask, resourceName string) bool {
+	for _, subTask := range subTasks {
+		if subTask == nil {
+			continue
+		}
+		if subTask.ResourceName == resourceName || hasResourceSubTask(subTask.DependentSubTasks, resourceName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FlattenCredentialsTasks converts the tasks to the format of the vcf_credentials_tasks data source.
+// The passwords of the sub-tasks are never included.
+func FlattenCredentialsTasks(tasks []*models.CredentialsTask) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		accounts := make([]map[string]interface{}, 0)
+		collectTaskAccounts(task.SubTasks, &accounts)
+
+		result = append(result, map[string]interface{}{
+			"id":                 task.ID,
+			"name":               task.Name,
+			"type":               task.Type,
+			"status":             task.Status,
+			"creation_timestamp": task.CreationTimestamp,
+			"is_auto_rotate":     task.IsAutoRotate,
+			"errors":             errorList(task.Errors),
+			"accounts":           accounts,
+		})
+	}
+
+	return result
+}
+
+func collectTaskAccounts(subTasks []*models.CredentialsSubTask, accounts *[]map[string]interface{}) {
+	for _, subTask := range subTasks {
+		if subTask == nil {
+			continue
+		}
+		if len(subTask.DependentSubTasks) > 0 {
+			collectTaskAccounts(subTask.DependentSubTasks, accounts)
+			continue
+		}
+
+		*accounts = append(*accounts, map[string]interface{}{
+			"resource_name":      subTask.ResourceName,
+			"resource_type":      subTask.EntityType,
+			"user_name":          subTask.Username,
+			"credential_type":    subTask.CredentialType,
+			"status":             subTask.Status,
+			"creation_timestamp": subTask.CreationTimestamp,
+			"errors":             errorList(subTask.Errors),
+		})
+	}
+}
+
+func errorList(taskErrors []*models.Error) []string {
+	result := make([]string, 0, len(taskErrors))
+	for _, taskError := range taskErrors {
+		if taskError != nil && len(taskError.Message) > 0 {
+			result = append(result, taskError.Message)
+		}
+	}
+
+	return result
+}
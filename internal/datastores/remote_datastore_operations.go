@@ -0,0 +1,91 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package datastores
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/clusters"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	// VsanRemoteDatastoresCriterion is the datastore query criterion listing the vSAN datastores a cluster can mount.
+	VsanRemoteDatastoresCriterion = "VSAN_REMOTE_DATASTORES"
+
+	datastoreQueryPollInterval = 5 * time.Second
+)
+
+// GetClusterDatastores returns the datastores mounted on the cluster.
+func GetClusterDatastores(ctx context.Context, clusterId string, apiClient *client.VcfClient) ([]*models.Datastore, error) {
+	params := clusters.NewGetClusterDatastoresParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	params.ID = clusterId
+
+	result, err := apiClient.Clusters.GetClusterDatastores(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// GetMountableRemoteDatastores queries the vSAN datastores of the other clusters the cluster can mount with
+// HCI Mesh, and polls the query until it completes.
+func GetMountableRemoteDatastores(ctx context.Context, clusterId string, apiClient *client.VcfClient) ([]*models.Datastore, error) {
+	params := clusters.NewPostDatastoreQueryParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	params.ID = clusterId
+	params.DsCriterion = &models.DatastoreCriterion{Name: VsanRemoteDatastoresCriterion}
+
+	result, err := apiClient.Clusters.PostDatastoreQuery(params)
+	if err != nil {
+		return nil, err
+	}
+	response := result.Payload
+
+	for {
+		if response == nil || response.QueryInfo == nil {
+			return nil, fmt.Errorf("the datastore query of cluster %s returned no query", clusterId)
+		}
+		queryInfo := response.QueryInfo
+		if queryInfo.Failure {
+			message := queryInfo.Status
+			if queryInfo.ErrorResponse != nil {
+				message = queryInfo.ErrorResponse.Message
+			}
+			return nil, fmt.Errorf("the datastore query of cluster %s failed: %s", clusterId, message)
+		}
+		if queryInfo.Completed {
+			if response.Result == nil {
+				return []*models.Datastore{}, nil
+			}
+			return response.Result.Elements, nil
+		}
+
+		log.Printf("[DEBUG] Datastore query %s of cluster %s is %s", queryInfo.QueryID, clusterId, queryInfo.Status)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for the datastore query of cluster %s: %w", clusterId, ctx.Err())
+		case <-time.After(datastoreQueryPollInterval):
+		}
+
+		queryParams := clusters.NewGetDatastoreQueryResponseParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout)
+		queryParams.ClusterID = clusterId
+		queryParams.QueryID = queryInfo.QueryID
+		queryResult, err := apiClient.Clusters.GetDatastoreQueryResponse(queryParams)
+		if err != nil {
+			return nil, err
+		}
+		response = queryResult.Payload
+	}
+}
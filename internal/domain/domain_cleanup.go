@@ -0,0 +1,83 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/vmware/vcf-sdk-go/client/domains"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// DeleteDomain marks the workload domain for deletion and deletes it together with its clusters. The hosts of
+// the domain are left unassigned.
+func DeleteDomain(ctx context.Context, vcfClient *api_client.SddcManagerClient, domainId string) error {
+	apiClient := vcfClient.ApiClient
+
+	domainUpdateParams := domains.NewUpdateDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	domainUpdateParams.DomainUpdateSpec = CreateDomainUpdateSpec(nil, true)
+	domainUpdateParams.ID = domainId
+
+	log.Printf("Marking domain %s for deletion", domainId)
+	acceptedUpdateTask, _, err := apiClient.Domains.UpdateDomain(domainUpdateParams)
+	if err != nil {
+		return err
+	}
+	taskId := acceptedUpdateTask.Payload.ID
+	if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+		return err
+	}
+
+	domainDeleteParams := domains.NewDeleteDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	domainDeleteParams.ID = domainId
+
+	log.Printf("Deleting domain %s", domainId)
+	acceptedDeleteTask, acceptedDeleteTask2, err := apiClient.Domains.DeleteDomain(domainDeleteParams)
+	if err != nil {
+		return err
+	}
+	if acceptedDeleteTask != nil {
+		taskId = acceptedDeleteTask.Payload.ID
+	}
+	if acceptedDeleteTask2 != nil {
+		taskId = acceptedDeleteTask2.Payload.ID
+	}
+
+	return vcfClient.WaitForTaskComplete(ctx, taskId, true)
+}
+
+// SweepDomains deletes the workload domains with the given names, e.g. the ones left behind by failed acceptance
+// tests. The management domain is never deleted. A failure does not stop the deletion of the other domains.
+func SweepDomains(ctx context.Context, vcfClient *api_client.SddcManagerClient, names []string) error {
+	params := domains.NewGetDomainsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	domainsResponse, err := vcfClient.ApiClient.Domains.GetDomains(params)
+	if err != nil {
+		return err
+	}
+	if domainsResponse.Payload == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, domainElement := range domainsResponse.Payload.Elements {
+		if domainElement == nil || domainElement.Type == ManagementDomainType || !slices.Contains(names, domainElement.Name) {
+			continue
+		}
+		if err := DeleteDomain(ctx, vcfClient, domainElement.ID); err != nil {
+			errs = append(errs, fmt.Errorf("cannot delete domain %s: %w", domainElement.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -7,7 +7,9 @@ package domain
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vcf-sdk-go/client"
@@ -18,10 +20,17 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/cluster"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/vcenter"
 )
 
+// ExcludableBlocks returns the computed blocks of a domain which can be left out of the state: the clusters, or
+// only the hosts and the vSphere Distributed Switches of the clusters, and the NSX Manager cluster.
+func ExcludableBlocks() []string {
+	return []string{"cluster", "cluster.host", "cluster.vds", "nsx_configuration"}
+}
+
 func CreateDomainCreationSpec(data *schema.ResourceData) (*models.DomainCreationSpec, error) {
 	result := new(models.DomainCreationSpec)
 	domainName := data.Get("name").(string)
@@ -153,8 +162,9 @@ func CreateDomainUpdateSpec(data *schema.ResourceData, markForDeletion bool) *mo
 	return result
 }
 
+// ImportDomain reads the domain into the data, the excluded blocks among ExcludableBlocks are left empty.
 func ImportDomain(ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient,
-	domainId string, allowManagementDomain bool) ([]*schema.ResourceData, error) {
+	domainId string, allowManagementDomain bool, exclude ...string) ([]*schema.ResourceData, error) {
 	domainObj, err := SetBasicDomainAttributes(ctx, domainId, data, apiClient)
 	if err != nil {
 		return nil, err
@@ -163,19 +173,29 @@ func ImportDomain(ctx context.Context, data *schema.ResourceData, apiClient *cli
 		return nil, fmt.Errorf("domain %s cannot be imported as it is management domain", domainId)
 	}
 
-	err = setClustersDataToDomainDataSource(domainObj.Clusters, ctx, data, apiClient)
-	if err != nil {
-		return nil, err
+	if !slices.Contains(exclude, "cluster") {
+		var clusterExclude []string
+		for _, block := range exclude {
+			if clusterBlock, found := strings.CutPrefix(block, "cluster."); found {
+				clusterExclude = append(clusterExclude, clusterBlock)
+			}
+		}
+		err = setClustersDataToDomainDataSource(domainObj.Clusters, ctx, data, apiClient, clusterExclude...)
+		if err != nil {
+			return nil, err
+		}
 	}
-	flattenedNsxClusterRef, err := network.FlattenNsxClusterRef(ctx, domainObj.NSXTCluster, apiClient)
-	if err != nil {
-		return nil, err
+	if !slices.Contains(exclude, "nsx_configuration") {
+		flattenedNsxClusterRef, err := network.FlattenNsxClusterRef(ctx, domainObj.NSXTCluster, apiClient)
+		if err != nil {
+			return nil, err
+		}
+		_ = data.Set("nsx_configuration", *flattenedNsxClusterRef)
 	}
-	_ = data.Set("nsx_configuration", *flattenedNsxClusterRef)
 	return []*schema.ResourceData{data}, nil
 }
 
-func setClustersDataToDomainDataSource(domainClusterRefs []*models.ClusterReference, ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient) error {
+func setClustersDataToDomainDataSource(domainClusterRefs []*models.ClusterReference, ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient, exclude ...string) error {
 	clusterIds := make([]string, len(domainClusterRefs))
 	for i, clusterReference := range domainClusterRefs {
 		clusterIds[i] = *clusterReference.ID
@@ -183,21 +203,22 @@ func setClustersDataToDomainDataSource(domainClusterRefs []*models.ClusterRefere
 	// Sort the id slice, to have a deterministic order in every run of the domain datasource read
 	sort.Strings(clusterIds)
 
-	flattenedClusters := make([]map[string]interface{}, len(domainClusterRefs))
-	for i, clusterId := range clusterIds {
-		getClusterParams := clusters.GetClusterParams{ID: clusterId}
-		getClusterParams.WithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
-		clusterResult, err := apiClient.Clusters.GetCluster(&getClusterParams)
-		if err != nil {
-			return err
-		}
-		clusterRef := clusterResult.Payload
-		flattenedCluster, err := cluster.FlattenCluster(ctx, clusterRef, apiClient)
-		if err != nil {
-			return err
-		}
-		flattenedClusters[i] = *flattenedCluster
-
+	flattenedClusters, err := utils.ParallelMap(ctx, clusterIds, constants.MaxParallelApiCalls,
+		func(ctx context.Context, clusterId string) (map[string]interface{}, error) {
+			getClusterParams := clusters.GetClusterParams{ID: clusterId}
+			getClusterParams.WithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+			clusterResult, err := apiClient.Clusters.GetCluster(&getClusterParams)
+			if err != nil {
+				return nil, err
+			}
+			flattenedCluster, err := cluster.FlattenCluster(ctx, clusterResult.Payload, apiClient, exclude...)
+			if err != nil {
+				return nil, err
+			}
+			return *flattenedCluster, nil
+		})
+	if err != nil {
+		return err
 	}
 	_ = data.Set("cluster", flattenedClusters)
 
@@ -241,3 +262,27 @@ func generateComputeSpecFromResourceData(data *schema.ResourceData) (*models.Com
 	}
 	return nil, fmt.Errorf("no cluster configuration")
 }
+
+// ManagementDomainType is the type of the domain deployed by the bring-up.
+const ManagementDomainType = "MANAGEMENT"
+
+// GetManagementDomain returns the management domain of the SDDC Manager instance.
+func GetManagementDomain(ctx context.Context, apiClient *client.VcfClient) (*models.Domain, error) {
+	params := domains.NewGetDomainsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithType(utils.ToStringPointer(ManagementDomainType))
+
+	domainsResponse, err := apiClient.Domains.GetDomains(params)
+	if err != nil {
+		return nil, err
+	}
+	if domainsResponse.Payload != nil {
+		for _, domainElement := range domainsResponse.Payload.Elements {
+			if domainElement != nil && domainElement.Type == ManagementDomainType {
+				return domainElement, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no management domain found")
+}
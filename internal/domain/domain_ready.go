@@ -0,0 +1,161 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/nsxt_clusters"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// domainStatusError is the status of a domain whose creation or last change failed.
+const domainStatusError = "ERROR"
+
+// nsxClusterStatusActive is the status of an NSX Manager cluster which is deployed and stable.
+const nsxClusterStatusActive = "ACTIVE"
+
+// endpointProbeTimeout is the time an endpoint of a domain has to answer a probe.
+const endpointProbeTimeout = 30 * time.Second
+
+// ReadyDomain is a domain which is ACTIVE, along with its NSX Manager cluster, nil if the domain has none.
+type ReadyDomain struct {
+	Domain     *models.Domain
+	NsxCluster *models.NsxTCluster
+}
+
+// WaitForDomainReady reads the domain every poll interval of the client until it is ACTIVE and its NSX Manager
+// cluster is ACTIVE. If checkEndpoints is set, the vCenter Server and the NSX Manager VIP must also answer over HTTPS
+// without a server error, which they answer while their services start. Returns an error without waiting if the
+// domain is in the ERROR status, or once the context is done if the domain is still not ready.
+func WaitForDomainReady(ctx context.Context, vcfClient *api_client.SddcManagerClient, domainId string,
+	checkEndpoints bool) (*ReadyDomain, error) {
+	for {
+		// The domain may have changed since it was cached by an earlier lookup
+		vcfClient.InvalidateReadCache()
+		readyDomain, notReady, err := getReadyDomain(ctx, vcfClient.ApiClient, domainId, checkEndpoints)
+		if err != nil {
+			return nil, err
+		}
+		if notReady == "" {
+			return readyDomain, nil
+		}
+
+		tflog.Debug(ctx, "Waiting for the domain to be ready", map[string]interface{}{
+			"domain_id": domainId,
+			"reason":    notReady,
+		})
+		select {
+		case <-time.After(vcfClient.PollInterval()):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("domain %s is not ready: %s: %w", domainId, notReady, ctx.Err())
+		}
+	}
+}
+
+// getReadyDomain reads the domain and returns why it is not ready yet, an empty reason if it is ready.
+func getReadyDomain(ctx context.Context, apiClient *client.VcfClient, domainId string, checkEndpoints bool) (*ReadyDomain, string, error) {
+	domainResult, err := apiClient.Domains.GetDomain(domains.NewGetDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(domainId))
+	if err != nil {
+		return nil, "", err
+	}
+	readyDomain := &ReadyDomain{Domain: domainResult.Payload}
+	switch readyDomain.Domain.Status {
+	case domainStatusActive:
+	case domainStatusError:
+		return nil, "", fmt.Errorf("domain %s is in the %s status", readyDomain.Domain.Name, readyDomain.Domain.Status)
+	default:
+		return nil, fmt.Sprintf("the domain is %s", readyDomain.Domain.Status), nil
+	}
+
+	if readyDomain.Domain.NSXTCluster != nil && readyDomain.Domain.NSXTCluster.ID != "" {
+		nsxResult, err := apiClient.NSXTClusters.GetNsxCluster(nsxt_clusters.NewGetNsxClusterParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(readyDomain.Domain.NSXTCluster.ID))
+		if err != nil {
+			return nil, "", err
+		}
+		readyDomain.NsxCluster = nsxResult.Payload
+		if readyDomain.NsxCluster.Status != nsxClusterStatusActive {
+			return nil, fmt.Sprintf("the NSX Manager cluster is %s", readyDomain.NsxCluster.Status), nil
+		}
+	}
+
+	if checkEndpoints {
+		for _, endpoint := range readyDomain.endpoints() {
+			if err = probeEndpoint(ctx, endpoint); err != nil {
+				return nil, err.Error(), nil
+			}
+		}
+	}
+	return readyDomain, "", nil
+}
+
+// endpoints returns the URLs of the vCenter Server and of the NSX Manager VIP of the domain.
+func (readyDomain *ReadyDomain) endpoints() []string {
+	var result []string
+	if url := readyDomain.VcenterUrl(); url != "" {
+		result = append(result, url)
+	}
+	if url := readyDomain.NsxUrl(); url != "" {
+		result = append(result, url)
+	}
+	return result
+}
+
+// VcenterUrl returns the URL of the vCenter Server of the domain, empty if the domain has none.
+func (readyDomain *ReadyDomain) VcenterUrl() string {
+	if len(readyDomain.Domain.VCENTERS) == 0 || readyDomain.Domain.VCENTERS[0] == nil ||
+		readyDomain.Domain.VCENTERS[0].Fqdn == "" {
+		return ""
+	}
+	return "https://" + readyDomain.Domain.VCENTERS[0].Fqdn
+}
+
+// NsxUrl returns the URL of the NSX Manager VIP of the domain, by FQDN if it has one, empty if the domain has no
+// NSX Manager cluster.
+func (readyDomain *ReadyDomain) NsxUrl() string {
+	nsxCluster := readyDomain.Domain.NSXTCluster
+	switch {
+	case nsxCluster == nil:
+		return ""
+	case nsxCluster.VipFqdn != "":
+		return "https://" + nsxCluster.VipFqdn
+	case nsxCluster.Vip != "":
+		return "https://" + nsxCluster.Vip
+	}
+	return ""
+}
+
+// probeEndpoint sends a request to the URL and returns an error unless it answers without a server error. The
+// certificates are verified unless the provider allows unverified TLS.
+func probeEndpoint(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, endpointProbeTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("%s is not reachable: %w", url, err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s answered %s", url, response.Status)
+	}
+	return nil
+}
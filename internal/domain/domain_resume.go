@@ -0,0 +1,56 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package domain
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// domainStatusActive is the status of a domain whose creation completed.
+const domainStatusActive = "ACTIVE"
+
+// ResumeDomainCreation resumes the creation of a workload domain which failed in a previous apply, or which was still
+// running when the previous apply stopped waiting for it, e.g. on the create timeout. SDDC Manager keeps such a domain,
+// in a status other than ACTIVE, along with its creation task: the running task is waited for again and the failed
+// task is retried from the failed stage, instead of deploying the domain again. Returns the ID of the domain once it
+// is created, or an empty ID if there is no creation to resume.
+func ResumeDomainCreation(ctx context.Context, vcfClient *api_client.SddcManagerClient, name string) (string, error) {
+	domainsResponse, err := vcfClient.ApiClient.Domains.GetDomains(
+		domains.NewGetDomainsParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return "", err
+	}
+
+	for _, domainElement := range domainsResponse.Payload.Elements {
+		if domainElement.Name != name || domainElement.Status == domainStatusActive {
+			continue
+		}
+		task, err := vcfClient.FindResumableTask(ctx, "DOMAIN", domainElement.ID)
+		if err != nil {
+			return "", err
+		}
+		if task == nil {
+			return "", nil
+		}
+
+		tflog.Info(ctx, "Resuming the creation of the domain from its previous task", map[string]interface{}{
+			"domain_id": domainElement.ID,
+			"task_id":   task.ID,
+			"status":    task.Status,
+		})
+		if err = vcfClient.WaitForTaskComplete(ctx, task.ID, true); err != nil {
+			return "", err
+		}
+		return domainElement.ID, nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,173 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+)
+
+// The fleet management of VCF 9 lists the VCF instances of the fleet at /v1/fleet/instances. An operation on several
+// instances is started with a POST of its spec to /v1/fleet/operations, its status and the status of each of its
+// instances are read at /v1/fleet/operations/{id}.
+const (
+	instancesPath  = "/v1/fleet/instances"
+	operationsPath = "/v1/fleet/operations"
+)
+
+const (
+	OperationTypeInventorySync = "INVENTORY_SYNC"
+	OperationTypePrecheck      = "PRECHECK"
+	OperationTypeHealthCheck   = "HEALTH_CHECK"
+
+	OperationStatusPending    = "PENDING"
+	OperationStatusInProgress = "IN_PROGRESS"
+	OperationStatusSuccessful = "SUCCESSFUL"
+	OperationStatusFailed     = "FAILED"
+)
+
+// OperationTypes returns the types of the operations the fleet management runs on several instances.
+func OperationTypes() []string {
+	return []string{OperationTypeInventorySync, OperationTypePrecheck, OperationTypeHealthCheck}
+}
+
+// Instance is a VCF instance of the fleet, managed by its own SDDC Manager.
+type Instance struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	SddcManagerFqdn string `json:"sddcManagerFqdn"`
+	Status          string `json:"status"`
+}
+
+// PageOfInstance is the list of the instances of the fleet.
+type PageOfInstance struct {
+	Elements []*Instance `json:"elements"`
+}
+
+// OperationSpec is the spec of an operation on instances of the fleet, on all of them if InstanceIDs is empty.
+type OperationSpec struct {
+	Type        string   `json:"type"`
+	InstanceIDs []string `json:"instanceIds,omitempty"`
+}
+
+// Operation is an operation run on instances of the fleet.
+type Operation struct {
+	ID                  string               `json:"id"`
+	Type                string               `json:"type"`
+	Status              string               `json:"status"`
+	CreationTimestamp   string               `json:"creationTimestamp"`
+	CompletionTimestamp string               `json:"completionTimestamp"`
+	InstanceOperations  []*InstanceOperation `json:"instanceOperations"`
+}
+
+// InstanceOperation is the part of an operation run on a single instance.
+type InstanceOperation struct {
+	InstanceID   string `json:"instanceId"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// GetInstances returns the VCF instances of the fleet.
+func GetInstances(ctx context.Context, client *api_client.FleetClient) ([]*Instance, error) {
+	page := &PageOfInstance{}
+	if err := client.Do(ctx, http.MethodGet, instancesPath, nil, page); err != nil {
+		return nil, err
+	}
+	return page.Elements, nil
+}
+
+// StartOperation starts an operation on instances of the fleet, it returns without waiting for its completion.
+func StartOperation(ctx context.Context, client *api_client.FleetClient, spec *OperationSpec) (*Operation, error) {
+	operation := &Operation{}
+	if err := client.Do(ctx, http.MethodPost, operationsPath, spec, operation); err != nil {
+		return nil, err
+	}
+	if operation.ID == "" {
+		return nil, fmt.Errorf("the %s operation did not return an ID", spec.Type)
+	}
+	return operation, nil
+}
+
+// GetOperation reads an operation run on instances of the fleet.
+func GetOperation(ctx context.Context, client *api_client.FleetClient, id string) (*Operation, error) {
+	operation := &Operation{}
+	if err := client.Do(ctx, http.MethodGet, fmt.Sprintf("%s/%s", operationsPath, id), nil, operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// WaitForOperation polls the operation every poll interval of the client until it is no longer pending or in
+// progress, or until the context is done. The operation is returned whatever its status is.
+func WaitForOperation(ctx context.Context, client *api_client.FleetClient, id string) (*Operation, error) {
+	for {
+		operation, err := GetOperation(ctx, client, id)
+		if err != nil {
+			return nil, err
+		}
+		if !IsRunning(operation) {
+			return operation, nil
+		}
+
+		select {
+		case <-time.After(client.PollInterval()):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("the %s operation %s did not complete: %w", operation.Type, id, ctx.Err())
+		}
+	}
+}
+
+// IsRunning returns whether the operation is pending or in progress.
+func IsRunning(operation *Operation) bool {
+	switch strings.ToUpper(operation.Status) {
+	case OperationStatusPending, OperationStatusInProgress:
+		return true
+	}
+	return false
+}
+
+// FailedInstanceOperations returns the parts of the operation which did not succeed on their instance.
+func FailedInstanceOperations(operation *Operation) []*InstanceOperation {
+	var failed []*InstanceOperation
+	for _, instanceOperation := range operation.InstanceOperations {
+		if instanceOperation != nil && !strings.EqualFold(instanceOperation.Status, OperationStatusSuccessful) {
+			failed = append(failed, instanceOperation)
+		}
+	}
+	return failed
+}
+
+// FlattenInstance returns the attributes of the instance of the vcf_fleet_instances data source.
+func FlattenInstance(instance *Instance) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                instance.ID,
+		"name":              instance.Name,
+		"version":           instance.Version,
+		"sddc_manager_fqdn": instance.SddcManagerFqdn,
+		"status":            instance.Status,
+	}
+}
+
+// FlattenInstanceOperations returns the attributes of the parts of the operation run on each instance.
+func FlattenInstanceOperations(operation *Operation) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(operation.InstanceOperations))
+	for _, instanceOperation := range operation.InstanceOperations {
+		if instanceOperation == nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"instance_id":   instanceOperation.InstanceID,
+			"status":        instanceOperation.Status,
+			"error_message": instanceOperation.ErrorMessage,
+		})
+	}
+	return result
+}
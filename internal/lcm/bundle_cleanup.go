@@ -0,0 +1,189 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+)
+
+const (
+	// LcmRepositoryDirectory is the mount point of the LCM repository on the SDDC Manager appliance
+	LcmRepositoryDirectory = "/nfs/vmware/vcf/nfs-mount"
+
+	bundleCleanupScript = "/opt/vmware/vcf/lcm/lcm-app/bin/bundle_cleanup.py"
+)
+
+// LcmDiskUsage is the disk usage of the LCM repository of the SDDC Manager appliance, in bytes.
+type LcmDiskUsage struct {
+	TotalBytes     int64
+	UsedBytes      int64
+	AvailableBytes int64
+	// BundleBytes is the space used by the downloaded and uploaded bundles
+	BundleBytes int64
+}
+
+// CleanupBundles deletes the downloaded bundles from the LCM repository with the cleanup script of the
+// appliance, which requires root privileges. SDDC Manager has no API to delete a bundle.
+// The bundles can be downloaded again afterwards.
+func CleanupBundles(ctx context.Context, bundleIds []string, connection ApplianceConnection, rootPassword string,
+	sddcClient *api_client.SddcManagerClient) error {
+	for _, bundleId := range bundleIds {
+		bundle, err := GetBundle(ctx, bundleId, sddcClient.ApiClient)
+		if err != nil {
+			return err
+		}
+		if bundle.DownloadStatus != nil && *bundle.DownloadStatus == DownloadStatusInProgress {
+			return fmt.Errorf("bundle %s is being downloaded, it cannot be deleted", bundleId)
+		}
+	}
+
+	sshClient, err := dialAppliance(sddcClient, connection)
+	if err != nil {
+		return err
+	}
+	defer sshClient.Close()
+
+	for _, bundleId := range bundleIds {
+		log.Printf("[DEBUG] Deleting bundle %s from the LCM repository", bundleId)
+		command := fmt.Sprintf("python %s %s", bundleCleanupScript, shellQuote(bundleId))
+		if output, err := runAsRoot(ctx, sshClient, command, rootPassword); err != nil {
+			return fmt.Errorf("cannot delete bundle %s: %w %s", bundleId, err, output)
+		}
+	}
+
+	return nil
+}
+
+// GetLcmDiskUsage returns the disk usage of the LCM repository of the SDDC Manager appliance.
+func GetLcmDiskUsage(ctx context.Context, connection ApplianceConnection, sddcClient *api_client.SddcManagerClient) (*LcmDiskUsage, error) {
+	sshClient, err := dialAppliance(sddcClient, connection)
+	if err != nil {
+		return nil, err
+	}
+	defer sshClient.Close()
+
+	// df reports the total, used and available 1-byte blocks of the file system
+	output, err := runCommand(ctx, sshClient, "df -P -B1 "+shellQuote(LcmRepositoryDirectory)+" | tail -n 1")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the disk usage of %s: %w %s", LcmRepositoryDirectory, err, output)
+	}
+	fields := strings.Fields(output)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("unexpected disk usage of %s: %s", LcmRepositoryDirectory, output)
+	}
+	usage := &LcmDiskUsage{}
+	for i, value := range []*int64{&usage.TotalBytes, &usage.UsedBytes, &usage.AvailableBytes} {
+		if *value, err = strconv.ParseInt(fields[i+1], 10, 64); err != nil {
+			return nil, fmt.Errorf("unexpected disk usage of %s: %s", LcmRepositoryDirectory, output)
+		}
+	}
+
+	// Some files of the repository may not be readable by the user, du still reports the others
+	output, err = runCommand(ctx, sshClient, "du -s -B1 "+shellQuote(LcmRepositoryDirectory+"/bundle")+" 2>/dev/null | cut -f 1")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the size of the bundles: %w %s", err, output)
+	}
+	if usage.BundleBytes, err = strconv.ParseInt(strings.TrimSpace(output), 10, 64); err != nil {
+		return nil, fmt.Errorf("unexpected size of the bundles: %s", output)
+	}
+
+	return usage, nil
+}
+
+func runCommand(ctx context.Context, sshClient *ssh.Client, command string) (string, error) {
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	stop := closeOnDone(ctx, session)
+	defer stop()
+
+	output, err := session.CombinedOutput(command)
+	return string(output), err
+}
+
+// runAsRoot runs the command with su, which reads the password of root from a terminal.
+func runAsRoot(ctx context.Context, sshClient *ssh.Client, command, rootPassword string) (string, error) {
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	if err := session.RequestPty("xterm", 40, 200, ssh.TerminalModes{ssh.ECHO: 0}); err != nil {
+		return "", err
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return "", err
+	}
+	output := &passwordPromptWriter{stdin: stdin, password: rootPassword}
+	session.Stdout = output
+	session.Stderr = output
+
+	stop := closeOnDone(ctx, session)
+	defer stop()
+
+	err = session.Run("su -c " + shellQuote(command) + " root")
+	return output.String(), err
+}
+
+// closeOnDone closes the session when the context is done, the returned function stops watching the context.
+func closeOnDone(ctx context.Context, session *ssh.Session) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = session.Close()
+		case <-done:
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// passwordPromptWriter collects the output of a command and answers the first password prompt.
+type passwordPromptWriter struct {
+	stdin    io.Writer
+	password string
+	answered bool
+	output   bytes.Buffer
+	lock     sync.Mutex
+}
+
+func (w *passwordPromptWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	n, err := w.output.Write(p)
+	if !w.answered && strings.Contains(strings.ToLower(w.output.String()), "password:") {
+		w.answered = true
+		if _, err := io.WriteString(w.stdin, w.password+"\n"); err != nil {
+			return n, err
+		}
+	}
+
+	return n, err
+}
+
+func (w *passwordPromptWriter) String() string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.output.String()
+}
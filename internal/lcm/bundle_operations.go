@@ -0,0 +1,250 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/bundles"
+	"github.com/vmware/vcf-sdk-go/client/tasks"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	DownloadStatusPending    = "PENDING"
+	DownloadStatusScheduled  = "SCHEDULED"
+	DownloadStatusInProgress = "IN_PROGRESS"
+	DownloadStatusSuccessful = "SUCCESSFUL"
+	DownloadStatusFailed     = "FAILED"
+	DownloadStatusRecalled   = "RECALLED"
+)
+
+func AllDownloadStatuses() []string {
+	return []string{DownloadStatusPending, DownloadStatusScheduled, DownloadStatusInProgress,
+		DownloadStatusSuccessful, DownloadStatusFailed, DownloadStatusRecalled}
+}
+
+const (
+	ImageTypePatch   = "PATCH"
+	ImageTypeInstall = "INSTALL"
+)
+
+func GetBundle(ctx context.Context, bundleId string, apiClient *client.VcfClient) (*models.Bundle, error) {
+	params := bundles.NewGetBundleParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(bundleId)
+
+	result, err := apiClient.Bundles.GetBundle(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+func GetBundles(ctx context.Context, apiClient *client.VcfClient) ([]*models.Bundle, error) {
+	params := bundles.NewGetBundlesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	result, err := apiClient.Bundles.GetBundles(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return []*models.Bundle{}, nil
+	}
+
+	return result.Payload.Elements, nil
+}
+
+// HasComponent tells whether the bundle contains a component of the given type (and image type)
+// which upgrades or installs the given version. Empty filters match any component.
+func HasComponent(bundle *models.Bundle, componentType, version, imageType string) bool {
+	for _, component := range bundle.Components {
+		if component == nil {
+			continue
+		}
+		if len(componentType) > 0 && !strings.EqualFold(component.Type, componentType) {
+			continue
+		}
+		if len(version) > 0 && component.ToVersion != version {
+			continue
+		}
+		if len(imageType) > 0 && (component.ImageType == nil || !strings.EqualFold(*component.ImageType, imageType)) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// FindBundle returns the single bundle containing the given component version.
+func FindBundle(ctx context.Context, componentType, version, imageType string, apiClient *client.VcfClient) (*models.Bundle, error) {
+	allBundles, err := GetBundles(ctx, apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*models.Bundle, 0)
+	for _, bundle := range allBundles {
+		if bundle != nil && HasComponent(bundle, componentType, version, imageType) {
+			matches = append(matches, bundle)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no bundle found for component %s version %s", componentType, version)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, bundle := range matches {
+			ids = append(ids, bundle.ID)
+		}
+		return nil, fmt.Errorf("multiple bundles found for component %s version %s: %s, set image_type or bundle_id",
+			componentType, version, strings.Join(ids, ", "))
+	}
+}
+
+// DownloadBundle starts the download of the bundle unless it has already been downloaded and waits
+// for it to complete. SDDC Manager validates the checksum and the signature of the bundle as part of
+// the download, so a bundle which fails the validation fails the download task.
+// The ID of the download task is returned, empty if the bundle was already downloaded.
+func DownloadBundle(ctx context.Context, bundleId string, sddcClient *api_client.SddcManagerClient) (string, error) {
+	bundle, err := GetBundle(ctx, bundleId, sddcClient.ApiClient)
+	if err != nil {
+		return "", err
+	}
+	if bundle.DownloadStatus != nil && *bundle.DownloadStatus == DownloadStatusSuccessful {
+		log.Printf("[DEBUG] Bundle %s is already downloaded", bundleId)
+		return "", nil
+	}
+
+	params := bundles.NewStartBundleDownloadByIDParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(bundleId).
+		WithBundleUpdateSpec(&models.BundleUpdateSpec{
+			BundleDownloadSpec: &models.BundleDownloadSpec{
+				DownloadNow: true,
+			},
+		})
+
+	ok, accepted, err := sddcClient.ApiClient.Bundles.StartBundleDownloadByID(params)
+	if err != nil {
+		return "", err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+	if task == nil {
+		return "", fmt.Errorf("download of bundle %s did not return a task", bundleId)
+	}
+
+	tracker := sddcClient.NewTaskTracker(ctx, task.ID).
+		WithProgressReporting(fmt.Sprintf("Download of bundle %s", bundleId))
+	if err := tracker.WaitForTask(); err != nil {
+		return task.ID, fmt.Errorf("download of bundle %s failed: %w", bundleId, err)
+	}
+
+	return task.ID, nil
+}
+
+// GetTransferProgress returns the estimated progress in percent of the bundle download or upload task.
+func GetTransferProgress(ctx context.Context, taskId string, apiClient *client.VcfClient) (int, error) {
+	params := tasks.NewGetTaskParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(taskId)
+
+	result, err := apiClient.Tasks.GetTask(params)
+	if err != nil {
+		return 0, err
+	}
+
+	return api_client.TaskProgress(result.Payload), nil
+}
+
+// FilterBundles returns the bundles containing the given component version and having one of the
+// given download statuses. Empty filters match any bundle.
+func FilterBundles(allBundles []*models.Bundle, componentType, version string, downloadStatuses []string) []*models.Bundle {
+	result := make([]*models.Bundle, 0)
+	for _, bundle := range allBundles {
+		if bundle == nil {
+			continue
+		}
+		if (len(componentType) > 0 || len(version) > 0) && !HasComponent(bundle, componentType, version, "") {
+			continue
+		}
+		if len(downloadStatuses) > 0 && (bundle.DownloadStatus == nil || !slices.Contains(downloadStatuses, *bundle.DownloadStatus)) {
+			continue
+		}
+		result = append(result, bundle)
+	}
+
+	return result
+}
+
+// FlattenBundles converts the bundles to the representation used by the vcf_bundles data source.
+func FlattenBundles(allBundles []*models.Bundle) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(allBundles))
+	for _, bundle := range allBundles {
+		components := make([]map[string]interface{}, 0, len(bundle.Components))
+		for _, component := range bundle.Components {
+			if component == nil {
+				continue
+			}
+			componentMap := map[string]interface{}{
+				"type":          component.Type,
+				"description":   component.Description,
+				"from_version":  component.FromVersion,
+				"to_version":    component.ToVersion,
+				"vendor":        component.Vendor,
+				"released_date": component.ReleasedDate,
+			}
+			if component.ImageType != nil {
+				componentMap["image_type"] = *component.ImageType
+			}
+			components = append(components, componentMap)
+		}
+
+		bundleMap := map[string]interface{}{
+			"id":                   bundle.ID,
+			"version":              bundle.Version,
+			"description":          bundle.Description,
+			"vendor":               bundle.Vendor,
+			"severity":             bundle.Severity,
+			"released_date":        bundle.ReleasedDate,
+			"size_mb":              bundle.SizeMB,
+			"is_compliant":         bundle.IsCompliant,
+			"is_cumulative":        bundle.IsCumulative,
+			"applicability_status": bundle.ApplicabilityStatus,
+			"components":           components,
+		}
+		if bundle.Type != nil {
+			bundleMap["type"] = *bundle.Type
+		}
+		if bundle.DownloadStatus != nil {
+			bundleMap["download_status"] = *bundle.DownloadStatus
+		}
+
+		result = append(result, bundleMap)
+	}
+
+	return result
+}
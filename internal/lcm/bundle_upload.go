@@ -0,0 +1,269 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client/bundles"
+	"github.com/vmware/vcf-sdk-go/models"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	resourceTypeBundle = "BUNDLE"
+
+	transferProgressInterval = 30 * time.Second
+)
+
+// ApplianceConnection holds the SSH credentials used to copy files to the SDDC Manager appliance.
+type ApplianceConnection struct {
+	Username string
+	Password string
+	// HostKey is the public key of the appliance in authorized_keys format. It is required unless the provider
+	// allows unverified TLS, in which case the key is not verified if empty
+	HostKey string
+}
+
+// BundleUploadSpec describes the files of a bundle. Each file is either a local path or an HTTP(S) URL.
+type BundleUploadSpec struct {
+	BundleFile      string
+	ManifestFile    string
+	SignatureFile   string
+	RemoteDirectory string
+}
+
+// UploadBundle copies the files of the bundle to the SDDC Manager appliance, registers the bundle and
+// waits for its validation. It returns the ID of the upload task and the ID of the bundle.
+func UploadBundle(ctx context.Context, spec BundleUploadSpec, connection ApplianceConnection,
+	sddcClient *api_client.SddcManagerClient) (string, string, error) {
+	sshClient, err := dialAppliance(sddcClient, connection)
+	if err != nil {
+		return "", "", err
+	}
+	defer sshClient.Close()
+
+	uploadSpec := &models.BundleUploadSpec{}
+	bundleFilePath, err := copyToAppliance(ctx, sshClient, spec.BundleFile, spec.RemoteDirectory)
+	if err != nil {
+		return "", "", err
+	}
+	uploadSpec.BundleFilePath = &bundleFilePath
+
+	manifestFilePath, err := copyToAppliance(ctx, sshClient, spec.ManifestFile, spec.RemoteDirectory)
+	if err != nil {
+		return "", "", err
+	}
+	uploadSpec.ManifestFilePath = &manifestFilePath
+
+	if len(spec.SignatureFile) > 0 {
+		uploadSpec.SignatureFilePath, err = copyToAppliance(ctx, sshClient, spec.SignatureFile, spec.RemoteDirectory)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	params := bundles.NewUploadBundleParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithBundleUploadSpec(uploadSpec)
+
+	ok, accepted, err := sddcClient.ApiClient.Bundles.UploadBundle(params)
+	if err != nil {
+		return "", "", err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+	if task == nil {
+		return "", "", fmt.Errorf("upload of bundle %s did not return a task", spec.BundleFile)
+	}
+
+	tracker := sddcClient.NewTaskTracker(ctx, task.ID).
+		WithProgressReporting(fmt.Sprintf("Upload of bundle %s", path.Base(bundleFilePath)))
+	if err := tracker.WaitForTask(); err != nil {
+		return task.ID, "", fmt.Errorf("upload of bundle %s failed: %w", spec.BundleFile, err)
+	}
+
+	bundleId, err := sddcClient.GetResourceIdAssociatedWithTask(ctx, task.ID, resourceTypeBundle)
+	if err != nil {
+		log.Printf("[WARN] Cannot find the bundle uploaded by task %s: %s", task.ID, err)
+		return task.ID, "", nil
+	}
+
+	return task.ID, bundleId, nil
+}
+
+// dialAppliance connects to the SDDC Manager appliance over SSH. The password of the connection, and in some cases the
+// one of root, is sent over the connection, the host key of the appliance is therefore verified unless the provider
+// allows unverified TLS.
+func dialAppliance(sddcClient *api_client.SddcManagerClient, connection ApplianceConnection) (*ssh.Client, error) {
+	host := sddcClient.Host()
+	var hostKeyCallback ssh.HostKeyCallback
+	if len(connection.HostKey) > 0 {
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(connection.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid host key of the SDDC Manager appliance: %w", err)
+		}
+		hostKeyCallback = ssh.FixedHostKey(hostKey)
+	} else if sddcClient.AllowsUnverifiedTls() {
+		log.Printf("[WARN] The host key of the SDDC Manager appliance %s is not verified", host)
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	} else {
+		return nil, fmt.Errorf("the host key of the SDDC Manager appliance %s is required to connect to it over SSH "+
+			"unless the provider allows unverified TLS: set appliance_host_key, e.g. to the output of "+
+			"ssh-keyscan -t ecdsa %s", host, host)
+	}
+
+	config := &ssh.ClientConfig{
+		User:            connection.Username,
+		Auth:            []ssh.AuthMethod{ssh.Password(connection.Password)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         constants.DefaultVcfApiCallTimeout,
+	}
+
+	sshClient, err := ssh.Dial("tcp", net.JoinHostPort(host, "22"), config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the SDDC Manager appliance %s: %w", host, err)
+	}
+
+	return sshClient, nil
+}
+
+// copyToAppliance streams the local file or the content of the URL to the remote directory of the
+// appliance and returns the path of the copy.
+func copyToAppliance(ctx context.Context, sshClient *ssh.Client, source, remoteDirectory string) (string, error) {
+	reader, name, size, err := openSource(ctx, source)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	return copyStream(ctx, sshClient, source, reader, name, size, remoteDirectory)
+}
+
+// CopyStreamToAppliance copies the content read from the reader to the file with the given name in the
+// remote directory of the appliance and returns the path of the copy. The size is only used to log the
+// progress, it is negative if unknown.
+func CopyStreamToAppliance(ctx context.Context, sddcClient *api_client.SddcManagerClient, connection ApplianceConnection,
+	reader io.Reader, name string, size int64, remoteDirectory string) (string, error) {
+	sshClient, err := dialAppliance(sddcClient, connection)
+	if err != nil {
+		return "", err
+	}
+	defer sshClient.Close()
+
+	return copyStream(ctx, sshClient, name, reader, name, size, remoteDirectory)
+}
+
+func copyStream(ctx context.Context, sshClient *ssh.Client, source string, reader io.Reader, name string, size int64,
+	remoteDirectory string) (string, error) {
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.Close()
+
+	remotePath := path.Join(remoteDirectory, name)
+	session.Stdin = &progressReader{reader: reader, description: "Copy of " + name, size: size}
+	log.Printf("[DEBUG] Copying %s to %s on the SDDC Manager appliance", source, remotePath)
+	command := fmt.Sprintf("mkdir -p %s && cat > %s", shellQuote(remoteDirectory), shellQuote(remotePath))
+
+	// Stop the copy when the create timeout of the resource is reached
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = session.Close()
+		case <-done:
+		}
+	}()
+
+	if output, err := session.CombinedOutput(command); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("copy of %s to the SDDC Manager appliance stopped: %w", source, ctx.Err())
+		}
+		return "", fmt.Errorf("cannot copy %s to the SDDC Manager appliance: %w %s", source, err, string(output))
+	}
+
+	return remotePath, nil
+}
+
+// openSource opens the local file or the HTTP(S) URL and returns its content, its file name and
+// its size, which is negative if unknown.
+func openSource(ctx context.Context, source string) (io.ReadCloser, string, int64, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		if response.StatusCode != http.StatusOK {
+			_ = response.Body.Close()
+			return nil, "", 0, fmt.Errorf("cannot download %s: %s", source, response.Status)
+		}
+
+		return response.Body, path.Base(request.URL.Path), response.ContentLength, nil
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, "", 0, err
+	}
+
+	return file, path.Base(strings.ReplaceAll(source, "\\", "/")), info.Size(), nil
+}
+
+// progressReader logs how much of the content has been read at most every transferProgressInterval.
+type progressReader struct {
+	reader      io.Reader
+	description string
+	size        int64
+	read        int64
+	lastLog     time.Time
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if time.Since(r.lastLog) >= transferProgressInterval || err == io.EOF {
+		r.lastLog = time.Now()
+		if r.size > 0 {
+			log.Printf("[INFO] %s: %d%% complete", r.description, r.read*100/r.size)
+		} else {
+			log.Printf("[INFO] %s: %d MB copied", r.description, r.read/(1024*1024))
+		}
+	}
+
+	return n, err
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,26 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+)
+
+func TestDialApplianceRequiresHostKey(t *testing.T) {
+	connection := ApplianceConnection{Username: "vcf", Password: "VMware123!VMware123!"}
+
+	// The password is not sent to an appliance whose identity cannot be verified
+	sddcClient := api_client.NewSddcManagerClient("admin@local", "VMware123!VMware123!", "127.0.0.1", false)
+	_, err := dialAppliance(sddcClient, connection)
+	assert.ErrorContains(t, err, "set appliance_host_key")
+
+	connection.HostKey = "not a key"
+	_, err = dialAppliance(sddcClient, connection)
+	assert.ErrorContains(t, err, "invalid host key of the SDDC Manager appliance")
+}
@@ -0,0 +1,177 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/depot_settings"
+	"github.com/vmware/vcf-sdk-go/client/trusted_certificates"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	DepotStatusConnectionSuccessful = "DEPOT_CONNECTION_SUCCESSFUL"
+
+	certificateUsageTrustedForOutbound = "TRUSTED_FOR_OUTBOUND"
+)
+
+// ErrCertificateNotTrusted is returned by FindTrustedCertificateAlias when SDDC Manager does not trust the certificate.
+var ErrCertificateNotTrusted = errors.New("certificate is not trusted by SDDC Manager")
+
+// DepotSettings holds the offline depot part of the depot settings of SDDC Manager, which
+// the models of the VCF SDK do not have, and the online depot account.
+type DepotSettings struct {
+	VmwareAccount      *models.DepotAccount `json:"vmwareAccount,omitempty"`
+	OfflineAccount     *models.DepotAccount `json:"offlineAccount,omitempty"`
+	DepotConfiguration *DepotConfiguration  `json:"depotConfiguration,omitempty"`
+}
+
+type DepotConfiguration struct {
+	IsOfflineDepot bool   `json:"isOfflineDepot"`
+	Hostname       string `json:"hostname,omitempty"`
+	Port           int32  `json:"port,omitempty"`
+}
+
+func GetDepotSettings(ctx context.Context, apiClient *client.VcfClient) (*DepotSettings, error) {
+	params := depot_settings.NewGetDepotSettingsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	settings := &DepotSettings{}
+	if _, err := apiClient.DepotSettings.GetDepotSettings(params, withResponseBody(settings)); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpdateDepotSettings configures the offline depot and returns the resulting settings, including the
+// status of the connection to the depot.
+func UpdateDepotSettings(ctx context.Context, settings *DepotSettings, apiClient *client.VcfClient) (*DepotSettings, error) {
+	params := depot_settings.NewUpdateDepotSettingsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	result := &DepotSettings{}
+	_, _, err := apiClient.DepotSettings.UpdateDepotSettings(params, withRequestBody(settings), withResponseBody(result))
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// TrustCertificate adds the PEM certificate to the certificates SDDC Manager trusts for outbound
+// connections and returns its alias.
+func TrustCertificate(ctx context.Context, certificate string, apiClient *client.VcfClient) (string, error) {
+	usage := certificateUsageTrustedForOutbound
+	params := trusted_certificates.NewAddTrustedCertificateParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithTrustedCertificateSpec(&models.TrustedCertificateSpec{
+			Certificate:          &certificate,
+			CertificateUsageType: &usage,
+		})
+
+	if _, err := apiClient.TrustedCertificates.AddTrustedCertificate(params); err != nil {
+		return "", err
+	}
+
+	return FindTrustedCertificateAlias(ctx, certificate, apiClient)
+}
+
+// FindTrustedCertificateAlias returns the alias of the trusted certificate, or an error if SDDC Manager does not trust it.
+func FindTrustedCertificateAlias(ctx context.Context, certificate string, apiClient *client.VcfClient) (string, error) {
+	params := trusted_certificates.NewGetTrustedCertificatesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	result, err := apiClient.TrustedCertificates.GetTrustedCertificates(params)
+	if err != nil {
+		return "", err
+	}
+	if result.Payload != nil {
+		for _, trustedCertificate := range result.Payload.Elements {
+			if trustedCertificate == nil || trustedCertificate.Certificate == nil || trustedCertificate.Alias == nil {
+				continue
+			}
+			if normalizeCertificate(*trustedCertificate.Certificate) == normalizeCertificate(certificate) {
+				return *trustedCertificate.Alias, nil
+			}
+		}
+	}
+
+	return "", ErrCertificateNotTrusted
+}
+
+func DeleteTrustedCertificate(ctx context.Context, alias string, apiClient *client.VcfClient) error {
+	params := trusted_certificates.NewDeleteTrustedCertificateParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithAlias(alias)
+
+	_, _, err := apiClient.TrustedCertificates.DeleteTrustedCertificate(params)
+
+	return err
+}
+
+func normalizeCertificate(certificate string) string {
+	return strings.Join(strings.Fields(certificate), "")
+}
+
+// withRequestBody replaces the body of the request, for the fields the models of the VCF SDK do not have.
+func withRequestBody(body interface{}) depot_settings.ClientOption {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(request runtime.ClientRequest, registry strfmt.Registry) error {
+			if err := params.WriteToRequest(request, registry); err != nil {
+				return err
+			}
+
+			return request.SetBodyParam(body)
+		})
+	}
+}
+
+// withResponseBody decodes the body of a successful response into target as well, for the fields
+// the models of the VCF SDK do not have.
+func withResponseBody(target interface{}) depot_settings.ClientOption {
+	return func(op *runtime.ClientOperation) {
+		reader := op.Reader
+		op.Reader = runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() < 200 || response.Code() >= 300 {
+				return reader.ReadResponse(response, consumer)
+			}
+
+			body, err := io.ReadAll(response.Body())
+			if err != nil {
+				return nil, err
+			}
+			if len(body) > 0 {
+				if err := json.Unmarshal(body, target); err != nil {
+					return nil, err
+				}
+			}
+
+			return reader.ReadResponse(&bufferedResponse{ClientResponse: response, body: body}, consumer)
+		})
+	}
+}
+
+// bufferedResponse lets the body of a response be read again.
+type bufferedResponse struct {
+	runtime.ClientResponse
+	body []byte
+}
+
+func (response *bufferedResponse) Body() io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(response.body))
+}
@@ -0,0 +1,226 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	vcenterTaskStatusSucceeded = "SUCCEEDED"
+	vcenterTaskStatusFailed    = "FAILED"
+
+	vcenterTaskPollInterval = 10 * time.Second
+)
+
+// VcenterConnection holds the credentials used to edit cluster images through the vSphere Lifecycle Manager
+// API of vCenter. SDDC Manager has no API to change the software specification of a cluster.
+type VcenterConnection struct {
+	Host     string
+	Username string
+	Password string
+}
+
+// ImageCustomization describes the software added on top of the ESXi base image of a cluster image.
+type ImageCustomization struct {
+	AddOn *ImageAddOn
+	// HardwareSupport selects the firmware and drivers package of a hardware support manager (HSM)
+	HardwareSupport *ImageHardwareSupport
+	// Components maps the names of the additional components to their versions
+	Components map[string]string
+}
+
+// ImageAddOn is a vendor add-on of a cluster image.
+type ImageAddOn struct {
+	Name    string
+	Version string
+}
+
+// ImageHardwareSupport is a firmware and drivers package provided by a hardware support manager.
+type ImageHardwareSupport struct {
+	Manager string
+	Package string
+	Version string
+}
+
+// CustomizeClusterImage sets the vendor add-on, the hardware support package and the components of the
+// desired image of the cluster and waits for the change to be committed. Only the desired image changes,
+// the hosts of the cluster are not remediated.
+func CustomizeClusterImage(ctx context.Context, connection VcenterConnection, clusterId string, customization ImageCustomization) error {
+	vcenter, err := newVcenterSession(ctx, connection)
+	if err != nil {
+		return err
+	}
+	defer vcenter.logout()
+
+	softwarePath := fmt.Sprintf("/api/esx/settings/clusters/%s/software", url.PathEscape(clusterId))
+
+	var draftId string
+	if err := vcenter.do(ctx, http.MethodPost, softwarePath+"/drafts", nil, &draftId); err != nil {
+		return fmt.Errorf("cannot create a draft of the image of cluster %s: %w", clusterId, err)
+	}
+	draftPath := softwarePath + "/drafts/" + url.PathEscape(draftId)
+
+	if err := applyImageCustomization(ctx, vcenter, draftPath, customization); err != nil {
+		if deleteErr := vcenter.do(ctx, http.MethodDelete, draftPath, nil, nil); deleteErr != nil {
+			log.Printf("[WARN] Cannot delete the draft %s of the image of cluster %s: %s", draftId, clusterId, deleteErr)
+		}
+		return fmt.Errorf("cannot customize the image of cluster %s: %w", clusterId, err)
+	}
+
+	var taskId string
+	commitSpec := map[string]interface{}{"message": "Customized by terraform-provider-vcf"}
+	if err := vcenter.do(ctx, http.MethodPost, draftPath+"?action=commit&vmw-task=true", commitSpec, &taskId); err != nil {
+		return fmt.Errorf("cannot commit the image of cluster %s: %w", clusterId, err)
+	}
+
+	if err := vcenter.waitForTask(ctx, taskId); err != nil {
+		return fmt.Errorf("commit of the image of cluster %s failed: %w", clusterId, err)
+	}
+
+	return nil
+}
+
+func applyImageCustomization(ctx context.Context, vcenter *vcenterSession, draftPath string, customization ImageCustomization) error {
+	if customization.AddOn != nil {
+		addOn := map[string]interface{}{
+			"name":    customization.AddOn.Name,
+			"version": customization.AddOn.Version,
+		}
+		if err := vcenter.do(ctx, http.MethodPut, draftPath+"/software/add-on", addOn, nil); err != nil {
+			return fmt.Errorf("cannot set add-on %s: %w", customization.AddOn.Name, err)
+		}
+	}
+
+	if customization.HardwareSupport != nil {
+		hardwareSupport := map[string]interface{}{
+			"pkg":     customization.HardwareSupport.Package,
+			"version": customization.HardwareSupport.Version,
+		}
+		managerPath := draftPath + "/software/hardware-support/managers/" + url.PathEscape(customization.HardwareSupport.Manager)
+		if err := vcenter.do(ctx, http.MethodPut, managerPath, hardwareSupport, nil); err != nil {
+			return fmt.Errorf("cannot set the hardware support package of %s: %w", customization.HardwareSupport.Manager, err)
+		}
+	}
+
+	if len(customization.Components) > 0 {
+		components := map[string]interface{}{"components_to_set": customization.Components}
+		if err := vcenter.do(ctx, http.MethodPatch, draftPath+"/software/components", components, nil); err != nil {
+			return fmt.Errorf("cannot set the components: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type vcenterSession struct {
+	baseUrl   string
+	sessionId string
+}
+
+func newVcenterSession(ctx context.Context, connection VcenterConnection) (*vcenterSession, error) {
+	session := &vcenterSession{baseUrl: "https://" + connection.Host}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, session.baseUrl+"/api/session", nil)
+	if err != nil {
+		return nil, err
+	}
+	request.SetBasicAuth(connection.Username, connection.Password)
+
+	var sessionId string
+	if err := session.send(request, &sessionId); err != nil {
+		return nil, fmt.Errorf("cannot log in to vCenter %s: %w", connection.Host, err)
+	}
+	session.sessionId = sessionId
+
+	return session, nil
+}
+
+func (session *vcenterSession) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, session.baseUrl+path, reader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("vmware-api-session-id", session.sessionId)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	return session.send(request, result)
+}
+
+func (session *vcenterSession) send(request *http.Request, result interface{}) error {
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s: %s", request.Method, request.URL.Path, response.Status, string(content))
+	}
+	if result == nil || len(content) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(content, result)
+}
+
+func (session *vcenterSession) waitForTask(ctx context.Context, taskId string) error {
+	for {
+		var task struct {
+			Status string          `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		}
+		if err := session.do(ctx, http.MethodGet, "/api/cis/tasks/"+url.PathEscape(taskId), nil, &task); err != nil {
+			return err
+		}
+
+		switch task.Status {
+		case vcenterTaskStatusSucceeded:
+			return nil
+		case vcenterTaskStatusFailed:
+			return fmt.Errorf("task %s failed: %s", taskId, string(task.Error))
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for task %s: %w", taskId, ctx.Err())
+		case <-time.After(vcenterTaskPollInterval):
+		}
+	}
+}
+
+func (session *vcenterSession) logout() {
+	request, err := http.NewRequest(http.MethodDelete, session.baseUrl+"/api/session", nil)
+	if err != nil {
+		return
+	}
+	request.Header.Set("vmware-api-session-id", session.sessionId)
+	if err := session.send(request, nil); err != nil {
+		log.Printf("[DEBUG] Cannot log out of vCenter: %s", err)
+	}
+}
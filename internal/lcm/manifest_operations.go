@@ -0,0 +1,114 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/hashicorp/go-version"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/manifests"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// GetManifest returns the LCM manifest SDDC Manager uses to know the releases and their upgrade paths.
+func GetManifest(ctx context.Context, apiClient *client.VcfClient) (*models.Manifest, error) {
+	params := manifests.NewGetManifestParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	result, err := apiClient.Manifests.GetManifest(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return nil, fmt.Errorf("SDDC Manager returned an empty LCM manifest")
+	}
+
+	return result.Payload, nil
+}
+
+// NewerReleases returns, from the oldest to the newest, the versions of the releases newer than the given version.
+func NewerReleases(allReleases []*models.Release, currentVersion string) ([]string, error) {
+	return filterReleaseVersions(allReleases, currentVersion, func(_ *models.Release) bool { return true })
+}
+
+// UpgradeTargets returns, from the oldest to the newest, the versions of the releases newer than the
+// given version which can be applied on top of it.
+func UpgradeTargets(allReleases []*models.Release, currentVersion string) ([]string, error) {
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", currentVersion, err)
+	}
+
+	return filterReleaseVersions(allReleases, currentVersion, func(release *models.Release) bool {
+		minCompatible := stringValue(release.MinCompatibleVcfVersion)
+		if len(minCompatible) == 0 {
+			return true
+		}
+		minCompatibleVersion, err := version.NewVersion(minCompatible)
+		if err != nil {
+			log.Printf("[WARN] Ignoring release %s with invalid minimum compatible version %q: %s",
+				stringValue(release.Version), minCompatible, err)
+			return false
+		}
+
+		return !current.LessThan(minCompatibleVersion)
+	})
+}
+
+func filterReleaseVersions(allReleases []*models.Release, currentVersion string, include func(*models.Release) bool) ([]string, error) {
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", currentVersion, err)
+	}
+
+	newer := make([]*version.Version, 0)
+	for _, release := range allReleases {
+		if release == nil || release.Version == nil {
+			continue
+		}
+		releaseVersion, err := version.NewVersion(*release.Version)
+		if err != nil {
+			log.Printf("[WARN] Ignoring release with invalid version %q: %s", *release.Version, err)
+			continue
+		}
+		if releaseVersion.GreaterThan(current) && include(release) {
+			newer = append(newer, releaseVersion)
+		}
+	}
+	slices.SortFunc(newer, func(a, b *version.Version) int { return a.Compare(b) })
+
+	result := make([]string, 0, len(newer))
+	for _, v := range newer {
+		result = append(result, v.Original())
+	}
+
+	return result, nil
+}
+
+// FlattenManifestReleases converts the releases of the manifest to the representation used by the vcf_manifest data source.
+func FlattenManifestReleases(allReleases []*models.Release) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(allReleases))
+	for _, release := range allReleases {
+		if release == nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"version":                    stringValue(release.Version),
+			"product":                    stringValue(release.Product),
+			"description":                stringValue(release.Description),
+			"release_date":               stringValue(release.ReleaseDate),
+			"eol":                        release.Eol,
+			"min_compatible_vcf_version": stringValue(release.MinCompatibleVcfVersion),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,235 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/personalities"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	PersonalityUploadModeRaw      = "RAW"
+	PersonalityUploadModeReferred = "REFERRED"
+)
+
+// PersonalityImageFiles describes the files of a cluster image exported from vSphere Lifecycle Manager.
+// Each file is either a local path or an HTTP(S) URL.
+type PersonalityImageFiles struct {
+	ZipFile         string
+	JsonFile        string
+	InfoJsonFile    string
+	IsoFile         string
+	RemoteDirectory string
+}
+
+// UploadPersonalityFromFiles copies the exported cluster image files to the SDDC Manager appliance,
+// creates the personality from them and waits for its creation.
+func UploadPersonalityFromFiles(ctx context.Context, name string, files PersonalityImageFiles,
+	connection ApplianceConnection, sddcClient *api_client.SddcManagerClient) error {
+	sshClient, err := dialAppliance(sddcClient, connection)
+	if err != nil {
+		return err
+	}
+	defer sshClient.Close()
+
+	rawSpec := &models.PersonalityUploadSpecRaw{}
+	zipFilePath, err := copyToAppliance(ctx, sshClient, files.ZipFile, files.RemoteDirectory)
+	if err != nil {
+		return err
+	}
+	rawSpec.PersonalityZIPFilePath = &zipFilePath
+
+	jsonFilePath, err := copyToAppliance(ctx, sshClient, files.JsonFile, files.RemoteDirectory)
+	if err != nil {
+		return err
+	}
+	rawSpec.PersonalityJSONFilePath = &jsonFilePath
+
+	infoJsonFilePath, err := copyToAppliance(ctx, sshClient, files.InfoJsonFile, files.RemoteDirectory)
+	if err != nil {
+		return err
+	}
+	rawSpec.PersonalityInfoJSONFilePath = &infoJsonFilePath
+
+	if len(files.IsoFile) > 0 {
+		rawSpec.PersonalityISOFilePath, err = copyToAppliance(ctx, sshClient, files.IsoFile, files.RemoteDirectory)
+		if err != nil {
+			return err
+		}
+	}
+
+	mode := PersonalityUploadModeRaw
+	return UploadPersonality(ctx, &models.PersonalityUploadSpec{
+		Name:              name,
+		UploadMode:        &mode,
+		UploadSpecRawMode: rawSpec,
+	}, sddcClient)
+}
+
+// UploadPersonality creates the personality described by the spec and waits for its creation.
+func UploadPersonality(ctx context.Context, spec *models.PersonalityUploadSpec, sddcClient *api_client.SddcManagerClient) error {
+	params := personalities.NewUploadPersonalityParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithPersonalityUploadSpec(spec)
+
+	ok, accepted, err := sddcClient.ApiClient.Personalities.UploadPersonality(params)
+	if err != nil {
+		return err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+	if task == nil {
+		return fmt.Errorf("upload of personality %s did not return a task", spec.Name)
+	}
+
+	if err := sddcClient.WaitForTaskComplete(ctx, task.ID, false); err != nil {
+		return fmt.Errorf("upload of personality %s failed: %w", spec.Name, err)
+	}
+
+	return nil
+}
+
+// PersonalityFilter holds the filters used to list personalities. Empty filters match any personality.
+type PersonalityFilter struct {
+	Name        string
+	EsxiVersion string
+}
+
+// GetPersonalities returns the personalities matching the filter.
+func GetPersonalities(ctx context.Context, filter PersonalityFilter, apiClient *client.VcfClient) ([]*models.Personality, error) {
+	params := personalities.NewGetPersonalitiesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	if len(filter.Name) > 0 {
+		params.SetPersonalityName(&filter.Name)
+	}
+	if len(filter.EsxiVersion) > 0 {
+		params.SetBaseOSVersion(&filter.EsxiVersion)
+	}
+
+	result, err := apiClient.Personalities.GetPersonalities(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return []*models.Personality{}, nil
+	}
+
+	return result.Payload.Elements, nil
+}
+
+// GetPersonalityByName returns the personality with the given name.
+func GetPersonalityByName(ctx context.Context, name string, apiClient *client.VcfClient) (*models.Personality, error) {
+	params := personalities.NewGetPersonalitiesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithPersonalityName(&name)
+
+	result, err := apiClient.Personalities.GetPersonalities(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil || len(result.Payload.Elements) == 0 || result.Payload.Elements[0] == nil {
+		return nil, fmt.Errorf("personality %s not found", name)
+	}
+
+	return result.Payload.Elements[0], nil
+}
+
+// GetPersonality returns the personality with the given ID.
+func GetPersonality(ctx context.Context, personalityId string, apiClient *client.VcfClient) (*models.Personality, error) {
+	params := personalities.NewGetPersonalityParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithPersonalityID(personalityId)
+
+	result, err := apiClient.Personalities.GetPersonality(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// PersonalityBaseImageVersion returns the ESXi version of the base image of the personality.
+func PersonalityBaseImageVersion(personality *models.Personality) string {
+	if personality.SoftwareInfo == nil || personality.SoftwareInfo.BaseImage == nil ||
+		personality.SoftwareInfo.BaseImage.Version == nil {
+		return ""
+	}
+
+	return *personality.SoftwareInfo.BaseImage.Version
+}
+
+// FlattenPersonalities converts the personalities to the representation used by the vcf_personalities data source.
+func FlattenPersonalities(allPersonalities []*models.Personality) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(allPersonalities))
+	for _, personality := range allPersonalities {
+		if personality == nil {
+			continue
+		}
+
+		personalityMap := map[string]interface{}{
+			"id":           stringValue(personality.PersonalityID),
+			"name":         stringValue(personality.PersonalityName),
+			"version":      stringValue(personality.Version),
+			"description":  stringValue(personality.Description),
+			"esxi_version": PersonalityBaseImageVersion(personality),
+			"created_by":   personality.CreatedBy,
+			"tags":         personality.Tags,
+			"components":   flattenPersonalityComponents(personality.SoftwareInfo),
+		}
+		if personality.SoftwareInfo != nil && personality.SoftwareInfo.AddOn != nil {
+			addOn := personality.SoftwareInfo.AddOn
+			personalityMap["add_on"] = []map[string]interface{}{{
+				"name":    stringValue(addOn.Name),
+				"vendor":  stringValue(addOn.Vendor),
+				"version": stringValue(addOn.Version),
+			}}
+		}
+
+		result = append(result, personalityMap)
+	}
+
+	return result
+}
+
+func flattenPersonalityComponents(softwareInfo *models.SoftwareInfo) []map[string]interface{} {
+	if softwareInfo == nil {
+		return []map[string]interface{}{}
+	}
+
+	names := make([]string, 0, len(softwareInfo.Components))
+	for name := range softwareInfo.Components {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	result := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		component := softwareInfo.Components[name]
+		componentMap := map[string]interface{}{
+			"name":    name,
+			"version": stringValue(component.Version),
+		}
+		if component.Details != nil {
+			componentMap["display_name"] = stringValue(component.Details.DisplayName)
+			componentMap["vendor"] = stringValue(component.Details.Vendor)
+		}
+		result = append(result, componentMap)
+	}
+
+	return result
+}
@@ -0,0 +1,235 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client/system_prechecks"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	PrecheckModeUpgrade  = "UPGRADE"
+	PrecheckModeRecovery = "RECOVERY"
+
+	PrecheckStatusSuccessful = "SUCCESSFUL"
+	PrecheckStatusWarning    = "WARNING"
+	PrecheckStatusFailed     = "FAILED"
+
+	PrecheckResourceTypeDomain  = "DOMAIN"
+	PrecheckResourceTypeCluster = "CLUSTER"
+)
+
+// PrecheckResult is the outcome of a single check of a precheck task.
+type PrecheckResult struct {
+	Name         string
+	Description  string
+	Status       string
+	ResourceName string
+	ResourceType string
+	Errors       []string
+}
+
+func (result PrecheckResult) String() string {
+	description := fmt.Sprintf("%s (%s)", result.Name, result.Status)
+	if len(result.ResourceName) > 0 {
+		description = fmt.Sprintf("%s on %s", description, result.ResourceName)
+	}
+	if len(result.Errors) > 0 {
+		description = fmt.Sprintf("%s: %s", description, strings.Join(result.Errors, ", "))
+	}
+
+	return description
+}
+
+// RunSystemPrecheck runs the SDDC Manager precheck of the given resources and waits for it to complete.
+// The precheck task is returned whatever the results of the checks are.
+func RunSystemPrecheck(ctx context.Context, spec *models.PrecheckSpec, sddcClient *api_client.SddcManagerClient) (*models.Task, error) {
+	apiClient := sddcClient.ApiClient
+	params := system_prechecks.NewStartPrecheckParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithPrecheckSpec(spec)
+
+	ok, accepted, err := apiClient.SystemPrechecks.StartPrecheck(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+	if task == nil {
+		return nil, fmt.Errorf("precheck did not return a task")
+	}
+
+	return waitForPrecheck(ctx, sddcClient.PollInterval(), func() (*models.Task, error) {
+		taskParams := system_prechecks.NewGetPrecheckTaskParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(task.ID)
+
+		result, err := apiClient.SystemPrechecks.GetPrecheckTask(taskParams)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Payload, nil
+	})
+}
+
+// waitForPrecheck polls the precheck task every poll interval until it is no longer pending or in progress, or until
+// the context is done.
+func waitForPrecheck(ctx context.Context, pollInterval time.Duration, getTask func() (*models.Task, error)) (*models.Task, error) {
+	for {
+		task, err := getTask()
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			return nil, fmt.Errorf("precheck task not found")
+		}
+
+		switch strings.ToUpper(task.Status) {
+		case "PENDING", "IN_PROGRESS", "IN PROGRESS":
+			select {
+			case <-time.After(pollInterval):
+				continue
+			case <-ctx.Done():
+				return nil, fmt.Errorf("precheck task %s did not complete: %w", task.ID, ctx.Err())
+			}
+		}
+
+		return task, nil
+	}
+}
+
+// GetPrecheckResults returns the results of the individual checks of the precheck task.
+func GetPrecheckResults(task *models.Task) []PrecheckResult {
+	results := make([]PrecheckResult, 0)
+	for _, subTask := range task.SubTasks {
+		results = collectPrecheckResults(subTask, results)
+	}
+
+	return results
+}
+
+func collectPrecheckResults(subTask *models.SubTask, results []PrecheckResult) []PrecheckResult {
+	if subTask == nil {
+		return results
+	}
+	// Group subtasks only carry the results of their own subtasks, unless they failed on their own
+	if len(subTask.SubTasks) == 0 || len(subTask.Errors) > 0 {
+		result := PrecheckResult{
+			Name:        subTask.Name,
+			Description: subTask.Description,
+			Status:      strings.ToUpper(subTask.Status),
+			Errors:      precheckErrorMessages(subTask.Errors),
+		}
+		if len(subTask.Resources) > 0 && subTask.Resources[0] != nil {
+			result.ResourceName = subTask.Resources[0].Name
+			if len(result.ResourceName) == 0 {
+				result.ResourceName = subTask.Resources[0].Fqdn
+			}
+			result.ResourceType = stringValue(subTask.Resources[0].Type)
+		}
+		results = append(results, result)
+	}
+	for _, child := range subTask.SubTasks {
+		results = collectPrecheckResults(child, results)
+	}
+
+	return results
+}
+
+func precheckErrorMessages(taskErrors []*models.Error) []string {
+	messages := make([]string, 0, len(taskErrors))
+	for _, taskError := range taskErrors {
+		if taskError == nil {
+			continue
+		}
+		message := taskError.Message
+		if len(taskError.RemediationMessage) > 0 {
+			message = fmt.Sprintf("%s (%s)", message, taskError.RemediationMessage)
+		}
+		if len(message) > 0 {
+			messages = append(messages, message)
+		}
+		messages = append(messages, precheckErrorMessages(taskError.NestedErrors)...)
+	}
+
+	return messages
+}
+
+// FilterPrecheckResults returns the results having one of the given statuses.
+func FilterPrecheckResults(results []PrecheckResult, statuses ...string) []PrecheckResult {
+	filtered := make([]PrecheckResult, 0)
+	for _, result := range results {
+		for _, status := range statuses {
+			if result.Status == status {
+				filtered = append(filtered, result)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+func FlattenPrecheckResults(results []PrecheckResult) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		flattened = append(flattened, map[string]interface{}{
+			"name":          result.Name,
+			"description":   result.Description,
+			"status":        result.Status,
+			"resource_name": result.ResourceName,
+			"resource_type": result.ResourceType,
+			"errors":        result.Errors,
+		})
+	}
+
+	return flattened
+}
+
+// PrecheckDomainUpgrade runs the upgrade precheck of the domain and returns the checks which failed. The
+// precheck includes the applicability of the next bundle towards the target release when there is one.
+func PrecheckDomainUpgrade(ctx context.Context, domainId, targetVersion string, sddcClient *api_client.SddcManagerClient) ([]PrecheckResult, error) {
+	resourceType := PrecheckResourceTypeDomain
+	spec := &models.PrecheckSpec{
+		Mode: PrecheckModeUpgrade,
+		Resources: []*models.Resource{{
+			ResourceID: &domainId,
+			Type:       &resourceType,
+		}},
+	}
+
+	allUpgradables, err := GetDomainUpgradables(ctx, domainId, targetVersion, sddcClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+	bundle, _, err := nextUpgrade(ctx, allUpgradables, sddcClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+	if bundle != nil {
+		spec.BundleID = bundle.ID
+	}
+
+	task, err := RunSystemPrecheck(ctx, spec, sddcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return FilterPrecheckResults(GetPrecheckResults(task), PrecheckStatusFailed), nil
+}
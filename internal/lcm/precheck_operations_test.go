@@ -0,0 +1,41 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+func TestWaitForPrecheck(t *testing.T) {
+	statuses := []string{"PENDING", "IN_PROGRESS", "SUCCESSFUL"}
+	reads := 0
+	task, err := waitForPrecheck(context.Background(), time.Millisecond, func() (*models.Task, error) {
+		status := statuses[reads]
+		reads++
+		return &models.Task{ID: "precheck-1", Status: status}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "SUCCESSFUL", task.Status)
+	assert.Equal(t, 3, reads)
+}
+
+func TestWaitForPrecheckContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// The wait stops with the context even though the poll interval is longer
+	start := time.Now()
+	_, err := waitForPrecheck(ctx, time.Hour, func() (*models.Task, error) {
+		return &models.Task{ID: "precheck-1", Status: "IN_PROGRESS"}, nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "precheck task precheck-1 did not complete")
+	assert.Less(t, time.Since(start), time.Minute)
+}
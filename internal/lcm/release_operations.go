@@ -0,0 +1,210 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/releases"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// ReleaseFilter narrows down the releases returned by GetReleases. Empty fields match any release.
+type ReleaseFilter struct {
+	Version              string
+	NewerThan            string
+	ApplicableForVersion string
+}
+
+func GetReleases(ctx context.Context, filter ReleaseFilter, apiClient *client.VcfClient) ([]*models.Release, error) {
+	params := releases.NewGetReleasesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	if len(filter.Version) > 0 {
+		params.SetVersionEq(&filter.Version)
+	}
+	if len(filter.NewerThan) > 0 {
+		params.SetVersionGt(&filter.NewerThan)
+	}
+	if len(filter.ApplicableForVersion) > 0 {
+		params.SetApplicableForVersion(&filter.ApplicableForVersion)
+	}
+
+	result, err := apiClient.Releases.GetReleases(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return []*models.Release{}, nil
+	}
+
+	return result.Payload.Elements, nil
+}
+
+// GetDomainRelease returns the current release of the domain.
+func GetDomainRelease(ctx context.Context, domainId string, apiClient *client.VcfClient) (*models.Release, error) {
+	params := releases.NewGetReleasesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithDomainID(&domainId)
+
+	result, err := apiClient.Releases.GetReleases(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil || len(result.Payload.Elements) == 0 {
+		return nil, fmt.Errorf("no release found for domain %s", domainId)
+	}
+
+	return result.Payload.Elements[0], nil
+}
+
+// GetDomainFutureReleases returns the releases newer than the current release of the domain,
+// together with their applicability to the domain.
+func GetDomainFutureReleases(ctx context.Context, domainId string, apiClient *client.VcfClient) ([]*models.DomainFutureRelease, error) {
+	params := releases.NewGetFutureReleasesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithDomainID(domainId)
+
+	result, err := apiClient.Releases.GetFutureReleases(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return []*models.DomainFutureRelease{}, nil
+	}
+
+	return result.Payload.Elements, nil
+}
+
+// GetSystemRelease returns the release SDDC Manager is currently running.
+func GetSystemRelease(ctx context.Context, apiClient *client.VcfClient) (*models.Release, error) {
+	params := releases.NewGetSystemReleaseParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	result, err := apiClient.Releases.GetSystemRelease(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// LatestVersion returns the highest of the given VCF versions, or an empty string if there are none.
+func LatestVersion(versions []string) (string, error) {
+	var latest *version.Version
+	for _, v := range versions {
+		parsed, err := version.NewVersion(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid release version %q: %w", v, err)
+		}
+		if latest == nil || parsed.GreaterThan(latest) {
+			latest = parsed
+		}
+	}
+	if latest == nil {
+		return "", nil
+	}
+
+	return latest.Original(), nil
+}
+
+func FlattenReleases(allReleases []*models.Release) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(allReleases))
+	for _, release := range allReleases {
+		if release == nil {
+			continue
+		}
+		releaseMap := map[string]interface{}{
+			"version":                    stringValue(release.Version),
+			"product":                    stringValue(release.Product),
+			"description":                stringValue(release.Description),
+			"release_date":               stringValue(release.ReleaseDate),
+			"eol":                        release.Eol,
+			"min_compatible_vcf_version": stringValue(release.MinCompatibleVcfVersion),
+			"is_applicable":              release.IsApplicable,
+			"not_applicable_reason":      release.NotApplicableReason,
+			"bom":                        flattenBom(release.Bom),
+			"patch_bundles":              flattenPatchBundles(release.PatchBundles),
+		}
+		result = append(result, releaseMap)
+	}
+
+	return result
+}
+
+func FlattenDomainFutureReleases(allReleases []*models.DomainFutureRelease) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(allReleases))
+	for _, release := range allReleases {
+		if release == nil {
+			continue
+		}
+		notApplicableReason := release.NotApplicableReason
+		if len(notApplicableReason) == 0 && release.ReasonNotApplicable != nil {
+			notApplicableReason = release.ReasonNotApplicable.Message
+		}
+		releaseMap := map[string]interface{}{
+			"version":                    stringValue(release.Version),
+			"product":                    stringValue(release.Product),
+			"description":                stringValue(release.Description),
+			"release_date":               stringValue(release.ReleaseDate),
+			"eol":                        release.Eol,
+			"min_compatible_vcf_version": stringValue(release.MinCompatibleVcfVersion),
+			"is_applicable":              release.IsApplicable,
+			"not_applicable_reason":      notApplicableReason,
+			"applicability_status":       release.ApplicabilityStatus,
+			"bom":                        flattenBom(release.Bom),
+			"patch_bundles":              flattenPatchBundles(release.PatchBundles),
+		}
+		result = append(result, releaseMap)
+	}
+
+	return result
+}
+
+func flattenBom(bom []*models.ProductVersion) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(bom))
+	for _, product := range bom {
+		if product == nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"name":        stringValue(product.Name),
+			"public_name": stringValue(product.PublicName),
+			"version":     stringValue(product.Version),
+			"release_url": product.ReleaseURL,
+		})
+	}
+
+	return result
+}
+
+func flattenPatchBundles(patchBundles []*models.PatchBundle) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(patchBundles))
+	for _, patchBundle := range patchBundles {
+		if patchBundle == nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"bundle_id":                   stringValue(patchBundle.BundleID),
+			"bundle_type":                 stringValue(patchBundle.BundleType),
+			"cumulative_from_vcf_version": stringValue(patchBundle.CumulativeFromVcfVersion),
+			"bundle_elements":             patchBundle.BundleElements,
+		})
+	}
+
+	return result
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
@@ -0,0 +1,183 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/sddc_managers"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	upgradeStatusCompletedWithFailure = "COMPLETED_WITH_FAILURE"
+	upgradeStatusFailed               = "FAILED"
+	upgradeStatusCancelled            = "CANCELLED"
+
+	sddcManagerUpgradePollInterval = 30 * time.Second
+)
+
+// SddcManagerUpgradeOptions configures how UpgradeSddcManager upgrades the SDDC Manager appliance.
+type SddcManagerUpgradeOptions struct {
+	TargetVersion string
+	// RunPrechecks creates the upgrade as a draft and commits it only after its precheck passed
+	RunPrechecks bool
+	// ScheduledStart is the time in RFC 3339 format at which SDDC Manager starts the upgrade, empty to start immediately
+	ScheduledStart string
+}
+
+// UpgradeSddcManager upgrades the SDDC Manager appliance to the target release and returns the ID of the
+// upgrade, empty if SDDC Manager already runs the target release. SDDC Manager restarts during its upgrade,
+// so the API being unavailable is tolerated while waiting and the session is established again afterwards.
+func UpgradeSddcManager(ctx context.Context, options SddcManagerUpgradeOptions, sddcClient *api_client.SddcManagerClient) (string, error) {
+	upToDate, err := isSddcManagerAtRelease(ctx, options.TargetVersion, sddcClient.ApiClient)
+	if err != nil {
+		return "", err
+	}
+	if upToDate {
+		log.Printf("[DEBUG] SDDC Manager already runs %s", options.TargetVersion)
+		return "", nil
+	}
+
+	sddcManager, err := GetSddcManager(ctx, sddcClient.ApiClient)
+	if err != nil {
+		return "", err
+	}
+	if sddcManager.Domain == nil || sddcManager.Domain.ID == nil {
+		return "", fmt.Errorf("the management domain of SDDC Manager %s is unknown", sddcManager.Fqdn)
+	}
+
+	allUpgradables, err := GetDomainUpgradables(ctx, *sddcManager.Domain.ID, options.TargetVersion, sddcClient.ApiClient)
+	if err != nil {
+		return "", err
+	}
+	var upgradable *models.Upgradable
+	for _, candidate := range allUpgradables {
+		if candidate != nil && candidate.Resource != nil && stringValue(candidate.Resource.Type) == resourceTypeSddcManager {
+			upgradable = candidate
+			break
+		}
+	}
+	if upgradable == nil {
+		return "", fmt.Errorf("no SDDC Manager upgrade to %s found, the upgrade bundle may not be available yet", options.TargetVersion)
+	}
+	if upgradable.Status != UpgradableStatusAvailable {
+		return "", fmt.Errorf("the SDDC Manager upgrade to %s cannot proceed: %s", options.TargetVersion,
+			describeUpgradables([]*models.Upgradable{upgradable}))
+	}
+
+	bundle, err := GetBundle(ctx, upgradable.BundleID, sddcClient.ApiClient)
+	if err != nil {
+		return "", err
+	}
+
+	// performUpgrade must not wait for the task, it cannot be read while SDDC Manager restarts
+	upgradeId, err := performUpgrade(ctx, bundle, []*models.Resource{upgradable.Resource}, UpgradeOptions{
+		DomainId:          *sddcManager.Domain.ID,
+		TargetVersion:     options.TargetVersion,
+		RunPrechecks:      options.RunPrechecks,
+		ScheduledStart:    options.ScheduledStart,
+		WaitForCompletion: false,
+	}, sddcClient)
+	if err != nil {
+		return upgradeId, err
+	}
+
+	return upgradeId, waitForSddcManagerUpgrade(ctx, upgradeId, options.TargetVersion, sddcClient)
+}
+
+// GetSddcManager returns the SDDC Manager appliance.
+func GetSddcManager(ctx context.Context, apiClient *client.VcfClient) (*models.SDDCManager, error) {
+	params := sddc_managers.NewGetSDDCManagersParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	result, err := apiClient.SDDCManagers.GetSDDCManagers(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil || len(result.Payload.Elements) == 0 || result.Payload.Elements[0] == nil {
+		return nil, fmt.Errorf("no SDDC Manager found")
+	}
+
+	return result.Payload.Elements[0], nil
+}
+
+// waitForSddcManagerUpgrade polls the upgrade until it completes and SDDC Manager reports the target release.
+// Errors are expected while the appliance restarts, the session is established again until it succeeds.
+func waitForSddcManagerUpgrade(ctx context.Context, upgradeId, targetVersion string, sddcClient *api_client.SddcManagerClient) error {
+	completed := false
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for the SDDC Manager upgrade %s: %w", upgradeId, ctx.Err())
+		case <-time.After(sddcManagerUpgradePollInterval):
+		}
+
+		if !completed {
+			upgrade, err := GetUpgrade(ctx, upgradeId, sddcClient.ApiClient)
+			if err != nil {
+				log.Printf("[DEBUG] SDDC Manager is not available during its upgrade: %s", err)
+				reconnect(sddcClient)
+				continue
+			}
+
+			status := stringValue(upgrade.Status)
+			log.Printf("[INFO] SDDC Manager upgrade %s is in state %s", upgradeId, status)
+			switch status {
+			case UpgradeStatusCompletedWithSuccess:
+				completed = true
+			case upgradeStatusCompletedWithFailure, upgradeStatusFailed, upgradeStatusCancelled:
+				return fmt.Errorf("SDDC Manager upgrade %s is in state %s", upgradeId, status)
+			default:
+				continue
+			}
+		}
+
+		// The services of SDDC Manager keep restarting for a while after the upgrade has completed
+		upToDate, err := isSddcManagerAtRelease(ctx, targetVersion, sddcClient.ApiClient)
+		if err != nil {
+			log.Printf("[DEBUG] SDDC Manager is not available after its upgrade: %s", err)
+			reconnect(sddcClient)
+			continue
+		}
+		if upToDate {
+			return nil
+		}
+	}
+}
+
+// reconnect establishes a new session, the access token of the previous one is no longer valid
+// once SDDC Manager has restarted.
+func reconnect(sddcClient *api_client.SddcManagerClient) {
+	if err := sddcClient.Connect(); err != nil {
+		log.Printf("[DEBUG] Cannot connect to SDDC Manager yet: %s", err)
+	}
+}
+
+func isSddcManagerAtRelease(ctx context.Context, targetVersion string, apiClient *client.VcfClient) (bool, error) {
+	release, err := GetSystemRelease(ctx, apiClient)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := version.NewVersion(stringValue(release.Version))
+	if err != nil {
+		return false, fmt.Errorf("invalid version %q of SDDC Manager: %w", stringValue(release.Version), err)
+	}
+	target, err := version.NewVersion(targetVersion)
+	if err != nil {
+		return false, fmt.Errorf("invalid target version %q: %w", targetVersion, err)
+	}
+
+	return !current.LessThan(target), nil
+}
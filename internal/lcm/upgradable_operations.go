@@ -0,0 +1,136 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/upgradables"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// GetDomainUpgradables returns the upgradable resources of the domain. If targetVersion is set only
+// the upgradables leading to the given release are returned.
+func GetDomainUpgradables(ctx context.Context, domainId, targetVersion string, apiClient *client.VcfClient) ([]*models.Upgradable, error) {
+	params := upgradables.NewGetUpgradablesByDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithDomainID(domainId)
+	if len(targetVersion) > 0 {
+		params.SetTargetVersion(&targetVersion)
+	}
+
+	result, err := apiClient.Upgradables.GetUpgradablesByDomain(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return []*models.Upgradable{}, nil
+	}
+
+	return result.Payload.Elements, nil
+}
+
+// FlattenUpgradables converts the upgradables to the representation used by the vcf_upgradables data source.
+// The versions and the prerequisites are taken from the bundles of the upgradables.
+func FlattenUpgradables(allUpgradables []*models.Upgradable, allBundles []*models.Bundle) []map[string]interface{} {
+	bundlesById := make(map[string]*models.Bundle, len(allBundles))
+	for _, bundle := range allBundles {
+		if bundle != nil {
+			bundlesById[bundle.ID] = bundle
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(allUpgradables))
+	for _, upgradable := range allUpgradables {
+		if upgradable == nil {
+			continue
+		}
+		upgradableMap := map[string]interface{}{
+			"bundle_id":               upgradable.BundleID,
+			"bundle_type":             upgradable.BundleType,
+			"status":                  upgradable.Status,
+			"prerequisite_bundle_ids": prerequisiteBundleIds(upgradable, allUpgradables, bundlesById),
+		}
+
+		var resourceType string
+		if upgradable.Resource != nil {
+			upgradableMap["resource_name"] = upgradable.Resource.Name
+			upgradableMap["resource_fqdn"] = upgradable.Resource.Fqdn
+			if upgradable.Resource.ResourceID != nil {
+				upgradableMap["resource_id"] = *upgradable.Resource.ResourceID
+			}
+			if upgradable.Resource.Type != nil {
+				resourceType = *upgradable.Resource.Type
+				upgradableMap["resource_type"] = resourceType
+			}
+		}
+
+		if bundle, ok := bundlesById[upgradable.BundleID]; ok {
+			upgradableMap["applicability_order"] = int(bundle.ApplicabilityOrder)
+			if bundle.DownloadStatus != nil {
+				upgradableMap["download_status"] = *bundle.DownloadStatus
+			}
+			if component := upgradedComponent(bundle, resourceType); component != nil {
+				upgradableMap["component_type"] = component.Type
+				upgradableMap["current_version"] = component.FromVersion
+				upgradableMap["target_version"] = component.ToVersion
+			}
+		}
+
+		result = append(result, upgradableMap)
+	}
+
+	return result
+}
+
+// upgradedComponent returns the component of the bundle which upgrades the given resource type,
+// falling back to the first component for bundles which name their components differently.
+func upgradedComponent(bundle *models.Bundle, resourceType string) *models.BundleComponent {
+	var first *models.BundleComponent
+	for _, component := range bundle.Components {
+		if component == nil {
+			continue
+		}
+		if first == nil {
+			first = component
+		}
+		if len(resourceType) > 0 && strings.EqualFold(component.Type, resourceType) {
+			return component
+		}
+	}
+
+	return first
+}
+
+// prerequisiteBundleIds returns the bundles of the other upgradables which SDDC Manager applies
+// before the bundle of the given upgradable, i.e. those with a lower applicability order.
+func prerequisiteBundleIds(upgradable *models.Upgradable, allUpgradables []*models.Upgradable, bundlesById map[string]*models.Bundle) []string {
+	bundle, ok := bundlesById[upgradable.BundleID]
+	if !ok {
+		return []string{}
+	}
+
+	result := make([]string, 0)
+	for _, other := range allUpgradables {
+		if other == nil || other.BundleID == upgradable.BundleID {
+			continue
+		}
+		otherBundle, ok := bundlesById[other.BundleID]
+		if !ok || otherBundle.ApplicabilityOrder >= bundle.ApplicabilityOrder {
+			continue
+		}
+		if !slices.Contains(result, other.BundleID) {
+			result = append(result, other.BundleID)
+		}
+	}
+	slices.Sort(result)
+
+	return result
+}
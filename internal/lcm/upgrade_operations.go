@@ -0,0 +1,539 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/upgrades"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const (
+	UpgradableStatusAvailable = "AVAILABLE"
+
+	UpgradeStatusCompletedWithSuccess = "COMPLETED_WITH_SUCCESS"
+
+	resourceTypeSddcManager = "SDDC_MANAGER"
+
+	ComponentTypeNsxtManager = "NSX_T_MANAGER"
+	ComponentTypeVcenter     = "VCENTER"
+	ComponentTypeHost        = "HOST"
+)
+
+// UpgradeComponentTypes returns the component types an upgrade can be limited to.
+func UpgradeComponentTypes() []string {
+	return []string{ComponentTypeNsxtManager, ComponentTypeVcenter, ComponentTypeHost}
+}
+
+// UpgradeOptions configures how UpgradeDomain upgrades the components of a domain.
+type UpgradeOptions struct {
+	DomainId      string
+	TargetVersion string
+	// Path is the sequence of releases the domain is upgraded through, ending with the target release.
+	// It is computed with PlanUpgradePath when empty
+	Path []string
+	// RunPrechecks creates every upgrade as a draft and commits it only after its precheck passed
+	RunPrechecks bool
+	// ScheduledStart is the time in RFC 3339 format at which SDDC Manager starts each upgrade, empty to start immediately
+	ScheduledStart string
+	// MaintenanceWindow restricts the start of every upgrade to the window, it replaces ScheduledStart when set
+	MaintenanceWindow *MaintenanceWindow
+	// WaitForCompletion waits for every upgrade to complete. Otherwise, only the next upgrade is started or
+	// scheduled and UpgradeDomain returns immediately
+	WaitForCompletion bool
+	// VcenterTemporaryNetwork is used by the vCenter upgrades which migrate to a new appliance
+	VcenterTemporaryNetwork *models.TemporaryNetwork
+	// ComponentTypes limits the upgrade to the bundles containing one of the component types, empty to upgrade all the components
+	ComponentTypes []string
+}
+
+// MaintenanceWindow is the time range in which SDDC Manager may start upgrades.
+type MaintenanceWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// scheduledStart returns the time in RFC 3339 format at which an upgrade requested at the given time
+// starts, empty to start immediately. It fails once the window has ended.
+func (window *MaintenanceWindow) scheduledStart(now time.Time) (string, error) {
+	if !now.Before(window.End) {
+		return "", fmt.Errorf("the maintenance window ended at %s, apply again during the next window to continue "+
+			"with the remaining upgrades", window.End.Format(time.RFC3339))
+	}
+	if now.Before(window.Start) {
+		return window.Start.Format(time.RFC3339), nil
+	}
+
+	return "", nil
+}
+
+// UpgradeDomain upgrades the domain through every release of the upgrade path and returns the IDs
+// of the performed upgrades. Every hop completes, including its prechecks, before the next one starts.
+func UpgradeDomain(ctx context.Context, options UpgradeOptions, sddcClient *api_client.SddcManagerClient) ([]string, error) {
+	path := options.Path
+	if len(path) == 0 && len(options.ComponentTypes) > 0 {
+		// The release of the domain changes only once all its components are upgraded, so there are no hops
+		path = []string{options.TargetVersion}
+	} else if len(path) == 0 {
+		var err error
+		path, err = PlanUpgradePath(ctx, options.DomainId, options.TargetVersion, sddcClient.ApiClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	upgradeIds := make([]string, 0)
+	for i, hop := range path {
+		log.Printf("[INFO] Upgrading domain %s to %s (hop %d of %d)", options.DomainId, hop, i+1, len(path))
+		hopUpgradeIds, err := upgradeDomainToRelease(ctx, hop, options, sddcClient)
+		upgradeIds = append(upgradeIds, hopUpgradeIds...)
+		if err != nil {
+			return upgradeIds, err
+		}
+		if !options.WaitForCompletion && len(hopUpgradeIds) > 0 {
+			return upgradeIds, nil
+		}
+	}
+
+	return upgradeIds, nil
+}
+
+// HasRemainingUpgrades tells whether the domain still has upgrades towards the target release among the bundles
+// containing one of the component types. Upgrading only some components does not change the release of the domain,
+// so the remaining upgrades are found through the upgradables of the domain.
+func HasRemainingUpgrades(ctx context.Context, domainId, targetVersion string, componentTypes []string,
+	apiClient *client.VcfClient) (bool, error) {
+	allUpgradables, err := GetDomainUpgradables(ctx, domainId, targetVersion, apiClient)
+	if err != nil {
+		return false, err
+	}
+
+	bundlesInScope := make(map[string]bool)
+	for _, upgradable := range allUpgradables {
+		if upgradable == nil {
+			continue
+		}
+		inScope, err := isInScope(ctx, upgradable, componentTypes, bundlesInScope, apiClient)
+		if err != nil {
+			return false, err
+		}
+		if inScope {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// PlanUpgradePath returns the releases the domain has to be upgraded through to reach the target
+// release, ending with the target release. Each hop is the newest release not beyond the target
+// which can be applied on top of the previous one. The path is empty if the domain is already at
+// the target release.
+func PlanUpgradePath(ctx context.Context, domainId, targetVersion string, apiClient *client.VcfClient) ([]string, error) {
+	target, err := version.NewVersion(targetVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target version %q: %w", targetVersion, err)
+	}
+
+	release, err := GetDomainRelease(ctx, domainId, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	currentVersion := stringValue(release.Version)
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q of domain %s: %w", currentVersion, domainId, err)
+	}
+
+	path := make([]string, 0)
+	for current.LessThan(target) {
+		candidates, err := GetReleases(ctx, ReleaseFilter{ApplicableForVersion: current.Original()}, apiClient)
+		if err != nil {
+			return nil, err
+		}
+
+		var next *version.Version
+		for _, candidate := range candidates {
+			if candidate == nil || candidate.Version == nil {
+				continue
+			}
+			candidateVersion, err := version.NewVersion(*candidate.Version)
+			if err != nil {
+				log.Printf("[WARN] Ignoring release with invalid version %q: %s", *candidate.Version, err)
+				continue
+			}
+			if !candidateVersion.GreaterThan(current) || candidateVersion.GreaterThan(target) {
+				continue
+			}
+			if next == nil || candidateVersion.GreaterThan(next) {
+				next = candidateVersion
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("no upgrade path from %s to %s for domain %s: no release up to %s can be applied on top of %s",
+				currentVersion, targetVersion, domainId, targetVersion, current.Original())
+		}
+
+		path = append(path, next.Original())
+		current = next
+	}
+
+	return path, nil
+}
+
+// upgradeDomainToRelease applies, in their applicability order, the bundles which upgrade the domain to
+// the given release and returns the IDs of the performed upgrades. Every bundle is downloaded first if needed.
+func upgradeDomainToRelease(ctx context.Context, targetVersion string, options UpgradeOptions,
+	sddcClient *api_client.SddcManagerClient) ([]string, error) {
+	upgradeIds := make([]string, 0)
+	appliedBundles := make(map[string]bool)
+	bundlesInScope := make(map[string]bool)
+	for {
+		allUpgradables, err := GetDomainUpgradables(ctx, options.DomainId, targetVersion, sddcClient.ApiClient)
+		if err != nil {
+			return upgradeIds, err
+		}
+
+		remaining := make([]*models.Upgradable, 0)
+		for _, upgradable := range allUpgradables {
+			if upgradable == nil || appliedBundles[upgradable.BundleID] {
+				continue
+			}
+			inScope, err := isInScope(ctx, upgradable, options.ComponentTypes, bundlesInScope, sddcClient.ApiClient)
+			if err != nil {
+				return upgradeIds, err
+			}
+			if inScope {
+				remaining = append(remaining, upgradable)
+			}
+		}
+		if len(remaining) == 0 {
+			return upgradeIds, nil
+		}
+
+		bundle, resources, err := nextUpgrade(ctx, remaining, sddcClient.ApiClient)
+		if err != nil {
+			return upgradeIds, err
+		}
+		if bundle == nil {
+			return upgradeIds, fmt.Errorf("the upgrade of domain %s to %s cannot proceed: %s", options.DomainId,
+				targetVersion, describeUpgradables(remaining))
+		}
+
+		upgradeId, err := performUpgrade(ctx, bundle, resources, options, sddcClient)
+		if len(upgradeId) > 0 {
+			upgradeIds = append(upgradeIds, upgradeId)
+		}
+		if err != nil {
+			return upgradeIds, err
+		}
+		if !options.WaitForCompletion {
+			// The following bundles become available only once this upgrade has completed
+			return upgradeIds, nil
+		}
+		appliedBundles[bundle.ID] = true
+	}
+}
+
+// isInScope tells whether the bundle of the upgradable contains one of the component types. The result
+// is cached per bundle.
+func isInScope(ctx context.Context, upgradable *models.Upgradable, componentTypes []string, bundlesInScope map[string]bool,
+	apiClient *client.VcfClient) (bool, error) {
+	if len(componentTypes) == 0 {
+		return true, nil
+	}
+	if inScope, ok := bundlesInScope[upgradable.BundleID]; ok {
+		return inScope, nil
+	}
+
+	bundle, err := GetBundle(ctx, upgradable.BundleID, apiClient)
+	if err != nil {
+		return false, err
+	}
+	inScope := false
+	for _, componentType := range componentTypes {
+		if HasComponent(bundle, componentType, "", "") {
+			inScope = true
+			break
+		}
+	}
+	bundlesInScope[upgradable.BundleID] = inScope
+
+	return inScope, nil
+}
+
+// nextUpgrade returns the available bundle with the lowest applicability order together with the
+// resources it upgrades. The bundle is nil if none of the upgradables is available.
+func nextUpgrade(ctx context.Context, allUpgradables []*models.Upgradable, apiClient *client.VcfClient) (*models.Bundle, []*models.Resource, error) {
+	var next *models.Bundle
+	for _, upgradable := range allUpgradables {
+		if upgradable.Status != UpgradableStatusAvailable || upgradable.Resource == nil {
+			continue
+		}
+		if upgradable.Resource.Type != nil && *upgradable.Resource.Type == resourceTypeSddcManager {
+			// SDDC Manager restarts during its own upgrade, it has to be upgraded separately
+			continue
+		}
+		if next != nil && next.ID == upgradable.BundleID {
+			continue
+		}
+
+		bundle, err := GetBundle(ctx, upgradable.BundleID, apiClient)
+		if err != nil {
+			return nil, nil, err
+		}
+		if next == nil || bundle.ApplicabilityOrder < next.ApplicabilityOrder {
+			next = bundle
+		}
+	}
+	if next == nil {
+		return nil, nil, nil
+	}
+
+	resources := make([]*models.Resource, 0)
+	for _, upgradable := range allUpgradables {
+		if upgradable.BundleID == next.ID && upgradable.Status == UpgradableStatusAvailable && upgradable.Resource != nil {
+			resources = append(resources, upgradable.Resource)
+		}
+	}
+
+	return next, resources, nil
+}
+
+func performUpgrade(ctx context.Context, bundle *models.Bundle, resources []*models.Resource, options UpgradeOptions,
+	sddcClient *api_client.SddcManagerClient) (string, error) {
+	if _, err := DownloadBundle(ctx, bundle.ID, sddcClient); err != nil {
+		return "", err
+	}
+
+	scheduledStart := options.ScheduledStart
+	if options.MaintenanceWindow != nil {
+		var err error
+		scheduledStart, err = options.MaintenanceWindow.scheduledStart(time.Now())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	spec := &models.UpgradeSpec{
+		BundleID:     &bundle.ID,
+		ResourceType: resources[0].Type,
+		DraftMode:    options.RunPrechecks,
+	}
+	for _, resource := range resources {
+		spec.ResourceUpgradeSpecs = append(spec.ResourceUpgradeSpecs, &models.ResourceUpgradeSpec{
+			ResourceID:         resource.ResourceID,
+			UpgradeNow:         len(scheduledStart) == 0,
+			ScheduledTimestamp: scheduledStart,
+		})
+	}
+	if options.VcenterTemporaryNetwork != nil && HasComponent(bundle, ComponentTypeVcenter, "", "") {
+		spec.VcenterUpgradeUserInputSpecs = []*models.VcenterUpgradeUserInputSpec{
+			{TemporaryNetwork: options.VcenterTemporaryNetwork},
+		}
+	}
+
+	log.Printf("[DEBUG] Upgrading %d resource(s) of type %s with bundle %s", len(resources), stringValue(spec.ResourceType), bundle.ID)
+	params := upgrades.NewPerformUpgradeParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithUpgradeSpec(spec)
+
+	ok, accepted, err := sddcClient.ApiClient.Upgrades.PerformUpgrade(params)
+	if err != nil {
+		return "", err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+	if task == nil {
+		return "", fmt.Errorf("upgrade with bundle %s did not return a task", bundle.ID)
+	}
+
+	upgrade, err := findUpgradeByTask(ctx, bundle.ID, task.ID, sddcClient.ApiClient)
+	if err != nil {
+		return "", err
+	}
+	upgradeId := stringValue(upgrade.ID)
+
+	if options.RunPrechecks {
+		if err := RunUpgradePrecheck(ctx, upgradeId, sddcClient); err != nil {
+			return upgradeId, err
+		}
+
+		if options.MaintenanceWindow != nil {
+			// The precheck may run past the start of the window
+			scheduledStart, err = options.MaintenanceWindow.scheduledStart(time.Now())
+			if err != nil {
+				return upgradeId, err
+			}
+		}
+		task, err = commitUpgrade(ctx, upgradeId, scheduledStart, sddcClient.ApiClient)
+		if err != nil {
+			return upgradeId, err
+		}
+	}
+
+	if !options.WaitForCompletion {
+		log.Printf("[INFO] Upgrade %s with bundle %s started, not waiting for its completion", upgradeId, bundle.ID)
+		return upgradeId, nil
+	}
+
+	if err := sddcClient.WaitForTaskComplete(ctx, task.ID, false); err != nil {
+		return upgradeId, fmt.Errorf("upgrade %s with bundle %s failed: %w", upgradeId, bundle.ID, err)
+	}
+
+	return upgradeId, nil
+}
+
+func GetUpgrade(ctx context.Context, upgradeId string, apiClient *client.VcfClient) (*models.Upgrade, error) {
+	params := upgrades.NewGetUpgradeByIDParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithUpgradeID(upgradeId)
+
+	result, err := apiClient.Upgrades.GetUpgradeByID(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Payload, nil
+}
+
+// findUpgradeByTask returns the upgrade of the given bundle which was created by the given task.
+func findUpgradeByTask(ctx context.Context, bundleId, taskId string, apiClient *client.VcfClient) (*models.Upgrade, error) {
+	params := upgrades.NewGetUpgradesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithBundleID(&bundleId)
+
+	result, err := apiClient.Upgrades.GetUpgrades(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload != nil {
+		for _, upgrade := range result.Payload.Elements {
+			if upgrade == nil {
+				continue
+			}
+			if stringValue(upgrade.TaskID) == taskId || stringValue(upgrade.ID) == taskId {
+				return upgrade, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no upgrade of bundle %s found for task %s", bundleId, taskId)
+}
+
+// RunUpgradePrecheck runs the precheck of a draft upgrade and returns an error if it did not pass.
+func RunUpgradePrecheck(ctx context.Context, upgradeId string, sddcClient *api_client.SddcManagerClient) error {
+	apiClient := sddcClient.ApiClient
+	params := upgrades.NewStartUpgradePrecheckParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithUpgradeID(upgradeId)
+
+	ok, accepted, err := apiClient.Upgrades.StartUpgradePrecheck(params)
+	if err != nil {
+		return err
+	}
+
+	var task *models.Task
+	if accepted != nil {
+		task = accepted.Payload
+	} else if ok != nil {
+		task = ok.Payload
+	}
+	if task == nil {
+		return fmt.Errorf("precheck of upgrade %s did not return a task", upgradeId)
+	}
+
+	precheck, err := waitForPrecheck(ctx, sddcClient.PollInterval(), func() (*models.Task, error) {
+		precheckParams := upgrades.NewGetUpgradePrecheckByIDParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithUpgradeID(upgradeId).
+			WithPrecheckID(task.ID)
+
+		result, err := apiClient.Upgrades.GetUpgradePrecheckByID(precheckParams)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Payload, nil
+	})
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(precheck.Status, PrecheckStatusSuccessful) {
+		return nil
+	}
+
+	return fmt.Errorf("precheck of upgrade %s is in state %s: %s", upgradeId, precheck.Status, describePrecheckFailures(precheck))
+}
+
+// commitUpgrade starts a draft upgrade, either immediately or at the given time.
+func commitUpgrade(ctx context.Context, upgradeId, scheduledStart string, apiClient *client.VcfClient) (*models.Task, error) {
+	params := upgrades.NewUpdateUpgradeScheduleParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithUpgradeID(upgradeId).
+		WithUpgradeCommitSpec(&models.UpgradeCommitSpec{
+			UpgradeNow:         len(scheduledStart) == 0,
+			ScheduledTimestamp: scheduledStart,
+		})
+
+	result, err := apiClient.Upgrades.UpdateUpgradeSchedule(params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Payload == nil {
+		return nil, fmt.Errorf("commit of upgrade %s did not return a task", upgradeId)
+	}
+
+	return result.Payload, nil
+}
+
+func describePrecheckFailures(precheck *models.Task) string {
+	failures := make([]string, 0)
+	for _, result := range FilterPrecheckResults(GetPrecheckResults(precheck), PrecheckStatusFailed) {
+		failures = append(failures, result.String())
+	}
+	for _, taskError := range precheck.Errors {
+		if taskError != nil && len(taskError.Message) > 0 {
+			failures = append(failures, taskError.Message)
+		}
+	}
+	if len(failures) == 0 {
+		return "no details available"
+	}
+
+	return strings.Join(failures, ", ")
+}
+
+func describeUpgradables(allUpgradables []*models.Upgradable) string {
+	descriptions := make([]string, 0, len(allUpgradables))
+	for _, upgradable := range allUpgradables {
+		resourceType := ""
+		if upgradable.Resource != nil {
+			resourceType = stringValue(upgradable.Resource.Type)
+		}
+		description := fmt.Sprintf("bundle %s for %s is %s", upgradable.BundleID, resourceType, upgradable.Status)
+		if resourceType == resourceTypeSddcManager {
+			description += ", SDDC Manager must be upgraded first"
+		}
+		descriptions = append(descriptions, description)
+	}
+
+	return strings.Join(descriptions, "; ")
+}
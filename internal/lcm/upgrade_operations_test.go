@@ -0,0 +1,61 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package lcm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceWindowScheduledStart(t *testing.T) {
+	window := &MaintenanceWindow{
+		Start: time.Date(2026, 10, 17, 22, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, 10, 18, 4, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name           string
+		now            time.Time
+		scheduledStart string
+		err            string
+	}{
+		{
+			name:           "before the window",
+			now:            time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
+			scheduledStart: "2026-10-17T22:00:00Z",
+		},
+		{
+			name: "at the start of the window",
+			now:  window.Start,
+		},
+		{
+			name: "during the window",
+			now:  time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "at the end of the window",
+			now:  window.End,
+			err:  "the maintenance window ended at 2026-10-18T04:00:00Z",
+		},
+		{
+			name: "after the window",
+			now:  time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC),
+			err:  "the maintenance window ended at 2026-10-18T04:00:00Z",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheduledStart, err := window.scheduledStart(test.now)
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.scheduledStart, scheduledStart)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/network_pools"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// SweepNetworkPools deletes the network pools with the given names, e.g. the ones left behind by failed acceptance
+// tests. SDDC Manager refuses to delete a network pool in use, so the hosts are to be decommissioned first.
+// A failure does not stop the deletion of the other network pools.
+func SweepNetworkPools(ctx context.Context, apiClient *client.VcfClient, names []string) error {
+	params := network_pools.NewGetNetworkPoolParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	poolsResponse, err := apiClient.NetworkPools.GetNetworkPool(params)
+	if err != nil {
+		return err
+	}
+	if poolsResponse.Payload == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, pool := range poolsResponse.Payload.Elements {
+		if pool == nil || !slices.Contains(names, pool.Name) {
+			continue
+		}
+		deleteParams := network_pools.NewDeleteNetworkPoolParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout)
+		deleteParams.ID = pool.ID
+
+		log.Printf("Deleting network pool %s", pool.Name)
+		if _, err := apiClient.NetworkPools.DeleteNetworkPool(deleteParams); err != nil {
+			errs = append(errs, fmt.Errorf("cannot delete network pool %s: %w", pool.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,154 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+// NetworkProfileSchema this helper function extracts the NetworkProfile schema, so that
+// it's made available for both workload domain and cluster creation.
+// Network profiles allow groups of hosts within a cluster to use different NSX host switch
+// configurations, e.g. separate TEP IP address pools per rack in an L3 (routed) fabric.
+func NetworkProfileSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The network profile name",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The network profile description",
+			},
+			"is_default": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Designates the network profile as a Global Network Config (true) or " +
+					"Sub Network Config (false)",
+			},
+			"nsx_host_switch_config": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The list of NSX host switch configurations",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vds_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the vSphere Distributed Switch",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"uplink_profile_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the uplink profile",
+						},
+						"ip_address_pool_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of the IP address pool used for the host TEPs. Omit for DHCP",
+						},
+						"vds_uplink_to_nsx_uplink": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The map of vSphere Distributed Switch uplinks to the NSX switch uplinks",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"vds_uplink_name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  "The uplink name of the vSphere Distributed Switch",
+										ValidateFunc: validation.NoZeroValues,
+									},
+									"nsx_uplink_name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  "The uplink name of the NSX switch",
+										ValidateFunc: validation.NoZeroValues,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TryConvertToNetworkProfile(object map[string]interface{}) (*models.NetworkProfile, error) {
+	result := &models.NetworkProfile{}
+	if object == nil {
+		return nil, fmt.Errorf("cannot convert to NetworkProfile, object is nil")
+	}
+	name := object["name"].(string)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("cannot convert to NetworkProfile, name is required")
+	}
+	result.Name = &name
+	if description, ok := object["description"]; ok && !validationutils.IsEmpty(description) {
+		result.Description = description.(string)
+	}
+	if isDefault, ok := object["is_default"]; ok && !validationutils.IsEmpty(isDefault) {
+		result.IsDefault = isDefault.(bool)
+	}
+	if hostSwitchConfigsRaw, ok := object["nsx_host_switch_config"]; ok && !validationutils.IsEmpty(hostSwitchConfigsRaw) {
+		result.NSXTHostSwitchConfigs = []*models.NSXTHostSwitchConfig{}
+		for _, hostSwitchConfigEntry := range hostSwitchConfigsRaw.([]interface{}) {
+			hostSwitchConfig, err := tryConvertToNsxHostSwitchConfig(hostSwitchConfigEntry.(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			result.NSXTHostSwitchConfigs = append(result.NSXTHostSwitchConfigs, hostSwitchConfig)
+		}
+	} else {
+		return nil, fmt.Errorf("cannot convert to NetworkProfile, nsx_host_switch_config is required")
+	}
+
+	return result, nil
+}
+
+func tryConvertToNsxHostSwitchConfig(object map[string]interface{}) (*models.NSXTHostSwitchConfig, error) {
+	result := &models.NSXTHostSwitchConfig{}
+	if object == nil {
+		return nil, fmt.Errorf("cannot convert to NSXTHostSwitchConfig, object is nil")
+	}
+	vdsName := object["vds_name"].(string)
+	if len(vdsName) == 0 {
+		return nil, fmt.Errorf("cannot convert to NSXTHostSwitchConfig, vds_name is required")
+	}
+	result.VdsName = vdsName
+	if uplinkProfileName, ok := object["uplink_profile_name"]; ok && !validationutils.IsEmpty(uplinkProfileName) {
+		result.UplinkProfileName = uplinkProfileName.(string)
+	}
+	if ipAddressPoolName, ok := object["ip_address_pool_name"]; ok && !validationutils.IsEmpty(ipAddressPoolName) {
+		result.IPAddressPoolName = ipAddressPoolName.(string)
+	}
+	if uplinkMappingsRaw, ok := object["vds_uplink_to_nsx_uplink"]; ok && !validationutils.IsEmpty(uplinkMappingsRaw) {
+		result.VdsUplinkToNsxUplink = []*models.UplinkMapping{}
+		for _, uplinkMappingEntry := range uplinkMappingsRaw.([]interface{}) {
+			uplinkMapping := uplinkMappingEntry.(map[string]interface{})
+			vdsUplinkName := uplinkMapping["vds_uplink_name"].(string)
+			nsxUplinkName := uplinkMapping["nsx_uplink_name"].(string)
+			result.VdsUplinkToNsxUplink = append(result.VdsUplinkToNsxUplink, &models.UplinkMapping{
+				VdsUplinkName: &vdsUplinkName,
+				NsxUplinkName: &nsxUplinkName,
+			})
+		}
+	}
+
+	return result, nil
+}
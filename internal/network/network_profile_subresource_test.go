@@ -0,0 +1,57 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryConvertToNetworkProfile(t *testing.T) {
+	networkProfile, err := TryConvertToNetworkProfile(map[string]interface{}{
+		"name":        "sfo-w01-cl01-rack01",
+		"description": "Hosts of rack 1",
+		"is_default":  true,
+		"nsx_host_switch_config": []interface{}{map[string]interface{}{
+			"vds_name":             "sfo-w01-cl01-vds01",
+			"uplink_profile_name":  "sfo-w01-cl01-uplink-profile",
+			"ip_address_pool_name": "sfo-w01-cl01-tep01",
+			"vds_uplink_to_nsx_uplink": []interface{}{
+				map[string]interface{}{"vds_uplink_name": "uplink1", "nsx_uplink_name": "uplink-1"},
+				map[string]interface{}{"vds_uplink_name": "uplink2", "nsx_uplink_name": "uplink-2"},
+			},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "sfo-w01-cl01-rack01", *networkProfile.Name)
+	assert.Equal(t, "Hosts of rack 1", networkProfile.Description)
+	assert.True(t, networkProfile.IsDefault)
+	assert.Len(t, networkProfile.NSXTHostSwitchConfigs, 1)
+	hostSwitchConfig := networkProfile.NSXTHostSwitchConfigs[0]
+	assert.Equal(t, "sfo-w01-cl01-vds01", hostSwitchConfig.VdsName)
+	assert.Equal(t, "sfo-w01-cl01-uplink-profile", hostSwitchConfig.UplinkProfileName)
+	assert.Equal(t, "sfo-w01-cl01-tep01", hostSwitchConfig.IPAddressPoolName)
+	assert.Len(t, hostSwitchConfig.VdsUplinkToNsxUplink, 2)
+	assert.Equal(t, "uplink2", *hostSwitchConfig.VdsUplinkToNsxUplink[1].VdsUplinkName)
+	assert.Equal(t, "uplink-2", *hostSwitchConfig.VdsUplinkToNsxUplink[1].NsxUplinkName)
+}
+
+func TestTryConvertToNetworkProfileErrors(t *testing.T) {
+	_, err := TryConvertToNetworkProfile(nil)
+	assert.EqualError(t, err, "cannot convert to NetworkProfile, object is nil")
+
+	_, err = TryConvertToNetworkProfile(map[string]interface{}{"name": ""})
+	assert.EqualError(t, err, "cannot convert to NetworkProfile, name is required")
+
+	_, err = TryConvertToNetworkProfile(map[string]interface{}{"name": "sfo-w01-cl01-rack01"})
+	assert.EqualError(t, err, "cannot convert to NetworkProfile, nsx_host_switch_config is required")
+
+	_, err = TryConvertToNetworkProfile(map[string]interface{}{
+		"name":                   "sfo-w01-cl01-rack01",
+		"nsx_host_switch_config": []interface{}{map[string]interface{}{"vds_name": ""}},
+	})
+	assert.EqualError(t, err, "cannot convert to NSXTHostSwitchConfig, vds_name is required")
+}
@@ -64,15 +64,15 @@ func NsxSchema() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				Sensitive:    true,
-				Description:  "NSX Manager admin user password",
-				ValidateFunc: validationutils.ValidatePassword,
+				Description:  "NSX Manager admin user password (at least 12 characters)",
+				ValidateFunc: validationutils.NsxPasswordPolicy.ValidateFunc(),
 			},
 			"nsx_manager_audit_password": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Sensitive:    true,
-				Description:  "NSX Manager audit user password",
-				ValidateFunc: validationutils.ValidatePassword,
+				Description:  "NSX Manager audit user password (at least 12 characters)",
+				ValidateFunc: validationutils.NsxPasswordPolicy.ValidateFunc(),
 			},
 			"nsx_manager_node": {
 				Type:        schema.TypeList,
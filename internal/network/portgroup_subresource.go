@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vcf-sdk-go/models"
 
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -21,9 +22,10 @@ func PortgroupSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Port group name",
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "Port group name. Overrides the name SDDC Manager would otherwise generate for the " +
+					"transport type, must be unique within the vSphere Distributed Switch",
 				ValidateFunc: validation.NoZeroValues,
 			},
 			"transport_type": {
@@ -44,6 +46,12 @@ func PortgroupSchema() *schema.Resource {
 				Description: "List of active uplinks associated with portgroup. This is only supported for VxRail.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"standby_uplinks": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "List of standby uplinks associated with portgroup. This is only supported for VxRail.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -62,9 +70,11 @@ func tryConvertToPortgroupSpec(object map[string]interface{}) (*models.Portgroup
 		transportTypeString := transportType.(string)
 		result.TransportType = &transportTypeString
 	}
-	if activeUplinks, ok := object["active_uplinks"].([]string); ok && !validationutils.IsEmpty(activeUplinks) {
-		result.ActiveUplinks = []string{}
-		result.ActiveUplinks = append(result.ActiveUplinks, activeUplinks...)
+	if activeUplinks, ok := object["active_uplinks"].([]interface{}); ok && !validationutils.IsEmpty(activeUplinks) {
+		result.ActiveUplinks = utils.ToStringSlice(activeUplinks)
+	}
+	if standbyUplinks, ok := object["standby_uplinks"].([]interface{}); ok && !validationutils.IsEmpty(standbyUplinks) {
+		result.StandByUplinks = utils.ToStringSlice(standbyUplinks)
 	}
 
 	return result, nil
@@ -78,6 +88,7 @@ func flattenPortgroupSpec(spec *models.PortgroupSpec) map[string]interface{} {
 	result["name"] = *spec.Name
 	result["transport_type"] = *spec.TransportType
 	result["active_uplinks"] = spec.ActiveUplinks
+	result["standby_uplinks"] = spec.StandByUplinks
 
 	return result
 }
@@ -0,0 +1,99 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+// UplinkProfileSchema this helper function extracts the UplinkProfile schema, so that
+// it's made available for both workload domain and cluster creation.
+func UplinkProfileSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The uplink profile name",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"transport_vlan": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The VLAN used for tagging overlay traffic of the associated Host Switch",
+				ValidateFunc: validation.IntBetween(0, 4095),
+			},
+			"teaming": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The teaming policies to be associated with the uplink profile",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"policy": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: "The teaming policy associated with the uplink profile, One among: " +
+								"FAILOVER_ORDER, LOADBALANCE_SRCID, LOADBALANCE_SRC_MAC",
+							ValidateFunc: validation.StringInSlice([]string{
+								"FAILOVER_ORDER", "LOADBALANCE_SRCID", "LOADBALANCE_SRC_MAC",
+							}, false),
+						},
+						"active_uplinks": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "The list of active uplinks",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"standby_uplinks": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The list of standby uplinks",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TryConvertToUplinkProfile(object map[string]interface{}) (*models.UplinkProfile, error) {
+	result := &models.UplinkProfile{}
+	if object == nil {
+		return nil, fmt.Errorf("cannot convert to UplinkProfile, object is nil")
+	}
+	name := object["name"].(string)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("cannot convert to UplinkProfile, name is required")
+	}
+	result.Name = name
+	if transportVlan, ok := object["transport_vlan"]; ok && !validationutils.IsEmpty(transportVlan) {
+		result.TransportVlan = int32(transportVlan.(int))
+	}
+	if teamingsRaw, ok := object["teaming"]; ok && !validationutils.IsEmpty(teamingsRaw) {
+		result.Teamings = []*models.TeamingSpec{}
+		for _, teamingEntry := range teamingsRaw.([]interface{}) {
+			teaming := teamingEntry.(map[string]interface{})
+			teamingSpec := &models.TeamingSpec{
+				Policy:        teaming["policy"].(string),
+				ActiveUplinks: utils.ToStringSlice(teaming["active_uplinks"].([]interface{})),
+			}
+			if standbyUplinks, ok := teaming["standby_uplinks"].([]interface{}); ok && !validationutils.IsEmpty(standbyUplinks) {
+				teamingSpec.StandByUplinks = utils.ToStringSlice(standbyUplinks)
+			}
+			result.Teamings = append(result.Teamings, teamingSpec)
+		}
+	}
+
+	return result, nil
+}
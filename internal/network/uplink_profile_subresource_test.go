@@ -0,0 +1,47 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryConvertToUplinkProfile(t *testing.T) {
+	uplinkProfile, err := TryConvertToUplinkProfile(map[string]interface{}{
+		"name":           "sfo-w01-cl01-uplink-profile",
+		"transport_vlan": 1614,
+		"teaming": []interface{}{
+			map[string]interface{}{
+				"policy":          "FAILOVER_ORDER",
+				"active_uplinks":  []interface{}{"uplink-1"},
+				"standby_uplinks": []interface{}{"uplink-2"},
+			},
+			map[string]interface{}{
+				"policy":          "LOADBALANCE_SRCID",
+				"active_uplinks":  []interface{}{"uplink-1", "uplink-2"},
+				"standby_uplinks": []interface{}{},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "sfo-w01-cl01-uplink-profile", uplinkProfile.Name)
+	assert.Equal(t, int32(1614), uplinkProfile.TransportVlan)
+	assert.Len(t, uplinkProfile.Teamings, 2)
+	assert.Equal(t, "FAILOVER_ORDER", uplinkProfile.Teamings[0].Policy)
+	assert.Equal(t, []string{"uplink-1"}, uplinkProfile.Teamings[0].ActiveUplinks)
+	assert.Equal(t, []string{"uplink-2"}, uplinkProfile.Teamings[0].StandByUplinks)
+	assert.Equal(t, []string{"uplink-1", "uplink-2"}, uplinkProfile.Teamings[1].ActiveUplinks)
+	assert.Empty(t, uplinkProfile.Teamings[1].StandByUplinks)
+}
+
+func TestTryConvertToUplinkProfileErrors(t *testing.T) {
+	_, err := TryConvertToUplinkProfile(nil)
+	assert.EqualError(t, err, "cannot convert to UplinkProfile, object is nil")
+
+	_, err = TryConvertToUplinkProfile(map[string]interface{}{"name": ""})
+	assert.EqualError(t, err, "cannot convert to UplinkProfile, name is required")
+}
@@ -68,11 +68,17 @@ func TryConvertToVdsSpec(object map[string]interface{}) (*models.VdsSpec, error)
 		portgroupsList := portgroupsRaw.([]interface{})
 		if len(portgroupsList) > 0 {
 			result.PortGroupSpecs = []*models.PortgroupSpec{}
+			portgroupNames := make(map[string]bool)
 			for _, portgroupListEntry := range portgroupsList {
 				portgroupSpec, err := tryConvertToPortgroupSpec(portgroupListEntry.(map[string]interface{}))
 				if err != nil {
 					return nil, err
 				}
+				if portgroupNames[*portgroupSpec.Name] {
+					return nil, fmt.Errorf("cannot convert to VdsSpec, portgroup name %q is used more than once in %q",
+						*portgroupSpec.Name, name)
+				}
+				portgroupNames[*portgroupSpec.Name] = true
 				result.PortGroupSpecs = append(result.PortGroupSpecs, portgroupSpec)
 			}
 		}
@@ -0,0 +1,49 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPortgroup(name, transportType string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            name,
+		"transport_type":  transportType,
+		"active_uplinks":  []interface{}{"uplink1"},
+		"standby_uplinks": []interface{}{"uplink2"},
+	}
+}
+
+func TestTryConvertToVdsSpec(t *testing.T) {
+	vdsSpec, err := TryConvertToVdsSpec(map[string]interface{}{
+		"name": "sfo-w01-cl01-vds01",
+		"portgroup": []interface{}{
+			testPortgroup("sfo-w01-cl01-vds01-pg-mgmt", "MANAGEMENT"),
+			testPortgroup("sfo-w01-cl01-vds01-pg-vmotion", "VMOTION"),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, vdsSpec.PortGroupSpecs, 2)
+	assert.Equal(t, "sfo-w01-cl01-vds01-pg-mgmt", *vdsSpec.PortGroupSpecs[0].Name)
+	assert.Equal(t, "MANAGEMENT", *vdsSpec.PortGroupSpecs[0].TransportType)
+	assert.Equal(t, []string{"uplink1"}, vdsSpec.PortGroupSpecs[0].ActiveUplinks)
+	assert.Equal(t, []string{"uplink2"}, vdsSpec.PortGroupSpecs[0].StandByUplinks)
+	assert.Equal(t, "sfo-w01-cl01-vds01-pg-vmotion", *vdsSpec.PortGroupSpecs[1].Name)
+}
+
+func TestTryConvertToVdsSpecDuplicatePortgroupName(t *testing.T) {
+	_, err := TryConvertToVdsSpec(map[string]interface{}{
+		"name": "sfo-w01-cl01-vds01",
+		"portgroup": []interface{}{
+			testPortgroup("sfo-w01-cl01-vds01-pg", "MANAGEMENT"),
+			testPortgroup("sfo-w01-cl01-vds01-pg", "VMOTION"),
+		},
+	})
+	assert.EqualError(t, err,
+		`cannot convert to VdsSpec, portgroup name "sfo-w01-cl01-vds01-pg" is used more than once in "sfo-w01-cl01-vds01"`)
+}
@@ -7,6 +7,7 @@ package nsx_edge_cluster
 import (
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/vcf-sdk-go/client"
@@ -150,6 +151,24 @@ func getNewNodes(currentNodes []*models.EdgeNodeReference, newNodesRaw []interfa
 	return result
 }
 
+// GetNodesWithChangedUplinks returns the names of the edge nodes which are present in both
+// configurations but whose Tier-0 uplinks (including the BGP peers) differ.
+func GetNodesWithChangedUplinks(oldNodesRaw, newNodesRaw []interface{}) []string {
+	result := make([]string, 0)
+
+	for _, oldNode := range oldNodesRaw {
+		oldNodeMap := oldNode.(map[string]interface{})
+		for _, newNode := range newNodesRaw {
+			newNodeMap := newNode.(map[string]interface{})
+			if oldNodeMap["name"] == newNodeMap["name"] && !reflect.DeepEqual(oldNodeMap["uplink"], newNodeMap["uplink"]) {
+				result = append(result, newNodeMap["name"].(string))
+			}
+		}
+	}
+
+	return result
+}
+
 func getNodeSpec(node map[string]interface{}, client *client.VcfClient) (*models.NsxTEdgeNodeSpec, error) {
 	name := node["name"].(string)
 	tep1IP := node["tep1_ip"].(string)
@@ -0,0 +1,91 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package nsx_edge_cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testEdgeNode(name, interfaceIp string, peerAsn int) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name,
+		"uplink": []interface{}{map[string]interface{}{
+			"interface_ip": interfaceIp,
+			"vlan":         2083,
+			"bgp_peer": []interface{}{map[string]interface{}{
+				"ip":       "192.168.18.1/24",
+				"password": "VMware1!",
+				"asn":      peerAsn,
+			}},
+		}},
+	}
+}
+
+func TestGetNodesWithChangedUplinks(t *testing.T) {
+	oldNodes := []interface{}{
+		testEdgeNode("sfo-m01-en01", "192.168.18.2/24", 65001),
+		testEdgeNode("sfo-m01-en02", "192.168.18.3/24", 65001),
+	}
+	tests := []struct {
+		name     string
+		newNodes []interface{}
+		changed  []string
+	}{
+		{
+			name:     "unchanged",
+			newNodes: oldNodes,
+			changed:  []string{},
+		},
+		{
+			name: "changed interface",
+			newNodes: []interface{}{
+				testEdgeNode("sfo-m01-en01", "192.168.18.12/24", 65001),
+				testEdgeNode("sfo-m01-en02", "192.168.18.3/24", 65001),
+			},
+			changed: []string{"sfo-m01-en01"},
+		},
+		{
+			name: "changed BGP peer",
+			newNodes: []interface{}{
+				testEdgeNode("sfo-m01-en01", "192.168.18.2/24", 65001),
+				testEdgeNode("sfo-m01-en02", "192.168.18.3/24", 65002),
+			},
+			changed: []string{"sfo-m01-en02"},
+		},
+		{
+			// Expanding the edge cluster is supported
+			name: "added node",
+			newNodes: []interface{}{
+				testEdgeNode("sfo-m01-en01", "192.168.18.2/24", 65001),
+				testEdgeNode("sfo-m01-en02", "192.168.18.3/24", 65001),
+				testEdgeNode("sfo-m01-en03", "192.168.18.4/24", 65001),
+			},
+			changed: []string{},
+		},
+		{
+			// Shrinking the edge cluster is supported
+			name:     "removed node",
+			newNodes: []interface{}{testEdgeNode("sfo-m01-en01", "192.168.18.2/24", 65001)},
+			changed:  []string{},
+		},
+		{
+			// A renamed node is a removed node and an added node
+			name: "renamed node",
+			newNodes: []interface{}{
+				testEdgeNode("sfo-m01-en01", "192.168.18.2/24", 65001),
+				testEdgeNode("sfo-m01-en04", "192.168.18.5/24", 65002),
+			},
+			changed: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.changed, GetNodesWithChangedUplinks(oldNodes, test.newNodes))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/backup"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+func DataSourceBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBackupsRead,
+		Description: "Datasource used to list the history of the file-based backups of SDDC Manager",
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(backup.DefaultReadTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				Description:  "The number of most recent tasks to read before the filters are applied",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"statuses": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The statuses of the backups to return. One or more among IN_PROGRESS, SUCCESSFUL, FAILED",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(backup.BackupStatuses(), false),
+				},
+			},
+			"require_successful_within": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Fail the read unless a backup succeeded within the given duration, e.g. 24h. Allows a plan to " +
+					"stop before destructive changes when there is no recent backup",
+				ValidateFunc: validationutils.ValidatePositiveDuration,
+			},
+			"target": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The backup location currently configured, as protocol://server:port/directory",
+			},
+			"last_successful_backup_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The completion time of the most recent successful backup, empty if there is none",
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching backups, newest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the backup task",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the backup task",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the backup. One among IN_PROGRESS, SUCCESSFUL, FAILED",
+						},
+						"creation_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the backup started",
+						},
+						"completion_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the backup completed",
+						},
+						"resource_types": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The types of the backed up resources, e.g. SDDC_MANAGER",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"target": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The backup location configured at read time. SDDC Manager does not record the location of each backup",
+						},
+						"errors": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The errors of the backup",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBackupsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	backupTasks, err := backup.GetBackupTasks(ctx, data.Get("limit").(int), apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if within := data.Get("require_successful_within").(string); len(within) > 0 {
+		duration, err := time.ParseDuration(within)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := backup.CheckRecentBackup(backupTasks, duration, time.Now()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	location, err := backup.GetBackupLocation(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	target := backup.BackupTarget(location)
+	_ = data.Set("target", target)
+
+	lastSuccessfulBackupTime := ""
+	if last := backup.LastSuccessfulBackup(backupTasks); last != nil {
+		lastSuccessfulBackupTime = last.CompletionTimestamp
+	}
+	_ = data.Set("last_successful_backup_time", lastSuccessfulBackupTime)
+
+	statuses := utils.ToStringSlice(data.Get("statuses").([]interface{}))
+	_ = data.Set("backups", backup.FlattenBackupTasks(backup.FilterBackupTasks(backupTasks, statuses), target))
+
+	id, err := utils.HashFields([]string{
+		strconv.Itoa(data.Get("limit").(int)),
+		strings.Join(statuses, ","),
+		data.Get("require_successful_within").(string),
+	})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
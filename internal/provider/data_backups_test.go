@@ -0,0 +1,36 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBackups(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceBackups(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_backups.successful", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_backups.successful", "target"),
+				resource.TestCheckResourceAttrSet("data.vcf_backups.successful", "last_successful_backup_time"),
+				resource.TestCheckResourceAttr("data.vcf_backups.successful", "backups.0.status", "SUCCESSFUL"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceBackups() string {
+	return `
+	data "vcf_backups" "successful" {
+		statuses                  = ["SUCCESSFUL"]
+		require_successful_within = "168h"
+	}
+`
+}
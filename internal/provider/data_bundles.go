@@ -0,0 +1,184 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceBundles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataBundlesRead,
+		Description: "Datasource used to list the LCM bundles known to SDDC Manager",
+		Schema: map[string]*schema.Schema{
+			"component_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the bundles containing a component of the given type, e.g. SDDC_MANAGER, VCENTER, NSX_T_MANAGER, HOST",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the bundles containing a component which upgrades to or installs the given version",
+			},
+			"download_status": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "Return only the bundles with one of the given download statuses. One or more among PENDING, " +
+					"SCHEDULED, IN_PROGRESS, SUCCESSFUL, FAILED, RECALLED",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(lcm.AllDownloadStatuses(), false),
+				},
+			},
+			"bundles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching bundles",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the bundle",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the bundle",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the bundle",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the bundle",
+						},
+						"vendor": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The vendor of the bundle",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The severity of the bundle",
+						},
+						"released_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The release date of the bundle",
+						},
+						"size_mb": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The size of the bundle in MB",
+						},
+						"download_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The download status of the bundle",
+						},
+						"is_compliant": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the bundle is compliant with the current VCF version",
+						},
+						"is_cumulative": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the bundle is cumulative",
+						},
+						"applicability_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Whether the bundle can be applied to the current environment",
+						},
+						"components": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The components of the bundle",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the component",
+									},
+									"description": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The description of the component",
+									},
+									"from_version": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The version of the component before the upgrade",
+									},
+									"to_version": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The version of the component after the upgrade",
+									},
+									"image_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The image type of the component. One among: PATCH, INSTALL",
+									},
+									"vendor": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The vendor of the component",
+									},
+									"released_date": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The release date of the component",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataBundlesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	allBundles, err := lcm.GetBundles(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	componentType := data.Get("component_type").(string)
+	version := data.Get("version").(string)
+	downloadStatuses := utils.ToStringSlice(data.Get("download_status").([]interface{}))
+
+	filteredBundles := lcm.FilterBundles(allBundles, componentType, version, downloadStatuses)
+	_ = data.Set("bundles", lcm.FlattenBundles(filteredBundles))
+
+	id, err := utils.HashFields([]string{componentType, version, strings.Join(downloadStatuses, ",")})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceBundles(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceBundles(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_bundles.downloaded", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_bundles.downloaded", "bundles.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceBundles() string {
+	return `
+	data "vcf_bundles" "downloaded" {
+		component_type  = "SDDC_MANAGER"
+		download_status = ["SUCCESSFUL"]
+	}
+`
+}
@@ -0,0 +1,63 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/client/ceip"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func DataSourceCeip() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataCeipRead,
+		Description: "Datasource used to read the status of the Customer Experience Improvement Program (CEIP) of SDDC Manager " +
+			"without managing it",
+		Schema: map[string]*schema.Schema{
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The status of CEIP. One among: ENABLED, DISABLED, ENABLING, DISABLING, ENABLING_FAILED, " +
+					"DISABLING_FAILED",
+			},
+			"is_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether CEIP is enabled",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the VMware Cloud Foundation instance reported to CEIP",
+			},
+		},
+	}
+}
+
+func dataCeipRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	ceipResult, err := apiClient.CEIP.GetCEIPStatus(
+		ceip.NewGetCEIPStatusParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ceipResult.Payload == nil || ceipResult.Payload.Status == nil {
+		return diag.Errorf("SDDC Manager returned no CEIP status")
+	}
+
+	status := *ceipResult.Payload.Status
+	_ = data.Set("status", status)
+	_ = data.Set("is_enabled", status == EnabledState)
+	_ = data.Set("instance_id", ceipResult.Payload.InstanceID)
+	data.SetId(ceipResult.Payload.InstanceID)
+
+	return nil
+}
@@ -12,25 +12,27 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/models"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
-	"github.com/vmware/terraform-provider-vcf/internal/certificates" // Ensure this package exists and contains necessary methods
+	"github.com/vmware/terraform-provider-vcf/internal/certificates"
 )
 
-func DataSourceCertificate() *schema.Resource {
+func DataSourceCertificates() *schema.Resource {
 	return &schema.Resource{
-		ReadContext: dataCertificateRead,
-		Description: "Datasource used to extract certificate details for various resources based on fields like domain, issued_by, issued_to, key_size, and others.",
+		ReadContext: dataCertificatesRead,
+		Description: "Datasource used to extract the details of the certificates of the resources of a domain or of all the " +
+			"domains, e.g. their issuer, subject and validity, optionally only the one of a resource",
 		Schema: map[string]*schema.Schema{
 			"domain_id": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the domain to fetch certificates for.",
+				Optional:    true,
+				Description: "The ID of the domain to fetch certificates for. All the domains if empty, they are read in parallel.",
 			},
 			"resource_fqdn": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "the fqdn of resource certificate.",
+				Optional:    true,
+				Description: "The FQDN of the resource to fetch the certificate of. All the certificates of the domain, or of all the domains, if empty.",
 			},
 			"certificate": {
 				Type:        schema.TypeList,
@@ -176,54 +178,72 @@ func DataSourceCertificate() *schema.Resource {
 	}
 }
 
-func dataCertificateRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
-	log.Print("[DEBUG] Function dataCertificateRead start")
-	// Extract the domain_id from ResourceData
-	domainId, ok := data.Get("domain_id").(string)
-	if !ok {
-		log.Print("[DEBUG] Function dataCertificateRead, domainId not found or not a string")
-	} else {
-		log.Printf("[DEBUG] Function dataCertificateRead, domainId: %s", domainId)
+// allDomainsCertificatesId is the ID of the data source when it reads the certificates of all the domains.
+const allDomainsCertificatesId = "all-domains"
+
+func dataCertificatesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	domainId := data.Get("domain_id").(string)
+	resourceFqdn := data.Get("resource_fqdn").(string)
+
+	if domainId == "" {
+		allCertificates, err := certificates.ReadAllCertificates(ctx, vcfClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if resourceFqdn == "" {
+			setCertificates(data, allCertificates)
+			data.SetId(allDomainsCertificatesId)
+			return nil
+		}
+		for _, cert := range allCertificates {
+			if cert.IssuedTo != nil && *cert.IssuedTo == resourceFqdn {
+				return setCertificate(data, cert)
+			}
+		}
+		return diag.Errorf("certificate with FQDN %s not found in any domain", resourceFqdn)
 	}
 
-	// Extract the resource_fqdn from ResourceData
-	resourceFqdn, ok := data.Get("resource_fqdn").(string)
-	if !ok {
-		log.Print("[DEBUG] Function dataCertificateRead, resourceFqdn not found or not a string")
-		return diag.Errorf("resource_fqdn is not set or is not a string")
+	if resourceFqdn == "" {
+		domainCertificates, err := certificates.ReadCertificates(ctx, vcfClient, domainId)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		setCertificates(data, domainCertificates)
+		data.SetId(domainId)
+		return nil
 	}
 
-	// Call ReadCertificate with the domainId and resourceFqdn
-	cert, err := certificates.ReadCertificate(ctx, apiClient, domainId, resourceFqdn)
+	cert, err := certificates.ReadCertificate(ctx, vcfClient, domainId, resourceFqdn)
 	if err != nil {
 		log.Printf("[ERROR] Failed to read certificate: %s", err)
 		return diag.FromErr(err)
 	}
-
 	if cert == nil {
 		return diag.Errorf("certificate with FQDN %s not found in domain ID %s", resourceFqdn, domainId)
 	}
+	return setCertificate(data, cert)
+}
 
-	log.Printf("[DEBUG] Function dataCertificateRead, cert: %+v", cert)
-
-	// Process and flatten the single certificate
-	flatCertificate := certificates.FlattenCertificateWithSubject(cert)
-	log.Printf("[DEBUG] flatCertificate Data type: %T", flatCertificate)
-	log.Printf("[DEBUG] flatCertificate Data value: %+v", flatCertificate)
+func setCertificates(data *schema.ResourceData, certs []*models.Certificate) {
+	flatCertificates := make([]interface{}, 0, len(certs))
+	for _, cert := range certs {
+		flatCertificates = append(flatCertificates, certificates.FlattenCertificateWithSubject(cert))
+	}
+	_ = data.Set("certificate", flatCertificates)
+}
 
-	// Wrap flatCertificate in a slice
-	_ = data.Set("certificate", []interface{}{flatCertificate})
+// setCertificate sets the certificate of a single resource, the ID is a hash of its fields.
+func setCertificate(data *schema.ResourceData, cert *models.Certificate) diag.Diagnostics {
+	_ = data.Set("certificate", []interface{}{certificates.FlattenCertificateWithSubject(cert)})
 
 	// create and set certificateID
 	id, err := createCertificateID(data)
-	log.Printf("[DEBUG] Function dataCertificateRead, cert-id: %+v", id)
 	if err != nil {
 		return diag.Errorf("error during id generation %s", err)
 	}
-
 	data.SetId(id)
-	log.Printf("[DEBUG] Function dataCertificateRead, dataset with ID: %+v", data)
+
 	return nil
 }
 
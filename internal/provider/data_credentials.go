@@ -14,6 +14,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 )
 
 func DataSourceCredentials() *schema.Resource {
@@ -64,6 +65,12 @@ func DataSourceCredentials() *schema.Resource {
 				Description:  "the size of the credentials list . Default is 0 so the user will get all records in one page",
 				ValidateFunc: validation.IntAtLeast(0),
 			},
+			"include_passwords": {
+				Type:        schema.TypeBool,
+				Default:     false,
+				Optional:    true,
+				Description: "Set to true to include the passwords in the result. The passwords are omitted by default.",
+			},
 			"credentials": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -81,7 +88,7 @@ func dataCredentialsRead(ctx context.Context, data *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-	flatCredentials := credentials.FlattenCredentials(creds)
+	flatCredentials := credentials.FlattenCredentials(creds, data.Get("include_passwords").(bool))
 	_ = data.Set("credentials", flatCredentials)
 
 	id, err := createCredentialID(data)
@@ -103,7 +110,8 @@ func createCredentialID(data *schema.ResourceData) (string, error) {
 		data.Get("account_type").(string),
 		strconv.Itoa(data.Get("page").(int)),
 		strconv.Itoa(data.Get("page_size").(int)),
+		strconv.FormatBool(data.Get("include_passwords").(bool)),
 	}
 
-	return credentials.HashFields(params)
+	return utils.HashFields(params)
 }
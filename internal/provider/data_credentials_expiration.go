@@ -0,0 +1,187 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceCredentialsExpiration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataCredentialsExpirationRead,
+		Description: "Datasource used to list the credentials which passwords are expiring or have already expired",
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The type of the resource. One among ESXI, VCENTER, PSC, NSX_MANAGER, NSX_CONTROLLER, NSXT_EDGE, NSXT_MANAGER, VRLI, VROPS, VRA, WSA, VRSLCM, VXRAIL_MANAGER, NSX_ALB, BACKUP",
+				ValidateFunc: validation.StringInSlice(credentials.AllResourceTypes(), true),
+			},
+			"domain_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The domain in which context we do the credentials read",
+			},
+			"account_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The type(s) of the account. One among USER, SYSTEM, SERVICE",
+				ValidateFunc: validation.StringInSlice(credentials.AllAccountTypes(), true),
+			},
+			"expiry_status": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "The expiry statuses of the credentials to return. One or more among ACTIVE, EXPIRING, EXPIRED, UNKNOWN. " +
+					"Defaults to EXPIRING and EXPIRED",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(credentials.AllExpiryStatuses(), false),
+				},
+			},
+			"expires_within_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Also return the credentials which expire within the given number of days, regardless of their expiry status",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"refresh": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Default:      false,
+				RequiredWith: []string{"resource_type"},
+				Description: "Run a password expiration check for the resource type before reading the credentials. " +
+					"Otherwise the result of the last check performed by SDDC Manager is returned",
+			},
+			"credentials": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching credentials",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the credential",
+						},
+						"user_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The username of the account to which the credential belong",
+						},
+						"account_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "One among USER, SYSTEM, SERVICE",
+						},
+						"credential_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the credential. For example FTP, SSH, etc.",
+						},
+						"modification_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The last time the credentials are changed",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the resource to which the credential belongs",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the resource to which the credential belongs",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the resource to which the credential belongs",
+						},
+						"domain_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VCF domain to which the resource belongs",
+						},
+						"expiry_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date on which the password expires",
+						},
+						"expiry_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expiration status of the password. One among ACTIVE, EXPIRING, EXPIRED, UNKNOWN",
+						},
+						"last_checked_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The last time the expiration of the password was checked",
+						},
+						"connectivity_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The connectivity status of the resource during the last check. One among ACTIVE, ERROR, UNKNOWN",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataCredentialsExpirationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	if data.Get("refresh").(bool) {
+		err := credentials.RefreshPasswordExpiration(ctx, data.Get("resource_type").(string),
+			data.Get("domain_name").(string), vcfClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	creds, err := credentials.ReadCredentials(ctx, data, vcfClient.ApiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statuses := utils.ToStringSlice(data.Get("expiry_status").([]interface{}))
+	if len(statuses) == 0 {
+		statuses = []string{credentials.ExpiryStatusExpiring, credentials.ExpiryStatusExpired}
+	}
+	withinDays := data.Get("expires_within_days").(int)
+
+	expiringCredentials := credentials.FilterExpiringCredentials(creds, statuses, withinDays, time.Now())
+	_ = data.Set("credentials", credentials.FlattenCredentialExpirations(expiringCredentials))
+
+	id, err := utils.HashFields([]string{
+		data.Get("resource_type").(string),
+		data.Get("domain_name").(string),
+		data.Get("account_type").(string),
+		strings.Join(statuses, ","),
+		strconv.Itoa(withinDays),
+	})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
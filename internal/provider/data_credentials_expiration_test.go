@@ -0,0 +1,35 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCredentialsExpiration(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccSDDCManagerOrCloudBuilderPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceCredentialsExpiration(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_credentials_expiration.vc_expiring", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_credentials_expiration.vc_expiring", "credentials.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceCredentialsExpiration() string {
+	return `
+	data "vcf_credentials_expiration" "vc_expiring" {
+		resource_type = "VCENTER"
+		expiry_status = ["ACTIVE", "EXPIRING", "EXPIRED"]
+		refresh = true
+	}
+`
+}
@@ -0,0 +1,194 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceCredentialsTasks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataCredentialsTasksRead,
+		Description: "Datasource used to list the history of the password operations (credentials tasks) performed by SDDC Manager",
+		Schema: map[string]*schema.Schema{
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				Description:  "The number of most recent credentials tasks to read before the filters are applied",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The types of the tasks to return. One or more among UPDATE, ROTATE, REMEDIATE, UPDATE_AUTO_ROTATE_POLICY",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(credentials.AllOperationTypes(), false),
+				},
+			},
+			"statuses": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "The statuses of the tasks to return. One or more among PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, " +
+					"USER_CANCELLED, INCONSISTENT",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(credentials.AllTaskStatuses(), false),
+				},
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the tasks which changed the credentials of the given resource",
+			},
+			"since": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Return only the tasks created at or after the given time, in RFC 3339 format",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"tasks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching credentials tasks, the passwords are never included",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the task",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the task",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the task. One among UPDATE, ROTATE, REMEDIATE, UPDATE_AUTO_ROTATE_POLICY",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the task",
+						},
+						"creation_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the task was created",
+						},
+						"is_auto_rotate": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the task was started by the auto-rotate policy of SDDC Manager rather than by a user",
+						},
+						"errors": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The errors of the task",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"accounts": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The accounts which credentials were changed by the task",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"resource_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the resource to which the account belongs",
+									},
+									"resource_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the resource to which the account belongs",
+									},
+									"user_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The user name of the account",
+									},
+									"credential_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the credential",
+									},
+									"status": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The status of the credential change",
+									},
+									"creation_timestamp": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The time the credential change was started",
+									},
+									"errors": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Description: "The errors of the credential change",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataCredentialsTasksRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	tasks, err := credentials.GetCredentialsTasks(ctx, data.Get("limit").(int), apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var since time.Time
+	if sinceRaw := data.Get("since").(string); len(sinceRaw) > 0 {
+		since, err = time.Parse(time.RFC3339, sinceRaw)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	types := utils.ToStringSlice(data.Get("types").([]interface{}))
+	statuses := utils.ToStringSlice(data.Get("statuses").([]interface{}))
+	resourceName := data.Get("resource_name").(string)
+
+	filteredTasks := credentials.FilterCredentialsTasks(tasks, types, statuses, resourceName, since)
+	_ = data.Set("tasks", credentials.FlattenCredentialsTasks(filteredTasks))
+
+	id, err := utils.HashFields([]string{
+		strconv.Itoa(data.Get("limit").(int)),
+		strings.Join(types, ","),
+		strings.Join(statuses, ","),
+		resourceName,
+		data.Get("since").(string),
+	})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
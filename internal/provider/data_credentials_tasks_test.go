@@ -0,0 +1,34 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCredentialsTasks(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceCredentialsTasks(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_credentials_tasks.rotations", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_credentials_tasks.rotations", "tasks.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceCredentialsTasks() string {
+	return `
+	data "vcf_credentials_tasks" "rotations" {
+		types = ["ROTATE", "UPDATE"]
+		since = "2024-01-01T00:00:00Z"
+	}
+`
+}
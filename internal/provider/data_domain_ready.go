@@ -0,0 +1,141 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/domain"
+)
+
+func DataSourceDomainReady() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataDomainReadyRead,
+		Description: "Datasource used to wait until a workload domain, its NSX Manager cluster and their endpoints are ready, " +
+			"e.g. to configure the vsphere and nsxt providers with the endpoints of a domain created in the same configuration",
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The ID of the workload domain, e.g. the id of a vcf_domain. One of domain_id or name has to be set",
+				ValidateFunc: validation.NoZeroValues,
+				ExactlyOneOf: []string{"domain_id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The name of the workload domain. One of domain_id or name has to be set",
+				ValidateFunc: validation.NoZeroValues,
+				ExactlyOneOf: []string{"domain_id", "name"},
+			},
+			"check_endpoints": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether the vCenter Server and the NSX Manager VIP must also answer over HTTPS without a " +
+					"server error. Defaults to true",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the workload domain, ACTIVE once it is ready",
+			},
+			"vcenter_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the vCenter Server of the workload domain",
+			},
+			"vcenter_fqdn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The FQDN of the vCenter Server of the workload domain",
+			},
+			"vcenter_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the vCenter Server of the workload domain",
+			},
+			"nsx_cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the NSX Manager cluster of the workload domain",
+			},
+			"nsx_vip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VIP of the NSX Manager cluster of the workload domain",
+			},
+			"nsx_vip_fqdn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The FQDN of the VIP of the NSX Manager cluster of the workload domain",
+			},
+			"nsx_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the NSX Manager cluster of the workload domain",
+			},
+			"nsx_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the NSX Manager cluster of the workload domain",
+			},
+		},
+	}
+}
+
+func dataDomainReadyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	apiClient := vcfClient.ApiClient
+
+	domainId := data.Get("domain_id").(string)
+	if domainId == "" {
+		domainInfo, err := getDomainByName(ctx, apiClient, data.Get("name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		domainId = domainInfo.ID
+	}
+
+	readyDomain, err := domain.WaitForDomainReady(ctx, vcfClient, domainId, data.Get("check_endpoints").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(readyDomain.Domain.ID)
+	_ = data.Set("domain_id", readyDomain.Domain.ID)
+	_ = data.Set("name", readyDomain.Domain.Name)
+	_ = data.Set("status", readyDomain.Domain.Status)
+	if len(readyDomain.Domain.VCENTERS) > 0 && readyDomain.Domain.VCENTERS[0] != nil {
+		vcenterRef := readyDomain.Domain.VCENTERS[0]
+		if vcenterRef.ID != nil {
+			_ = data.Set("vcenter_id", *vcenterRef.ID)
+		}
+		_ = data.Set("vcenter_fqdn", vcenterRef.Fqdn)
+	}
+	_ = data.Set("vcenter_url", readyDomain.VcenterUrl())
+	if nsxCluster := readyDomain.Domain.NSXTCluster; nsxCluster != nil {
+		_ = data.Set("nsx_cluster_id", nsxCluster.ID)
+		_ = data.Set("nsx_vip", nsxCluster.Vip)
+		_ = data.Set("nsx_vip_fqdn", nsxCluster.VipFqdn)
+	}
+	_ = data.Set("nsx_url", readyDomain.NsxUrl())
+	if readyDomain.NsxCluster != nil {
+		_ = data.Set("nsx_version", readyDomain.NsxCluster.Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/fleet"
+)
+
+func DataSourceFleetInstances() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataFleetInstancesRead,
+		Description: "Datasource used to list the VCF instances of a VCF 9 fleet. Requires the fleet management " +
+			"connection of the provider",
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the instances which version starts with the given one, e.g. 9.0",
+			},
+			"instances": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching VCF instances, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the instance in the fleet",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the instance",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VCF version of the instance",
+						},
+						"sddc_manager_fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The FQDN of the SDDC Manager of the instance",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the instance in the fleet, e.g. ACTIVE",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataFleetInstancesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := getFleetClient(meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	instances, err := fleet.GetInstances(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Name < instances[j].Name
+	})
+
+	versionPrefix := data.Get("version").(string)
+	flattened := make([]map[string]interface{}, 0, len(instances))
+	for _, instance := range instances {
+		if instance == nil || !hasVersionPrefix(instance.Version, versionPrefix) {
+			continue
+		}
+		flattened = append(flattened, fleet.FlattenInstance(instance))
+	}
+
+	data.SetId(client.Host())
+	_ = data.Set("instances", flattened)
+	return nil
+}
+
+// hasVersionPrefix returns whether the version is the prefix or one of its patches, 9.0 matches 9.0.1.0 and not 9.01.
+func hasVersionPrefix(version, prefix string) bool {
+	if prefix == "" || version == prefix {
+		return true
+	}
+	return strings.HasPrefix(version, prefix+".")
+}
+
+// getFleetClient returns the client of the fleet management, or an error if the provider is connected to SDDC
+// Manager or Cloud Builder instead.
+func getFleetClient(meta interface{}) (*api_client.FleetClient, diag.Diagnostics) {
+	client, ok := meta.(*api_client.FleetClient)
+	if !ok {
+		return nil, diag.Errorf("the fleet resources and data sources require the fleet_management_host, " +
+			"fleet_management_username and fleet_management_password arguments of the provider")
+	}
+	return client, nil
+}
@@ -0,0 +1,165 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/terraform-provider-vcf/internal/sos"
+)
+
+func DataSourceHealthCheck() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataHealthCheckRead,
+		Description: "Datasource used to run the SoS health checks of SDDC Manager and read their results for each " +
+			"component. The checks are run each time the data source is read, e.g. on every plan",
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"checks": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Description: "The health checks to run, all of them if not set. One or more among: CERTIFICATE, " +
+					"COMPOSABILITY, COMPUTE, CONNECTIVITY, DNS, GENERAL, HARDWARE_COMPATIBILITY, NTP, PASSWORD, SERVICES, " +
+					"STORAGE, VERSION",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(sos.HealthCheckNames(), false),
+				},
+			},
+			"domains": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The names of the domains to check, all of them if not set",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"include_free_hosts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the commissioned hosts which are not assigned to a domain are also checked. Defaults to false",
+			},
+			"force": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the checks run even though a workflow is running in SDDC Manager. Defaults to false",
+			},
+			"fail_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether a RED result fails the read, with an error for each RED result. Defaults to false",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the run of the health checks, e.g. COMPLETED_WITH_SUCCESS",
+			},
+			"creation_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the health checks started",
+			},
+			"completion_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the health checks completed",
+			},
+			"bundle_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the archive of the results on SDDC Manager",
+			},
+			"is_healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether no result is RED",
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The results of the health checks for each component, sorted by category, check and component",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"category": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The category of the check, e.g. Compute Health",
+						},
+						"check": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The check, e.g. ESXi Time",
+						},
+						"component": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The component checked, e.g. the FQDN of a host",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The result of the check. One among: GREEN, YELLOW, RED, GRAY if the check does not apply",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The message of the check",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataHealthCheckRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	spec := sos.NewHealthSummarySpec(
+		utils.ToStringSlice(data.Get("checks").(*schema.Set).List()),
+		utils.ToStringSlice(data.Get("domains").(*schema.Set).List()),
+		data.Get("include_free_hosts").(bool),
+		data.Get("force").(bool))
+	summary, results, err := sos.RunHealthCheck(ctx, vcfClient, spec)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	failed := sos.FailedResults(results)
+	if data.Get("fail_on_failure").(bool) && len(failed) > 0 {
+		diags := diag.Diagnostics{}
+		for _, result := range failed {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s: %s failed for %s", result.Category, result.Check, result.Component),
+				Detail:   result.Message,
+			})
+		}
+		return diags
+	}
+
+	data.SetId(summary.ID)
+	_ = data.Set("status", summary.Status)
+	_ = data.Set("creation_timestamp", summary.CreationTimestamp)
+	_ = data.Set("completion_timestamp", summary.CompletionTimestamp)
+	_ = data.Set("bundle_name", summary.BundleName)
+	_ = data.Set("is_healthy", len(failed) == 0)
+	_ = data.Set("results", sos.FlattenResults(results))
+	return nil
+}
@@ -0,0 +1,262 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/client/system"
+	"github.com/vmware/vcf-sdk-go/client/tasks"
+	"github.com/vmware/vcf-sdk-go/client/vcf_services"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+// healthSummaryTaskLimit is the number of most recent tasks read to find the running ones.
+const healthSummaryTaskLimit = 100
+
+// healthSummary holds what the vcf_health_summary data source reads from SDDC Manager.
+type healthSummary struct {
+	services     []*models.VcfService
+	ntpServers   []string
+	dnsServers   []string
+	depot        *lcm.DepotSettings
+	runningTasks []*models.Task
+}
+
+func DataSourceHealthSummary() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataHealthSummaryRead,
+		Description: "Datasource used to summarize the health of SDDC Manager: its services, the NTP and DNS configuration, " +
+			"the depot connectivity and the running tasks",
+		Schema: map[string]*schema.Schema{
+			"is_healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether no issue was found",
+			},
+			"issues": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The issues found, empty if SDDC Manager is healthy",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"services": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The services of SDDC Manager",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the service",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the service",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the service",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the service, e.g. UP or DOWN",
+						},
+					},
+				},
+			},
+			"ntp_servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The NTP servers SDDC Manager is configured with",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dns_servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The DNS servers SDDC Manager is configured with, the primary server first",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"depot_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the connection to the configured depot, empty if no depot is configured",
+			},
+			"depot_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The message of the connection to the configured depot",
+			},
+			"running_task_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the pending or running tasks among the 100 most recent tasks",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataHealthSummaryRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sddcManagerClient := meta.(*api_client.SddcManagerClient)
+	apiClient := sddcManagerClient.ApiClient
+
+	summary := healthSummary{}
+
+	servicesResult, err := apiClient.VcfServices.GetVcfServices(
+		vcf_services.NewGetVcfServicesParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if servicesResult.Payload != nil {
+		summary.services = servicesResult.Payload.Elements
+	}
+
+	ntpResult, err := apiClient.System.GetNtpConfiguration(
+		system.NewGetNtpConfigurationParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ntpResult.Payload != nil {
+		for _, ntpServer := range ntpResult.Payload.NtpServers {
+			if ntpServer != nil && ntpServer.IPAddress != nil {
+				summary.ntpServers = append(summary.ntpServers, *ntpServer.IPAddress)
+			}
+		}
+	}
+
+	dnsResult, err := apiClient.System.GetDNSConfiguration(
+		system.NewGetDNSConfigurationParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if dnsResult.Payload != nil {
+		summary.dnsServers = getDnsServerAddresses(dnsResult.Payload.DNSServers)
+	}
+
+	if summary.depot, err = lcm.GetDepotSettings(ctx, apiClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	limit := int32(healthSummaryTaskLimit)
+	orderBy := "creationTimestamp"
+	orderDirection := "DESC"
+	tasksResult, err := apiClient.Tasks.GetTasks(tasks.NewGetTasksParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithLimit(&limit).
+		WithOrderBy(&orderBy).
+		WithOrderDirection(&orderDirection))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if tasksResult.Payload != nil {
+		summary.runningTasks = filterTasks(tasksResult.Payload.Elements,
+			taskFilter{statuses: []string{"PENDING", "IN_PROGRESS"}})
+	}
+
+	services := make([]map[string]interface{}, 0, len(summary.services))
+	for _, service := range summary.services {
+		if service == nil {
+			continue
+		}
+		services = append(services, map[string]interface{}{
+			"id":      service.ID,
+			"name":    service.Name,
+			"version": service.Version,
+			"status":  service.Status,
+		})
+	}
+	_ = data.Set("services", services)
+	_ = data.Set("ntp_servers", summary.ntpServers)
+	_ = data.Set("dns_servers", summary.dnsServers)
+	if depotAccount := configuredDepotAccount(summary.depot); depotAccount != nil {
+		_ = data.Set("depot_status", depotAccount.Status)
+		_ = data.Set("depot_message", depotAccount.Message)
+	}
+	runningTaskIds := make([]string, 0, len(summary.runningTasks))
+	for _, task := range summary.runningTasks {
+		runningTaskIds = append(runningTaskIds, task.ID)
+	}
+	_ = data.Set("running_task_ids", runningTaskIds)
+
+	issues := summary.issues()
+	_ = data.Set("issues", issues)
+	_ = data.Set("is_healthy", len(issues) == 0)
+
+	data.SetId(sddcManagerClient.Host())
+
+	return nil
+}
+
+// getDnsServerAddresses returns the addresses of the DNS servers, the primary server first.
+func getDnsServerAddresses(dnsServers []*models.DNSServer) []string {
+	primary := make([]string, 0)
+	secondary := make([]string, 0)
+	for _, dnsServer := range dnsServers {
+		if dnsServer == nil || dnsServer.IPAddress == nil {
+			continue
+		}
+		if dnsServer.IsPrimary != nil && *dnsServer.IsPrimary {
+			primary = append(primary, *dnsServer.IPAddress)
+		} else {
+			secondary = append(secondary, *dnsServer.IPAddress)
+		}
+	}
+
+	return append(primary, secondary...)
+}
+
+// configuredDepotAccount returns the account of the offline depot if SDDC Manager uses one, otherwise the
+// account of the online depot. Returns nil if no depot is configured.
+func configuredDepotAccount(settings *lcm.DepotSettings) *models.DepotAccount {
+	if settings == nil {
+		return nil
+	}
+	if settings.DepotConfiguration != nil && settings.DepotConfiguration.IsOfflineDepot {
+		return settings.OfflineAccount
+	}
+	if settings.VmwareAccount != nil && settings.VmwareAccount.Username != nil && len(*settings.VmwareAccount.Username) > 0 {
+		return settings.VmwareAccount
+	}
+
+	return nil
+}
+
+// issues describes what is unhealthy in the summary.
+func (summary healthSummary) issues() []string {
+	issues := make([]string, 0)
+	for _, service := range summary.services {
+		if service != nil && !strings.EqualFold(service.Status, "UP") {
+			issues = append(issues, fmt.Sprintf("service %s is %s", service.Name, service.Status))
+		}
+	}
+	if len(summary.ntpServers) == 0 {
+		issues = append(issues, "no NTP server is configured")
+	}
+	if len(summary.dnsServers) == 0 {
+		issues = append(issues, "no DNS server is configured")
+	}
+	if depotAccount := configuredDepotAccount(summary.depot); depotAccount != nil &&
+		depotAccount.Status != lcm.DepotStatusConnectionSuccessful {
+		issues = append(issues, fmt.Sprintf("the depot connection is %s: %s", depotAccount.Status, depotAccount.Message))
+	}
+	if len(summary.runningTasks) > 0 {
+		issues = append(issues, fmt.Sprintf("%d tasks are running", len(summary.runningTasks)))
+	}
+
+	return issues
+}
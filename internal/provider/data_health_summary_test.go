@@ -0,0 +1,74 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+func TestAccDataSourceHealthSummary(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: `data "vcf_health_summary" "sddc_manager" {}`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_health_summary.sddc_manager", "is_healthy"),
+				resource.TestCheckResourceAttrSet("data.vcf_health_summary.sddc_manager", "services.#"),
+				resource.TestCheckResourceAttrSet("data.vcf_health_summary.sddc_manager", "ntp_servers.0"),
+				resource.TestCheckResourceAttrSet("data.vcf_health_summary.sddc_manager", "dns_servers.0"),
+			),
+		}},
+	})
+}
+
+func TestHealthSummaryIssues(t *testing.T) {
+	username := "depot-user"
+	summary := healthSummary{
+		services: []*models.VcfService{
+			{Name: "LCM", Status: "UP"},
+			{Name: "DOMAIN_MANAGER", Status: "DOWN"},
+		},
+		ntpServers: []string{"10.0.0.250"},
+		depot: &lcm.DepotSettings{
+			VmwareAccount: &models.DepotAccount{Username: &username, Status: "DEPOT_CONNECTION_FAILED", Message: "unreachable"},
+		},
+		runningTasks: []*models.Task{{ID: "task-id"}},
+	}
+
+	assert.Equal(t, []string{
+		"service DOMAIN_MANAGER is DOWN",
+		"no DNS server is configured",
+		"the depot connection is DEPOT_CONNECTION_FAILED: unreachable",
+		"1 tasks are running",
+	}, summary.issues())
+
+	summary.services = summary.services[:1]
+	summary.dnsServers = []string{"10.0.0.1"}
+	summary.depot.VmwareAccount.Status = lcm.DepotStatusConnectionSuccessful
+	summary.runningTasks = nil
+	assert.Empty(t, summary.issues())
+
+	// The online depot account is ignored when SDDC Manager uses an offline depot
+	summary.depot.DepotConfiguration = &lcm.DepotConfiguration{IsOfflineDepot: true}
+	summary.depot.OfflineAccount = &models.DepotAccount{Status: "DEPOT_CONNECTION_FAILED"}
+	assert.Len(t, summary.issues(), 1)
+}
+
+func TestGetDnsServerAddresses(t *testing.T) {
+	primary, secondary := "10.0.0.1", "10.0.0.2"
+	isPrimary, isSecondary := true, false
+	assert.Equal(t, []string{primary, secondary}, getDnsServerAddresses([]*models.DNSServer{
+		{IPAddress: &secondary, IsPrimary: &isSecondary},
+		{IPAddress: &primary, IsPrimary: &isPrimary},
+		nil,
+	}))
+}
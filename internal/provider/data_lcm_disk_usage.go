@@ -0,0 +1,105 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+func DataSourceLcmDiskUsage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLcmDiskUsageRead,
+		Description: "Reports the disk usage of the LCM repository of the SDDC Manager appliance",
+		Schema: map[string]*schema.Schema{
+			"appliance_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "vcf",
+				Description: "The user used to connect to the SDDC Manager appliance over SSH",
+			},
+			"appliance_password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The password of the user used to connect to the SDDC Manager appliance over SSH",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"appliance_host_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The SSH public key of the SDDC Manager appliance in authorized_keys format, e.g. the output of " +
+					"ssh-keyscan -t ecdsa <sddc-manager>. Required unless allow_unverified_tls is set on the provider, in which " +
+					"case the key of the appliance is not verified if omitted",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The mount point of the LCM repository",
+			},
+			"total_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the file system of the LCM repository in bytes",
+			},
+			"used_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The used space of the file system of the LCM repository in bytes",
+			},
+			"available_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The available space of the file system of the LCM repository in bytes",
+			},
+			"used_percent": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The used space of the file system of the LCM repository in percent",
+			},
+			"bundle_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The space used by the downloaded and uploaded bundles in bytes",
+			},
+		},
+	}
+}
+
+func dataSourceLcmDiskUsageRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	connection := lcm.ApplianceConnection{
+		Username: data.Get("appliance_username").(string),
+		Password: data.Get("appliance_password").(string),
+		HostKey:  data.Get("appliance_host_key").(string),
+	}
+
+	usage, err := lcm.GetLcmDiskUsage(ctx, connection, vcfClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(vcfClient.Host() + ":" + lcm.LcmRepositoryDirectory)
+	_ = data.Set("path", lcm.LcmRepositoryDirectory)
+	_ = data.Set("total_bytes", usage.TotalBytes)
+	_ = data.Set("used_bytes", usage.UsedBytes)
+	_ = data.Set("available_bytes", usage.AvailableBytes)
+	_ = data.Set("bundle_bytes", usage.BundleBytes)
+	usedPercent := 0.0
+	if usage.TotalBytes > 0 {
+		usedPercent = math.Round(float64(usage.UsedBytes)*10000/float64(usage.TotalBytes)) / 100
+	}
+	_ = data.Set("used_percent", usedPercent)
+
+	return nil
+}
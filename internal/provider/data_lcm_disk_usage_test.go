@@ -0,0 +1,38 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccDataSourceLcmDiskUsage(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceLcmDiskUsage(os.Getenv(constants.VcfTestApplianceVcfPassword)),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_lcm_disk_usage.usage", "total_bytes"),
+				resource.TestCheckResourceAttrSet("data.vcf_lcm_disk_usage.usage", "available_bytes"),
+				resource.TestCheckResourceAttrSet("data.vcf_lcm_disk_usage.usage", "bundle_bytes"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceLcmDiskUsage(appliancePassword string) string {
+	return fmt.Sprintf(`
+	data "vcf_lcm_disk_usage" "usage" {
+		appliance_password = %q
+	}
+`, appliancePassword)
+}
@@ -0,0 +1,233 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/client/license_keys"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+// licenseKeyStatuses are the validity statuses of the license keys.
+var licenseKeyStatuses = []string{"ACTIVE", "EXPIRED", "NEVER_EXPIRES"}
+
+func DataSourceLicenseKeys() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataLicenseKeysRead,
+		Description: "Datasource used to list the license keys of SDDC Manager with their usage, e.g. to pick a license key " +
+			"with enough capacity for a new cluster",
+		Schema: map[string]*schema.Schema{
+			"product_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Return only the license keys of the product. One among VCENTER, VSAN, SDDC_MANAGER, ESXI, NSXT, NSXIO, WCP, HORIZON_VIEW",
+				ValidateFunc: validation.StringInSlice(licenseProductTypes, false),
+			},
+			"product_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the license keys matching the major version of the given product version",
+			},
+			"statuses": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The validity statuses of the license keys to return. One or more among ACTIVE, EXPIRED, NEVER_EXPIRES",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(licenseKeyStatuses, false),
+				},
+			},
+			"min_remaining": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Return only the license keys with at least the given number of units left. Unlimited license keys always match",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"license_keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching license keys, the unlimited ones and then the ones with the most units left first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the license key",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+							Description: "The license key",
+						},
+						"product_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The product of the license key",
+						},
+						"product_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the product of the license key",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the license key",
+						},
+						"is_unlimited": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the usage of the license key is unlimited",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The validity status of the license key. One among ACTIVE, EXPIRED, NEVER_EXPIRES",
+						},
+						"expiry_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expiry date of the license key, empty if it never expires",
+						},
+						"license_unit": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unit the usage of the license key is counted in, e.g. CPU or CORE",
+						},
+						"total": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of units the license key allows",
+						},
+						"used": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of units in use",
+						},
+						"remaining": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of units left",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataLicenseKeysRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	statuses := utils.ToStringSlice(data.Get("statuses").([]interface{}))
+	params := license_keys.NewGetLicenseKeysParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithLicenseKeyStatus(statuses)
+	if productType := data.Get("product_type").(string); len(productType) > 0 {
+		params.WithProductType([]string{productType})
+	}
+	if productVersion := data.Get("product_version").(string); len(productVersion) > 0 {
+		params.WithProductVersion(&productVersion)
+	}
+
+	result, err := apiClient.LicenseKeys.GetLicenseKeys(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var licenseKeys []*models.LicenseKey
+	if result.Payload != nil {
+		licenseKeys = result.Payload.Elements
+	}
+	_ = data.Set("license_keys", flattenLicenseKeys(filterLicenseKeys(licenseKeys, data.Get("min_remaining").(int))))
+
+	id, err := utils.HashFields([]string{
+		data.Get("product_type").(string),
+		data.Get("product_version").(string),
+		strings.Join(statuses, ","),
+		strconv.Itoa(data.Get("min_remaining").(int)),
+	})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
+
+// filterLicenseKeys returns the license keys which are unlimited or have at least minRemaining units left,
+// the unlimited ones first and then by the number of units left, in descending order.
+func filterLicenseKeys(licenseKeys []*models.LicenseKey, minRemaining int) []*models.LicenseKey {
+	result := make([]*models.LicenseKey, 0)
+	for _, licenseKey := range licenseKeys {
+		if licenseKey == nil {
+			continue
+		}
+		if !licenseKey.IsUnlimited && int(licenseKeyRemaining(licenseKey)) < minRemaining {
+			continue
+		}
+		result = append(result, licenseKey)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].IsUnlimited != result[j].IsUnlimited {
+			return result[i].IsUnlimited
+		}
+		return licenseKeyRemaining(result[i]) > licenseKeyRemaining(result[j])
+	})
+
+	return result
+}
+
+func licenseKeyRemaining(licenseKey *models.LicenseKey) int32 {
+	if licenseKey.LicenseKeyUsage == nil {
+		return 0
+	}
+	return licenseKey.LicenseKeyUsage.Remaining
+}
+
+func flattenLicenseKeys(licenseKeys []*models.LicenseKey) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(licenseKeys))
+	for _, licenseKey := range licenseKeys {
+		flattened := map[string]interface{}{
+			"id":              licenseKey.ID,
+			"product_version": licenseKey.ProductVersion,
+			"is_unlimited":    licenseKey.IsUnlimited,
+		}
+		if licenseKey.Key != nil {
+			flattened["key"] = *licenseKey.Key
+		}
+		if licenseKey.ProductType != nil {
+			flattened["product_type"] = *licenseKey.ProductType
+		}
+		if licenseKey.Description != nil {
+			flattened["description"] = *licenseKey.Description
+		}
+		if licenseKey.LicenseKeyValidity != nil {
+			flattened["status"] = licenseKey.LicenseKeyValidity.LicenseKeyStatus
+			flattened["expiry_date"] = licenseKey.LicenseKeyValidity.ExpiryDate
+		}
+		if licenseKey.LicenseKeyUsage != nil {
+			flattened["license_unit"] = licenseKey.LicenseKeyUsage.LicenseUnit
+			flattened["total"] = int(licenseKey.LicenseKeyUsage.Total)
+			flattened["used"] = int(licenseKey.LicenseKeyUsage.Used)
+			flattened["remaining"] = int(licenseKey.LicenseKeyUsage.Remaining)
+		}
+		result = append(result, flattened)
+	}
+
+	return result
+}
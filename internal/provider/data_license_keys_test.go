@@ -0,0 +1,78 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+func TestAccDataSourceLicenseKeys(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceLicenseKeys(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_license_keys.esxi", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_license_keys.esxi", "license_keys.#"),
+				resource.TestCheckResourceAttr("data.vcf_license_keys.esxi", "license_keys.0.product_type", "ESXI"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceLicenseKeys() string {
+	return `
+	data "vcf_license_keys" "esxi" {
+		product_type  = "ESXI"
+		statuses      = ["ACTIVE", "NEVER_EXPIRES"]
+		min_remaining = 1
+	}
+`
+}
+
+func TestFilterLicenseKeys(t *testing.T) {
+	licenseKeys := []*models.LicenseKey{
+		{ID: "1", LicenseKeyUsage: &models.LicenseKeyUsage{Remaining: 4}},
+		{ID: "2", LicenseKeyUsage: &models.LicenseKeyUsage{Remaining: 16}},
+		{ID: "3", IsUnlimited: true},
+		{ID: "4"},
+		nil,
+	}
+	ids := func(filtered []*models.LicenseKey) []string {
+		result := make([]string, 0)
+		for _, licenseKey := range filtered {
+			result = append(result, licenseKey.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"3", "2", "1", "4"}, ids(filterLicenseKeys(licenseKeys, 0)))
+	assert.Equal(t, []string{"3", "2", "1"}, ids(filterLicenseKeys(licenseKeys, 4)))
+	assert.Equal(t, []string{"3"}, ids(filterLicenseKeys(licenseKeys, 17)))
+}
+
+func TestFlattenLicenseKeys(t *testing.T) {
+	key := "XXXXX-XXXXX-XXXXX-XXXXX-XXXXX"
+	productType := "ESXI"
+	flattened := flattenLicenseKeys([]*models.LicenseKey{{
+		ID:                 "1",
+		Key:                &key,
+		ProductType:        &productType,
+		LicenseKeyUsage:    &models.LicenseKeyUsage{LicenseUnit: "CPU", Total: 16, Used: 12, Remaining: 4},
+		LicenseKeyValidity: &models.LicenseKeyValidity{LicenseKeyStatus: "ACTIVE"},
+	}})
+
+	assert.Len(t, flattened, 1)
+	assert.Equal(t, key, flattened[0]["key"])
+	assert.Equal(t, "ESXI", flattened[0]["product_type"])
+	assert.Equal(t, "ACTIVE", flattened[0]["status"])
+	assert.Equal(t, 4, flattened[0]["remaining"])
+	assert.NotContains(t, flattened[0], "description")
+}
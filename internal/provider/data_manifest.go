@@ -0,0 +1,170 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceManifest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataManifestRead,
+		Description: "Datasource used to read the LCM manifest, which lists the known VCF releases and their upgrade paths",
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The VCF version the newer releases and upgrade targets are computed for. Defaults to the " +
+					"version SDDC Manager is currently running",
+			},
+			"sequence_number": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The sequence number of the manifest, which increases with every published manifest",
+			},
+			"published_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the manifest was published",
+			},
+			"latest_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The highest release version in the manifest",
+			},
+			"releases_behind": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of releases in the manifest newer than version",
+			},
+			"newer_versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The versions of the releases newer than version, from the oldest to the newest",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"upgrade_targets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: "The versions of the releases which can be applied directly on top of version, from the oldest " +
+					"to the newest",
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"releases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The releases in the manifest",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the release",
+						},
+						"product": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the product",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the release",
+						},
+						"release_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The release date",
+						},
+						"eol": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The end of life date of the release",
+						},
+						"min_compatible_vcf_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The minimum VCF version the release can be applied on top of",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataManifestRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	manifest, err := lcm.GetManifest(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	currentVersion := data.Get("version").(string)
+	if len(currentVersion) == 0 {
+		release, err := lcm.GetSystemRelease(ctx, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if release.Version != nil {
+			currentVersion = *release.Version
+		}
+	}
+
+	newerVersions, err := lcm.NewerReleases(manifest.Releases, currentVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	upgradeTargets, err := lcm.UpgradeTargets(manifest.Releases, currentVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allVersions := make([]string, 0, len(manifest.Releases))
+	for _, release := range manifest.Releases {
+		if release != nil && release.Version != nil {
+			allVersions = append(allVersions, *release.Version)
+		}
+	}
+	latestVersion, err := lcm.LatestVersion(allVersions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = data.Set("version", currentVersion)
+	if manifest.SequenceNumber != nil {
+		_ = data.Set("sequence_number", int(*manifest.SequenceNumber))
+	}
+	if manifest.PublishedDate != nil {
+		_ = data.Set("published_date", *manifest.PublishedDate)
+	}
+	_ = data.Set("latest_version", latestVersion)
+	_ = data.Set("releases_behind", len(newerVersions))
+	_ = data.Set("newer_versions", newerVersions)
+	_ = data.Set("upgrade_targets", upgradeTargets)
+	_ = data.Set("releases", lcm.FlattenManifestReleases(manifest.Releases))
+
+	sequenceNumber := ""
+	if manifest.SequenceNumber != nil {
+		sequenceNumber = strconv.Itoa(int(*manifest.SequenceNumber))
+	}
+	id, err := utils.HashFields([]string{currentVersion, sequenceNumber})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceManifest(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceManifest(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_manifest.manifest", "version"),
+				resource.TestCheckResourceAttrSet("data.vcf_manifest.manifest", "latest_version"),
+				resource.TestCheckResourceAttrSet("data.vcf_manifest.manifest", "releases_behind"),
+				resource.TestCheckResourceAttrSet("data.vcf_manifest.manifest", "releases.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceManifest() string {
+	return `
+	data "vcf_manifest" "manifest" {
+	}
+`
+}
@@ -0,0 +1,156 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourcePersonalities() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataPersonalitiesRead,
+		Description: "Datasource used to list the cluster images (personalities) available in SDDC Manager",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the personality with the given name",
+			},
+			"esxi_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the personalities which base image has the given ESXi version",
+			},
+			"personalities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching personalities",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the personality, used as cluster_image_id of a vcf_cluster",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the personality",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the personality",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the personality",
+						},
+						"esxi_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ESXi version of the base image of the personality",
+						},
+						"created_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The creator of the personality",
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The tags of the personality",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"add_on": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The vendor add-on of the personality",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the add-on",
+									},
+									"vendor": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The vendor of the add-on",
+									},
+									"version": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The version of the add-on",
+									},
+								},
+							},
+						},
+						"components": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The components of the personality in addition to the base image",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the component",
+									},
+									"display_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The display name of the component",
+									},
+									"vendor": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The vendor of the component",
+									},
+									"version": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The version of the component",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataPersonalitiesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	filter := lcm.PersonalityFilter{
+		Name:        data.Get("name").(string),
+		EsxiVersion: data.Get("esxi_version").(string),
+	}
+
+	allPersonalities, err := lcm.GetPersonalities(ctx, filter, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("personalities", lcm.FlattenPersonalities(allPersonalities))
+
+	id, err := utils.HashFields([]string{filter.Name, filter.EsxiVersion})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
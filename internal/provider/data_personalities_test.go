@@ -0,0 +1,32 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourcePersonalities(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourcePersonalities(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_personalities.all", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_personalities.all", "personalities.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourcePersonalities() string {
+	return `
+	data "vcf_personalities" "all" {
+	}
+`
+}
@@ -0,0 +1,230 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceReleases() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataReleasesRead,
+		Description: "Datasource used to list the VCF releases and their bill of materials",
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Return the releases newer than the current release of the given workload domain, together with their applicability to the domain",
+				ConflictsWith: []string{"version", "newer_than", "applicable_for_version"},
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the release with the given version",
+			},
+			"newer_than": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the releases newer than the given version",
+			},
+			"applicable_for_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the releases which can be applied on top of the given version",
+			},
+			"current_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VCF version SDDC Manager is currently running",
+			},
+			"latest_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The highest version among the returned releases. When domain_id is set, only the releases " +
+					"applicable to the domain are considered. Empty if there is no such release",
+			},
+			"releases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching releases",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the release",
+						},
+						"product": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the product, e.g. VCF",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the release",
+						},
+						"release_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The release date of the release",
+						},
+						"eol": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The end of life date of the release",
+						},
+						"min_compatible_vcf_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The minimum VCF version which can be upgraded to the release",
+						},
+						"is_applicable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the release can be applied",
+						},
+						"not_applicable_reason": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The reason the release cannot be applied",
+						},
+						"applicability_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The applicability status of the release for the domain. Only set when domain_id is set",
+						},
+						"bom": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The bill of materials of the release, i.e. the versions of its components",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the component",
+									},
+									"public_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The public name of the component",
+									},
+									"version": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The version of the component",
+									},
+									"release_url": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The URL of the release notes of the component",
+									},
+								},
+							},
+						},
+						"patch_bundles": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The patch bundles of the release",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"bundle_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the bundle",
+									},
+									"bundle_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the bundle",
+									},
+									"cumulative_from_vcf_version": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The minimum VCF version the bundle can be cumulatively applied to",
+									},
+									"bundle_elements": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Description: "The elements of the bundle",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataReleasesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	systemRelease, err := lcm.GetSystemRelease(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if systemRelease != nil && systemRelease.Version != nil {
+		_ = data.Set("current_version", *systemRelease.Version)
+	}
+
+	var flattenedReleases []map[string]interface{}
+	var versions []string
+	domainId := data.Get("domain_id").(string)
+	if len(domainId) > 0 {
+		domainReleases, err := lcm.GetDomainFutureReleases(ctx, domainId, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		flattenedReleases = lcm.FlattenDomainFutureReleases(domainReleases)
+		for _, release := range domainReleases {
+			if release != nil && release.IsApplicable && release.Version != nil {
+				versions = append(versions, *release.Version)
+			}
+		}
+	} else {
+		filter := lcm.ReleaseFilter{
+			Version:              data.Get("version").(string),
+			NewerThan:            data.Get("newer_than").(string),
+			ApplicableForVersion: data.Get("applicable_for_version").(string),
+		}
+		allReleases, err := lcm.GetReleases(ctx, filter, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		flattenedReleases = lcm.FlattenReleases(allReleases)
+		for _, release := range allReleases {
+			if release != nil && release.Version != nil {
+				versions = append(versions, *release.Version)
+			}
+		}
+	}
+	_ = data.Set("releases", flattenedReleases)
+
+	latestVersion, err := lcm.LatestVersion(versions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("latest_version", latestVersion)
+
+	id, err := utils.HashFields([]string{domainId, data.Get("version").(string),
+		data.Get("newer_than").(string), data.Get("applicable_for_version").(string)})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceReleases(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceReleases(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_releases.current", "current_version"),
+				resource.TestCheckResourceAttr("data.vcf_releases.current", "releases.#", "1"),
+				resource.TestCheckResourceAttrPair("data.vcf_releases.current", "releases.0.version",
+					"data.vcf_releases.current", "current_version"),
+				resource.TestCheckResourceAttrSet("data.vcf_releases.current", "releases.0.bom.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceReleases() string {
+	return `
+	data "vcf_releases" "system" {
+	}
+
+	data "vcf_releases" "current" {
+		version = data.vcf_releases.system.current_version
+	}
+`
+}
@@ -0,0 +1,137 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/datastores"
+)
+
+func DataSourceRemoteDatastores() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataRemoteDatastoresRead,
+		Description: "Datasource used to list the datastores mounted on a cluster and the vSAN datastores of the other " +
+			"clusters it can mount with HCI Mesh",
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the cluster",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"mounted_datastores": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The datastores mounted on the cluster, its own and the remote ones",
+				Elem:        remoteDatastoreSchema(),
+			},
+			"mountable_datastores": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The vSAN datastores of the other clusters the cluster can mount as remote datastores",
+				Elem:        remoteDatastoreSchema(),
+			},
+		},
+	}
+}
+
+func remoteDatastoreSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the datastore, to use in datastore_uuids of vsan_remote_datastore_cluster",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the datastore",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the datastore, e.g. VSAN or NFS",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the datastore",
+			},
+			"total_capacity_gb": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The total capacity of the datastore in GB",
+			},
+			"free_capacity_gb": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The free capacity of the datastore in GB",
+			},
+			"vm_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of virtual machines on the datastore",
+			},
+			"datacenter_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the vCenter datacenter of the datastore",
+			},
+		},
+	}
+}
+
+func dataRemoteDatastoresRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	clusterId := data.Get("cluster_id").(string)
+	mounted, err := datastores.GetClusterDatastores(ctx, clusterId, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	mountable, err := datastores.GetMountableRemoteDatastores(ctx, clusterId, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = data.Set("mounted_datastores", flattenRemoteDatastores(mounted))
+	_ = data.Set("mountable_datastores", flattenRemoteDatastores(mountable))
+	data.SetId(clusterId)
+
+	return nil
+}
+
+func flattenRemoteDatastores(datastoresToFlatten []*models.Datastore) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(datastoresToFlatten))
+	for _, datastore := range datastoresToFlatten {
+		if datastore == nil {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"id":                datastore.ID,
+			"name":              datastore.Name,
+			"type":              datastore.DatastoreType,
+			"url":               datastore.URL,
+			"total_capacity_gb": datastore.TotalCapacityGB,
+			"free_capacity_gb":  datastore.FreeCapacityGB,
+			"vm_count":          int(datastore.VMCount),
+			"datacenter_name":   datastore.VcDatacenterName,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccDataSourceRemoteDatastores(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceRemoteDatastores(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("data.vcf_remote_datastores.cluster", "id", os.Getenv(constants.VcfTestClusterId)),
+				resource.TestCheckResourceAttrSet("data.vcf_remote_datastores.cluster", "mounted_datastores.0.id"),
+				resource.TestCheckResourceAttrSet("data.vcf_remote_datastores.cluster", "mountable_datastores.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceRemoteDatastores() string {
+	return fmt.Sprintf(`
+	data "vcf_remote_datastores" "cluster" {
+		cluster_id = %q
+	}
+`, os.Getenv(constants.VcfTestClusterId))
+}
+
+func TestFlattenRemoteDatastores(t *testing.T) {
+	flattened := flattenRemoteDatastores([]*models.Datastore{
+		{ID: "ds-1", Name: "sfo-w01-cl01-ds-vsan01", DatastoreType: "VSAN", TotalCapacityGB: 1024, FreeCapacityGB: 512, VMCount: 3},
+		nil,
+	})
+
+	assert.Len(t, flattened, 1)
+	assert.Equal(t, "ds-1", flattened[0]["id"])
+	assert.Equal(t, "VSAN", flattened[0]["type"])
+	assert.Equal(t, float64(512), flattened[0]["free_capacity_gb"])
+	assert.Equal(t, 3, flattened[0]["vm_count"])
+}
@@ -0,0 +1,174 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/client/resource_functionalities"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceResourceFunctionalities() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataResourceFunctionalitiesRead,
+		Description: "Datasource used to read the operations SDDC Manager currently allows on its resources, e.g. whether " +
+			"a cluster can be expanded while another operation runs against its domain",
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the resources of the given type, e.g. DOMAIN, CLUSTER or HOST",
+			},
+			"resource_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Return only the resources with the given IDs",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"functionality_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the operations of the given type, e.g. CREATE_CLUSTER or EXPAND_CLUSTER",
+			},
+			"is_allowed_globally": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether SDDC Manager allows operations on its resources at all, e.g. not during an upgrade",
+			},
+			"global_error_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reason why the operations are not allowed globally",
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the resources and the operations SDDC Manager allows on them",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the resource",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the resource",
+						},
+						"functionalities": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The operations on the resource",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the operation",
+									},
+									"is_allowed": {
+										Type:        schema.TypeBool,
+										Computed:    true,
+										Description: "Whether the operation is currently allowed",
+									},
+									"error_message": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The reason why the operation is not allowed",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataResourceFunctionalitiesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	globalParams := resource_functionalities.NewGetResourcesFunctionalitiesAllowedGlobalParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	globalResult, err := apiClient.ResourceFunctionalities.GetResourcesFunctionalitiesAllowedGlobal(globalParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if globalResult.Payload != nil {
+		_ = data.Set("is_allowed_globally", globalResult.Payload.IsAllowed)
+		_ = data.Set("global_error_message", globalResult.Payload.ErrorMessage)
+	}
+
+	resourceIds := utils.ToStringSlice(data.Get("resource_ids").([]interface{}))
+	params := resource_functionalities.NewGetResourceFunctionalitiesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithResourceIds(resourceIds)
+	if resourceType := data.Get("resource_type").(string); len(resourceType) > 0 {
+		params.WithResourceType(&resourceType)
+	}
+	if functionalityType := data.Get("functionality_type").(string); len(functionalityType) > 0 {
+		params.WithFunctionalityType(&functionalityType)
+	}
+
+	result, err := apiClient.ResourceFunctionalities.GetResourceFunctionalities(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var resources []*models.ResourceFunctionalities
+	if result.Payload != nil {
+		resources = result.Payload.Elements
+	}
+	_ = data.Set("resources", flattenResourceFunctionalities(resources))
+
+	id, err := utils.HashFields([]string{
+		data.Get("resource_type").(string),
+		strings.Join(resourceIds, ","),
+		data.Get("functionality_type").(string),
+	})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
+
+func flattenResourceFunctionalities(resources []*models.ResourceFunctionalities) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(resources))
+	for _, resource := range resources {
+		if resource == nil {
+			continue
+		}
+		functionalities := make([]map[string]interface{}, 0, len(resource.Functionalities))
+		for _, functionality := range resource.Functionalities {
+			if functionality == nil {
+				continue
+			}
+			functionalities = append(functionalities, map[string]interface{}{
+				"type":          functionality.Type,
+				"is_allowed":    functionality.IsAllowed,
+				"error_message": functionality.ErrorMessage,
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"resource_id":     resource.ResourceID,
+			"resource_type":   resource.ResourceType,
+			"functionalities": functionalities,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,39 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccDataSourceResourceFunctionalities(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceResourceFunctionalities(os.Getenv(constants.VcfTestDomainDataSourceId)),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_resource_functionalities.domain", "is_allowed_globally"),
+				resource.TestCheckResourceAttr("data.vcf_resource_functionalities.domain", "resources.#", "1"),
+				resource.TestCheckResourceAttrSet("data.vcf_resource_functionalities.domain", "resources.0.functionalities.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceResourceFunctionalities(domainId string) string {
+	return fmt.Sprintf(`
+	data "vcf_resource_functionalities" "domain" {
+		resource_type = "DOMAIN"
+		resource_ids  = [%q]
+	}
+`, domainId)
+}
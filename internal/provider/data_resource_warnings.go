@@ -0,0 +1,233 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/client/resource_warnings"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceResourceWarnings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataResourceWarningsRead,
+		Description: "Datasource used to list the warnings SDDC Manager reports on its resources, e.g. a host skipped " +
+			"during an upgrade or a configuration drift",
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the warnings of the resources of the given type. One among HOST, CLUSTER, DOMAIN",
+			},
+			"resource_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Return only the warnings of the resources with the given IDs",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"severities": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The severities of the warnings to return, e.g. MAJOR. Case insensitive",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"warning_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "The types of the warnings to return. One or more among SKIPPED_RESOURCE, VALIDATION, " +
+					"CONFIGURATION, OTHER",
+				Elem: &schema.Schema{Type: schema.TypeString},
+			},
+			"since": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Return only the warnings which occurred at or after the given time, in RFC 3339 format",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching warnings",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the warning",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The severity of the warning, e.g. MINOR or MAJOR",
+						},
+						"warning_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the warning",
+						},
+						"warning_code": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The code of the warning",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The message of the warning",
+						},
+						"remediation_message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "How to remediate the warning",
+						},
+						"occurred_at_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the warning occurred",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the resource",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the resource",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the resource",
+						},
+						"task_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the task during which the warning occurred, if any",
+						},
+						"reference_token": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The token correlating the warning with the logs of SDDC Manager, to send when reporting an issue",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataResourceWarningsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	resourceIds := utils.ToStringSlice(data.Get("resource_ids").([]interface{}))
+	params := resource_warnings.NewGetResourceWarningsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithResourceIds(resourceIds)
+	if resourceType := data.Get("resource_type").(string); len(resourceType) > 0 {
+		params.WithResourceType(&resourceType)
+	}
+
+	var since time.Time
+	if sinceRaw := data.Get("since").(string); len(sinceRaw) > 0 {
+		var err error
+		if since, err = time.Parse(time.RFC3339, sinceRaw); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	result, err := apiClient.ResourceWarnings.GetResourceWarnings(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var warnings []*models.ResourceWarning
+	if result.Payload != nil {
+		warnings = result.Payload.Elements
+	}
+
+	severities := utils.ToStringSlice(data.Get("severities").([]interface{}))
+	warningTypes := utils.ToStringSlice(data.Get("warning_types").([]interface{}))
+	_ = data.Set("warnings", flattenResourceWarnings(filterResourceWarnings(warnings, severities, warningTypes, since)))
+
+	id, err := utils.HashFields([]string{
+		data.Get("resource_type").(string),
+		strings.Join(resourceIds, ","),
+		strings.Join(severities, ","),
+		strings.Join(warningTypes, ","),
+		data.Get("since").(string),
+	})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
+
+// filterResourceWarnings returns the warnings with one of the severities and types which occurred at or after since.
+// No severity, type or time matches any warning.
+func filterResourceWarnings(warnings []*models.ResourceWarning, severities, warningTypes []string,
+	since time.Time) []*models.ResourceWarning {
+	result := make([]*models.ResourceWarning, 0)
+	for _, warning := range warnings {
+		if warning == nil {
+			continue
+		}
+		if len(severities) > 0 && !slices.ContainsFunc(severities, func(severity string) bool {
+			return strings.EqualFold(severity, warning.Severity)
+		}) {
+			continue
+		}
+		if len(warningTypes) > 0 && !slices.Contains(warningTypes, warning.WarningType) {
+			continue
+		}
+		if !since.IsZero() {
+			if occurred, err := time.Parse(time.RFC3339, warning.OccurredAtTimestamp); err != nil || occurred.Before(since) {
+				continue
+			}
+		}
+		result = append(result, warning)
+	}
+
+	return result
+}
+
+func flattenResourceWarnings(warnings []*models.ResourceWarning) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(warnings))
+	for _, warning := range warnings {
+		taskId := ""
+		if warning.AssociatedTask != nil && warning.AssociatedTask.TaskID != nil {
+			taskId = *warning.AssociatedTask.TaskID
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":                    warning.ID,
+			"severity":              warning.Severity,
+			"warning_type":          warning.WarningType,
+			"warning_code":          warning.WarningCode,
+			"message":               warning.Message,
+			"remediation_message":   warning.RemediationMessage,
+			"occurred_at_timestamp": warning.OccurredAtTimestamp,
+			"resource_id":           warning.ResourceID,
+			"resource_name":         warning.ResourceName,
+			"resource_type":         warning.ResourceType,
+			"task_id":               taskId,
+			"reference_token":       warning.ReferenceToken,
+		})
+	}
+
+	return result
+}
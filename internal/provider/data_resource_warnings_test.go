@@ -0,0 +1,56 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+func TestAccDataSourceResourceWarnings(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceResourceWarnings(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_resource_warnings.major", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_resource_warnings.major", "warnings.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceResourceWarnings() string {
+	return `
+	data "vcf_resource_warnings" "major" {
+		severities = ["MAJOR"]
+	}
+`
+}
+
+func TestFilterResourceWarnings(t *testing.T) {
+	warnings := []*models.ResourceWarning{
+		{ID: "1", Severity: "MAJOR", WarningType: "SKIPPED_RESOURCE", OccurredAtTimestamp: "2025-03-02T10:00:00.000Z"},
+		{ID: "2", Severity: "MINOR", WarningType: "CONFIGURATION", OccurredAtTimestamp: "2025-02-01T10:00:00.000Z"},
+		nil,
+	}
+	ids := func(filtered []*models.ResourceWarning) []string {
+		result := make([]string, 0)
+		for _, warning := range filtered {
+			result = append(result, warning.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"1", "2"}, ids(filterResourceWarnings(warnings, nil, nil, time.Time{})))
+	assert.Equal(t, []string{"1"}, ids(filterResourceWarnings(warnings, []string{"major"}, nil, time.Time{})))
+	assert.Equal(t, []string{"2"}, ids(filterResourceWarnings(warnings, nil, []string{"CONFIGURATION"}, time.Time{})))
+	assert.Equal(t, []string{"1"}, ids(filterResourceWarnings(warnings, nil, nil, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))))
+}
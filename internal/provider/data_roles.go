@@ -0,0 +1,103 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/client/users"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceRoles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataRolesRead,
+		Description: "Datasource used to list the roles of SDDC Manager that can be assigned to users, groups and service accounts",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the role with the given name, e.g. ADMIN",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching roles",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the role",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the role, used as role_name of a vcf_user",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the role",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataRolesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	rolesResponse, err := apiClient.Users.GetRoles(
+		users.NewGetRolesParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := data.Get("name").(string)
+	var allRoles []*models.Role
+	if rolesResponse.Payload != nil {
+		allRoles = rolesResponse.Payload.Elements
+	}
+	_ = data.Set("roles", flattenRoles(allRoles, name))
+
+	id, err := utils.HashFields([]string{name})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
+
+func flattenRoles(roles []*models.Role, name string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		if role == nil || role.Name == nil || role.ID == nil {
+			continue
+		}
+		if len(name) > 0 && *role.Name != name {
+			continue
+		}
+		description := ""
+		if role.Description != nil {
+			description = *role.Description
+		}
+		result = append(result, map[string]interface{}{
+			"id":          *role.ID,
+			"name":        *role.Name,
+			"description": description,
+		})
+	}
+	return result
+}
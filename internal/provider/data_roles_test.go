@@ -0,0 +1,38 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRoles(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceRoles(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_roles.all", "roles.#"),
+				resource.TestCheckResourceAttr("data.vcf_roles.admin", "roles.#", "1"),
+				resource.TestCheckResourceAttr("data.vcf_roles.admin", "roles.0.name", "ADMIN"),
+				resource.TestCheckResourceAttrSet("data.vcf_roles.admin", "roles.0.id"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceRoles() string {
+	return `
+	data "vcf_roles" "all" {
+	}
+
+	data "vcf_roles" "admin" {
+		name = "ADMIN"
+	}
+`
+}
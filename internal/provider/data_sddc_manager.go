@@ -0,0 +1,112 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+func DataSourceSddcManager() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSddcManagerRead,
+		Description: "Datasource used to read the version and the identity of the SDDC Manager the provider is connected to, " +
+			"e.g. to configure a module depending on the VCF version",
+		Schema: map[string]*schema.Schema{
+			"fqdn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The FQDN of SDDC Manager",
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address of SDDC Manager",
+			},
+			"domain_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the management domain SDDC Manager belongs to",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of SDDC Manager without the build number, e.g. 5.2.1.0",
+			},
+			"build": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The build number of SDDC Manager, e.g. 24307856",
+			},
+			"vcf_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the VMware Cloud Foundation release of the system, e.g. 5.2.1",
+			},
+			"major_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The major version of the VMware Cloud Foundation release, e.g. 5",
+			},
+			"minor_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The minor version of the VMware Cloud Foundation release, e.g. 2",
+			},
+		},
+	}
+}
+
+func dataSddcManagerRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	sddcManager, err := lcm.GetSddcManager(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	release, err := lcm.GetSystemRelease(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sddcManagerVersion, build := splitSddcManagerVersion(sddcManager.Version)
+	vcfVersion := sddcManagerVersion
+	if release != nil && release.Version != nil && len(*release.Version) > 0 {
+		vcfVersion = *release.Version
+	}
+	parsedVersion, err := version.NewVersion(vcfVersion)
+	if err != nil {
+		return diag.Errorf("invalid version %q of VMware Cloud Foundation: %s", vcfVersion, err)
+	}
+	segments := parsedVersion.Segments()
+
+	_ = data.Set("fqdn", sddcManager.Fqdn)
+	_ = data.Set("ip_address", sddcManager.IPAddress)
+	if sddcManager.Domain != nil && sddcManager.Domain.ID != nil {
+		_ = data.Set("domain_id", *sddcManager.Domain.ID)
+	}
+	_ = data.Set("version", sddcManagerVersion)
+	_ = data.Set("build", build)
+	_ = data.Set("vcf_version", vcfVersion)
+	_ = data.Set("major_version", segments[0])
+	_ = data.Set("minor_version", segments[1])
+	data.SetId(sddcManager.ID)
+
+	return nil
+}
+
+// splitSddcManagerVersion splits the version SDDC Manager reports, e.g. 5.2.1.0-24307856, in the version and the
+// build number.
+func splitSddcManagerVersion(fullVersion string) (string, string) {
+	sddcManagerVersion, build, _ := strings.Cut(fullVersion, "-")
+	return sddcManagerVersion, build
+}
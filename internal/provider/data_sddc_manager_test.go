@@ -0,0 +1,47 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccDataSourceSddcManager(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceSddcManager(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_sddc_manager.sddc_manager", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_sddc_manager.sddc_manager", "fqdn"),
+				resource.TestCheckResourceAttrSet("data.vcf_sddc_manager.sddc_manager", "build"),
+				resource.TestMatchResourceAttr("data.vcf_sddc_manager.sddc_manager", "vcf_version",
+					regexp.MustCompile(`^\d+\.\d+`)),
+				resource.TestCheckResourceAttrSet("data.vcf_sddc_manager.sddc_manager", "major_version"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceSddcManager() string {
+	return `
+	data "vcf_sddc_manager" "sddc_manager" {}
+`
+}
+
+func TestSplitSddcManagerVersion(t *testing.T) {
+	sddcManagerVersion, build := splitSddcManagerVersion("5.2.1.0-24307856")
+	assert.Equal(t, "5.2.1.0", sddcManagerVersion)
+	assert.Equal(t, "24307856", build)
+
+	sddcManagerVersion, build = splitSddcManagerVersion("5.2.1.0")
+	assert.Equal(t, "5.2.1.0", sddcManagerVersion)
+	assert.Empty(t, build)
+}
@@ -0,0 +1,208 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sddc_api "github.com/vmware/vcf-sdk-go/client/sddc"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceCloudBuilderStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudBuilderStatusRead,
+		Description: "Datasource used to read the status of the bring-up and of the last spec validation of the Cloud Builder appliance",
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the Cloud Builder appliance",
+			},
+			"bringup_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the last bring-up, empty if none was started",
+			},
+			"bringup_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the last bring-up",
+			},
+			"bringup_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the last bring-up, e.g. IN_PROGRESS, COMPLETED_WITH_SUCCESS, COMPLETED_WITH_FAILURE",
+			},
+			"bringup_creation_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the last bring-up started",
+			},
+			"failed_subtasks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The failed steps of the last bring-up",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the step",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the step",
+						},
+						"errors": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The errors of the step",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"can_resume": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the last bring-up failed and can be resumed",
+			},
+			"validation_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the last validation of a bring-up spec, empty if none was run",
+			},
+			"validation_execution_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The execution status of the last validation, e.g. IN_PROGRESS, COMPLETED",
+			},
+			"validation_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The result of the last validation, e.g. SUCCEEDED, FAILED",
+			},
+			"validation_failed_checks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The descriptions of the failed checks of the last validation",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceCloudBuilderStatusRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.CloudBuilderClient)
+
+	aboutResponse, err := client.ApiClient.SDDC.GetBringupAppInfo(
+		sddc_api.NewGetBringupAppInfoParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version := ""
+	if aboutResponse.Payload != nil {
+		version = aboutResponse.Payload.Version
+	}
+	_ = data.Set("version", version)
+
+	bringUp, err := getLastBringUp(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bringUpId := ""
+	if bringUp != nil {
+		bringUpId = bringUp.ID
+		_ = data.Set("bringup_name", bringUp.Name)
+		_ = data.Set("bringup_status", bringUp.Status)
+		_ = data.Set("bringup_creation_timestamp", bringUp.CreationTimestamp)
+		_ = data.Set("failed_subtasks", flattenFailedBringupSubTasks(bringUp.SDDCSubTasks))
+	}
+	_ = data.Set("bringup_id", bringUpId)
+	_ = data.Set("can_resume", bringUp != nil && bringUp.Status == "COMPLETED_WITH_FAILURE")
+
+	validation, err := getLastBringupValidation(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	validationId := ""
+	if validation != nil {
+		validationId = validation.ID
+		_ = data.Set("validation_execution_status", validation.ExecutionStatus)
+		_ = data.Set("validation_status", validation.ResultStatus)
+		_ = data.Set("validation_failed_checks", failedValidationChecks(validation.ValidationChecks))
+	}
+	_ = data.Set("validation_id", validationId)
+
+	id, err := utils.HashFields([]string{version, bringUpId, validationId})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
+
+// getLastBringupValidation returns the last validation of a bring-up spec, nil if none was run.
+func getLastBringupValidation(ctx context.Context, client *api_client.CloudBuilderClient) (*models.Validation, error) {
+	validationsResponse, err := client.ApiClient.SDDC.GetBringupValidations(
+		sddc_api.NewGetBringupValidationsParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+	// Cloud Builder lists the most recent validation first, as it does for the bring-up tasks
+	if validationsResponse.Payload != nil && len(validationsResponse.Payload.Elements) > 0 {
+		return validationsResponse.Payload.Elements[0], nil
+	}
+	return nil, nil
+}
+
+func flattenFailedBringupSubTasks(subTasks []*models.SDDCSubTask) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	for _, subTask := range subTasks {
+		if subTask == nil || !strings.Contains(strings.ToUpper(subTask.Status), "FAIL") {
+			continue
+		}
+		errorMessages := make([]string, 0, len(subTask.Errors))
+		for _, subTaskError := range subTask.Errors {
+			if subTaskError != nil {
+				errorMessages = append(errorMessages, subTaskError.Message)
+			}
+		}
+		result = append(result, map[string]interface{}{
+			"name":        subTask.Name,
+			"description": subTask.Description,
+			"errors":      errorMessages,
+		})
+	}
+	return result
+}
+
+func failedValidationChecks(validationChecks []*models.ValidationCheck) []string {
+	result := make([]string, 0)
+	for _, validationCheck := range validationChecks {
+		if validationCheck == nil {
+			continue
+		}
+		if validationCheck.ResultStatus == "FAILED" {
+			result = append(result, validationCheck.Description)
+		}
+		result = append(result, failedValidationChecks(validationCheck.NestedValidationChecks)...)
+	}
+	return result
+}
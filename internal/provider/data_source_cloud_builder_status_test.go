@@ -0,0 +1,34 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceVcfCloudBuilderStatus(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfCloudBuilderStatusDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_cloud_builder_status.status", "id"),
+					resource.TestCheckResourceAttrSet("data.vcf_cloud_builder_status.status", "version"),
+					resource.TestCheckResourceAttrSet("data.vcf_cloud_builder_status.status", "can_resume"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfCloudBuilderStatusDataSourceConfig() string {
+	return `
+	data "vcf_cloud_builder_status" "status" {
+	}`
+}
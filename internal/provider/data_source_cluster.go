@@ -15,6 +15,7 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/cluster"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 )
 
 func DataSourceCluster() *schema.Resource {
@@ -72,6 +73,16 @@ func DataSourceCluster() *schema.Resource {
 				Computed:    true,
 				Description: "Status of the cluster if stretched or not",
 			},
+			"exclude": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Description: "The computed blocks which are not read, to reduce the size of the state and the time to " +
+					"read large clusters. One or more among host, vds",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(cluster.ExcludableBlocks(), false),
+				},
+			},
 		},
 	}
 }
@@ -79,7 +90,8 @@ func DataSourceCluster() *schema.Resource {
 func dataSourceClusterRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
 	clusterId := data.Get("cluster_id").(string)
-	_, err := cluster.ImportCluster(ctx, data, apiClient, clusterId)
+	exclude := utils.ToStringSlice(data.Get("exclude").(*schema.Set).List())
+	_, err := cluster.ImportCluster(ctx, data, apiClient, clusterId, exclude...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 	"github.com/vmware/terraform-provider-vcf/internal/domain"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	"github.com/vmware/terraform-provider-vcf/internal/vcenter"
 )
 
@@ -85,6 +86,16 @@ func DataSourceDomain() *schema.Resource {
 				Computed:    true,
 				Description: "Indicates if the workload domain is joined to the management domain's SSO domain.",
 			},
+			"exclude": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Description: "The computed blocks which are not read, to reduce the size of the state and the time to " +
+					"read large domains. One or more among cluster, cluster.host, cluster.vds, nsx_configuration",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(domain.ExcludableBlocks(), false),
+				},
+			},
 		},
 	}
 }
@@ -107,7 +118,8 @@ func dataSourceDomainRead(ctx context.Context, data *schema.ResourceData, meta i
 		domainId = domainInfo.ID
 	}
 
-	_, err := domain.ImportDomain(ctx, data, apiClient, domainId, true)
+	exclude := utils.ToStringSlice(data.Get("exclude").(*schema.Set).List())
+	_, err := domain.ImportDomain(ctx, data, apiClient, domainId, true, exclude...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,107 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/terraform-provider-vcf/internal/sddc"
+)
+
+// instanceSpecExcludedArguments are the arguments of vcf_instance that do not contribute to the bring-up spec.
+var instanceSpecExcludedArguments = []string{
+	"status",
+	"creation_timestamp",
+	"current_stage",
+	"sddc_manager_fqdn",
+	"sddc_manager_id",
+	"sddc_manager_version",
+	"validate_only",
+	"revalidate_on_resume",
+	"validation_id",
+	"validation_status",
+	"validation_checks",
+	"import_management_domain",
+	"sddc_manager_api_username",
+	"sddc_manager_api_password",
+	"management_domain_id",
+	"management_domain_name",
+	"management_vcenter_fqdn",
+	"management_nsx_vip_fqdn",
+}
+
+func DataSourceInstanceSpec() *schema.Resource {
+	instanceSchema := resourceVcfInstanceSchema()
+	for _, key := range instanceSpecExcludedArguments {
+		delete(instanceSchema, key)
+	}
+	instanceSchema["json"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The bring-up spec vcf_instance submits to Cloud Builder for the same arguments, in JSON",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceInstanceSpecRead,
+		Description: "Datasource used to render the bring-up spec of a VCF instance without executing the bring-up. " +
+			"Accepts the same arguments as vcf_instance",
+		Schema: instanceSchema,
+	}
+}
+
+func dataSourceInstanceSpecRead(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	sddcSpec, err := buildSddcSpec(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	useInstaller := isInstallerVersion(data.Get("version").(string))
+	if missing := missingSddcSpecArguments(sddcSpec, useInstaller); len(missing) > 0 {
+		return diag.Errorf("the following arguments must be set in the configuration or in spec_json: %s",
+			strings.Join(missing, ", "))
+	}
+	for _, key := range []string{"nfs_datastore", "vmfs_datastore"} {
+		if _, ok := data.GetOk(key); ok && !useInstaller {
+			return diag.Errorf("%s requires version 9.0 or later, Cloud Builder deploys the management domain on vSAN", key)
+		}
+	}
+	dvSwitchVersion := sddcSpec.DvSwitchVersion
+	if useInstaller && len(dvSwitchVersion) == 0 {
+		dvSwitchVersion = sddc.DvSwitchVersionVcf52
+	}
+	if err := sddc.ValidateDvsSpecs(dvSwitchVersion, sddcSpec.DvsSpecs); err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("task_name", *sddcSpec.TaskName)
+
+	var renderedSpec map[string]interface{}
+	if useInstaller {
+		renderedSpec, err = buildInstallerSpec(data)
+	} else {
+		renderedSpec, err = buildSddcSpecProperties(data)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	specJson, err := json.MarshalIndent(renderedSpec, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("json", string(specJson))
+
+	id, err := utils.HashFields([]string{string(specJson)})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
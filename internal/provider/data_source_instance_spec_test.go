@@ -0,0 +1,49 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceVcfInstanceSpec(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfInstanceSpecDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_instance_spec.spec", "id"),
+					resource.TestCheckResourceAttr("data.vcf_instance_spec.spec", "task_name", "workflowconfig/workflowspec-ems.json"),
+					resource.TestMatchResourceAttr("data.vcf_instance_spec.spec", "json", regexp.MustCompile(`"sddcId": "sddcId-1002"`)),
+					resource.TestMatchResourceAttr("data.vcf_instance_spec.spec", "json", regexp.MustCompile(`"managementPoolName": "bringup-networkpool"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfInstanceSpecDataSourceConfig() string {
+	return `
+	data "vcf_instance_spec" "spec" {
+		instance_id = "sddcId-1002"
+		spec_json = jsonencode({
+			sddcId             = "sddcId-1001"
+			managementPoolName = "bringup-networkpool"
+			dvSwitchVersion    = "7.0.0"
+			ntpServers         = ["10.0.0.250"]
+			clusterSpec        = { clusterName = "SDDC-Cluster1", vcenterName = "vcenter-1" }
+			dnsSpec            = { domain = "vsphere.local", nameserver = "10.0.0.250" }
+			dvsSpecs           = [{ dvsName = "SDDC-Dswitch-Private", mtu = 9000, vmnics = ["vmnic0", "vmnic1"] }]
+			hostSpecs          = [{ hostname = "esxi-1", vSwitch = "vSwitch0", association = "SDDC-Datacenter" }]
+			networkSpecs       = [{ networkType = "MANAGEMENT", vlanId = "0", subnet = "10.0.0.0/22" }]
+			vcenterSpec        = { vcenterHostname = "vcenter-1", vcenterIp = "10.0.0.6" }
+		})
+	}`
+}
@@ -0,0 +1,155 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/users"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceSsoDomains() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSsoDomainsRead,
+		Description: "Datasource used to list the SSO domains known to SDDC Manager with the workload domains joined to them",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the SSO domain with the given name, e.g. vsphere.local",
+			},
+			"sso_domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching SSO domains",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the SSO domain",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the SSO domain, empty when no workload domain is joined to it",
+						},
+						"is_management_sso_domain": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the management domain is joined to the SSO domain",
+						},
+						"domain_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The IDs of the workload domains joined to the SSO domain",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"domain_names": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The names of the workload domains joined to the SSO domain",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSsoDomainsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	ssoDomainsResponse, err := apiClient.Users.GetSSODomains(
+		users.NewGetSSODomainsParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	domainsResponse, err := apiClient.Domains.GetDomains(
+		domains.NewGetDomainsParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var ssoDomainNames []string
+	if ssoDomainsResponse.Payload != nil {
+		ssoDomainNames = ssoDomainsResponse.Payload.Elements
+	}
+	var allDomains []*models.Domain
+	if domainsResponse.Payload != nil {
+		allDomains = domainsResponse.Payload.Elements
+	}
+
+	name := data.Get("name").(string)
+	_ = data.Set("sso_domains", flattenSsoDomains(ssoDomainNames, allDomains, name))
+
+	id, err := utils.HashFields([]string{name})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
+
+// flattenSsoDomains lists the SSO domains with the workload domains joined to them. The SSO domains of isolated
+// workload domains are listed as well, even if SDDC Manager does not return them.
+func flattenSsoDomains(ssoDomainNames []string, allDomains []*models.Domain, name string) []map[string]interface{} {
+	ssoDomains := make(map[string]map[string]interface{})
+	addSsoDomain := func(ssoDomainName string) map[string]interface{} {
+		if ssoDomain, ok := ssoDomains[ssoDomainName]; ok {
+			return ssoDomain
+		}
+		ssoDomain := map[string]interface{}{
+			"name":                     ssoDomainName,
+			"id":                       "",
+			"is_management_sso_domain": false,
+			"domain_ids":               []string{},
+			"domain_names":             []string{},
+		}
+		ssoDomains[ssoDomainName] = ssoDomain
+		return ssoDomain
+	}
+
+	for _, ssoDomainName := range ssoDomainNames {
+		addSsoDomain(ssoDomainName)
+	}
+	for _, domain := range allDomains {
+		if domain == nil || len(domain.SSOName) == 0 {
+			continue
+		}
+		ssoDomain := addSsoDomain(domain.SSOName)
+		if len(domain.SSOID) > 0 {
+			ssoDomain["id"] = domain.SSOID
+		}
+		if domain.Type == "MANAGEMENT" {
+			ssoDomain["is_management_sso_domain"] = true
+		}
+		ssoDomain["domain_ids"] = append(ssoDomain["domain_ids"].([]string), domain.ID)
+		ssoDomain["domain_names"] = append(ssoDomain["domain_names"].([]string), domain.Name)
+	}
+
+	result := make([]map[string]interface{}, 0, len(ssoDomains))
+	for ssoDomainName, ssoDomain := range ssoDomains {
+		if len(name) > 0 && ssoDomainName != name {
+			continue
+		}
+		result = append(result, ssoDomain)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i]["name"].(string) < result[j]["name"].(string)
+	})
+	return result
+}
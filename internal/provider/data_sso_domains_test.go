@@ -0,0 +1,62 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+func TestAccDataSourceSsoDomains(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceSsoDomains(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_sso_domains.all", "sso_domains.#"),
+				resource.TestCheckResourceAttr("data.vcf_sso_domains.management", "sso_domains.#", "1"),
+				resource.TestCheckResourceAttr("data.vcf_sso_domains.management", "sso_domains.0.is_management_sso_domain", "true"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceSsoDomains() string {
+	return `
+	data "vcf_sso_domains" "all" {
+	}
+
+	data "vcf_sso_domains" "management" {
+		name = "vsphere.local"
+	}
+`
+}
+
+func TestFlattenSsoDomains(t *testing.T) {
+	allDomains := []*models.Domain{
+		{ID: "mgmt-id", Name: "sfo-m01", Type: "MANAGEMENT", SSOID: "sso-1", SSOName: "vsphere.local"},
+		{ID: "wld01-id", Name: "sfo-w01", Type: "VI", SSOID: "sso-1", SSOName: "vsphere.local"},
+		{ID: "wld02-id", Name: "sfo-w02", Type: "VI", SSOID: "sso-2", SSOName: "wld02.local"},
+	}
+
+	ssoDomains := flattenSsoDomains([]string{"vsphere.local"}, allDomains, "")
+	assert.Len(t, ssoDomains, 2)
+	assert.Equal(t, "vsphere.local", ssoDomains[0]["name"])
+	assert.Equal(t, "sso-1", ssoDomains[0]["id"])
+	assert.Equal(t, true, ssoDomains[0]["is_management_sso_domain"])
+	assert.Equal(t, []string{"mgmt-id", "wld01-id"}, ssoDomains[0]["domain_ids"])
+	// The isolated SSO domain of a workload domain
+	assert.Equal(t, "wld02.local", ssoDomains[1]["name"])
+	assert.Equal(t, false, ssoDomains[1]["is_management_sso_domain"])
+	assert.Equal(t, []string{"sfo-w02"}, ssoDomains[1]["domain_names"])
+
+	ssoDomains = flattenSsoDomains([]string{"vsphere.local"}, allDomains, "wld02.local")
+	assert.Len(t, ssoDomains, 1)
+	assert.Equal(t, "sso-2", ssoDomains[0]["id"])
+}
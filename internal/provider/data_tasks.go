@@ -0,0 +1,302 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/client/tasks"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+// taskStatuses are the statuses of the SDDC Manager tasks, normalized by normalizeTaskStatus.
+var taskStatuses = []string{"PENDING", "IN_PROGRESS", "SUCCESSFUL", "FAILED", "CANCELLED", "COMPLETED_WITH_WARNING"}
+
+// taskFilter holds the filters of the vcf_tasks data source applied to the tasks read from SDDC Manager.
+type taskFilter struct {
+	statuses []string
+	types    []string
+	since    time.Time
+	until    time.Time
+}
+
+func DataSourceTasks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataTasksRead,
+		Description: "Datasource used to list the tasks of SDDC Manager, e.g. to check whether a task is running against a domain",
+		Schema: map[string]*schema.Schema{
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				Description:  "The number of most recent tasks to read before the statuses, types and time range filters are applied",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"resource_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the tasks of the resource with the given ID, e.g. a domain or a cluster",
+			},
+			"resource_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the tasks of the resources of the given type, e.g. DOMAIN, CLUSTER or HOST",
+			},
+			"statuses": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "The statuses of the tasks to return. One or more among PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, " +
+					"CANCELLED, COMPLETED_WITH_WARNING",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(taskStatuses, false),
+				},
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The types of the tasks to return, e.g. HOST_COMMISSION or DOMAIN_CREATION",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"since": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Return only the tasks created at or after the given time, in RFC 3339 format",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"until": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Return only the tasks created before the given time, in RFC 3339 format",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"tasks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the matching tasks, newest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the task",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the task",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the task",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the task. One among PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, CANCELLED, COMPLETED_WITH_WARNING",
+						},
+						"creation_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the task was created",
+						},
+						"completion_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the task was completed, empty while it runs",
+						},
+						"is_cancellable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the task can be cancelled",
+						},
+						"is_retryable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the task can be retried",
+						},
+						"resource_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The IDs of the resources the task operates on",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"errors": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The errors of the task",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"failed_subtasks": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The failed subtasks of the task, including the nested ones",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the subtask",
+									},
+									"description": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The description of the subtask, e.g. Deploy NSX Manager",
+									},
+									"errors": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Description: "The errors of the subtask",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataTasksRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	limit := int32(data.Get("limit").(int))
+	orderBy := "creationTimestamp"
+	orderDirection := "DESC"
+	params := tasks.NewGetTasksParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithLimit(&limit).
+		WithOrderBy(&orderBy).
+		WithOrderDirection(&orderDirection)
+	if resourceID := data.Get("resource_id").(string); len(resourceID) > 0 {
+		params.WithResourceID(&resourceID)
+	}
+	if resourceType := data.Get("resource_type").(string); len(resourceType) > 0 {
+		params.WithResourceType(&resourceType)
+	}
+
+	filter := taskFilter{
+		statuses: utils.ToStringSlice(data.Get("statuses").([]interface{})),
+		types:    utils.ToStringSlice(data.Get("types").([]interface{})),
+	}
+	var err error
+	if since := data.Get("since").(string); len(since) > 0 {
+		if filter.since, err = time.Parse(time.RFC3339, since); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if until := data.Get("until").(string); len(until) > 0 {
+		if filter.until, err = time.Parse(time.RFC3339, until); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	result, err := apiClient.Tasks.GetTasks(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var allTasks []*models.Task
+	if result.Payload != nil {
+		allTasks = result.Payload.Elements
+	}
+	_ = data.Set("tasks", flattenTasks(filterTasks(allTasks, filter)))
+
+	id, err := utils.HashFields([]string{
+		strconv.Itoa(data.Get("limit").(int)),
+		data.Get("resource_id").(string),
+		data.Get("resource_type").(string),
+		strings.Join(filter.statuses, ","),
+		strings.Join(filter.types, ","),
+		data.Get("since").(string),
+		data.Get("until").(string),
+	})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
+
+// normalizeTaskStatus converts the status of a task, e.g. "In Progress" or "Successful", to upper snake case.
+func normalizeTaskStatus(status string) string {
+	return strings.ToUpper(strings.ReplaceAll(status, " ", "_"))
+}
+
+// filterTasks returns the tasks matching the filter. Tasks which creation time cannot be parsed do not match
+// a time range.
+func filterTasks(allTasks []*models.Task, filter taskFilter) []*models.Task {
+	result := make([]*models.Task, 0)
+	for _, task := range allTasks {
+		if task == nil {
+			continue
+		}
+		if len(filter.statuses) > 0 && !slices.Contains(filter.statuses, normalizeTaskStatus(task.Status)) {
+			continue
+		}
+		if len(filter.types) > 0 && !slices.Contains(filter.types, task.Type) {
+			continue
+		}
+		if !filter.since.IsZero() || !filter.until.IsZero() {
+			created, err := time.Parse(time.RFC3339, task.CreationTimestamp)
+			if err != nil || created.Before(filter.since) || (!filter.until.IsZero() && !created.Before(filter.until)) {
+				continue
+			}
+		}
+		result = append(result, task)
+	}
+
+	return result
+}
+
+func flattenTasks(tasksToFlatten []*models.Task) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(tasksToFlatten))
+	for _, task := range tasksToFlatten {
+		resourceIDs := make([]string, 0)
+		for _, resource := range task.Resources {
+			if resource != nil && resource.ResourceID != nil {
+				resourceIDs = append(resourceIDs, *resource.ResourceID)
+			}
+		}
+		failedSubTasks := make([]map[string]interface{}, 0)
+		for _, subTask := range api_client.FailedSubTasks(task) {
+			failedSubTasks = append(failedSubTasks, map[string]interface{}{
+				"name":        subTask.Name,
+				"description": subTask.Description,
+				"errors":      api_client.ErrorMessages(subTask.Errors),
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":                   task.ID,
+			"name":                 task.Name,
+			"type":                 task.Type,
+			"status":               normalizeTaskStatus(task.Status),
+			"creation_timestamp":   task.CreationTimestamp,
+			"completion_timestamp": task.CompletionTimestamp,
+			"is_cancellable":       task.IsCancellable,
+			"is_retryable":         task.IsRetryable,
+			"resource_ids":         resourceIDs,
+			"errors":               api_client.ErrorMessages(task.Errors),
+			"failed_subtasks":      failedSubTasks,
+		})
+	}
+
+	return result
+}
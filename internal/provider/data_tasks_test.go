@@ -0,0 +1,94 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+func TestAccDataSourceTasks(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceTasks(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_tasks.recent", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_tasks.recent", "tasks.#"),
+				resource.TestCheckResourceAttrSet("data.vcf_tasks.failed", "tasks.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceTasks() string {
+	return `
+	data "vcf_tasks" "recent" {
+		limit = 10
+	}
+
+	data "vcf_tasks" "failed" {
+		statuses = ["FAILED"]
+		since    = "2024-01-01T00:00:00Z"
+	}
+`
+}
+
+func TestFilterTasks(t *testing.T) {
+	allTasks := []*models.Task{
+		{ID: "1", Type: "HOST_COMMISSION", Status: "In Progress", CreationTimestamp: "2025-03-02T10:00:00.000Z"},
+		{ID: "2", Type: "DOMAIN_CREATION", Status: "Successful", CreationTimestamp: "2025-03-01T10:00:00.000Z"},
+		{ID: "3", Type: "HOST_COMMISSION", Status: "FAILED", CreationTimestamp: "2025-02-01T10:00:00.000Z"},
+		nil,
+	}
+	ids := func(filtered []*models.Task) []string {
+		result := make([]string, 0)
+		for _, task := range filtered {
+			result = append(result, task.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"1", "2", "3"}, ids(filterTasks(allTasks, taskFilter{})))
+	assert.Equal(t, []string{"1"}, ids(filterTasks(allTasks, taskFilter{statuses: []string{"IN_PROGRESS", "PENDING"}})))
+	assert.Equal(t, []string{"1", "3"}, ids(filterTasks(allTasks, taskFilter{types: []string{"HOST_COMMISSION"}})))
+
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, []string{"1", "2"}, ids(filterTasks(allTasks, taskFilter{since: since})))
+	assert.Equal(t, []string{"2", "3"}, ids(filterTasks(allTasks, taskFilter{until: until})))
+	assert.Equal(t, []string{"2"}, ids(filterTasks(allTasks, taskFilter{since: since, until: until})))
+}
+
+func TestFlattenTasks(t *testing.T) {
+	resourceID := "domain-id"
+	flattened := flattenTasks([]*models.Task{{
+		ID:        "1",
+		Status:    "In Progress",
+		Resources: []*models.Resource{{ResourceID: &resourceID}, nil},
+		Errors:    []*models.Error{{Message: "failure"}, {}},
+		SubTasks: []*models.SubTask{
+			{Name: "Deploy", Status: "SUCCESSFUL"},
+			{Name: "Configure", Status: "SUCCESSFUL", SubTasks: []*models.SubTask{
+				{Name: "DeployNsxManager", Description: "Deploy NSX Manager", Status: "FAILED", Errors: []*models.Error{{Message: "timeout"}}},
+			}},
+		},
+	}})
+
+	assert.Len(t, flattened, 1)
+	assert.Equal(t, "IN_PROGRESS", flattened[0]["status"])
+	assert.Equal(t, []string{"domain-id"}, flattened[0]["resource_ids"])
+	assert.Equal(t, []string{"failure"}, flattened[0]["errors"])
+	assert.Equal(t, []map[string]interface{}{{
+		"name":        "DeployNsxManager",
+		"description": "Deploy NSX Manager",
+		"errors":      []string{"timeout"},
+	}}, flattened[0]["failed_subtasks"])
+}
@@ -0,0 +1,139 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func DataSourceUpgradables() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataUpgradablesRead,
+		Description: "Datasource used to list the upgradable components of a workload domain",
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the workload domain",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"target_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the upgradables leading to the given VCF release",
+			},
+			"upgradables": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of the upgradable components of the domain",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bundle_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the bundle which upgrades the component",
+						},
+						"bundle_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the bundle",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The upgrade status of the component, e.g. AVAILABLE, PENDING, SCHEDULED",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the upgradable resource",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the upgradable resource",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the upgradable resource",
+						},
+						"resource_fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The FQDN of the upgradable resource",
+						},
+						"component_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the component upgraded by the bundle",
+						},
+						"current_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version the bundle upgrades the component from",
+						},
+						"target_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version the bundle upgrades the component to",
+						},
+						"download_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The download status of the bundle",
+						},
+						"applicability_order": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The order in which SDDC Manager applies the bundle",
+						},
+						"prerequisite_bundle_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The IDs of the bundles of the other upgradables which must be applied first",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataUpgradablesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	domainId := data.Get("domain_id").(string)
+	targetVersion := data.Get("target_version").(string)
+
+	allUpgradables, err := lcm.GetDomainUpgradables(ctx, domainId, targetVersion, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allBundles, err := lcm.GetBundles(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = data.Set("upgradables", lcm.FlattenUpgradables(allUpgradables, allBundles))
+
+	id, err := utils.HashFields([]string{domainId, targetVersion})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return nil
+}
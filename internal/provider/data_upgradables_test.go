@@ -0,0 +1,37 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccDataSourceUpgradables(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceUpgradables(os.Getenv(constants.VcfTestDomainDataSourceId)),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.vcf_upgradables.management", "id"),
+				resource.TestCheckResourceAttrSet("data.vcf_upgradables.management", "upgradables.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceUpgradables(domainId string) string {
+	return fmt.Sprintf(`
+	data "vcf_upgradables" "management" {
+		domain_id = %q
+	}
+`, domainId)
+}
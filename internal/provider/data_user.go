@@ -0,0 +1,103 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/client/users"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func DataSourceUser() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataUserRead,
+		Description: "Datasource used to look up a user, group or service account of SDDC Manager by name",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the user, group or service account, e.g. user@rainpole.io",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The domain of the user. Required when users of several domains have the name",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the user. One of: USER, GROUP, SERVICE",
+			},
+			"role_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the role of the user",
+			},
+			"role_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the role of the user",
+			},
+			"creation_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the user was added to SDDC Manager",
+			},
+		},
+	}
+}
+
+func dataUserRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	usersResponse, err := client.Users.GetUsers(
+		users.NewGetUsersParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := data.Get("name").(string)
+	domain := data.Get("domain").(string)
+	var matches []string
+	for _, user := range usersResponse.Payload.Elements {
+		if user == nil || user.Name == nil || *user.Name != name {
+			continue
+		}
+		if len(domain) > 0 && !strings.EqualFold(user.Domain, domain) {
+			continue
+		}
+		matches = append(matches, user.ID)
+		if len(matches) > 1 {
+			return diag.Errorf("several users are named %s, set domain to select one", name)
+		}
+
+		data.SetId(user.ID)
+		_ = data.Set("domain", user.Domain)
+		_ = data.Set("type", user.Type)
+		_ = data.Set("creation_timestamp", user.CreationTimestamp)
+		if user.Role != nil && user.Role.ID != nil {
+			roleName, err := getRoleName(ctx, client, *user.Role.ID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			_ = data.Set("role_id", *user.Role.ID)
+			_ = data.Set("role_name", roleName)
+		}
+	}
+	if len(matches) == 0 {
+		return diag.Errorf("user not found: %s", name)
+	}
+
+	return nil
+}
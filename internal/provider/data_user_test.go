@@ -0,0 +1,43 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceUser(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceUser(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrPair("data.vcf_user.operator", "id", "vcf_user.operator", "id"),
+				resource.TestCheckResourceAttr("data.vcf_user.operator", "type", "USER"),
+				resource.TestCheckResourceAttr("data.vcf_user.operator", "role_name", "OPERATOR"),
+				resource.TestCheckResourceAttrSet("data.vcf_user.operator", "role_id"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceUser() string {
+	return `
+	resource "vcf_user" "operator" {
+		name      = "testuser2@vrack.vsphere.local"
+		domain    = "vrack.vsphere.local"
+		type      = "USER"
+		role_name = "OPERATOR"
+	}
+
+	data "vcf_user" "operator" {
+		name   = vcf_user.operator.name
+		domain = vcf_user.operator.domain
+	}
+`
+}
@@ -0,0 +1,118 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+func DataSourceValidation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataValidationRead,
+		Description: "Datasource used to validate a host commission, cluster, domain or edge cluster spec with SDDC Manager " +
+			"without deploying anything, e.g. to check the input of a module before creating its resources",
+		Schema: map[string]*schema.Schema{
+			"spec_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The type of the spec. One among: HOST_COMMISSION, CLUSTER_CREATION, DOMAIN_CREATION, " +
+					"EDGE_CLUSTER_CREATION",
+				ValidateFunc: validation.StringInSlice(validationUtils.SpecTypes(), false),
+			},
+			"spec": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+				Description: "The spec in the JSON format of the SDDC Manager API, e.g. built with jsonencode. A HOST_COMMISSION " +
+					"spec is a list of hosts",
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"fail_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether a failed validation fails the read, with an error for each failed check. Defaults to false",
+			},
+			"valid": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the spec passed the validation",
+			},
+			"execution_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The execution status of the validation, e.g. COMPLETED",
+			},
+			"result_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The result of the validation, e.g. SUCCEEDED or FAILED",
+			},
+			"checks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The checks of the validation, the nested checks follow the check they belong to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the check",
+						},
+						"result_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The result of the check, e.g. SUCCEEDED, WARNING or FAILED",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The severity of the check, e.g. ERROR",
+						},
+						"error_messages": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The messages of the errors the check reported",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataValidationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	specType := data.Get("spec_type").(string)
+	spec := data.Get("spec").(string)
+	result, err := validationUtils.ValidateSpec(ctx, vcfClient.ApiClient, specType, spec, vcfClient.PollInterval())
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	if data.Get("fail_on_failure").(bool) && validationUtils.HasValidationFailed(result) {
+		return validationUtils.ConvertValidationResultToDiag(result)
+	}
+
+	if result.ID != "" {
+		data.SetId(result.ID)
+	} else {
+		// The synchronous validations have no ID
+		data.SetId(specType)
+	}
+	_ = data.Set("valid", !validationUtils.HasValidationFailed(result))
+	_ = data.Set("execution_status", result.ExecutionStatus)
+	_ = data.Set("result_status", result.ResultStatus)
+	_ = data.Set("checks", validationUtils.FlattenValidationChecks(result.ValidationChecks))
+	return nil
+}
@@ -0,0 +1,126 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vmware/vcf-sdk-go/client"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+)
+
+const (
+	defaultHostCredentialsUserName       = "root"
+	defaultHostCredentialsCredentialType = "SSH"
+)
+
+type EphemeralHostCredentialsModel struct {
+	Fqdn           types.String `tfsdk:"fqdn"`
+	UserName       types.String `tfsdk:"user_name"`
+	CredentialType types.String `tfsdk:"credential_type"`
+	Id             types.String `tfsdk:"id"`
+	Password       types.String `tfsdk:"password"`
+}
+
+type EphemeralHostCredentials struct {
+	client *client.VcfClient
+}
+
+func (r *EphemeralHostCredentials) Metadata(ctx context.Context, req ephemeral.MetadataRequest, res *ephemeral.MetadataResponse) {
+	res.TypeName = "vcf_host_credentials"
+}
+
+func (r *EphemeralHostCredentials) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	sddcManagerClient, ok := req.ProviderData.(*api_client.SddcManagerClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("vcf_host_credentials requires a connection to SDDC Manager, got %T.", req.ProviderData))
+		return
+	}
+	r.client = sddcManagerClient.ApiClient
+}
+
+func (r *EphemeralHostCredentials) Schema(ctx context.Context, req ephemeral.SchemaRequest, res *ephemeral.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Description: "Fetches the current password of an ESXi host account from SDDC Manager without storing it in the state. " +
+			"Requires Terraform 1.10 or later",
+		Attributes: map[string]schema.Attribute{
+			"fqdn": schema.StringAttribute{
+				Required:    true,
+				Description: "The fully qualified domain name of the ESXi host",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"user_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The user name of the account. Defaults to root",
+			},
+			"credential_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The type of the credential. One among: SSO, SSH, API, FTP, AUDIT. Defaults to SSH",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(credentials.AllCredentialTypes()...),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the credential",
+			},
+			"password": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The current password of the account",
+			},
+		},
+	}
+}
+
+func (r *EphemeralHostCredentials) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data EphemeralHostCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to read the host credentials",
+			"the ephemeral resource requires a connection to SDDC Manager"))
+		return
+	}
+
+	if data.UserName.IsNull() || data.UserName.IsUnknown() {
+		data.UserName = types.StringValue(defaultHostCredentialsUserName)
+	}
+	if data.CredentialType.IsNull() || data.CredentialType.IsUnknown() {
+		data.CredentialType = types.StringValue(defaultHostCredentialsCredentialType)
+	}
+
+	cred, err := credentials.GetResourceCredential(ctx, r.client, credentials.ResourceTypeEsxi,
+		data.Fqdn.ValueString(), data.UserName.ValueString(), data.CredentialType.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to read the host credentials", err.Error()))
+		return
+	}
+
+	data.Id = types.StringPointerValue(cred.ID)
+	data.Password = types.StringValue(cred.Password)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,32 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEphemeralHostCredentials(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			// The values of ephemeral resources are not persisted, so the step only verifies that the lookup succeeds
+			Config: testAccEphemeralHostCredentialsConfig(),
+		}},
+	})
+}
+
+func testAccEphemeralHostCredentialsConfig() string {
+	return fmt.Sprintf(`
+	ephemeral "vcf_host_credentials" "esxi_root" {
+		fqdn = %q
+	}
+`, os.Getenv("VCF_TEST_HOST1_FQDN"))
+}
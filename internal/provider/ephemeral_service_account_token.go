@@ -0,0 +1,100 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/vmware/vcf-sdk-go/client"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+)
+
+type EphemeralServiceAccountTokenModel struct {
+	ServiceAccountId types.String `tfsdk:"service_account_id"`
+	Name             types.String `tfsdk:"name"`
+	ApiKey           types.String `tfsdk:"api_key"`
+}
+
+type EphemeralServiceAccountToken struct {
+	client *client.VcfClient
+}
+
+func (r *EphemeralServiceAccountToken) Metadata(ctx context.Context, req ephemeral.MetadataRequest, res *ephemeral.MetadataResponse) {
+	res.TypeName = "vcf_service_account_token"
+}
+
+func (r *EphemeralServiceAccountToken) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	sddcManagerClient, ok := req.ProviderData.(*api_client.SddcManagerClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("vcf_service_account_token requires a connection to SDDC Manager, got %T.", req.ProviderData))
+		return
+	}
+	r.client = sddcManagerClient.ApiClient
+}
+
+func (r *EphemeralServiceAccountToken) Schema(ctx context.Context, req ephemeral.SchemaRequest, res *ephemeral.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Description: "Fetches the API key of a service account of SDDC Manager without storing it in the state. " +
+			"Requires Terraform 1.10 or later",
+		Attributes: map[string]schema.Attribute{
+			"service_account_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the service account, e.g. the id of a vcf_service_account",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the service account",
+			},
+			"api_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The API key of the service account, exchanged for an access token at /v1/tokens",
+			},
+		},
+	}
+}
+
+func (r *EphemeralServiceAccountToken) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data EphemeralServiceAccountTokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to read the service account token",
+			"the ephemeral resource requires a connection to SDDC Manager"))
+		return
+	}
+
+	serviceAccount, err := getServiceAccount(ctx, r.client, data.ServiceAccountId.ValueString())
+	if err == nil && serviceAccount == nil {
+		err = fmt.Errorf("service account not found: %s", data.ServiceAccountId.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to read the service account token", err.Error()))
+		return
+	}
+
+	data.Name = types.StringPointerValue(serviceAccount.Name)
+	data.ApiKey = types.StringValue(serviceAccount.APIKey)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
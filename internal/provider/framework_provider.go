@@ -9,9 +9,13 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -32,7 +36,29 @@ type FrameworkProviderModel struct {
 	CloudBuilderPassword types.String `tfsdk:"cloud_builder_password"`
 	CloudBuilderHost     types.String `tfsdk:"cloud_builder_host"`
 
+	FleetManagementUsername types.String `tfsdk:"fleet_management_username"`
+	FleetManagementPassword types.String `tfsdk:"fleet_management_password"`
+	FleetManagementHost     types.String `tfsdk:"fleet_management_host"`
+
 	AllowUnverifiedTls types.Bool `tfsdk:"allow_unverified_tls"`
+
+	TaskAutoRetry     types.Int64  `tfsdk:"task_auto_retry"`
+	TaskPollInterval  types.String `tfsdk:"task_poll_interval"`
+	TaskMaxWait       types.String `tfsdk:"task_max_wait"`
+	TaskFailOnWarning types.Bool   `tfsdk:"task_fail_on_warning"`
+	TaskLockMaxWait   types.String `tfsdk:"task_lock_max_wait"`
+
+	Simulator types.Bool `tfsdk:"simulator"`
+
+	DefaultTimeouts []DefaultTimeoutsModel `tfsdk:"default_timeouts"`
+}
+
+// DefaultTimeoutsModel is the default_timeouts block of the provider.
+type DefaultTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
 }
 
 type FrameworkProvider struct {
@@ -41,10 +67,15 @@ type FrameworkProvider struct {
 	// via the ConfigureRequest
 	SddcManagerClient  *api_client.SddcManagerClient
 	CloudBuilderClient *api_client.CloudBuilderClient
+	FleetClient        *api_client.FleetClient
+
+	networkPoolRanges *networkPoolRanges
+	// defaultTimeouts are set when the provider is configured, after the resources are created
+	defaultTimeouts *operationTimeouts
 }
 
 func New() provider.Provider {
-	return &FrameworkProvider{}
+	return &FrameworkProvider{networkPoolRanges: newNetworkPoolRanges(), defaultTimeouts: &operationTimeouts{}}
 }
 
 func (frameworkProvider *FrameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, res *provider.SchemaResponse) {
@@ -122,9 +153,90 @@ func (frameworkProvider *FrameworkProvider) Schema(ctx context.Context, req prov
 						}...),
 				},
 			},
+			"fleet_management_username": schema.StringAttribute{
+				Optional:    true,
+				Description: "The username to authenticate to the fleet management of VCF 9.",
+				Validators: []validator.String{
+					getFleetManagementConflictsValidator(),
+					stringvalidator.AlsoRequires(
+						path.Expressions{
+							path.MatchRoot("fleet_management_password"),
+							path.MatchRoot("fleet_management_host"),
+						}...),
+				},
+			},
+			"fleet_management_password": schema.StringAttribute{
+				Optional:    true,
+				Description: "The password to authenticate to the fleet management of VCF 9.",
+				Validators: []validator.String{
+					getFleetManagementConflictsValidator(),
+					stringvalidator.AlsoRequires(
+						path.Expressions{
+							path.MatchRoot("fleet_management_username"),
+							path.MatchRoot("fleet_management_host"),
+						}...),
+				},
+			},
+			"fleet_management_host": schema.StringAttribute{
+				Optional: true,
+				Description: "The fully qualified domain name or IP address of the fleet management of VCF 9. The fleet " +
+					"resources and data sources manage the VCF instances of the fleet through it.",
+				Validators: []validator.String{
+					getFleetManagementConflictsValidator(),
+					stringvalidator.AlsoRequires(
+						path.Expressions{
+							path.MatchRoot("fleet_management_username"),
+							path.MatchRoot("fleet_management_password"),
+						}...),
+				},
+			},
 			"allow_unverified_tls": schema.BoolAttribute{
+				Optional: true,
+				Description: "Allow unverified TLS certificates, and the SSH connections to the SDDC Manager appliance without " +
+					"appliance_host_key, which do not verify the host key of the appliance.",
+			},
+			"task_auto_retry": schema.Int64Attribute{
+				Optional: true,
+				Description: "The number of times a failed SDDC Manager task which can be retried is retried before the " +
+					"failure is reported. Defaults to 0.",
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"task_poll_interval": schema.StringAttribute{
 				Optional:    true,
-				Description: "Allow unverified TLS certificates.",
+				Description: "The time between two reads of the status of an SDDC Manager task, e.g. 30s. Defaults to 20s.",
+			},
+			"task_max_wait": schema.StringAttribute{
+				Optional: true,
+				Description: "The time after which the provider stops waiting for an SDDC Manager task, which keeps " +
+					"running, e.g. 4h. No limit by default.",
+			},
+			"task_fail_on_warning": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the SDDC Manager tasks which complete with warnings fail. Defaults to false.",
+			},
+			"task_lock_max_wait": schema.StringAttribute{
+				Optional: true,
+				Description: "The time during which a change SDDC Manager rejects because another workflow holds a lock " +
+					"is retried every task_poll_interval, e.g. 1h. The change fails right away by default.",
+			},
+			"simulator": schema.BoolAttribute{
+				Optional: true,
+				Description: "Connect to a built-in SDDC Manager simulator with a canned inventory instead of a VCF " +
+					"instance, e.g. for tests and demos. The SDDC Manager host is ignored. Defaults to false.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"default_timeouts": schema.ListNestedBlock{
+				Description: defaultTimeoutsDescription,
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"create": schema.StringAttribute{Optional: true, Description: defaultCreateTimeoutDescription},
+						"read":   schema.StringAttribute{Optional: true, Description: defaultReadTimeoutDescription},
+						"update": schema.StringAttribute{Optional: true, Description: defaultUpdateTimeoutDescription},
+						"delete": schema.StringAttribute{Optional: true, Description: defaultDeleteTimeoutDescription},
+					},
+				},
+				Validators: []validator.List{listvalidator.SizeAtMost(1)},
 			},
 		},
 	}
@@ -137,7 +249,10 @@ func (frameworkProvider *FrameworkProvider) Metadata(ctx context.Context, req pr
 func (frameworkProvider *FrameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		func() resource.Resource {
-			return &ResourceNetworkPool{}
+			return &ResourceNetworkPool{
+				plannedRanges:   frameworkProvider.networkPoolRanges,
+				defaultTimeouts: frameworkProvider.defaultTimeouts,
+			}
 		},
 	}
 }
@@ -146,21 +261,95 @@ func (frameworkProvider *FrameworkProvider) DataSources(ctx context.Context) []f
 	return []func() datasource.DataSource{}
 }
 
+func (frameworkProvider *FrameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		func() ephemeral.EphemeralResource {
+			return &EphemeralHostCredentials{}
+		},
+		func() ephemeral.EphemeralResource {
+			return &EphemeralServiceAccountToken{}
+		},
+	}
+}
+
+func (frameworkProvider *FrameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewFunctionPasswordViolations,
+	}
+}
+
 func (frameworkProvider *FrameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, res *provider.ConfigureResponse) {
 	var data FrameworkProviderModel
 
 	res.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
+	if len(data.DefaultTimeouts) > 0 {
+		timeouts := data.DefaultTimeouts[0]
+		defaultTimeouts, err := getOperationTimeouts(timeouts.Create.ValueString(), timeouts.Read.ValueString(),
+			timeouts.Update.ValueString(), timeouts.Delete.ValueString())
+		if err != nil {
+			res.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid default timeouts", err.Error()))
+			return
+		}
+		*frameworkProvider.defaultTimeouts = defaultTimeouts
+	}
+
 	sddcManagerUsername := getAttributeValue(data.SddcManagerUsername.ValueString(), constants.VcfTestUsername).(string)
 
-	if sddcManagerUsername != "" {
+	if getAttributeValue(data.Simulator.ValueBool(), constants.VcfSimulator).(bool) {
+		// Connect to the SDDC Manager simulator
+		taskWaitSettings, err := getTaskWaitSettings(data.TaskPollInterval.ValueString(), data.TaskMaxWait.ValueString(),
+			data.TaskFailOnWarning.ValueBool(), data.TaskLockMaxWait.ValueString())
+		if err != nil {
+			res.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid task wait settings", err.Error()))
+			return
+		}
+		client, err := connectSimulator(data.SddcManagerUsername.ValueString(), data.SddcManagerPassword.ValueString(),
+			int(data.TaskAutoRetry.ValueInt64()), taskWaitSettings)
+		if err != nil {
+			res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to connect to the SDDC Manager simulator", err.Error()))
+			return
+		}
+
+		frameworkProvider.SddcManagerClient = client
+		res.ResourceData = client
+		res.EphemeralResourceData = client
+	} else if fleetHost := data.FleetManagementHost.ValueString(); fleetHost != "" {
+		// Connect to the fleet management
+		taskWaitSettings, err := getTaskWaitSettings(data.TaskPollInterval.ValueString(), data.TaskMaxWait.ValueString(),
+			data.TaskFailOnWarning.ValueBool(), data.TaskLockMaxWait.ValueString())
+		if err != nil {
+			res.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid task wait settings", err.Error()))
+			return
+		}
+		client := api_client.NewFleetClient(
+			data.FleetManagementUsername.ValueString(),
+			data.FleetManagementPassword.ValueString(),
+			fleetHost,
+			getAttributeValue(data.AllowUnverifiedTls.ValueBool(), constants.VcfTestAllowUnverifiedTls).(bool),
+		).WithTaskWaitSettings(taskWaitSettings)
+
+		if err := client.Connect(); err != nil {
+			res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to connect to the fleet management", err.Error()))
+		}
+
+		frameworkProvider.FleetClient = client
+		res.ResourceData = client
+	} else if sddcManagerUsername != "" {
 		// Connect to SDDC Manager
+		taskWaitSettings, err := getTaskWaitSettings(data.TaskPollInterval.ValueString(), data.TaskMaxWait.ValueString(),
+			data.TaskFailOnWarning.ValueBool(), data.TaskLockMaxWait.ValueString())
+		if err != nil {
+			res.Diagnostics.Append(diag.NewErrorDiagnostic("Invalid task wait settings", err.Error()))
+			return
+		}
 		client := api_client.NewSddcManagerClient(
 			sddcManagerUsername,
 			getAttributeValue(data.SddcManagerPassword.ValueString(), constants.VcfTestPassword).(string),
 			getAttributeValue(data.SddcManagerHost.ValueString(), constants.VcfTestUrl).(string),
 			getAttributeValue(data.AllowUnverifiedTls.ValueBool(), constants.VcfTestAllowUnverifiedTls).(bool),
-		)
+		).WithTaskAutoRetry(int(data.TaskAutoRetry.ValueInt64())).
+			WithTaskWaitSettings(taskWaitSettings)
 
 		if err := client.Connect(); err != nil {
 			res.Diagnostics.Append(diag.NewErrorDiagnostic("Failed to connect to the SDDC Manager", err.Error()))
@@ -168,6 +357,7 @@ func (frameworkProvider *FrameworkProvider) Configure(ctx context.Context, req p
 
 		frameworkProvider.SddcManagerClient = client
 		res.ResourceData = client
+		res.EphemeralResourceData = client
 	} else {
 		// Connect to Cloud Builder
 		client := api_client.NewCloudBuilderClient(
@@ -211,3 +401,15 @@ func getCloudBuilderConflictsValidator() validator.String {
 			path.MatchRoot("sddc_manager_host"),
 		}...)
 }
+
+func getFleetManagementConflictsValidator() validator.String {
+	return stringvalidator.ConflictsWith(
+		path.Expressions{
+			path.MatchRoot("sddc_manager_username"),
+			path.MatchRoot("sddc_manager_password"),
+			path.MatchRoot("sddc_manager_host"),
+			path.MatchRoot("cloud_builder_username"),
+			path.MatchRoot("cloud_builder_password"),
+			path.MatchRoot("cloud_builder_host"),
+		}...)
+}
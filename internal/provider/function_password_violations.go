@@ -0,0 +1,74 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+// FunctionPasswordViolations checks a password against a password policy of VCF, e.g. in a validation block of a variable
+// or in a precondition of a resource which takes a password the provider cannot check.
+type FunctionPasswordViolations struct{}
+
+func NewFunctionPasswordViolations() function.Function {
+	return &FunctionPasswordViolations{}
+}
+
+func (f *FunctionPasswordViolations) Metadata(ctx context.Context, req function.MetadataRequest, res *function.MetadataResponse) {
+	res.Name = "password_violations"
+}
+
+func (f *FunctionPasswordViolations) Definition(ctx context.Context, req function.DefinitionRequest, res *function.DefinitionResponse) {
+	res.Definition = function.Definition{
+		Summary: "Returns the violations of a VCF password policy by a password",
+		Description: "Returns the violations of the password policy by the password, an empty list if the password complies. " +
+			"The password must not contain the host names of the FQDNs either, e.g. the one of the appliance it is set on. " +
+			"Requires Terraform 1.8 or later",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "password",
+				Description: "The password to check",
+			},
+			function.StringParameter{
+				Name:        "policy",
+				Description: "The password policy. One among: " + strings.Join(validationUtils.PasswordPolicyNames(), ", "),
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "fqdns",
+			Description: "The FQDNs whose host names, i.e. first labels, the password must not contain",
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *FunctionPasswordViolations) Run(ctx context.Context, req function.RunRequest, res *function.RunResponse) {
+	var password, policyName string
+	var fqdns []string
+	res.Error = function.ConcatFuncErrors(res.Error, req.Arguments.Get(ctx, &password, &policyName, &fqdns))
+	if res.Error != nil {
+		return
+	}
+
+	policy, ok := validationUtils.PasswordPolicies()[policyName]
+	if !ok {
+		res.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unknown password policy %q, expected one among: %s",
+			policyName, strings.Join(validationUtils.PasswordPolicyNames(), ", ")))
+		return
+	}
+
+	violations := make([]string, 0)
+	for _, err := range policy.Check(password, fqdns...) {
+		violations = append(violations, err.Error())
+	}
+	res.Error = function.ConcatFuncErrors(res.Error, res.Result.Set(ctx, violations))
+}
@@ -0,0 +1,48 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func runPasswordViolations(t *testing.T, password, policy string, fqdns ...string) (types.List, *function.FuncError) {
+	// The variadic arguments are passed as a tuple
+	fqdnTypes := make([]attr.Type, 0)
+	fqdnValues := make([]attr.Value, 0)
+	for _, fqdn := range fqdns {
+		fqdnTypes = append(fqdnTypes, types.StringType)
+		fqdnValues = append(fqdnValues, types.StringValue(fqdn))
+	}
+	fqdnTuple, diags := types.TupleValue(fqdnTypes, fqdnValues)
+	assert.False(t, diags.HasError())
+
+	res := &function.RunResponse{Result: function.NewResultData(types.ListUnknown(types.StringType))}
+	NewFunctionPasswordViolations().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(password), types.StringValue(policy), fqdnTuple}),
+	}, res)
+	return res.Result.Value().(types.List), res.Error
+}
+
+func TestFunctionPasswordViolations(t *testing.T) {
+	violations, err := runPasswordViolations(t, "VMware123!VMware123!", "nsx", "sfo-m01-nsx01.rainpole.io")
+	assert.Nil(t, err)
+	assert.Empty(t, violations.Elements())
+
+	violations, err = runPasswordViolations(t, "Sfo-M01-Nsx01", "nsx", "sfo-m01-nsx01.rainpole.io")
+	assert.Nil(t, err)
+	assert.Equal(t, []attr.Value{
+		types.StringValue("the password must not contain the host name \"sfo-m01-nsx01\" of the FQDN sfo-m01-nsx01.rainpole.io"),
+	}, violations.Elements())
+
+	_, err = runPasswordViolations(t, "VMware123!VMware123!", "vsan")
+	assert.NotNil(t, err)
+}
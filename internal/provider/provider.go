@@ -6,17 +6,25 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/simulator"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 // Provider returns the resource configuration of the provider.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"sddc_manager_username": {
 				Type:          schema.TypeString,
@@ -66,23 +74,122 @@ func Provider() *schema.Provider {
 				RequiredWith:  []string{"cloud_builder_username", "cloud_builder_password"},
 				DefaultFunc:   schema.EnvDefaultFunc(constants.CloudBuilderTestUrl, nil),
 			},
+			"fleet_management_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The username to authenticate to the fleet management of VCF 9.",
+				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_host",
+					"cloud_builder_username", "cloud_builder_password", "cloud_builder_host"},
+				RequiredWith: []string{"fleet_management_password", "fleet_management_host"},
+			},
+			"fleet_management_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The password to authenticate to the fleet management of VCF 9.",
+				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_host",
+					"cloud_builder_username", "cloud_builder_password", "cloud_builder_host"},
+				RequiredWith: []string{"fleet_management_username", "fleet_management_host"},
+			},
+			"fleet_management_host": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The fully qualified domain name or IP address of the fleet management of VCF 9. The fleet " +
+					"resources and data sources manage the VCF instances of the fleet through it.",
+				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_host",
+					"cloud_builder_username", "cloud_builder_password", "cloud_builder_host"},
+				RequiredWith: []string{"fleet_management_username", "fleet_management_password"},
+			},
 			"allow_unverified_tls": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Allow unverified TLS certificates, and the SSH connections to the SDDC Manager appliance without " +
+					"appliance_host_key, which do not verify the host key of the appliance.",
+				DefaultFunc: schema.EnvDefaultFunc(constants.VcfTestAllowUnverifiedTls, false),
+			},
+			"task_auto_retry": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "The number of times a failed SDDC Manager task which can be retried is retried before the " +
+					"failure is reported. Defaults to 0.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"task_poll_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The time between two reads of the status of an SDDC Manager task, e.g. 30s. Defaults to 20s.",
+				ValidateFunc: validationutils.ValidatePositiveDuration,
+			},
+			"task_max_wait": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The time after which the provider stops waiting for an SDDC Manager task, which keeps " +
+					"running, e.g. 4h. No limit by default.",
+				ValidateFunc: validationutils.ValidatePositiveDuration,
+			},
+			"task_fail_on_warning": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Allow unverified TLS certificates.",
-				DefaultFunc: schema.EnvDefaultFunc(constants.VcfTestAllowUnverifiedTls, false),
+				Description: "Whether the SDDC Manager tasks which complete with warnings fail. Defaults to false.",
+			},
+			"task_lock_max_wait": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The time during which a change SDDC Manager rejects because another workflow holds a lock " +
+					"is retried every task_poll_interval, e.g. 1h. The change fails right away by default.",
+				ValidateFunc: validationutils.ValidatePositiveDuration,
+			},
+			"simulator": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Connect to a built-in SDDC Manager simulator with a canned inventory instead of a VCF " +
+					"instance, e.g. for tests and demos. The SDDC Manager host is ignored. Defaults to false.",
+				ConflictsWith: []string{"cloud_builder_username", "cloud_builder_password", "cloud_builder_host"},
+				DefaultFunc:   schema.EnvDefaultFunc(constants.VcfSimulator, false),
 			},
+			"default_timeouts": defaultTimeoutsSchema(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"vcf_cluster":      DataSourceCluster(),
-			"vcf_domain":       DataSourceDomain(),
-			"vcf_credentials":  DataSourceCredentials(),
-			"vcf_network_pool": DataSourceNetworkPool(),
-			"vcf_certificate":  DataSourceCertificate(),
+			"vcf_backups":                  DataSourceBackups(),
+			"vcf_bundles":                  DataSourceBundles(),
+			"vcf_cluster":                  DataSourceCluster(),
+			"vcf_domain":                   DataSourceDomain(),
+			"vcf_domain_ready":             DataSourceDomainReady(),
+			"vcf_instance_spec":            DataSourceInstanceSpec(),
+			"vcf_cloud_builder_status":     DataSourceCloudBuilderStatus(),
+			"vcf_credentials":              DataSourceCredentials(),
+			"vcf_credentials_expiration":   DataSourceCredentialsExpiration(),
+			"vcf_credentials_tasks":        DataSourceCredentialsTasks(),
+			"vcf_fleet_instances":          DataSourceFleetInstances(),
+			"vcf_health_check":             DataSourceHealthCheck(),
+			"vcf_lcm_disk_usage":           DataSourceLcmDiskUsage(),
+			"vcf_manifest":                 DataSourceManifest(),
+			"vcf_network_pool":             DataSourceNetworkPool(),
+			"vcf_personalities":            DataSourcePersonalities(),
+			"vcf_releases":                 DataSourceReleases(),
+			"vcf_resource_functionalities": DataSourceResourceFunctionalities(),
+			"vcf_resource_warnings":        DataSourceResourceWarnings(),
+			"vcf_roles":                    DataSourceRoles(),
+			"vcf_sso_domains":              DataSourceSsoDomains(),
+			"vcf_tasks":                    DataSourceTasks(),
+			"vcf_license_keys":             DataSourceLicenseKeys(),
+			"vcf_ceip":                     DataSourceCeip(),
+			"vcf_remote_datastores":        DataSourceRemoteDatastores(),
+			"vcf_sddc_manager":             DataSourceSddcManager(),
+			"vcf_upgradables":              DataSourceUpgradables(),
+			"vcf_user":                     DataSourceUser(),
+			"vcf_validation":               DataSourceValidation(),
+			"vcf_certificates":             DataSourceCertificates(),
+			"vcf_certificate":              deprecatedAlias("vcf_certificates", DataSourceCertificates()),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"vcf_backup_configuration":           ResourceBackupConfiguration(),
+			"vcf_backup_credentials":             ResourceBackupCredentials(),
+			"vcf_backup_restore":                 ResourceBackupRestore(),
+			"vcf_bundle_cleanup":                 ResourceBundleCleanup(),
+			"vcf_bundle_download":                ResourceBundleDownload(),
+			"vcf_bundle_upload":                  ResourceBundleUpload(),
 			"vcf_certificate":                    ResourceCertificate(),
 			"vcf_certificate_authority":          ResourceCertificateAuthority(),
 			"vcf_ceip":                           ResourceCeip(),
@@ -90,32 +197,100 @@ func Provider() *schema.Provider {
 			"vcf_cluster_personality":            ResourceClusterPersonality(),
 			"vcf_credentials_auto_rotate_policy": ResourceCredentialsAutoRotatePolicy(),
 			"vcf_credentials_rotate":             ResourceCredentialsRotate(),
+			"vcf_credentials_rotation":           ResourceCredentialsRotation(),
+			"vcf_credentials_task_recovery":      ResourceCredentialsTaskRecovery(),
 			"vcf_credentials_update":             ResourceCredentialsUpdate(),
 			"vcf_csr":                            ResourceCsr(),
 			"vcf_domain":                         ResourceDomain(),
 			"vcf_edge_cluster":                   ResourceEdgeCluster(),
 			"vcf_external_certificate":           ResourceExternalCertificate(),
+			"vcf_fleet_operation":                ResourceFleetOperation(),
+			"vcf_group":                          ResourceGroup(),
 			"vcf_host":                           ResourceHost(),
+			"vcf_identity_provider":              ResourceIdentityProvider(),
+			"vcf_identity_source":                ResourceIdentitySource(),
+			"vcf_license_key":                    ResourceLicenseKey(),
+			"vcf_tag_category":                   ResourceTagCategory(),
+			"vcf_tag":                            ResourceTag(),
+			"vcf_tag_assignment":                 ResourceTagAssignment(),
 			"vcf_instance":                       ResourceVcfInstance(),
+			"vcf_local_account":                  ResourceLocalAccount(),
+			"vcf_offline_depot":                  ResourceOfflineDepot(),
+			"vcf_sddc_manager_upgrade":           ResourceSddcManagerUpgrade(),
+			"vcf_service_account":                ResourceServiceAccount(),
+			"vcf_system_precheck":                ResourceSystemPrecheck(),
+			"vcf_upgrade":                        ResourceUpgrade(),
 			"vcf_user":                           ResourceUser(),
 		},
+	}
 
-		ConfigureContextFunc: providerConfigure,
+	for _, r := range p.ResourcesMap {
+		withOperationContext(r)
 	}
+
+	p.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		timeouts, err := getSdkOperationTimeouts(data)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		for _, r := range p.ResourcesMap {
+			timeouts.applyTo(r)
+		}
+		return providerConfigure(ctx, data)
+	}
+
+	return p
 }
 
 func providerConfigure(_ context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	sddcManagerUsername, isVcfUsernameSet := data.GetOk("sddc_manager_username")
 	allowUnverifiedTLS := data.Get("allow_unverified_tls")
+	if data.Get("simulator").(bool) {
+		taskWaitSettings, err := getTaskWaitSettings(data.Get("task_poll_interval").(string),
+			data.Get("task_max_wait").(string), data.Get("task_fail_on_warning").(bool),
+			data.Get("task_lock_max_wait").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		sddcManagerClient, err := connectSimulator(data.Get("sddc_manager_username").(string),
+			data.Get("sddc_manager_password").(string), data.Get("task_auto_retry").(int), taskWaitSettings)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return sddcManagerClient, nil
+	}
+	if fleetHost, isFleetHostSet := data.GetOk("fleet_management_host"); isFleetHostSet {
+		taskWaitSettings, err := getTaskWaitSettings(data.Get("task_poll_interval").(string),
+			data.Get("task_max_wait").(string), data.Get("task_fail_on_warning").(bool),
+			data.Get("task_lock_max_wait").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		fleetClient := api_client.NewFleetClient(data.Get("fleet_management_username").(string),
+			data.Get("fleet_management_password").(string), fleetHost.(string), allowUnverifiedTLS.(bool)).
+			WithTaskWaitSettings(taskWaitSettings)
+		if err = fleetClient.Connect(); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return fleetClient, nil
+	}
 	if isVcfUsernameSet {
 		password, isSetPassword := data.GetOk("sddc_manager_password")
 		hostName, isSetHost := data.GetOk("sddc_manager_host")
 		if !isVcfUsernameSet || !isSetPassword || !isSetHost {
 			return nil, diag.Errorf("SDDC Manager username, password, and host must be provided.")
 		}
+		taskWaitSettings, err := getTaskWaitSettings(data.Get("task_poll_interval").(string),
+			data.Get("task_max_wait").(string), data.Get("task_fail_on_warning").(bool),
+			data.Get("task_lock_max_wait").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 		var sddcManagerClient = api_client.NewSddcManagerClient(sddcManagerUsername.(string), password.(string),
-			hostName.(string), allowUnverifiedTLS.(bool))
-		err := sddcManagerClient.Connect()
+			hostName.(string), allowUnverifiedTLS.(bool)).
+			WithTaskAutoRetry(data.Get("task_auto_retry").(int)).
+			WithTaskWaitSettings(taskWaitSettings)
+		err = sddcManagerClient.Connect()
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
@@ -132,3 +307,93 @@ func providerConfigure(_ context.Context, data *schema.ResourceData) (interface{
 		return cloudBuilderClient, nil
 	}
 }
+
+// deprecatedAlias keeps a resource or a data source registered under its former name once it has been renamed,
+// the configurations using the former name get a deprecation warning and keep working until the alias is removed.
+func deprecatedAlias(name string, r *schema.Resource) *schema.Resource {
+	r.DeprecationMessage = fmt.Sprintf("Use %s instead, this name is an alias which will be removed in a future release.", name)
+	return r
+}
+
+// removeFromState removes a resource deleted outside of Terraform from the state when it is read, the warning tells
+// why the plan creates it again.
+func removeFromState(data *schema.ResourceData, kind string) diag.Diagnostics {
+	log.Printf("%s %s not found, removing it from the state", kind, data.Id())
+	diags := diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s %s not found", kind, data.Id()),
+		Detail:   fmt.Sprintf("The %s was deleted outside of Terraform, it is removed from the state.", strings.ToLower(kind)),
+	}}
+	data.SetId("")
+	return diags
+}
+
+// creationTaskDiagnostics returns the error of the creation of a resource. When the wait for the creation task stopped
+// while the task keeps running in SDDC Manager, e.g. on the create timeout, the detail tells that the next apply
+// waits for the task instead of creating the resource again.
+func creationTaskDiagnostics(err error, kind string) diag.Diagnostics {
+	diags := diag.FromErr(err)
+	var interrupted *api_client.TaskInterruptedError
+	if errors.As(err, &interrupted) && !interrupted.Cancelled {
+		diags[0].Detail = fmt.Sprintf("The creation task %s of the %s is still running in SDDC Manager. The next apply "+
+			"waits for the task to complete instead of creating the %s again.", interrupted.TaskID, strings.ToLower(kind),
+			strings.ToLower(kind))
+	}
+	return diags
+}
+
+// connectSimulator connects to the SDDC Manager simulator of the process, which accepts any credentials and
+// serves a self-signed certificate. Empty credentials are replaced by the default ones of SDDC Manager.
+func connectSimulator(username, password string, taskAutoRetry int,
+	taskWaitSettings api_client.TaskWaitSettings) (*api_client.SddcManagerClient, error) {
+	if len(username) == 0 {
+		username = "admin@local"
+	}
+	sddcManagerClient := api_client.NewSddcManagerClient(username, password, simulator.Shared().Host(), true).
+		WithTaskAutoRetry(taskAutoRetry).
+		WithTaskWaitSettings(taskWaitSettings)
+	if err := sddcManagerClient.Connect(); err != nil {
+		return nil, err
+	}
+	return sddcManagerClient, nil
+}
+
+// withOperationContext makes the changes of the resource wait for the locks held by other SDDC Manager workflows
+// until the operation times out rather than until the API call does.
+func withOperationContext(r *schema.Resource) {
+	r.CreateContext = wrapWithOperationContext(r.CreateContext)
+	r.UpdateContext = wrapWithOperationContext(r.UpdateContext)
+	r.DeleteContext = wrapWithOperationContext(r.DeleteContext)
+}
+
+func wrapWithOperationContext[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](operation F) F {
+	if operation == nil {
+		return nil
+	}
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return operation(api_client.WithOperationContext(ctx), data, meta)
+	}
+}
+
+// getTaskWaitSettings parses the task wait arguments of the provider. Empty durations keep the defaults.
+func getTaskWaitSettings(pollInterval, maxWait string, failOnWarning bool,
+	lockMaxWait string) (api_client.TaskWaitSettings, error) {
+	settings := api_client.TaskWaitSettings{FailOnWarning: failOnWarning}
+	var err error
+	if len(pollInterval) > 0 {
+		if settings.PollInterval, err = time.ParseDuration(pollInterval); err != nil {
+			return settings, err
+		}
+	}
+	if len(maxWait) > 0 {
+		if settings.MaxWait, err = time.ParseDuration(maxWait); err != nil {
+			return settings, err
+		}
+	}
+	if len(lockMaxWait) > 0 {
+		if settings.LockMaxWait, err = time.ParseDuration(lockMaxWait); err != nil {
+			return settings, err
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,980 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/cluster"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/domain"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	"github.com/vmware/terraform-provider-vcf/internal/simulator"
+)
+
+func testSimulatorMeta(t *testing.T) interface{} {
+	meta, diags := providerConfigure(context.Background(),
+		schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"simulator": true}))
+	if diags.HasError() {
+		t.Fatalf("cannot connect to the simulator: %v", diags)
+	}
+	assert.IsType(t, &api_client.SddcManagerClient{}, meta)
+	return meta
+}
+
+func TestSimulatorSddcManager(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	data := schema.TestResourceDataRaw(t, DataSourceSddcManager().Schema, map[string]interface{}{})
+	diags := dataSddcManagerRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, simulator.SddcManagerFqdn, data.Get("fqdn"))
+	assert.Equal(t, simulator.ManagementDomainId, data.Get("domain_id"))
+	assert.Equal(t, simulator.VcfVersion, data.Get("vcf_version"))
+}
+
+func TestSimulatorNetworkPool(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	data := schema.TestResourceDataRaw(t, DataSourceNetworkPool().Schema, map[string]interface{}{"name": "sfo-m01-np01"})
+	diags := dataSourceNetworkPoolRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, simulator.ManagementPoolId, data.Id())
+	assert.Equal(t, 2, data.Get("network.#"))
+}
+
+func TestSimulatorDomainExclude(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	data := schema.TestResourceDataRaw(t, DataSourceDomain().Schema, map[string]interface{}{
+		"name":    "sfo-m01",
+		"exclude": []interface{}{"cluster.host", "nsx_configuration"},
+	})
+	diags := dataSourceDomainRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, simulator.ManagementDomainId, data.Id())
+	assert.Equal(t, 1, data.Get("cluster.#"))
+	assert.Equal(t, "sfo-m01-cl01", data.Get("cluster.0.name"))
+	assert.Equal(t, 0, data.Get("cluster.0.host.#"))
+	assert.Equal(t, 0, data.Get("nsx_configuration.#"))
+}
+
+func TestSimulatorClusterExclude(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	data := schema.TestResourceDataRaw(t, DataSourceCluster().Schema, map[string]interface{}{
+		"cluster_id": simulator.ManagementClusterId,
+	})
+	diags := dataSourceClusterRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 4, data.Get("host.#"))
+	assert.Equal(t, simulator.ManagementDomainId, data.Get("domain_id"))
+
+	data = schema.TestResourceDataRaw(t, DataSourceCluster().Schema, map[string]interface{}{
+		"cluster_id": simulator.ManagementClusterId,
+		"exclude":    []interface{}{"host", "vds"},
+	})
+	diags = dataSourceClusterRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 0, data.Get("host.#"))
+	assert.Equal(t, "sfo-m01-cl01-ds-vsan01", data.Get("primary_datastore_name"))
+}
+
+func TestSimulatorCertificates(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	// The certificates of all the domains
+	data := schema.TestResourceDataRaw(t, DataSourceCertificates().Schema, map[string]interface{}{})
+	diags := dataCertificatesRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, allDomainsCertificatesId, data.Id())
+	assert.Equal(t, 3, data.Get("certificate.#"))
+	assert.Equal(t, "sfo-m01-vc01.sfo.rainpole.io", data.Get("certificate.0.issued_to"))
+
+	// The certificate of a resource, searched in all the domains
+	data = schema.TestResourceDataRaw(t, DataSourceCertificates().Schema, map[string]interface{}{
+		"resource_fqdn": "sfo-m01-nsx01.sfo.rainpole.io",
+	})
+	diags = dataCertificatesRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1, data.Get("certificate.#"))
+	assert.Equal(t, "sfo-m01-nsx01.sfo.rainpole.io", data.Get("certificate.0.subject_cn"))
+
+	data = schema.TestResourceDataRaw(t, DataSourceCertificates().Schema, map[string]interface{}{
+		"resource_fqdn": "sfo-w01-vc01.sfo.rainpole.io",
+	})
+	diags = dataCertificatesRead(context.Background(), data, meta)
+	assert.True(t, diags.HasError())
+
+	// The certificates of a domain
+	data = schema.TestResourceDataRaw(t, DataSourceCertificates().Schema, map[string]interface{}{
+		"domain_id": simulator.ManagementDomainId,
+	})
+	diags = dataCertificatesRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, simulator.ManagementDomainId, data.Id())
+	assert.Equal(t, 3, data.Get("certificate.#"))
+}
+
+func TestSimulatorDeletedOutsideTerraform(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	for name, read := range map[string]schema.ReadContextFunc{
+		"vcf_domain":  resourceDomainRead,
+		"vcf_cluster": resourceClusterRead,
+		"vcf_host":    resourceHostRead,
+	} {
+		data := schema.TestResourceDataRaw(t, Provider().ResourcesMap[name].Schema, map[string]interface{}{})
+		data.SetId("00000000-0000-0000-0000-deleted00001")
+		diags := read(context.Background(), data, meta)
+		assert.False(t, diags.HasError(), "%s: %v", name, diags)
+		if assert.Len(t, diags, 1, name) {
+			assert.Equal(t, diag.Warning, diags[0].Severity, name)
+		}
+		assert.Empty(t, data.Id(), name)
+	}
+}
+
+func TestSimulatorApiVersions(t *testing.T) {
+	vcfClient := testSimulatorMeta(t).(*api_client.SddcManagerClient)
+	vcfVersion, err := vcfClient.VcfVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, simulator.VcfVersion, vcfVersion.String())
+	assert.True(t, vcfClient.Supports(context.Background(), api_client.ResourceCertificatesApi))
+	assert.NoError(t, vcfClient.Require(context.Background(), api_client.PasswordExpirationApi))
+
+	// VCF 4.x has the former certificates API, the certificates lack their expiration status
+	legacySimulator := simulator.New()
+	defer legacySimulator.Close()
+	legacySimulator.SetVersion("4.5.2.0-22223457")
+	legacyClient := api_client.NewSddcManagerClient("admin@local", "", legacySimulator.Host(), true)
+	assert.NoError(t, legacyClient.Connect())
+
+	assert.False(t, legacyClient.Supports(context.Background(), api_client.ResourceCertificatesApi))
+	assert.ErrorContains(t, legacyClient.Require(context.Background(), api_client.PasswordExpirationApi),
+		"requires VCF 5.0.0 or later")
+
+	data := schema.TestResourceDataRaw(t, DataSourceCertificates().Schema, map[string]interface{}{
+		"domain_id": simulator.ManagementDomainId,
+	})
+	diags := dataCertificatesRead(context.Background(), data, legacyClient)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1, legacySimulator.RequestCount(http.MethodGet,
+		"/v1/domains/"+simulator.ManagementDomainId+"/certificates"))
+	assert.Equal(t, 1, data.Get("certificate.#"))
+	assert.Equal(t, "ACTIVE", data.Get("certificate.0.expiration_status"))
+	assert.Positive(t, data.Get("certificate.0.number_of_days_to_expire"))
+}
+
+func TestSimulatorApiVersionReadOnce(t *testing.T) {
+	versionSimulator := simulator.New()
+	defer versionSimulator.Close()
+	versionSimulator.SetVersion("not a version")
+	vcfClient := testIsolatedSimulatorMeta(t, versionSimulator)
+	reads := versionSimulator.RequestCount(http.MethodGet, "/v1/sddc-managers")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := vcfClient.VcfVersion(context.Background())
+			assert.ErrorContains(t, err, `invalid version "not a version" of SDDC Manager`)
+		}()
+	}
+	wg.Wait()
+
+	// The error is kept for the life of the client
+	versionSimulator.SetVersion("5.2.1.0-24307856")
+	_, _ = vcfClient.FindRetryableTask(context.Background(), "DOMAIN", simulator.ManagementDomainId)
+	_, err := vcfClient.VcfVersion(context.Background())
+	assert.ErrorContains(t, err, "invalid version")
+	assert.Equal(t, 1, versionSimulator.RequestCount(http.MethodGet, "/v1/sddc-managers")-reads)
+
+	// The version is read whatever the context of the first caller
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	vcfVersion, err := testIsolatedSimulatorMeta(t, versionSimulator).VcfVersion(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "5.2.1.0", vcfVersion.String())
+}
+
+func TestSimulatorLicenseKey(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	data := schema.TestResourceDataRaw(t, ResourceLicenseKey().Schema, map[string]interface{}{
+		"product_type": "VCENTER",
+		"key":          "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE",
+		"description":  "vCenter Server license key",
+	})
+	diags := resourceLicenseKeyCreate(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, data.Id())
+	assert.Equal(t, "NEVER_EXPIRES", data.Get("status"))
+
+	diags = resourceLicenseKeyDelete(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+
+	diags = resourceLicenseKeyRead(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, data.Id())
+}
+
+func TestSimulatorResumeCreation(t *testing.T) {
+	vcfClient := testSimulatorMeta(t).(*api_client.SddcManagerClient)
+
+	// The management domain and its cluster were created successfully, there is nothing to resume
+	domainId, err := domain.ResumeDomainCreation(context.Background(), vcfClient, "sfo-m01")
+	assert.NoError(t, err)
+	assert.Empty(t, domainId)
+
+	clusterId, err := cluster.ResumeClusterCreation(context.Background(), vcfClient, simulator.ManagementDomainId, "sfo-m01-cl01")
+	assert.NoError(t, err)
+	assert.Empty(t, clusterId)
+
+	task, err := vcfClient.FindRetryableTask(context.Background(), "CLUSTER", simulator.ManagementClusterId)
+	assert.NoError(t, err)
+	assert.Nil(t, task)
+}
+
+func TestSimulatorReadCache(t *testing.T) {
+	vcfClient := testSimulatorMeta(t).(*api_client.SddcManagerClient)
+	getDomains := func() {
+		_, err := vcfClient.ApiClient.Domains.GetDomains(domains.NewGetDomainsParamsWithContext(context.Background()).
+			WithTimeout(constants.DefaultVcfApiCallTimeout))
+		assert.NoError(t, err)
+	}
+	requestCount := func() int {
+		return simulator.Shared().RequestCount(http.MethodGet, "/v1/domains")
+	}
+
+	// The identical lookups, concurrent or not, are served by a single call
+	before := requestCount()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			getDomains()
+		}()
+	}
+	wg.Wait()
+	getDomains()
+	assert.Equal(t, before+1, requestCount())
+
+	// A change empties the cache
+	data := schema.TestResourceDataRaw(t, ResourceLicenseKey().Schema, map[string]interface{}{
+		"product_type": "NSXT",
+		"key":          "FFFFF-GGGGG-HHHHH-JJJJJ-KKKKK",
+		"description":  "NSX license key",
+	})
+	diags := resourceLicenseKeyCreate(context.Background(), data, vcfClient)
+	assert.False(t, diags.HasError(), "%v", diags)
+	getDomains()
+	assert.Equal(t, before+2, requestCount())
+
+	diags = resourceLicenseKeyDelete(context.Background(), data, vcfClient)
+	assert.False(t, diags.HasError(), "%v", diags)
+}
+
+func TestSimulatorLockWait(t *testing.T) {
+	p := Provider()
+	configure := func(config map[string]interface{}) interface{} {
+		config["simulator"] = true
+		meta, diags := p.ConfigureContextFunc(context.Background(), schema.TestResourceDataRaw(t, p.Schema, config))
+		assert.False(t, diags.HasError(), "%v", diags)
+		return meta
+	}
+	licenseKey := func() *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, ResourceLicenseKey().Schema, map[string]interface{}{
+			"product_type": "VSAN",
+			"key":          "LLLLL-MMMMM-NNNNN-PPPPP-QQQQQ",
+			"description":  "vSAN license key",
+		})
+	}
+	licenseKeys := p.ResourcesMap["vcf_license_key"]
+
+	// The changes fail right away by default
+	meta := configure(map[string]interface{}{})
+	simulator.Shared().RejectChangesAsLocked(1)
+	diags := licenseKeys.CreateContext(context.Background(), licenseKey(), meta)
+	assert.True(t, diags.HasError())
+
+	// The changes are retried while another workflow holds the lock
+	meta = configure(map[string]interface{}{"task_poll_interval": "10ms", "task_lock_max_wait": "1m"})
+	before := simulator.Shared().RequestCount(http.MethodPost, "/v1/license-keys")
+	simulator.Shared().RejectChangesAsLocked(2)
+	data := licenseKey()
+	diags = licenseKeys.CreateContext(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, data.Id())
+	assert.Equal(t, before+3, simulator.Shared().RequestCount(http.MethodPost, "/v1/license-keys"))
+
+	diags = licenseKeys.DeleteContext(context.Background(), data, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+}
+
+func TestSimulatorValidation(t *testing.T) {
+	meta, diags := providerConfigure(context.Background(), schema.TestResourceDataRaw(t, Provider().Schema,
+		map[string]interface{}{"simulator": true, "task_poll_interval": "10ms"}))
+	assert.False(t, diags.HasError(), "%v", diags)
+	validate := func(specType, spec string, failOnFailure bool) (*schema.ResourceData, diag.Diagnostics) {
+		data := schema.TestResourceDataRaw(t, DataSourceValidation().Schema, map[string]interface{}{
+			"spec_type":       specType,
+			"spec":            spec,
+			"fail_on_failure": failOnFailure,
+		})
+		return data, DataSourceValidation().ReadContext(context.Background(), data, meta)
+	}
+	hostSpecs := func(fqdn string) string {
+		return fmt.Sprintf(`[{"fqdn": %q, "networkPoolId": %q, "username": "root", "password": "VMware123!", `+
+			`"storageType": "VSAN"}]`, fqdn, simulator.ManagementPoolId)
+	}
+
+	data, diags := validate("HOST_COMMISSION", hostSpecs("sfo01-w01-esx01.sfo.rainpole.io"), true)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, data.Get("valid").(bool))
+	assert.Equal(t, "SUCCEEDED", data.Get("result_status"))
+	assert.Equal(t, 1, data.Get("checks.#"))
+
+	// A commissioned host fails the validation, which is reported in the results unless it fails the read
+	data, diags = validate("HOST_COMMISSION", hostSpecs("sfo01-m01-esx01.sfo.rainpole.io"), false)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.False(t, data.Get("valid").(bool))
+	assert.Equal(t, "host sfo01-m01-esx01.sfo.rainpole.io is already commissioned", data.Get("checks.0.error_messages.0"))
+	_, diags = validate("HOST_COMMISSION", hostSpecs("sfo01-m01-esx01.sfo.rainpole.io"), true)
+	assert.True(t, diags.HasError())
+
+	// The specs missing required properties are not sent
+	before := simulator.Shared().RequestCount(http.MethodPost, "/v1/hosts/validations")
+	_, diags = validate("HOST_COMMISSION", `[{"fqdn": "sfo01-w01-esx02.sfo.rainpole.io"}]`, false)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "networkPoolId in body is required")
+	assert.Equal(t, before, simulator.Shared().RequestCount(http.MethodPost, "/v1/hosts/validations"))
+}
+
+func TestSimulatorFleet(t *testing.T) {
+	fleetSimulator := simulator.New()
+	defer fleetSimulator.Close()
+
+	p := Provider()
+	meta, diags := p.ConfigureContextFunc(context.Background(), schema.TestResourceDataRaw(t, p.Schema,
+		map[string]interface{}{
+			"fleet_management_host":     fleetSimulator.Host(),
+			"fleet_management_username": "admin@local",
+			"fleet_management_password": "VMware123!VMware123!",
+			"allow_unverified_tls":      true,
+			"task_poll_interval":        "10ms",
+		}))
+	assert.False(t, diags.HasError(), "%v", diags)
+
+	instances := schema.TestResourceDataRaw(t, DataSourceFleetInstances().Schema, map[string]interface{}{"version": "9.0"})
+	diags = DataSourceFleetInstances().ReadContext(context.Background(), instances, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1, instances.Get("instances.#"))
+	assert.Equal(t, simulator.FleetInstanceId, instances.Get("instances.0.id"))
+	assert.Equal(t, "lax-vcf01", instances.Get("instances.0.name"))
+
+	// The operation runs on all the instances by default
+	operations := ResourceFleetOperation()
+	operation := schema.TestResourceDataRaw(t, operations.Schema, map[string]interface{}{"type": "INVENTORY_SYNC"})
+	diags = operations.CreateContext(context.Background(), operation, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, operation.Id())
+	assert.Equal(t, "SUCCESSFUL", operation.Get("status"))
+	assert.Equal(t, 2, operation.Get("instance.#"))
+
+	diags = operations.ReadContext(context.Background(), operation, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 2, operation.Get("instance.#"))
+
+	// The operation fails on a disconnected instance
+	fleetSimulator.DisconnectFleetInstance(simulator.FleetInstanceId)
+	operation = schema.TestResourceDataRaw(t, operations.Schema, map[string]interface{}{
+		"type":         "PRECHECK",
+		"instance_ids": []interface{}{simulator.FleetInstanceId},
+	})
+	diags = operations.CreateContext(context.Background(), operation, meta)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Detail, "lax-vcf01 is DISCONNECTED")
+	assert.Empty(t, operation.Id())
+
+	// The fleet resources require the fleet management connection
+	diags = DataSourceFleetInstances().ReadContext(context.Background(), instances, testSimulatorMeta(t))
+	assert.True(t, diags.HasError())
+}
+
+func TestSimulatorUnsupportedRequest(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	data := schema.TestResourceDataRaw(t, DataSourceCredentials().Schema, map[string]interface{}{})
+	diags := DataSourceCredentials().ReadContext(context.Background(), data, meta)
+	assert.True(t, diags.HasError())
+}
+
+func TestSimulatorDomainReady(t *testing.T) {
+	domainSimulator := simulator.New()
+	defer domainSimulator.Close()
+
+	meta, diags := providerConfigure(context.Background(), schema.TestResourceDataRaw(t, Provider().Schema,
+		map[string]interface{}{
+			"sddc_manager_host":     domainSimulator.Host(),
+			"sddc_manager_username": "administrator@vsphere.local",
+			"sddc_manager_password": "VMware123!VMware123!",
+			"allow_unverified_tls":  true,
+			"task_poll_interval":    "10ms",
+		}))
+	assert.False(t, diags.HasError(), "%v", diags)
+	read := func(ctx context.Context, config map[string]interface{}) (*schema.ResourceData, diag.Diagnostics) {
+		data := schema.TestResourceDataRaw(t, DataSourceDomainReady().Schema, config)
+		return data, DataSourceDomainReady().ReadContext(ctx, data, meta)
+	}
+
+	data, diags := read(context.Background(), map[string]interface{}{"name": "sfo-m01", "check_endpoints": false})
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, simulator.ManagementDomainId, data.Id())
+	assert.Equal(t, "ACTIVE", data.Get("status"))
+	assert.Equal(t, "https://sfo-m01-vc01.sfo.rainpole.io", data.Get("vcenter_url"))
+	assert.Equal(t, "https://sfo-m01-nsx01.sfo.rainpole.io", data.Get("nsx_url"))
+	assert.Equal(t, simulator.NsxVersion, data.Get("nsx_version"))
+
+	// A domain which is being created is read again until it is ACTIVE
+	domainPath := "/v1/domains/" + simulator.ManagementDomainId
+	before := domainSimulator.RequestCount(http.MethodGet, domainPath)
+	domainSimulator.SetDomainStatus(simulator.ManagementDomainId, "ACTIVATING")
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		domainSimulator.SetDomainStatus(simulator.ManagementDomainId, "ACTIVE")
+	}()
+	data, diags = read(context.Background(), map[string]interface{}{
+		"domain_id":       simulator.ManagementDomainId,
+		"check_endpoints": false,
+	})
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "sfo-m01", data.Get("name"))
+	assert.Greater(t, domainSimulator.RequestCount(http.MethodGet, domainPath)-before, 1)
+
+	// A failed domain is not waited for
+	domainSimulator.SetDomainStatus(simulator.ManagementDomainId, "ERROR")
+	_, diags = read(context.Background(), map[string]interface{}{"name": "sfo-m01"})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "domain sfo-m01 is in the ERROR status")
+
+	// The endpoints of the simulated domain do not exist
+	domainSimulator.SetDomainStatus(simulator.ManagementDomainId, "ACTIVE")
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_, diags = read(ctx, map[string]interface{}{"name": "sfo-m01"})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "https://sfo-m01-vc01.sfo.rainpole.io")
+}
+
+func TestSimulatorHealthCheck(t *testing.T) {
+	sosSimulator := simulator.New()
+	defer sosSimulator.Close()
+
+	meta, diags := providerConfigure(context.Background(), schema.TestResourceDataRaw(t, Provider().Schema,
+		map[string]interface{}{
+			"sddc_manager_host":     sosSimulator.Host(),
+			"sddc_manager_username": "administrator@vsphere.local",
+			"sddc_manager_password": "VMware123!VMware123!",
+			"allow_unverified_tls":  true,
+			"task_poll_interval":    "10ms",
+		}))
+	assert.False(t, diags.HasError(), "%v", diags)
+	read := func(config map[string]interface{}) (*schema.ResourceData, diag.Diagnostics) {
+		data := schema.TestResourceDataRaw(t, DataSourceHealthCheck().Schema, config)
+		return data, DataSourceHealthCheck().ReadContext(context.Background(), data, meta)
+	}
+
+	data, diags := read(map[string]interface{}{"checks": []interface{}{"NTP", "SERVICES"}})
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, data.Id())
+	assert.Equal(t, "COMPLETED_WITH_SUCCESS", data.Get("status"))
+	assert.True(t, data.Get("is_healthy").(bool))
+	// The four hosts of the management cluster and its vCenter Server, then SDDC Manager
+	assert.Equal(t, 6, data.Get("results.#"))
+	assert.Equal(t, "NTP Health", data.Get("results.0.category"))
+	assert.Equal(t, "ESXi Time", data.Get("results.0.check"))
+	assert.Equal(t, "GREEN", data.Get("results.0.state"))
+	assert.Equal(t, simulator.SddcManagerFqdn, data.Get("results.5.component"))
+
+	// A RED result makes the run unhealthy, and fails it if requested
+	sosSimulator.FailHealthCheck(simulator.SddcManagerFqdn, "Service lcm is DOWN")
+	data, diags = read(map[string]interface{}{"checks": []interface{}{"SERVICES"}})
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.False(t, data.Get("is_healthy").(bool))
+	assert.Equal(t, "RED", data.Get("results.0.state"))
+	_, diags = read(map[string]interface{}{"fail_on_failure": true})
+	assert.Len(t, diags, 2)
+	assert.Equal(t, "Certificate Health: Certificate Expiry failed for "+simulator.SddcManagerFqdn, diags[0].Summary)
+	assert.Equal(t, "Service lcm is DOWN", diags[1].Detail)
+}
+
+func TestSimulatorMuxedProvider(t *testing.T) {
+	ctx := context.Background()
+	server, err := muxedFactories()["vcf"]()
+	assert.NoError(t, err)
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, schemas.Diagnostics)
+
+	// Both halves of the provider connect to the simulator
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, schemas.Provider, map[string]tftypes.Value{
+			"simulator": tftypes.NewValue(tftypes.Bool, true),
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, configured.Diagnostics)
+
+	poolSchema := schemas.ResourceSchemas["vcf_network_pool"]
+	read, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "vcf_network_pool",
+		CurrentState: testDynamicValue(t, poolSchema, map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, simulator.ManagementPoolId),
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, read.Diagnostics)
+
+	state, err := read.NewState.Unmarshal(poolSchema.ValueType())
+	assert.NoError(t, err)
+	attributes := map[string]tftypes.Value{}
+	assert.NoError(t, state.As(&attributes))
+	var name string
+	assert.NoError(t, attributes["name"].As(&name))
+	assert.Equal(t, "sfo-m01-np01", name)
+}
+
+// testDynamicValue returns the value of the schema with the attributes, the other attributes and blocks are null.
+func testDynamicValue(t *testing.T, schema *tfprotov6.Schema, attributes map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	objectType := schema.ValueType().(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	value, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("cannot encode the value: %v", err)
+	}
+	return &value
+}
+
+func TestSimulatorCredentialsRotationBatchFailure(t *testing.T) {
+	rotationSimulator := simulator.New()
+	defer rotationSimulator.Close()
+	meta, diags := providerConfigure(context.Background(), schema.TestResourceDataRaw(t, Provider().Schema,
+		map[string]interface{}{
+			"sddc_manager_host":     rotationSimulator.Host(),
+			"sddc_manager_username": "administrator@vsphere.local",
+			"sddc_manager_password": "VMware123!VMware123!",
+			"allow_unverified_tls":  true,
+			"task_poll_interval":    "10ms",
+		}))
+	assert.False(t, diags.HasError(), "%v", diags)
+	rotationSimulator.FailCredentialOperation("esx02.sfo.rainpole.io", "root", "the host is not reachable")
+
+	resources := make([]interface{}, 0)
+	for _, resourceName := range []string{"esx01.sfo.rainpole.io", "esx02.sfo.rainpole.io", "esx03.sfo.rainpole.io"} {
+		resources = append(resources, map[string]interface{}{
+			"resource_name": resourceName,
+			"resource_type": "ESXI",
+			"credentials": []interface{}{
+				map[string]interface{}{"credential_type": "SSH", "user_name": "root"},
+			},
+		})
+	}
+	rotation := ResourceCredentialsRotation()
+	data := schema.TestResourceDataRaw(t, rotation.Schema, map[string]interface{}{
+		"resource":   resources,
+		"batch_size": 1,
+	})
+	diags = rotation.CreateContext(context.Background(), data, meta)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "batch 2 of 3 failed to rotate")
+
+	// The state records the task of the first batch, which rotated the password of esx01
+	assert.NotEmpty(t, data.Id())
+	assert.Equal(t, 1, data.Get("task_ids.#"))
+	assert.Equal(t, data.Id(), data.Get("task_ids.0"))
+	assert.Contains(t, diags[0].Detail, data.Id())
+	assert.Equal(t, 1, data.Get("failed_accounts.#"))
+	assert.Equal(t, "esx02.sfo.rainpole.io", data.Get("failed_accounts.0.resource_name"))
+}
+
+// testIsolatedSimulatorMeta connects to a simulator of the test, whose inventory and tasks the test can change
+// without affecting the other tests.
+func testIsolatedSimulatorMeta(t *testing.T, sim *simulator.Simulator) *api_client.SddcManagerClient {
+	meta, diags := providerConfigure(context.Background(), schema.TestResourceDataRaw(t, Provider().Schema,
+		map[string]interface{}{
+			"sddc_manager_host":     sim.Host(),
+			"sddc_manager_username": "administrator@vsphere.local",
+			"sddc_manager_password": "VMware123!VMware123!",
+			"allow_unverified_tls":  true,
+			"task_poll_interval":    "10ms",
+			"task_auto_retry":       2,
+		}))
+	if diags.HasError() {
+		t.Fatalf("cannot connect to the simulator: %v", diags)
+	}
+	return meta.(*api_client.SddcManagerClient)
+}
+
+func TestSimulatorTaskInterruption(t *testing.T) {
+	taskSimulator := simulator.New()
+	defer taskSimulator.Close()
+	vcfClient := testIsolatedSimulatorMeta(t, taskSimulator)
+
+	tests := []struct {
+		name        string
+		cancellable bool
+		cancelled   bool
+		deadline    bool
+		status      string
+	}{
+		{name: "interrupted apply cancels the task", cancellable: true, cancelled: true, status: "Cancelled"},
+		{name: "interrupted apply leaves a task which cannot be cancelled", status: "In Progress"},
+		{name: "timeout leaves the task running", cancellable: true, deadline: true, status: "In Progress"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			taskId := taskSimulator.StartTask("Simulated workflow", nil, test.cancellable)
+			ctx, cancel := context.WithCancel(context.Background())
+			if test.deadline {
+				ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+			} else {
+				time.AfterFunc(50*time.Millisecond, cancel)
+			}
+			defer cancel()
+
+			err := vcfClient.WaitForTaskComplete(ctx, taskId, true)
+			var interrupted *api_client.TaskInterruptedError
+			assert.ErrorAs(t, err, &interrupted)
+			assert.Equal(t, taskId, interrupted.TaskID)
+			assert.Equal(t, test.cancelled, interrupted.Cancelled)
+			assert.Equal(t, test.status, taskSimulator.TaskStatus(taskId))
+			cancelRequests := taskSimulator.RequestCount(http.MethodDelete, "/v1/tasks/"+taskId)
+			if test.cancellable && !test.deadline {
+				assert.Equal(t, 1, cancelRequests)
+			} else {
+				assert.Zero(t, cancelRequests)
+			}
+
+			// The task which keeps running is reported for the next apply
+			diags := creationTaskDiagnostics(err, "Domain")
+			if test.cancelled {
+				assert.Empty(t, diags[0].Detail)
+			} else {
+				assert.Contains(t, diags[0].Detail, "The creation task "+taskId+" of the domain is still running")
+			}
+		})
+	}
+}
+
+func TestSimulatorResumeRunningCreation(t *testing.T) {
+	resumeSimulator := simulator.New()
+	defer resumeSimulator.Close()
+	vcfClient := testIsolatedSimulatorMeta(t, resumeSimulator)
+
+	// The creation of the domain outlasted the previous apply, the next apply waits for its task again
+	domainType := "DOMAIN"
+	domainId := simulator.ManagementDomainId
+	taskId := resumeSimulator.StartTask("Creating domain sfo-m01", &models.Resource{Type: &domainType, ResourceID: &domainId},
+		false, "Deploy vCenter Server", "Deploy NSX Manager")
+	resumeSimulator.SetDomainStatus(simulator.ManagementDomainId, "ACTIVATING")
+
+	task, err := vcfClient.FindResumableTask(context.Background(), "DOMAIN", simulator.ManagementDomainId)
+	assert.NoError(t, err)
+	assert.Equal(t, taskId, task.ID)
+	retryable, err := vcfClient.FindRetryableTask(context.Background(), "DOMAIN", simulator.ManagementDomainId)
+	assert.NoError(t, err)
+	assert.Nil(t, retryable)
+
+	resumedDomainId, err := domain.ResumeDomainCreation(context.Background(), vcfClient, "sfo-m01")
+	assert.NoError(t, err)
+	assert.Equal(t, simulator.ManagementDomainId, resumedDomainId)
+	assert.Equal(t, "Successful", resumeSimulator.TaskStatus(taskId))
+	assert.Zero(t, resumeSimulator.RequestCount(http.MethodPatch, "/v1/tasks/"+taskId))
+
+	// A completed creation is not resumed
+	resumeSimulator.SetDomainStatus(simulator.ManagementDomainId, "ACTIVE")
+	task, err = vcfClient.FindResumableTask(context.Background(), "DOMAIN", simulator.ManagementDomainId)
+	assert.NoError(t, err)
+	assert.Nil(t, task)
+}
+
+func TestSimulatorUpgradeContinuation(t *testing.T) {
+	upgradeSimulator := simulator.New()
+	defer upgradeSimulator.Close()
+	vcfClient := testIsolatedSimulatorMeta(t, upgradeSimulator)
+
+	domainId := simulator.ManagementDomainId
+	bundleType := "VMWARE_SOFTWARE"
+	upgradeSimulator.AddUpgradable(domainId, &models.Bundle{
+		ID:         "a8b3c2d1-0e9f-4a7b-8c6d-simulator009",
+		Type:       &bundleType,
+		Components: []*models.BundleComponent{{Type: "NSX_T_MANAGER", ToVersion: "4.2.1.0"}},
+	})
+	completed := upgradeSimulator.AddUpgrade(domainId, "a8b3c2d1-0e9f-4a7b-8c6d-simulator009",
+		lcm.UpgradeStatusCompletedWithSuccess)
+	inProgress := upgradeSimulator.AddUpgrade(domainId, "a8b3c2d1-0e9f-4a7b-8c6d-simulator009", "INPROGRESS")
+
+	tests := []struct {
+		name              string
+		currentVersion    string
+		componentTypes    []interface{}
+		waitForCompletion bool
+		upgradeId         string
+		removed           bool
+	}{
+		{name: "next hop of the upgrade path", currentVersion: "5.2.0.0", upgradeId: completed, removed: true},
+		{name: "target release reached", currentVersion: "5.2.1.0", upgradeId: completed},
+		{name: "upgrade in progress", currentVersion: "5.2.0.0", upgradeId: inProgress},
+		{name: "waited for the upgrades", currentVersion: "5.2.0.0", waitForCompletion: true, upgradeId: completed},
+		{
+			name:           "remaining upgrade of the component types",
+			currentVersion: "5.2.0.0",
+			componentTypes: []interface{}{"NSX_T_MANAGER"},
+			upgradeId:      completed,
+			removed:        true,
+		},
+		{
+			// The release of the domain stays the same until all its components are upgraded
+			name:           "no remaining upgrade of the component types",
+			currentVersion: "5.2.0.0",
+			componentTypes: []interface{}{"VCENTER"},
+			upgradeId:      completed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			upgradeSimulator.SetDomainRelease(domainId, test.currentVersion)
+			data := schema.TestResourceDataRaw(t, ResourceUpgrade().Schema, map[string]interface{}{
+				"domain_id":           domainId,
+				"target_version":      "5.2.1.0",
+				"component_types":     test.componentTypes,
+				"wait_for_completion": test.waitForCompletion,
+			})
+			data.SetId("upgrade")
+			_ = data.Set("upgrade_ids", []interface{}{test.upgradeId})
+
+			diags := resourceUpgradeRead(context.Background(), data, vcfClient)
+			assert.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, test.currentVersion, data.Get("current_version"))
+			assert.Equal(t, test.removed, data.Id() == "")
+		})
+	}
+}
+
+func TestSimulatorUpgradePrecheckOnPlan(t *testing.T) {
+	precheckSimulator := simulator.New()
+	defer precheckSimulator.Close()
+
+	domainId := simulator.ManagementDomainId
+	state := &terraform.InstanceState{ID: "upgrade", Attributes: map[string]string{
+		"id":                  "upgrade",
+		"domain_id":           domainId,
+		"target_version":      "5.2.1.0",
+		"precheck_on_plan":    "false",
+		"run_prechecks":       "true",
+		"wait_for_completion": "true",
+	}}
+	tests := []struct {
+		name           string
+		state          *terraform.InstanceState
+		targetVersion  string
+		failedPrecheck bool
+		prechecks      int
+		err            string
+	}{
+		{name: "creation", targetVersion: "5.2.1.0", prechecks: 1},
+		{
+			name:           "creation failing the precheck",
+			targetVersion:  "5.2.1.0",
+			failedPrecheck: true,
+			prechecks:      1,
+			err: "the upgrade precheck of domain " + domainId + " reported 1 critical issue(s): " +
+				"Password expiration (FAILED): the password of root expires in 1 day",
+		},
+		{name: "precheck_on_plan enabled on an upgrade in the state", state: state, targetVersion: "5.2.1.0"},
+		{
+			name:           "new target release of an upgrade in the state",
+			state:          state,
+			targetVersion:  "5.2.2.0",
+			failedPrecheck: true,
+			prechecks:      1,
+			err:            "reported 1 critical issue(s)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.failedPrecheck {
+				precheckSimulator.FailPrecheck("Password expiration", "the password of root expires in 1 day")
+			}
+			before := precheckSimulator.RequestCount(http.MethodPost, "/v1/system/prechecks")
+
+			// Each plan connects again, the precheck runs once per plan
+			vcfClient := testIsolatedSimulatorMeta(t, precheckSimulator)
+			_, err := ResourceUpgrade().Diff(context.Background(), test.state, terraform.NewResourceConfigRaw(
+				map[string]interface{}{
+					"domain_id":        domainId,
+					"target_version":   test.targetVersion,
+					"precheck_on_plan": true,
+				}), vcfClient)
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.prechecks, precheckSimulator.RequestCount(http.MethodPost, "/v1/system/prechecks")-before)
+		})
+	}
+}
+
+func TestSimulatorTaskAutoRetry(t *testing.T) {
+	retrySimulator := simulator.New()
+	defer retrySimulator.Close()
+	// The client retries the failed tasks twice
+	vcfClient := testIsolatedSimulatorMeta(t, retrySimulator)
+
+	tests := []struct {
+		name          string
+		retryable     bool
+		failedRetries int
+		retry         bool
+		retries       int
+		err           string
+	}{
+		{name: "retryable task", retryable: true, retries: 1},
+		{name: "retryable task failing again", retryable: true, failedRetries: 1, retries: 2},
+		{
+			name:          "retryable task failing beyond the retry limit",
+			retryable:     true,
+			failedRetries: 2,
+			retries:       2,
+			err:           "is in state Failed",
+		},
+		{name: "task which cannot be retried", err: "is in state Failed"},
+		{
+			// The waits with retry retry up to 6 times
+			name:          "wait with retry",
+			retryable:     true,
+			failedRetries: 4,
+			retry:         true,
+			retries:       5,
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			domainType := "DOMAIN"
+			domainId := fmt.Sprintf("simulated-domain-%d", i)
+			taskId := retrySimulator.StartTask("Simulated workflow", &models.Resource{Type: &domainType, ResourceID: &domainId},
+				false, "Deploy vCenter Server", "Deploy NSX Manager")
+			retrySimulator.FailTask(taskId, test.retryable, test.failedRetries)
+
+			// Only the failed tasks SDDC Manager can retry are resumed by the next apply
+			retryableTask, err := vcfClient.FindRetryableTask(context.Background(), domainType, domainId)
+			assert.NoError(t, err)
+			if test.retryable {
+				assert.Equal(t, taskId, retryableTask.ID)
+			} else {
+				assert.Nil(t, retryableTask)
+			}
+
+			err = vcfClient.WaitForTaskComplete(context.Background(), taskId, test.retry)
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+				assert.Equal(t, "Failed", retrySimulator.TaskStatus(taskId))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Successful", retrySimulator.TaskStatus(taskId))
+			}
+			assert.Equal(t, test.retries, retrySimulator.RequestCount(http.MethodPatch, "/v1/tasks/"+taskId))
+		})
+	}
+}
+
+func TestSimulatorTaskStages(t *testing.T) {
+	stageSimulator := simulator.New()
+	defer stageSimulator.Close()
+	vcfClient := testIsolatedSimulatorMeta(t, stageSimulator)
+
+	tests := []struct {
+		name   string
+		failed bool
+		stages []string
+	}{
+		{
+			name: "stages run one after the other",
+			stages: []string{
+				"[IN_PROGRESS] Deploy vCenter Server",
+				"[PENDING] Deploy NSX Manager",
+				"[SUCCESSFUL] Deploy vCenter Server",
+				"[IN_PROGRESS] Deploy NSX Manager",
+				"[SUCCESSFUL] Deploy NSX Manager",
+			},
+		},
+		{
+			name:   "retried stage",
+			failed: true,
+			stages: []string{
+				"[FAILED] Deploy vCenter Server",
+				"[PENDING] Deploy NSX Manager",
+				"[SUCCESSFUL] Deploy vCenter Server",
+				"[SUCCESSFUL] Deploy NSX Manager",
+			},
+		},
+	}
+	elapsed := regexp.MustCompile(`( after \S+)? \(elapsed \S+\)$`)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			taskId := stageSimulator.StartTask("Simulated workflow", nil, false,
+				"Deploy vCenter Server", "Deploy NSX Manager")
+			if test.failed {
+				stageSimulator.FailTask(taskId, true, 0)
+			}
+
+			var output bytes.Buffer
+			assert.NoError(t, vcfClient.WaitForTaskComplete(tflogtest.RootLogger(context.Background(), &output), taskId, false))
+
+			// Each transition of a stage is logged once, as it is seen
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			assert.NoError(t, err)
+			stages := make([]string, 0)
+			for _, entry := range entries {
+				message, _ := entry["@message"].(string)
+				if strings.HasPrefix(message, "[") {
+					stages = append(stages, elapsed.ReplaceAllString(message, ""))
+				}
+			}
+			assert.Equal(t, test.stages, stages)
+		})
+	}
+}
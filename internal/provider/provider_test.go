@@ -9,6 +9,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
@@ -34,6 +36,87 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestDeprecatedAliases(t *testing.T) {
+	dataSources := Provider().DataSourcesMap
+	assert.NotEmpty(t, dataSources["vcf_certificate"].DeprecationMessage)
+	assert.Contains(t, dataSources["vcf_certificate"].DeprecationMessage, "vcf_certificates")
+	assert.Empty(t, dataSources["vcf_certificates"].DeprecationMessage)
+	assert.Equal(t, dataSources["vcf_certificates"].CoreConfigSchema().ImpliedType(),
+		dataSources["vcf_certificate"].CoreConfigSchema().ImpliedType())
+}
+
+func TestResourceTimeouts(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		if assert.NotNil(t, r.Timeouts, name) {
+			assert.NotNil(t, r.Timeouts.Create, "%s has no create timeout", name)
+			assert.NotNil(t, r.Timeouts.Read, "%s has no read timeout", name)
+			assert.NotNil(t, r.Timeouts.Update, "%s has no update timeout", name)
+			assert.NotNil(t, r.Timeouts.Delete, "%s has no delete timeout", name)
+		}
+	}
+}
+
+func TestMuxedProviderSchemas(t *testing.T) {
+	muxServer, err := muxedFactories()["vcf"]()
+	assert.NoError(t, err)
+
+	res, err := muxServer.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	assert.NoError(t, err)
+	for _, diagnostic := range res.Diagnostics {
+		assert.NotEqual(t, tfprotov6.DiagnosticSeverityError, diagnostic.Severity, "%s: %s",
+			diagnostic.Summary, diagnostic.Detail)
+	}
+}
+
+func TestDefaultTimeouts(t *testing.T) {
+	timeouts, err := getOperationTimeouts("6h", "", "", "2h")
+	assert.NoError(t, err)
+	assert.Equal(t, operationTimeouts{Create: 6 * time.Hour, Delete: 2 * time.Hour}, timeouts)
+
+	_, err = getOperationTimeouts("", "soon", "", "")
+	assert.Error(t, err)
+
+	// The defaults of the provider configuration replace the built-in ones, except those which are not set
+	r := ResourceCluster()
+	timeouts.applyTo(r)
+	assert.Equal(t, 6*time.Hour, *r.Timeouts.Create)
+	assert.Equal(t, 10*time.Minute, *r.Timeouts.Read)
+	assert.Equal(t, 2*time.Hour, *r.Timeouts.Update)
+	assert.Equal(t, 2*time.Hour, *r.Timeouts.Delete)
+
+	p := Provider()
+	data := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+		"simulator":        true,
+		"default_timeouts": []interface{}{map[string]interface{}{"read": "45m"}},
+	})
+	_, diags := p.ConfigureContextFunc(context.Background(), data)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 45*time.Minute, *p.ResourcesMap["vcf_domain"].Timeouts.Read)
+	assert.Equal(t, 4*time.Hour, *p.ResourcesMap["vcf_domain"].Timeouts.Create)
+	assert.Equal(t, 45*time.Minute, *p.ResourcesMap["vcf_tag"].Timeouts.Read)
+}
+
+func TestGetTaskWaitSettings(t *testing.T) {
+	settings, err := getTaskWaitSettings("30s", "4h", true, "1h")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, settings.PollInterval)
+	assert.Equal(t, 4*time.Hour, settings.MaxWait)
+	assert.True(t, settings.FailOnWarning)
+	assert.Equal(t, time.Hour, settings.LockMaxWait)
+
+	// The defaults of the client are kept
+	settings, err = getTaskWaitSettings("", "", false, "")
+	assert.NoError(t, err)
+	assert.Zero(t, settings.PollInterval)
+	assert.Zero(t, settings.MaxWait)
+	assert.Zero(t, settings.LockMaxWait)
+
+	_, err = getTaskWaitSettings("soon", "", false, "")
+	assert.Error(t, err)
+	_, err = getTaskWaitSettings("", "", false, "later")
+	assert.Error(t, err)
+}
+
 func muxedFactories() map[string]func() (tfprotov6.ProviderServer, error) {
 	ctx := context.Background()
 	upgradedSdkServer, err := tf5to6server.UpgradeServer(
@@ -0,0 +1,119 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+// The descriptions of the default_timeouts block, shared by the SDK and the framework providers whose schemas must
+// be identical.
+const (
+	defaultTimeoutsDescription = "The timeouts of the operations of all the resources, which replace their built-in " +
+		"defaults, e.g. for slow nested labs. The timeouts block of a resource takes precedence."
+	defaultCreateTimeoutDescription = "The timeout of the creations, e.g. 6h."
+	defaultReadTimeoutDescription   = "The timeout of the reads, e.g. 30m."
+	defaultUpdateTimeoutDescription = "The timeout of the updates, e.g. 6h."
+	defaultDeleteTimeoutDescription = "The timeout of the deletions, e.g. 2h."
+)
+
+// operationTimeouts are the default timeouts of the operations of the resources set in the provider configuration.
+// The zero durations keep the built-in defaults of the resources.
+type operationTimeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// defaultTimeoutsSchema is the default_timeouts block of the SDK provider.
+func defaultTimeoutsSchema() *schema.Schema {
+	timeoutSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  description,
+			ValidateFunc: validationutils.ValidatePositiveDuration,
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: defaultTimeoutsDescription,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"create": timeoutSchema(defaultCreateTimeoutDescription),
+				"read":   timeoutSchema(defaultReadTimeoutDescription),
+				"update": timeoutSchema(defaultUpdateTimeoutDescription),
+				"delete": timeoutSchema(defaultDeleteTimeoutDescription),
+			},
+		},
+	}
+}
+
+// getOperationTimeouts parses the timeouts of the default_timeouts block. Empty durations keep the defaults.
+func getOperationTimeouts(createTimeout, readTimeout, updateTimeout, deleteTimeout string) (operationTimeouts, error) {
+	var timeouts operationTimeouts
+	for _, timeout := range []struct {
+		name     string
+		value    string
+		duration *time.Duration
+	}{
+		{"create", createTimeout, &timeouts.Create},
+		{"read", readTimeout, &timeouts.Read},
+		{"update", updateTimeout, &timeouts.Update},
+		{"delete", deleteTimeout, &timeouts.Delete},
+	} {
+		if len(timeout.value) == 0 {
+			continue
+		}
+		duration, err := time.ParseDuration(timeout.value)
+		if err != nil || duration <= 0 {
+			return timeouts, fmt.Errorf("invalid default %s timeout %q, expected a positive duration, e.g. 2h",
+				timeout.name, timeout.value)
+		}
+		*timeout.duration = duration
+	}
+	return timeouts, nil
+}
+
+// getSdkOperationTimeouts parses the default_timeouts block of the SDK provider.
+func getSdkOperationTimeouts(data *schema.ResourceData) (operationTimeouts, error) {
+	return getOperationTimeouts(data.Get("default_timeouts.0.create").(string),
+		data.Get("default_timeouts.0.read").(string), data.Get("default_timeouts.0.update").(string),
+		data.Get("default_timeouts.0.delete").(string))
+}
+
+// applyTo replaces the defaults of the timeouts the resource declares. The SDK applies the timeouts block of a
+// resource on top of these defaults, it keeps taking precedence.
+func (t operationTimeouts) applyTo(r *schema.Resource) {
+	if r.Timeouts == nil {
+		return
+	}
+	replace := func(builtIn **time.Duration, timeout time.Duration) {
+		if *builtIn != nil && timeout > 0 {
+			*builtIn = schema.DefaultTimeout(timeout)
+		}
+	}
+	replace(&r.Timeouts.Create, t.Create)
+	replace(&r.Timeouts.Read, t.Read)
+	replace(&r.Timeouts.Update, t.Update)
+	replace(&r.Timeouts.Delete, t.Delete)
+}
+
+// orDefault returns the timeout set in the provider configuration, the built-in default if it is not set.
+func orDefault(timeout, builtIn time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	return builtIn
+}
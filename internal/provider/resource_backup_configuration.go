@@ -0,0 +1,446 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/backup"
+)
+
+func ResourceBackupConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBackupConfigurationCreate,
+		ReadContext:   resourceBackupConfigurationRead,
+		UpdateContext: resourceBackupConfigurationUpdate,
+		DeleteContext: resourceBackupConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "Manages the backup server, the encryption passphrase and the schedule of the file-based backups of " +
+			"SDDC Manager",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(backup.DefaultConfigurationTimeout),
+			Read:   schema.DefaultTimeout(backup.DefaultReadTimeout),
+			Update: schema.DefaultTimeout(backup.DefaultConfigurationTimeout),
+			Delete: schema.DefaultTimeout(backup.DefaultConfigurationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"server": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The IP address or FQDN of the backup server",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      22,
+				Description:  "The port of the backup server",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      backup.ProtocolSftp,
+				Description:  "The protocol used to transfer the backup files. One among: SFTP",
+				ValidateFunc: validation.StringInSlice([]string{backup.ProtocolSftp}, false),
+			},
+			"directory_path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The directory on the backup server where the backup files are saved",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"user_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The user name of the backup server account",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The password of the backup server account",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"ssh_fingerprint": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The SSH fingerprint of the backup server, e.g. SHA256:yRz8ZOGMcmnRqGnoglANrZMHoc1g7t3QCS3PsVWhJjA",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"encryption_passphrase": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"encryption_passphrase", "encryption_passphrase_wo"},
+				Description:  "The passphrase used to encrypt the backup files. It is required to restore the backups",
+				ValidateFunc: validation.StringLenBetween(12, 255),
+			},
+			"encryption_passphrase_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"encryption_passphrase", "encryption_passphrase_wo"},
+				Description: "The passphrase used to encrypt the backup files as a write-only argument which is never stored " +
+					"in the state. Requires Terraform 1.11 or later",
+				ValidateFunc: validation.StringLenBetween(12, 255),
+			},
+			"encryption_passphrase_wo_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "Arbitrary version of the write-only passphrase. Changing it sends the passphrase again, " +
+					"e.g. to rotate it",
+			},
+			"schedule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The schedule of the SDDC Manager backups. If omitted, backups are only taken on demand",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"frequency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The backup frequency. One among: WEEKLY, HOURLY",
+							ValidateFunc: validation.StringInSlice([]string{backup.FrequencyWeekly, backup.FrequencyHourly}, false),
+						},
+						"days_of_week": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The days of the week of the weekly backups, e.g. MONDAY",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(backup.DaysOfWeek(), false),
+							},
+						},
+						"hour_of_day": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "The hour of the day of the weekly backups",
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+						"minute_of_hour": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "The minute of the hour the backups start at",
+							ValidateFunc: validation.IntBetween(0, 59),
+						},
+						"take_scheduled_backups": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the scheduled backups are enabled",
+						},
+						"take_backup_on_state_change": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							Description: "Whether SDDC Manager takes a backup after each operation which changes its state. " +
+								"Requires the scheduled backups to be enabled",
+						},
+						"retention_policy": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Which backup files SDDC Manager keeps on the backup server, the others are deleted automatically",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"number_of_most_recent_backups": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										Description:  "The number of most recent backup files to keep, between 1 and 600",
+										ValidateFunc: validation.IntBetween(1, 600),
+									},
+									"number_of_days_of_hourly_backups": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Description: "The number of days for which one backup file per hour is kept in addition, " +
+											"between 0 and 14",
+										ValidateFunc: validation.IntBetween(0, 14),
+									},
+									"number_of_days_of_daily_backups": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Description: "The number of days for which one backup file per day is kept in addition, " +
+											"between 0 and 30",
+										ValidateFunc: validation.IntBetween(0, 30),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"nsx_backup": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "Applies the same backup server, passphrase and schedule to the NSX Manager clusters, so that " +
+					"both control planes are backed up consistently",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain_ids": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The IDs of the domains which NSX Manager clusters are configured. If omitted, all NSX Manager clusters are",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.NoZeroValues,
+							},
+						},
+						"directory_path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The directory on the backup server where the NSX backup files are saved. Defaults to directory_path",
+						},
+						"nsx_managers": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The FQDNs of the NSX Manager clusters the configuration was applied to",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"is_configured": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether both the backup server and the encryption passphrase are configured",
+			},
+		},
+	}
+}
+
+func resourceBackupConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	passphrase, diags := backupEncryptionPassphrase(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	spec := toBackupConfigurationSpec(d, passphrase)
+	if err := backup.SetBackupConfiguration(ctx, spec, vcfClient); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("server").(string))
+
+	if diags := syncNsxBackupConfiguration(ctx, d, spec, passphrase, vcfClient); diags.HasError() {
+		return diags
+	}
+
+	return resourceBackupConfigurationRead(ctx, d, meta)
+}
+
+func resourceBackupConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	backupConfiguration, err := backup.GetBackupConfiguration(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if backupConfiguration == nil || len(backupConfiguration.BackupLocations) == 0 || backupConfiguration.BackupLocations[0] == nil {
+		log.Printf("[WARN] No backup server is configured, removing %s from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// The passwords of the backup server and the encryption passphrase are never returned
+	location := backupConfiguration.BackupLocations[0]
+	if location.Server != nil {
+		d.SetId(*location.Server)
+		_ = d.Set("server", *location.Server)
+	}
+	if location.Port != nil {
+		_ = d.Set("port", int(*location.Port))
+	}
+	if location.Protocol != nil {
+		_ = d.Set("protocol", *location.Protocol)
+	}
+	if location.DirectoryPath != nil {
+		_ = d.Set("directory_path", *location.DirectoryPath)
+	}
+	if location.Username != nil {
+		_ = d.Set("user_name", *location.Username)
+	}
+	_ = d.Set("ssh_fingerprint", location.SSHFingerprint)
+	_ = d.Set("is_configured", backupConfiguration.IsConfigured)
+
+	schedules := make([]map[string]interface{}, 0)
+	if schedule := backup.GetSddcManagerBackupSchedule(backupConfiguration); schedule != nil {
+		scheduleMap := map[string]interface{}{
+			"days_of_week":                schedule.DaysOfWeek,
+			"hour_of_day":                 int(schedule.HourOfDay),
+			"minute_of_hour":              int(schedule.MinuteOfHour),
+			"take_scheduled_backups":      schedule.TakeScheduledBackups,
+			"take_backup_on_state_change": schedule.TakeBackupOnStateChange,
+		}
+		if schedule.Frequency != nil {
+			scheduleMap["frequency"] = *schedule.Frequency
+		}
+		retentionPolicies := make([]map[string]interface{}, 0)
+		if policy := schedule.RetentionPolicy; policy != nil && policy.NumberOfMostRecentBackups != nil {
+			retentionPolicies = append(retentionPolicies, map[string]interface{}{
+				"number_of_most_recent_backups":    int(*policy.NumberOfMostRecentBackups),
+				"number_of_days_of_hourly_backups": int(policy.NumberOfDaysOfHourlyBackups),
+				"number_of_days_of_daily_backups":  int(policy.NumberOfDaysOfDailyBackups),
+			})
+		}
+		scheduleMap["retention_policy"] = retentionPolicies
+		schedules = append(schedules, scheduleMap)
+	}
+	_ = d.Set("schedule", schedules)
+
+	return nil
+}
+
+func resourceBackupConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	// The passphrase is only sent when it is rotated, SDDC Manager keeps the current one otherwise
+	passphrase := ""
+	if d.HasChanges("encryption_passphrase", "encryption_passphrase_wo_version") {
+		var diags diag.Diagnostics
+		if passphrase, diags = backupEncryptionPassphrase(d); diags.HasError() {
+			return diags
+		}
+	}
+
+	spec := toBackupConfigurationSpec(d, passphrase)
+	if err := backup.UpdateBackupConfiguration(ctx, spec, vcfClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := syncNsxBackupConfiguration(ctx, d, spec, passphrase, vcfClient); diags.HasError() {
+		return diags
+	}
+
+	return resourceBackupConfigurationRead(ctx, d, meta)
+}
+
+func resourceBackupConfigurationDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// SDDC Manager has no API to remove the backup configuration, it stays in place
+	return nil
+}
+
+// syncNsxBackupConfiguration applies the backup configuration to the NSX Manager clusters selected by the
+// nsx_backup block, if any. NSX Manager keeps its current passphrase when the passphrase is empty.
+func syncNsxBackupConfiguration(ctx context.Context, d *schema.ResourceData, spec *models.BackupConfigurationSpec,
+	passphrase string, vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	nsxBackups := d.Get("nsx_backup").([]interface{})
+	if len(nsxBackups) == 0 || nsxBackups[0] == nil {
+		return nil
+	}
+	nsxBackup := nsxBackups[0].(map[string]interface{})
+
+	domainIds := make([]string, 0)
+	for _, domainId := range nsxBackup["domain_ids"].(*schema.Set).List() {
+		domainIds = append(domainIds, domainId.(string))
+	}
+	clusters, err := backup.GetNsxClusters(ctx, domainIds, vcfClient.ApiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	settings := backup.NsxBackupSettings{
+		Location:      spec.BackupLocations[0],
+		DirectoryPath: nsxBackup["directory_path"].(string),
+		Passphrase:    passphrase,
+	}
+	if len(spec.BackupSchedules) > 0 {
+		settings.Schedule = spec.BackupSchedules[0]
+	}
+
+	nsxManagers, err := backup.SyncNsxBackupConfiguration(ctx, settings, clusters, vcfClient.ApiClient)
+	nsxBackup["nsx_managers"] = nsxManagers
+	_ = d.Set("nsx_backup", []interface{}{nsxBackup})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func backupEncryptionPassphrase(d *schema.ResourceData) (string, diag.Diagnostics) {
+	passphrase := d.Get("encryption_passphrase").(string)
+	if len(passphrase) == 0 {
+		// Write-only values are never stored in the state, so they have to be read from the configuration
+		passphraseWo, diags := d.GetRawConfigAt(cty.GetAttrPath("encryption_passphrase_wo"))
+		if diags.HasError() {
+			return "", diags
+		}
+		if passphraseWo.IsKnown() && !passphraseWo.IsNull() {
+			passphrase = passphraseWo.AsString()
+		}
+	}
+
+	return passphrase, nil
+}
+
+// toBackupConfigurationSpec converts the configuration to a spec, without encryption if the passphrase is empty.
+func toBackupConfigurationSpec(d *schema.ResourceData, passphrase string) *models.BackupConfigurationSpec {
+	server := d.Get("server").(string)
+	port := int32(d.Get("port").(int))
+	protocol := d.Get("protocol").(string)
+	directoryPath := d.Get("directory_path").(string)
+	userName := d.Get("user_name").(string)
+
+	spec := &models.BackupConfigurationSpec{
+		BackupLocations: []*models.BackupLocation{{
+			Server:         &server,
+			Port:           &port,
+			Protocol:       &protocol,
+			DirectoryPath:  &directoryPath,
+			Username:       &userName,
+			Password:       d.Get("password").(string),
+			SSHFingerprint: d.Get("ssh_fingerprint").(string),
+		}},
+		BackupSchedules: []*models.BackupSchedule{},
+	}
+	if len(passphrase) > 0 {
+		spec.Encryption = &models.Encryption{Passphrase: &passphrase}
+	}
+
+	if schedules := d.Get("schedule").([]interface{}); len(schedules) > 0 && schedules[0] != nil {
+		scheduleMap := schedules[0].(map[string]interface{})
+		frequency := scheduleMap["frequency"].(string)
+		resourceType := backup.ResourceTypeSddcManager
+		schedule := &models.BackupSchedule{
+			Frequency:               &frequency,
+			ResourceType:            &resourceType,
+			HourOfDay:               int32(scheduleMap["hour_of_day"].(int)),
+			MinuteOfHour:            int32(scheduleMap["minute_of_hour"].(int)),
+			TakeScheduledBackups:    scheduleMap["take_scheduled_backups"].(bool),
+			TakeBackupOnStateChange: scheduleMap["take_backup_on_state_change"].(bool),
+		}
+		for _, day := range scheduleMap["days_of_week"].(*schema.Set).List() {
+			schedule.DaysOfWeek = append(schedule.DaysOfWeek, day.(string))
+		}
+		if policies := scheduleMap["retention_policy"].([]interface{}); len(policies) > 0 && policies[0] != nil {
+			policyMap := policies[0].(map[string]interface{})
+			mostRecentBackups := int32(policyMap["number_of_most_recent_backups"].(int))
+			schedule.RetentionPolicy = &models.BackupRetentionPolicy{
+				NumberOfMostRecentBackups:   &mostRecentBackups,
+				NumberOfDaysOfHourlyBackups: int32(policyMap["number_of_days_of_hourly_backups"].(int)),
+				NumberOfDaysOfDailyBackups:  int32(policyMap["number_of_days_of_daily_backups"].(int)),
+			}
+		}
+		spec.BackupSchedules = append(spec.BackupSchedules, schedule)
+	}
+
+	return spec
+}
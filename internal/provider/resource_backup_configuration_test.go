@@ -0,0 +1,111 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceBackupConfiguration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBackupConfigurationConfig("MONDAY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_backup_configuration.backup", "server", os.Getenv(constants.VcfTestBackupServer)),
+					resource.TestCheckResourceAttr("vcf_backup_configuration.backup", "is_configured", "true"),
+					resource.TestCheckResourceAttr("vcf_backup_configuration.backup", "schedule.0.days_of_week.#", "1"),
+					resource.TestCheckResourceAttr("vcf_backup_configuration.backup", "schedule.0.retention_policy.0.number_of_most_recent_backups", "10"),
+					resource.TestCheckResourceAttrSet("vcf_backup_configuration.backup", "nsx_backup.0.nsx_managers.0"),
+				),
+			},
+			{
+				Config: testAccResourceBackupConfigurationConfig("FRIDAY"),
+				Check:  resource.TestCheckTypeSetElemAttr("vcf_backup_configuration.backup", "schedule.0.days_of_week.*", "FRIDAY"),
+			},
+			{
+				ResourceName:      "vcf_backup_configuration.backup",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The secrets are never returned by SDDC Manager and the NSX backups are not read back
+				ImportStateVerifyIgnore: []string{"password", "encryption_passphrase", "nsx_backup"},
+			},
+		},
+	})
+}
+
+func testAccResourceBackupConfigurationConfig(dayOfWeek string) string {
+	return fmt.Sprintf(`
+		resource "vcf_backup_configuration" "backup" {
+			server                = %q
+			directory_path        = %q
+			user_name             = %q
+			password              = %q
+			ssh_fingerprint       = %q
+			encryption_passphrase = %q
+
+			schedule {
+				frequency      = "WEEKLY"
+				days_of_week   = [%q]
+				hour_of_day    = 2
+				minute_of_hour = 30
+
+				retention_policy {
+					number_of_most_recent_backups   = 10
+					number_of_days_of_daily_backups = 7
+				}
+			}
+
+			nsx_backup {}
+		}
+`, os.Getenv(constants.VcfTestBackupServer), os.Getenv(constants.VcfTestBackupDirectory),
+		os.Getenv(constants.VcfTestBackupUsername), os.Getenv(constants.VcfTestBackupPassword),
+		os.Getenv(constants.VcfTestBackupSshFingerprint), os.Getenv(constants.VcfTestBackupPassphrase), dayOfWeek)
+}
+
+func TestAccResourceBackupConfiguration_passphraseRotation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBackupConfigurationWriteOnlyConfig(os.Getenv(constants.VcfTestBackupPassphrase), 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_backup_configuration.backup", "is_configured", "true"),
+					resource.TestCheckNoResourceAttr("vcf_backup_configuration.backup", "encryption_passphrase_wo"),
+				),
+			},
+			{
+				// Bumping the version sends the new passphrase
+				Config: testAccResourceBackupConfigurationWriteOnlyConfig(os.Getenv(constants.VcfTestBackupPassphrase)+"-2", 2),
+				Check:  resource.TestCheckResourceAttr("vcf_backup_configuration.backup", "encryption_passphrase_wo_version", "2"),
+			},
+		},
+	})
+}
+
+func testAccResourceBackupConfigurationWriteOnlyConfig(passphrase string, version int) string {
+	return fmt.Sprintf(`
+		resource "vcf_backup_configuration" "backup" {
+			server                           = %q
+			directory_path                   = %q
+			user_name                        = %q
+			password                         = %q
+			ssh_fingerprint                  = %q
+			encryption_passphrase_wo         = %q
+			encryption_passphrase_wo_version = %d
+		}
+`, os.Getenv(constants.VcfTestBackupServer), os.Getenv(constants.VcfTestBackupDirectory),
+		os.Getenv(constants.VcfTestBackupUsername), os.Getenv(constants.VcfTestBackupPassword),
+		os.Getenv(constants.VcfTestBackupSshFingerprint), passphrase, version)
+}
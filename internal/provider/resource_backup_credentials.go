@@ -0,0 +1,174 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/backup"
+)
+
+func ResourceBackupCredentials() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBackupCredentialsCreate,
+		ReadContext:   resourceBackupCredentialsRead,
+		UpdateContext: resourceBackupCredentialsUpdate,
+		DeleteContext: resourceBackupCredentialsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "Manages the credentials of the backup server user which SDDC Manager and NSX Manager use to " +
+			"upload file-based backups",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(backup.DefaultConfigurationTimeout),
+			Read:   schema.DefaultTimeout(backup.DefaultReadTimeout),
+			Update: schema.DefaultTimeout(backup.DefaultConfigurationTimeout),
+			Delete: schema.DefaultTimeout(backup.DefaultConfigurationTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"user_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The user name of the backup server account",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				Description:  "The password of the backup server account",
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				Description: "The password of the backup server account as a write-only argument which is never stored " +
+					"in the state. Requires Terraform 1.11 or later",
+			},
+			"password_wo_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "Arbitrary version of the write-only password. Changing it pushes the password again, " +
+					"e.g. after it has been rotated on the backup server",
+			},
+			"server": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address or FQDN of the backup server",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The port of the backup server",
+			},
+			"protocol": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The protocol used to transfer the backup files",
+			},
+			"directory_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The directory on the backup server where the backup files are saved",
+			},
+			"last_update_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last credentials update",
+			},
+		},
+	}
+}
+
+func resourceBackupCredentialsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := updateBackupCredentials(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceBackupCredentialsRead(ctx, d, meta)
+}
+
+func resourceBackupCredentialsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	location, err := backup.GetBackupLocation(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if location == nil {
+		log.Printf("[WARN] No backup server is configured, removing %s from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if location.Server != nil {
+		d.SetId(*location.Server)
+		_ = d.Set("server", *location.Server)
+	}
+	if location.Username != nil {
+		_ = d.Set("user_name", *location.Username)
+	}
+	if location.Port != nil {
+		_ = d.Set("port", int(*location.Port))
+	}
+	if location.Protocol != nil {
+		_ = d.Set("protocol", *location.Protocol)
+	}
+	if location.DirectoryPath != nil {
+		_ = d.Set("directory_path", *location.DirectoryPath)
+	}
+
+	return nil
+}
+
+func resourceBackupCredentialsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges("user_name", "password", "password_wo_version") {
+		if diags := updateBackupCredentials(ctx, d, meta); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceBackupCredentialsRead(ctx, d, meta)
+}
+
+func resourceBackupCredentialsDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The backup server requires a user, removing the resource leaves the credentials in place
+	return nil
+}
+
+func updateBackupCredentials(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	password := d.Get("password").(string)
+	if len(password) == 0 {
+		// Write-only values are never stored in the state, so they have to be read from the configuration
+		passwordWo, diags := d.GetRawConfigAt(cty.GetAttrPath("password_wo"))
+		if diags.HasError() {
+			return diags
+		}
+		if passwordWo.IsKnown() && !passwordWo.IsNull() {
+			password = passwordWo.AsString()
+		}
+	}
+
+	if err := backup.UpdateBackupLocationCredentials(ctx, d.Get("user_name").(string), password, vcfClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("last_update_time", time.Now().Format(time.RFC3339))
+
+	return nil
+}
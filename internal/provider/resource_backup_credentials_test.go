@@ -0,0 +1,47 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceBackupCredentials(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBackupCredentialsConfig(1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_backup_credentials.backup", "server"),
+					resource.TestCheckResourceAttr("vcf_backup_credentials.backup", "user_name", os.Getenv(constants.VcfTestBackupUsername)),
+					resource.TestCheckNoResourceAttr("vcf_backup_credentials.backup", "password_wo"),
+				),
+			},
+			{
+				// Bumping the version pushes the password again
+				Config: testAccResourceBackupCredentialsConfig(2),
+				Check:  resource.TestCheckResourceAttr("vcf_backup_credentials.backup", "password_wo_version", "2"),
+			},
+		},
+	})
+}
+
+func testAccResourceBackupCredentialsConfig(version int) string {
+	return fmt.Sprintf(`
+		resource "vcf_backup_credentials" "backup" {
+			user_name = %q
+			password_wo = %q
+			password_wo_version = %d
+		}
+`, os.Getenv(constants.VcfTestBackupUsername), os.Getenv(constants.VcfTestBackupPassword), version)
+}
@@ -0,0 +1,212 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/backup"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+func ResourceBackupRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBackupRestoreCreate,
+		ReadContext:   resourceBackupRestoreRead,
+		UpdateContext: resourceBackupRestoreUpdate,
+		DeleteContext: resourceBackupRestoreDelete,
+		Description:   "Restores SDDC Manager from a file-based backup and waits for the restore to complete",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(backup.DefaultRestoreTimeout),
+			Read:   schema.DefaultTimeout(backup.DefaultReadTimeout),
+			Update: schema.DefaultTimeout(backup.DefaultRestoreTimeout),
+			Delete: schema.DefaultTimeout(backup.DefaultReadTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"backup_file": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "The path of the backup file on the backup server when backup_server is set, otherwise on the " +
+					"SDDC Manager appliance",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"encryption_passphrase": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				Description:  "The passphrase the backup file was encrypted with",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"backup_server": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"backup_server", "appliance_password"},
+				Description:  "The SFTP server the backup file is copied from to the SDDC Manager appliance",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The IP address or FQDN of the backup server",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      22,
+							Description:  "The SSH port of the backup server",
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"user_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The user name of the backup server account",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"password": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Sensitive:    true,
+							Description:  "The password of the backup server account",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"ssh_fingerprint": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Description: "The SHA256 or MD5 fingerprint of the SSH key of the backup server. If omitted, the key " +
+								"presented by the server is accepted and its fingerprint is reported in accepted_fingerprint",
+						},
+					},
+				},
+			},
+			"remote_directory": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "/tmp",
+				Description: "The directory of the SDDC Manager appliance the backup file is copied to",
+			},
+			"appliance_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "vcf",
+				Description: "The user used to copy the backup file to the SDDC Manager appliance over SSH",
+			},
+			"appliance_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password of the user used to copy the backup file to the SDDC Manager appliance over SSH",
+			},
+			"appliance_host_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The SSH public key of the SDDC Manager appliance in authorized_keys format, e.g. the output of " +
+					"ssh-keyscan -t ecdsa <sddc-manager>. Required unless allow_unverified_tls is set on the provider, in which " +
+					"case the key of the appliance is not verified if omitted",
+			},
+			"restored_file": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path of the backup file on the SDDC Manager appliance",
+			},
+			"accepted_fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 fingerprint of the SSH key the backup server presented",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the restore task",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the restore task",
+			},
+		},
+	}
+}
+
+func resourceBackupRestoreCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	backupFile := data.Get("backup_file").(string)
+	if servers := data.Get("backup_server").([]interface{}); len(servers) > 0 && servers[0] != nil {
+		serverMap := servers[0].(map[string]interface{})
+		server := backup.BackupServer{
+			Host:           serverMap["server"].(string),
+			Port:           serverMap["port"].(int),
+			Username:       serverMap["user_name"].(string),
+			Password:       serverMap["password"].(string),
+			SshFingerprint: serverMap["ssh_fingerprint"].(string),
+		}
+		connection := lcm.ApplianceConnection{
+			Username: data.Get("appliance_username").(string),
+			Password: data.Get("appliance_password").(string),
+			HostKey:  data.Get("appliance_host_key").(string),
+		}
+
+		restoredFile, fingerprint, err := backup.FetchBackupFile(ctx, server, backupFile, connection,
+			data.Get("remote_directory").(string), vcfClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = data.Set("accepted_fingerprint", fingerprint)
+		backupFile = restoredFile
+	}
+	_ = data.Set("restored_file", backupFile)
+
+	taskId, err := backup.StartRestore(ctx, backupFile, data.Get("encryption_passphrase").(string), vcfClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(taskId)
+	_ = data.Set("task_id", taskId)
+
+	if err := backup.WaitForRestore(ctx, taskId, vcfClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBackupRestoreRead(ctx, data, meta)
+}
+
+func resourceBackupRestoreRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	task, err := backup.GetRestoreTask(ctx, data.Id(), meta.(*api_client.SddcManagerClient))
+	if err != nil {
+		// The restore is a one-off operation, the state is kept when its task can no longer be read
+		log.Printf("[WARN] Cannot read the restore task %s: %s", data.Id(), err)
+		return nil
+	}
+	_ = data.Set("status", task.Status)
+
+	return nil
+}
+
+func resourceBackupRestoreUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only the SSH connection settings of the appliance can change, they are used at creation only
+	return resourceBackupRestoreRead(ctx, data, meta)
+}
+
+func resourceBackupRestoreDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// A restore cannot be undone
+	return nil
+}
@@ -0,0 +1,50 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceBackupRestore(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBackupRestoreConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_backup_restore.restore", "task_id"),
+					resource.TestCheckResourceAttrSet("vcf_backup_restore.restore", "accepted_fingerprint"),
+					resource.TestCheckResourceAttrSet("vcf_backup_restore.restore", "restored_file"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceBackupRestoreConfig() string {
+	return fmt.Sprintf(`
+		resource "vcf_backup_restore" "restore" {
+			backup_file           = %q
+			encryption_passphrase = %q
+			appliance_password    = %q
+
+			backup_server {
+				server    = %q
+				user_name = %q
+				password  = %q
+			}
+		}
+`, os.Getenv(constants.VcfTestBackupFile), os.Getenv(constants.VcfTestBackupPassphrase),
+		os.Getenv(constants.VcfTestApplianceVcfPassword), os.Getenv(constants.VcfTestBackupServer),
+		os.Getenv(constants.VcfTestBackupUsername), os.Getenv(constants.VcfTestBackupPassword))
+}
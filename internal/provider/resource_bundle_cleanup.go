@@ -0,0 +1,143 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func ResourceBundleCleanup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBundleCleanupCreate,
+		ReadContext:   resourceBundleCleanupRead,
+		UpdateContext: resourceBundleCleanupUpdate,
+		DeleteContext: resourceBundleCleanupDelete,
+		Description:   "Deletes downloaded LCM bundles which are no longer needed from the LCM repository of SDDC Manager",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"bundle_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The IDs of the bundles to delete",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"appliance_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "vcf",
+				Description: "The user used to connect to the SDDC Manager appliance over SSH",
+			},
+			"appliance_password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The password of the user used to connect to the SDDC Manager appliance over SSH",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"appliance_root_password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The password of the root user of the SDDC Manager appliance, the cleanup script runs as root",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"appliance_host_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The SSH public key of the SDDC Manager appliance in authorized_keys format, e.g. the output of " +
+					"ssh-keyscan -t ecdsa <sddc-manager>. Required unless allow_unverified_tls is set on the provider, in which " +
+					"case the key of the appliance is not verified if omitted",
+			},
+			"download_status": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The download status of each deleted bundle, PENDING once the bundle has been deleted",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceBundleCleanupCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	bundleIds := bundleCleanupIds(data)
+	connection := lcm.ApplianceConnection{
+		Username: data.Get("appliance_username").(string),
+		Password: data.Get("appliance_password").(string),
+		HostKey:  data.Get("appliance_host_key").(string),
+	}
+
+	if err := lcm.CleanupBundles(ctx, bundleIds, connection, data.Get("appliance_root_password").(string), vcfClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := utils.HashFields([]string{strings.Join(bundleIds, ","), time.Now().String()})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+
+	return resourceBundleCleanupRead(ctx, data, meta)
+}
+
+func resourceBundleCleanupRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	downloadStatus := make(map[string]string)
+	for _, bundleId := range bundleCleanupIds(data) {
+		bundle, err := lcm.GetBundle(ctx, bundleId, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if bundle.DownloadStatus != nil {
+			downloadStatus[bundleId] = *bundle.DownloadStatus
+		}
+	}
+	_ = data.Set("download_status", downloadStatus)
+
+	return nil
+}
+
+func resourceBundleCleanupUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only the SSH connection settings can change, they are used at creation only
+	return resourceBundleCleanupRead(ctx, data, meta)
+}
+
+func resourceBundleCleanupDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The bundles are not downloaded again, vcf_bundle_download does that
+	return nil
+}
+
+func bundleCleanupIds(data *schema.ResourceData) []string {
+	bundleIds := make([]string, 0)
+	for _, bundleId := range data.Get("bundle_ids").(*schema.Set).List() {
+		bundleIds = append(bundleIds, bundleId.(string))
+	}
+	slices.Sort(bundleIds)
+
+	return bundleIds
+}
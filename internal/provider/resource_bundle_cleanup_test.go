@@ -0,0 +1,45 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceBundleCleanup(t *testing.T) {
+	bundleId := os.Getenv(constants.VcfTestCleanupBundleId)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBundleCleanupConfig(
+					bundleId,
+					os.Getenv(constants.VcfTestApplianceVcfPassword),
+					os.Getenv(constants.VcfTestApplianceRootPassword)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_bundle_cleanup.cleanup", "bundle_ids.#", "1"),
+					resource.TestCheckResourceAttrSet("vcf_bundle_cleanup.cleanup", "download_status."+bundleId),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceBundleCleanupConfig(bundleId, appliancePassword, rootPassword string) string {
+	return fmt.Sprintf(`
+		resource "vcf_bundle_cleanup" "cleanup" {
+			bundle_ids              = [%q]
+			appliance_password      = %q
+			appliance_root_password = %q
+		}
+`, bundleId, appliancePassword, rootPassword)
+}
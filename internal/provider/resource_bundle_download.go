@@ -0,0 +1,177 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+func ResourceBundleDownload() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBundleDownloadCreate,
+		ReadContext:   resourceBundleDownloadRead,
+		DeleteContext: resourceBundleDownloadDelete,
+		Description: "Downloads an LCM bundle to SDDC Manager and waits for the download to complete, " +
+			"so that the bundle is staged for an upgrade",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(4 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(4 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"bundle_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"bundle_id", "component_type"},
+				Description:  "The ID of the bundle to download",
+			},
+			"component_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"bundle_id", "component_type"},
+				RequiredWith: []string{"component_type", "version"},
+				Description: "The type of the component which bundle will be downloaded, " +
+					"e.g. SDDC_MANAGER, VCENTER, NSX_T_MANAGER, HOST",
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"component_type", "version"},
+				Description:  "The version of the component the bundle upgrades to or installs",
+			},
+			"image_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The image type of the bundle when several bundles contain the component version. One among: PATCH, INSTALL",
+				ValidateFunc: validation.StringInSlice([]string{lcm.ImageTypePatch, lcm.ImageTypeInstall}, false),
+			},
+			"bundle_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the bundle",
+			},
+			"bundle_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the bundle",
+			},
+			"size_mb": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The size of the bundle in MB",
+			},
+			"download_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The download status of the bundle. SDDC Manager validates the checksum and the signature of the " +
+					"bundle as part of the download",
+			},
+			"applicability_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the bundle can be applied to the current environment",
+			},
+			"is_compliant": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the bundle is compliant with the current VCF version",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the download task. Empty if the bundle had already been downloaded",
+			},
+			"progress": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The estimated progress of the download in percent",
+			},
+		},
+	}
+}
+
+func resourceBundleDownloadCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	bundleId := data.Get("bundle_id").(string)
+	if len(bundleId) == 0 {
+		bundle, err := lcm.FindBundle(ctx, data.Get("component_type").(string), data.Get("version").(string),
+			data.Get("image_type").(string), vcfClient.ApiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		bundleId = bundle.ID
+	}
+
+	taskId, err := lcm.DownloadBundle(ctx, bundleId, vcfClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(bundleId)
+	_ = data.Set("bundle_id", bundleId)
+	_ = data.Set("task_id", taskId)
+
+	return resourceBundleDownloadRead(ctx, data, meta)
+}
+
+func resourceBundleDownloadRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	bundle, err := lcm.GetBundle(ctx, data.Id(), apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	setBundleAttributes(data, bundle)
+
+	switch data.Get("download_status").(string) {
+	case lcm.DownloadStatusSuccessful:
+		_ = data.Set("progress", 100)
+	case lcm.DownloadStatusInProgress:
+		if taskId := data.Get("task_id").(string); len(taskId) > 0 {
+			progress, err := lcm.GetTransferProgress(ctx, taskId, apiClient)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			_ = data.Set("progress", progress)
+		}
+	}
+
+	return nil
+}
+
+func resourceBundleDownloadDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// SDDC Manager has no API to delete a downloaded bundle, it stays in the LCM repository
+	return nil
+}
+
+func setBundleAttributes(data *schema.ResourceData, bundle *models.Bundle) {
+	_ = data.Set("bundle_version", bundle.Version)
+	_ = data.Set("size_mb", bundle.SizeMB)
+	_ = data.Set("applicability_status", bundle.ApplicabilityStatus)
+	_ = data.Set("is_compliant", bundle.IsCompliant)
+	if bundle.Type != nil {
+		_ = data.Set("bundle_type", *bundle.Type)
+	}
+	if bundle.DownloadStatus != nil {
+		_ = data.Set("download_status", *bundle.DownloadStatus)
+	}
+}
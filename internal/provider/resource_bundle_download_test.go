@@ -0,0 +1,40 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceBundleDownload(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBundleDownloadConfig(os.Getenv(constants.VcfTestBundleId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_bundle_download.bundle", "id", os.Getenv(constants.VcfTestBundleId)),
+					resource.TestCheckResourceAttr("vcf_bundle_download.bundle", "download_status", "SUCCESSFUL"),
+					resource.TestCheckResourceAttr("vcf_bundle_download.bundle", "progress", "100"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceBundleDownloadConfig(bundleId string) string {
+	return fmt.Sprintf(`
+		resource "vcf_bundle_download" "bundle" {
+			bundle_id = %q
+		}
+`, bundleId)
+}
@@ -0,0 +1,172 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func ResourceBundleUpload() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBundleUploadCreate,
+		ReadContext:   resourceBundleUploadRead,
+		UpdateContext: resourceBundleUploadUpdate,
+		DeleteContext: resourceBundleUploadDelete,
+		Description: "Uploads an LCM bundle to SDDC Manager from a local path or an HTTP(S) URL and waits for its validation, " +
+			"for sites which cannot download the bundles from a depot",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(4 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(4 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"bundle_file": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The local path or the HTTP(S) URL of the bundle tarball",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"manifest_file": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The local path or the HTTP(S) URL of the manifest file of the bundle",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"signature_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The local path or the HTTP(S) URL of the signature file of the bundle",
+			},
+			"remote_directory": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "/nfs/vmware/vcf/nfs-mount/bundle",
+				Description: "The directory of the SDDC Manager appliance the files are copied to",
+			},
+			"appliance_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "vcf",
+				Description: "The user used to copy the files to the SDDC Manager appliance over SSH",
+			},
+			"appliance_password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The password of the user used to copy the files to the SDDC Manager appliance over SSH",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"appliance_host_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The SSH public key of the SDDC Manager appliance in authorized_keys format, e.g. the output of " +
+					"ssh-keyscan -t ecdsa <sddc-manager>. Required unless allow_unverified_tls is set on the provider, in which " +
+					"case the key of the appliance is not verified if omitted",
+			},
+			"bundle_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the uploaded bundle",
+			},
+			"download_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The download status of the uploaded bundle",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the upload task",
+			},
+			"progress": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The estimated progress of the upload in percent",
+			},
+		},
+	}
+}
+
+func resourceBundleUploadCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	spec := lcm.BundleUploadSpec{
+		BundleFile:      data.Get("bundle_file").(string),
+		ManifestFile:    data.Get("manifest_file").(string),
+		SignatureFile:   data.Get("signature_file").(string),
+		RemoteDirectory: data.Get("remote_directory").(string),
+	}
+	connection := lcm.ApplianceConnection{
+		Username: data.Get("appliance_username").(string),
+		Password: data.Get("appliance_password").(string),
+		HostKey:  data.Get("appliance_host_key").(string),
+	}
+
+	taskId, bundleId, err := lcm.UploadBundle(ctx, spec, connection, vcfClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := utils.HashFields([]string{spec.BundleFile, spec.ManifestFile, taskId})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+	_ = data.Set("task_id", taskId)
+	_ = data.Set("bundle_id", bundleId)
+	_ = data.Set("progress", 100)
+
+	diags := resourceBundleUploadRead(ctx, data, meta)
+	if len(bundleId) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Uploaded bundle not found",
+			Detail:   "The bundle was uploaded but the upload task " + taskId + " does not reference it, bundle_id is empty",
+		})
+	}
+
+	return diags
+}
+
+func resourceBundleUploadRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bundleId := data.Get("bundle_id").(string)
+	if len(bundleId) == 0 {
+		return nil
+	}
+
+	bundle, err := lcm.GetBundle(ctx, bundleId, meta.(*api_client.SddcManagerClient).ApiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if bundle.DownloadStatus != nil {
+		_ = data.Set("download_status", *bundle.DownloadStatus)
+	}
+
+	return nil
+}
+
+func resourceBundleUploadUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only the SSH connection settings can change, they are used at creation only
+	return resourceBundleUploadRead(ctx, data, meta)
+}
+
+func resourceBundleUploadDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// SDDC Manager has no API to delete an uploaded bundle, it stays in the LCM repository
+	return nil
+}
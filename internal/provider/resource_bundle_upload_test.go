@@ -0,0 +1,44 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceBundleUpload(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBundleUploadConfig(
+					os.Getenv(constants.VcfTestBundleFile),
+					os.Getenv(constants.VcfTestBundleManifestFile),
+					os.Getenv(constants.VcfTestApplianceVcfPassword)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_bundle_upload.bundle", "task_id"),
+					resource.TestCheckResourceAttrSet("vcf_bundle_upload.bundle", "bundle_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceBundleUploadConfig(bundleFile, manifestFile, appliancePassword string) string {
+	return fmt.Sprintf(`
+		resource "vcf_bundle_upload" "bundle" {
+			bundle_file        = %q
+			manifest_file      = %q
+			appliance_password = %q
+		}
+`, bundleFile, manifestFile, appliancePassword)
+}
@@ -34,25 +34,37 @@ func ResourceCertificate() *schema.Resource {
 			Update: schema.DefaultTimeout(50 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
-		Schema: map[string]*schema.Schema{
-			"csr_id": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The ID of the CSR generated for a resource",
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"ca_id": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Certificate of the CA issuing the replacement certificate",
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"certificate": {
-				Type:        schema.TypeList,
-				Computed:    true,
-				Description: "The resulting Certificate details",
-				Elem:        certificates.CertificateSchema(),
-			},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{{
+			Version: 0,
+			Type:    (&schema.Resource{Schema: resourceCertificateSchema()}).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceCertificateStateUpgradeV0,
+		}},
+		Schema: resourceCertificateSchema(),
+	}
+}
+
+// resourceCertificateSchema returns the schema of the resource, unchanged since version 0 but for the format
+// of the CSR ID.
+func resourceCertificateSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"csr_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The ID of the CSR generated for a resource",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"ca_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Certificate of the CA issuing the replacement certificate",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"certificate": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The resulting Certificate details",
+			Elem:        certificates.CertificateSchema(),
 		},
 	}
 }
@@ -110,7 +122,7 @@ func resourceResourceCertificateCreate(ctx context.Context, data *schema.Resourc
 }
 
 func resourceResourceCertificateRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+	vcfClient := meta.(*api_client.SddcManagerClient)
 
 	csrID := data.Get("csr_id").(string)
 	csrIdComponents := strings.Split(csrID, ":")
@@ -121,7 +133,7 @@ func resourceResourceCertificateRead(ctx context.Context, data *schema.ResourceD
 	domainID := csrIdComponents[1]
 	resourceFqdn := csrIdComponents[3]
 
-	cert, err := certificates.ReadCertificate(ctx, apiClient, domainID, resourceFqdn)
+	cert, err := certificates.ReadCertificate(ctx, vcfClient, domainID, resourceFqdn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -139,3 +151,21 @@ func resourceResourceCertificateUpdate(ctx context.Context, data *schema.Resourc
 func resourceResourceCertificateDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	return nil
 }
+
+// resourceCertificateStateUpgradeV0 adds the FQDN of the resource, to which the certificate is issued, to the
+// CSR IDs of the format used before version 0.10.0 of the provider.
+func resourceCertificateStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	csrId, _ := rawState["csr_id"].(string)
+	var resourceFqdn string
+	if certificateList, ok := rawState["certificate"].([]interface{}); ok && len(certificateList) > 0 {
+		if certificate, ok := certificateList[0].(map[string]interface{}); ok {
+			resourceFqdn, _ = certificate["issued_to"].(string)
+		}
+	}
+	rawState["csr_id"] = certificates.UpgradeCsrIdV0(csrId, resourceFqdn)
+
+	return rawState, nil
+}
@@ -5,11 +5,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 )
@@ -172,3 +174,18 @@ func testAccVcfResourceCertificate(domainID, msftCaServerUrl, msftCaUser, msftCa
 		fqdn,
 	)
 }
+
+func TestResourceCertificateStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":     "cert:domain-1:VCENTER:task-2",
+		"csr_id": "csr:domain-1:VCENTER:task-1",
+		"ca_id":  "OpenSSL",
+		"certificate": []interface{}{map[string]interface{}{
+			"issued_to": "sfo-m01-vc01.sfo.rainpole.io",
+		}},
+	}
+	upgraded, err := resourceCertificateStateUpgradeV0(context.Background(), rawState, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "csr:domain-1:VCENTER:sfo-m01-vc01.sfo.rainpole.io:task-1", upgraded["csr_id"])
+	assert.Equal(t, "cert:domain-1:VCENTER:task-2", upgraded["id"])
+}
@@ -8,7 +8,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
@@ -31,23 +30,6 @@ import (
 )
 
 func ResourceCluster() *schema.Resource {
-	clusterResourceSchema := clusterSubresourceSchema().Schema
-	clusterResourceSchema["domain_id"] = &schema.Schema{
-		Type:         schema.TypeString,
-		Optional:     true,
-		Computed:     true,
-		Description:  "The ID of a workload domain that the cluster belongs to",
-		ValidateFunc: validation.NoZeroValues,
-	}
-
-	clusterResourceSchema["domain_name"] = &schema.Schema{
-		Type:         schema.TypeString,
-		Optional:     true,
-		Computed:     true,
-		Description:  "The name of a workload domain that the cluster belongs to",
-		ValidateFunc: validation.NoZeroValues,
-	}
-
 	return &schema.Resource{
 		CreateContext: resourceClusterCreate,
 		ReadContext:   resourceClusterRead,
@@ -60,7 +42,13 @@ func ResourceCluster() *schema.Resource {
 				return cluster.ImportCluster(ctx, data, apiClient, clusterId)
 			},
 		},
-		Schema: clusterResourceSchema,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{{
+			Version: 0,
+			Type:    (&schema.Resource{Schema: resourceClusterSchema()}).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceClusterStateUpgradeV0,
+		}},
+		Schema: resourceClusterSchema(),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Hour),
 			Read:   schema.DefaultTimeout(10 * time.Minute),
@@ -70,6 +58,62 @@ func ResourceCluster() *schema.Resource {
 	}
 }
 
+// resourceClusterSchema returns the schema of the cluster subresource of a domain with the attributes
+// referencing the domain.
+func resourceClusterSchema() map[string]*schema.Schema {
+	clusterResourceSchema := clusterSubresourceSchema().Schema
+	clusterResourceSchema["domain_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "The ID of a workload domain that the cluster belongs to",
+		ValidateFunc: validation.NoZeroValues,
+	}
+
+	clusterResourceSchema["domain_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "The name of a workload domain that the cluster belongs to",
+		ValidateFunc: validation.NoZeroValues,
+	}
+
+	return clusterResourceSchema
+}
+
+// resourceClusterStateUpgradeV0 sets the ID of the domain in the state of the clusters imported before version
+// 0.4.0 of the provider, which did not store it. The schema is otherwise unchanged.
+func resourceClusterStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	if domainId, _ := rawState["domain_id"].(string); domainId != "" {
+		return rawState, nil
+	}
+	clusterId, _ := rawState["id"].(string)
+	vcfClient, ok := meta.(*api_client.SddcManagerClient)
+	if !ok || clusterId == "" {
+		return rawState, nil
+	}
+
+	domainsResult, err := vcfClient.ApiClient.Domains.GetDomains(
+		domains.NewGetDomainsParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+	for _, domain := range domainsResult.Payload.Elements {
+		for _, clusterRef := range domain.Clusters {
+			if clusterRef.ID != nil && *clusterRef.ID == clusterId {
+				rawState["domain_id"] = domain.ID
+				rawState["domain_name"] = domain.Name
+				return rawState, nil
+			}
+		}
+	}
+
+	return rawState, nil
+}
+
 // clusterSubresourceSchema this helper function extracts the Cluster schema, so that
 // it's made available for merging in the Domain resource schema.
 func clusterSubresourceSchema() *schema.Resource {
@@ -177,11 +221,25 @@ func clusterSubresourceSchema() *schema.Resource {
 			"ip_address_pool": {
 				Type:     schema.TypeList,
 				Optional: true,
-				MaxItems: 1,
 				Description: "Contains the parameters required to create or reuse an IP address pool. Omit for DHCP, " +
-					"provide name only to reuse existing IP Pool, if subnets are provided a new IP Pool will be created",
+					"provide name only to reuse existing IP Pool, if subnets are provided a new IP Pool will be created. " +
+					"Multiple pools can be specified for routed (L3) TEP configurations, in which case each pool " +
+					"is referenced by name from a network_profile",
 				Elem: network.IpAddressPoolSchema(),
 			},
+			"uplink_profile": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The list of NSX uplink profiles for the cluster hosts",
+				Elem:        network.UplinkProfileSchema(),
+			},
+			"network_profile": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "The list of network profiles. Hosts reference a network profile by name to use a " +
+					"different NSX host switch configuration, e.g. a per-rack TEP IP address pool",
+				Elem: network.NetworkProfileSchema(),
+			},
 			"vds": {
 				Type:        schema.TypeList,
 				Required:    true,
@@ -250,6 +308,16 @@ func resourceClusterCreate(ctx context.Context, data *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
+	// The failed creation of a previous apply is not in the state, SDDC Manager keeps its progress
+	resumedClusterId, err := cluster.ResumeClusterCreation(ctx, vcfClient, domainId, data.Get("name").(string))
+	if err != nil {
+		return creationTaskDiagnostics(err, "Cluster")
+	}
+	if resumedClusterId != "" {
+		data.SetId(resumedClusterId)
+		return resourceClusterRead(ctx, data, meta)
+	}
+
 	clusterId, diagnostics := createCluster(ctx, domainId, clusterSpec, vcfClient)
 	if diagnostics != nil {
 		return diagnostics
@@ -269,6 +337,10 @@ func resourceClusterRead(ctx context.Context, data *schema.ResourceData, meta in
 
 	clusterResult, err := apiClient.Clusters.GetCluster(getClusterParams)
 	if err != nil {
+		var notFound *clusters.GetClusterNotFound
+		if errors.As(err, &notFound) {
+			return removeFromState(data, "Cluster")
+		}
 		return diag.FromErr(err)
 	}
 	clusterObj := clusterResult.Payload
@@ -341,7 +413,7 @@ func createCluster(ctx context.Context, domainId string, clusterSpec *models.Clu
 	taskId := accepted.Payload.ID
 	err = vcfClient.WaitForTaskComplete(ctx, taskId, true)
 	if err != nil {
-		return "", diag.FromErr(err)
+		return "", creationTaskDiagnostics(err, "Cluster")
 	}
 	clusterId, err := vcfClient.GetResourceIdAssociatedWithTask(ctx, taskId, "Cluster")
 	if err != nil {
@@ -382,44 +454,7 @@ func updateCluster(ctx context.Context, clusterId string, clusterUpdateSpec *mod
 }
 
 func deleteCluster(ctx context.Context, clusterId string, vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
-	clusterUpdateParams := clusters.NewUpdateClusterParamsWithContext(ctx).
-		WithTimeout(constants.DefaultVcfApiCallTimeout)
-	clusterUpdateParams.ID = clusterId
-	clusterUpdateSpec, _ := cluster.CreateClusterUpdateSpec(nil, true)
-	clusterUpdateParams.SetClusterUpdateSpec(clusterUpdateSpec)
-
-	apiClient := vcfClient.ApiClient
-	log.Printf("Marking Cluster %s for deletion", clusterId)
-	acceptedUpdateTask, acceptedUpdateTask2, err := apiClient.Clusters.UpdateCluster(clusterUpdateParams)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	var taskId string
-	if acceptedUpdateTask != nil {
-		taskId = acceptedUpdateTask.Payload.ID
-	}
-	if acceptedUpdateTask2 != nil {
-		taskId = acceptedUpdateTask2.Payload.ID
-	}
-	err = vcfClient.WaitForTaskComplete(ctx, taskId, false)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	clusterDeleteParams := clusters.NewDeleteClusterParamsWithContext(ctx).
-		WithTimeout(constants.DefaultVcfApiCallTimeout)
-	clusterDeleteParams.ID = clusterId
-
-	log.Printf("Deleting Cluster %s", clusterId)
-	_, acceptedDeleteTask, err := apiClient.Clusters.DeleteCluster(clusterDeleteParams)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if acceptedDeleteTask != nil {
-		taskId = acceptedDeleteTask.Payload.ID
-	}
-	err = vcfClient.WaitForTaskComplete(ctx, taskId, true)
-	if err != nil {
+	if err := cluster.DeleteCluster(ctx, vcfClient, clusterId); err != nil {
 		return diag.FromErr(err)
 	}
 	return nil
@@ -17,22 +17,21 @@ import (
 	"github.com/vmware/vcf-sdk-go/models"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
-)
-
-const (
-	uploadModeReferred = "REFERRED"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
 )
 
 func ResourceClusterPersonality() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceClusterPersonalityCreate,
 		ReadContext:   resourceClusterPersonalityRead,
+		UpdateContext: resourceClusterPersonalityUpdate,
 		DeleteContext: resourceClusterPersonalityDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
@@ -46,80 +45,300 @@ func ResourceClusterPersonality() *schema.Resource {
 			},
 			"domain_id": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				Description:  "The identifier of the domain which contains the vcenter and source cluster",
 				ValidateFunc: validation.NoZeroValues,
 				ForceNew:     true,
+				ExactlyOneOf: []string{"domain_id", "image_depot"},
+				RequiredWith: []string{"domain_id", "cluster_id"},
 			},
 			"cluster_id": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				Description:  "The identifier of the source cluster within the vCenter server (e.g. domain-c1)",
 				ValidateFunc: validation.NoZeroValues,
 				ForceNew:     true,
+				RequiredWith: []string{"domain_id", "cluster_id"},
+			},
+			"image_depot": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"domain_id", "image_depot"},
+				Description: "The files of a cluster image exported from vSphere Lifecycle Manager. The files are copied " +
+					"to the SDDC Manager appliance over SSH and the personality is created from them",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zip_file": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The local path or the HTTP(S) URL of the offline depot ZIP file of the image",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"json_file": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The local path or the HTTP(S) URL of the JSON specification of the image",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"info_json_file": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The local path or the HTTP(S) URL of the JSON file with the information about the image",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"iso_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The local path or the HTTP(S) URL of the ISO file of the image",
+						},
+						"remote_directory": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Default:     "/nfs/vmware/vcf/nfs-mount/personality",
+							Description: "The directory of the SDDC Manager appliance the files are copied to",
+						},
+						"appliance_username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "vcf",
+							Description: "The user used to copy the files to the SDDC Manager appliance over SSH",
+						},
+						"appliance_password": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							Description:  "The password of the user used to copy the files to the SDDC Manager appliance over SSH",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"appliance_host_key": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "The SSH public key of the SDDC Manager appliance in authorized_keys format, e.g. the output of " +
+								"ssh-keyscan -t ecdsa <sddc-manager>. Required unless allow_unverified_tls is set on the provider, in which " +
+								"case the key of the appliance is not verified if omitted",
+						},
+					},
+				},
+			},
+			"image_customization": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"image_depot"},
+				Description: "The vendor add-on, firmware package and components set on the image of the source cluster before " +
+					"the personality is extracted from it. The image is changed through the vSphere Lifecycle Manager API of " +
+					"the vCenter server, the hosts of the cluster are not remediated",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vcenter_username": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The user used to change the image of the source cluster in the vCenter server",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"vcenter_password": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							Description:  "The password of the user used to change the image of the source cluster in the vCenter server",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"add_on": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Description: "The vendor add-on of the image",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										Description:  "The name of the add-on",
+										ValidateFunc: validation.NoZeroValues,
+									},
+									"version": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										Description:  "The version of the add-on",
+										ValidateFunc: validation.NoZeroValues,
+									},
+								},
+							},
+						},
+						"hardware_support": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Description: "The firmware and drivers package of a hardware support manager (HSM) registered in the vCenter server",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"manager": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										Description:  "The name of the hardware support manager, e.g. com.dell.om",
+										ValidateFunc: validation.NoZeroValues,
+									},
+									"package": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										Description:  "The name of the firmware and drivers package",
+										ValidateFunc: validation.NoZeroValues,
+									},
+									"version": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										Description:  "The version of the firmware and drivers package",
+										ValidateFunc: validation.NoZeroValues,
+									},
+								},
+							},
+						},
+						"component": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "An additional component of the image, e.g. a driver",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										Description:  "The name of the component",
+										ValidateFunc: validation.NoZeroValues,
+									},
+									"version": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										Description:  "The version of the component",
+										ValidateFunc: validation.NoZeroValues,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the personality",
+			},
+			"esxi_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ESXi version of the base image of the personality",
 			},
 		},
 	}
 }
 
 func resourceClusterPersonalityCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*api_client.SddcManagerClient).ApiClient
+	vcfClient := meta.(*api_client.SddcManagerClient)
 
-	mode := uploadModeReferred
 	name := data.Get("name").(string)
 
-	vcenterId, err := getVcenterId(data, meta)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	if imageDepot, ok := data.GetOk("image_depot"); ok {
+		depot := imageDepot.([]interface{})[0].(map[string]interface{})
+		files := lcm.PersonalityImageFiles{
+			ZipFile:         depot["zip_file"].(string),
+			JsonFile:        depot["json_file"].(string),
+			InfoJsonFile:    depot["info_json_file"].(string),
+			IsoFile:         depot["iso_file"].(string),
+			RemoteDirectory: depot["remote_directory"].(string),
+		}
+		connection := lcm.ApplianceConnection{
+			Username: depot["appliance_username"].(string),
+			Password: depot["appliance_password"].(string),
+			HostKey:  depot["appliance_host_key"].(string),
+		}
 
-	clusterId := data.Get("cluster_id").(string)
+		if err := lcm.UploadPersonalityFromFiles(ctx, name, files, connection, vcfClient); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		vcenter, err := getVcenter(data, meta)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-	spec := models.PersonalityUploadSpec{
-		Name:       name,
-		UploadMode: &mode,
-		UploadSpecReferredMode: &models.PersonalityUploadSpecReferred{
-			ClusterID: &clusterId,
-			VCenterID: vcenterId,
-		},
-	}
+		mode := lcm.PersonalityUploadModeReferred
+		clusterId := data.Get("cluster_id").(string)
 
-	_, task, err := client.Personalities.UploadPersonality(&personalities.UploadPersonalityParams{PersonalityUploadSpec: &spec})
+		if customizations := data.Get("image_customization").([]interface{}); len(customizations) > 0 && customizations[0] != nil {
+			customization := customizations[0].(map[string]interface{})
+			connection := lcm.VcenterConnection{
+				Host:     vcenter.Fqdn,
+				Username: customization["vcenter_username"].(string),
+				Password: customization["vcenter_password"].(string),
+			}
+			if err := lcm.CustomizeClusterImage(ctx, connection, clusterId, toImageCustomization(customization)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
 
-	if err != nil {
-		return diag.FromErr(err)
-	}
+		spec := &models.PersonalityUploadSpec{
+			Name:       name,
+			UploadMode: &mode,
+			UploadSpecReferredMode: &models.PersonalityUploadSpecReferred{
+				ClusterID: &clusterId,
+				VCenterID: &vcenter.ID,
+			},
+		}
 
-	if err := meta.(*api_client.SddcManagerClient).WaitForTaskComplete(ctx, task.Payload.ID, false); err != nil {
-		return diag.FromErr(err)
+		if err := lcm.UploadPersonality(ctx, spec, vcfClient); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	if personalitiesResp, err := client.Personalities.GetPersonalities(&personalities.GetPersonalitiesParams{
-		PersonalityName: &name,
-	}); err != nil {
+	personality, err := lcm.GetPersonalityByName(ctx, name, vcfClient.ApiClient)
+	if err != nil {
 		return diag.FromErr(err)
-	} else if len(personalitiesResp.Payload.Elements) == 0 {
-		return diag.Errorf("Personality %s not found", name)
-	} else {
-		data.SetId(*personalitiesResp.Payload.Elements[0].PersonalityID)
 	}
+	data.SetId(*personality.PersonalityID)
 
-	return nil
+	return resourceClusterPersonalityRead(ctx, data, meta)
 }
 
 func resourceClusterPersonalityRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api_client.SddcManagerClient).ApiClient
 
-	// Just check if the personality exists. There are no computed attributes.
-	if _, err := client.Personalities.GetPersonality(&personalities.GetPersonalityParams{
-		PersonalityID: data.Id(),
-	}); err != nil {
+	personality, err := lcm.GetPersonality(ctx, data.Id(), client)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if personality.PersonalityName != nil {
+		_ = data.Set("name", *personality.PersonalityName)
+	}
+	if personality.Version != nil {
+		_ = data.Set("version", *personality.Version)
+	}
+	_ = data.Set("esxi_version", lcm.PersonalityBaseImageVersion(personality))
+
 	return nil
 }
 
+func resourceClusterPersonalityUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only the credentials used to copy the image files or to customize the image can change,
+	// they are used only when the personality is created
+	return resourceClusterPersonalityRead(ctx, data, meta)
+}
+
 func resourceClusterPersonalityDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api_client.SddcManagerClient).ApiClient
 
@@ -133,7 +352,7 @@ func resourceClusterPersonalityDelete(ctx context.Context, data *schema.Resource
 	return nil
 }
 
-func getVcenterId(data *schema.ResourceData, meta interface{}) (*string, error) {
+func getVcenter(data *schema.ResourceData, meta interface{}) (*models.Vcenter, error) {
 	client := meta.(*api_client.SddcManagerClient).ApiClient
 
 	domainId := data.Get("domain_id").(string)
@@ -143,10 +362,38 @@ func getVcenterId(data *schema.ResourceData, meta interface{}) (*string, error)
 	} else {
 		for _, vc := range vcs.Payload.Elements {
 			if *vc.Domain.ID == domainId {
-				return &vc.ID, nil
+				return vc, nil
 			}
 		}
 	}
 
 	return nil, fmt.Errorf("vcenter for domain %s not found", domainId)
 }
+
+func toImageCustomization(customization map[string]interface{}) lcm.ImageCustomization {
+	result := lcm.ImageCustomization{}
+	if addOns := customization["add_on"].([]interface{}); len(addOns) > 0 && addOns[0] != nil {
+		addOn := addOns[0].(map[string]interface{})
+		result.AddOn = &lcm.ImageAddOn{
+			Name:    addOn["name"].(string),
+			Version: addOn["version"].(string),
+		}
+	}
+	if packages := customization["hardware_support"].([]interface{}); len(packages) > 0 && packages[0] != nil {
+		hardwareSupport := packages[0].(map[string]interface{})
+		result.HardwareSupport = &lcm.ImageHardwareSupport{
+			Manager: hardwareSupport["manager"].(string),
+			Package: hardwareSupport["package"].(string),
+			Version: hardwareSupport["version"].(string),
+		}
+	}
+	if components := customization["component"].([]interface{}); len(components) > 0 {
+		result.Components = make(map[string]string, len(components))
+		for _, raw := range components {
+			component := raw.(map[string]interface{})
+			result.Components[component["name"].(string)] = component["version"].(string)
+		}
+	}
+
+	return result
+}
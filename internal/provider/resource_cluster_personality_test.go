@@ -23,6 +23,24 @@ func TestAccClusterPersonality_basic(t *testing.T) {
 				Config: getClusterPersonalityConfig(),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet("vcf_cluster_personality.personality", "id"),
+					resource.TestCheckResourceAttrSet("vcf_cluster_personality.personality", "esxi_version"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClusterPersonality_imageDepot(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPersonalityImageDepotPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: getClusterPersonalityImageDepotConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_cluster_personality.personality", "id"),
+					resource.TestCheckResourceAttrSet("vcf_cluster_personality.personality", "version"),
+					resource.TestCheckResourceAttrSet("vcf_cluster_personality.personality", "esxi_version"),
 				),
 			},
 		},
@@ -41,6 +59,24 @@ func getClusterPersonalityConfig() string {
 		os.Getenv(constants.VcfTestDomainDataSourceId))
 }
 
+func getClusterPersonalityImageDepotConfig() string {
+	return fmt.Sprintf(`
+		resource "vcf_cluster_personality" "personality" {
+			name = "personality2"
+			image_depot {
+				zip_file           = %q
+				json_file          = %q
+				info_json_file     = %q
+				appliance_password = %q
+			}
+		}
+		`,
+		os.Getenv(constants.VcfTestPersonalityZipFile),
+		os.Getenv(constants.VcfTestPersonalityJsonFile),
+		os.Getenv(constants.VcfTestPersonalityInfoJsonFile),
+		os.Getenv(constants.VcfTestApplianceVcfPassword))
+}
+
 // testAccPreCheck validates all required environment variables for running these acceptance
 // tests are set.
 func testAccPersonalityPreCheck(t *testing.T) {
@@ -51,3 +87,12 @@ func testAccPersonalityPreCheck(t *testing.T) {
 		t.Fatalf("%s must be set for acceptance tests", constants.VcfTestDomainDataSourceId)
 	}
 }
+
+func testAccPersonalityImageDepotPreCheck(t *testing.T) {
+	for _, variable := range []string{constants.VcfTestPersonalityZipFile, constants.VcfTestPersonalityJsonFile,
+		constants.VcfTestPersonalityInfoJsonFile, constants.VcfTestApplianceVcfPassword} {
+		if v := os.Getenv(variable); v == "" {
+			t.Fatalf("%s must be set for acceptance tests", variable)
+		}
+	}
+}
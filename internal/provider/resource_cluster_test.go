@@ -14,10 +14,12 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 	"github.com/vmware/vcf-sdk-go/client/clusters"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/simulator"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
@@ -611,3 +613,18 @@ func clusterImportStateCheck(states []*terraform.InstanceState) error {
 	}
 	return fmt.Errorf("cluster InstanceState not found! Import failed")
 }
+
+func TestResourceClusterStateUpgradeV0(t *testing.T) {
+	meta := testSimulatorMeta(t)
+
+	upgraded, err := resourceClusterStateUpgradeV0(context.Background(),
+		map[string]interface{}{"id": simulator.ManagementClusterId, "name": "sfo-m01-cl01"}, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, simulator.ManagementDomainId, upgraded["domain_id"])
+	assert.Equal(t, "sfo-m01", upgraded["domain_name"])
+
+	upgraded, err = resourceClusterStateUpgradeV0(context.Background(),
+		map[string]interface{}{"id": simulator.ManagementClusterId, "domain_id": "domain-1"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "domain-1", upgraded["domain_id"])
+}
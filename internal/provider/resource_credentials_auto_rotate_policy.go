@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,13 +18,24 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 )
 
 func ResourceCredentialsAutoRotatePolicy() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceCredentialsAutoRotatePolicyCreate,
 		ReadContext:   resourceCredentialsAutoRotatePolicyRead,
+		UpdateContext: resourceCredentialsAutoRotatePolicyUpdate,
 		DeleteContext: resourceCredentialsAutoRotatePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCredentialsAutoRotatePolicyImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"resource_id": {
 				Type:        schema.TypeString,
@@ -53,7 +66,6 @@ func ResourceCredentialsAutoRotatePolicy() *schema.Resource {
 				Type:        schema.TypeBool,
 				Required:    true,
 				Description: "Enable or disable the automatic credential rotation",
-				ForceNew:    true,
 			},
 			"auto_rotate_days": {
 				Type:         schema.TypeInt,
@@ -61,7 +73,6 @@ func ResourceCredentialsAutoRotatePolicy() *schema.Resource {
 				Optional:     true,
 				Description:  fmt.Sprintf("The number of days after the credentials will be automatically rotated. Must be between %v and %v", credentials.AutoRotateDaysMin, credentials.AutorotateDaysMax),
 				ValidateFunc: validation.All(validation.IntAtLeast(credentials.AutoRotateDaysMin), validation.IntAtMost(credentials.AutorotateDays90)),
-				ForceNew:     true,
 			},
 			"auto_rotate_next_schedule": {
 				Type:        schema.TypeString,
@@ -84,11 +95,10 @@ func resourceCredentialsAutoRotatePolicyCreate(ctx context.Context, d *schema.Re
 func resourceCredentialsAutoRotatePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
 	matchedCredentials, err := credentials.ReadCredentials(ctx, d, apiClient)
-	matchedCredentials = filterCredentials(d.Get("user_name").(string), d.Get("resource_id").(string), matchedCredentials)
-
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	matchedCredentials = filterCredentials(d.Get("user_name").(string), d.Get("resource_id").(string), matchedCredentials)
 
 	lenCredentials := len(matchedCredentials)
 	if lenCredentials != 1 {
@@ -108,14 +118,24 @@ func resourceCredentialsAutoRotatePolicyRead(ctx context.Context, d *schema.Reso
 		_ = d.Set("auto_rotate_days", matchedCredentials[0].AutoRotatePolicy.FrequencyInDays)
 		_ = d.Set("auto_rotate_next_schedule", matchedCredentials[0].AutoRotatePolicy.NextSchedule)
 	} else {
+		// auto_rotate_days is kept as configured, there is no frequency while the policy is disabled
 		_ = d.Set("enable_auto_rotation", false)
-		_ = d.Set("auto_rotate_days", 0)
 		_ = d.Set("auto_rotate_next_schedule", "")
 	}
 
 	return nil
 }
 
+func resourceCredentialsAutoRotatePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges("enable_auto_rotation", "auto_rotate_days") {
+		if err := credentials.CreateAutoRotatePolicy(ctx, d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceCredentialsAutoRotatePolicyRead(ctx, d, meta)
+}
+
 func resourceCredentialsAutoRotatePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if err := credentials.RemoveAutoRotatePolicy(ctx, d, meta); err != nil {
 		return diag.FromErr(err)
@@ -124,6 +144,20 @@ func resourceCredentialsAutoRotatePolicyDelete(ctx context.Context, d *schema.Re
 	return nil
 }
 
+// resourceCredentialsAutoRotatePolicyImport imports the policy of an account by the type and the name of its
+// resource and its user name, e.g. VCENTER:sfo-m01-vc01.sfo.rainpole.io:root. The read computes the ID.
+func resourceCredentialsAutoRotatePolicyImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 3)
+	if len(parts) != 3 || len(parts[0]) == 0 || len(parts[1]) == 0 || len(parts[2]) == 0 {
+		return nil, fmt.Errorf("invalid ID %q, expected <resource type>:<resource name>:<user name>", d.Id())
+	}
+	_ = d.Set("resource_type", strings.ToUpper(parts[0]))
+	_ = d.Set("resource_name", parts[1])
+	_ = d.Set("user_name", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func createAutorotateID(data *schema.ResourceData) (string, error) {
 	params := []string{
 		data.Get("resource_id").(string),
@@ -132,13 +166,17 @@ func createAutorotateID(data *schema.ResourceData) (string, error) {
 		data.Get("user_name").(string),
 	}
 
-	return credentials.HashFields(params)
+	return utils.HashFields(params)
 }
 
 func filterCredentials(userName, resourceId string, creds []*models.Credential) []*models.Credential {
 	result := make([]*models.Credential, 0)
 	for _, cred := range creds {
-		if *cred.Username == userName && cred.Resource != nil && *cred.Resource.ResourceID == resourceId {
+		if *cred.Username != userName || cred.Resource == nil {
+			continue
+		}
+		// The resource may be referenced by name only, in which case the API query already filtered it
+		if resourceId == "" || *cred.Resource.ResourceID == resourceId {
 			result = append(result, cred)
 		}
 	}
@@ -5,12 +5,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/vmware/terraform-provider-vcf/internal/credentials"
 )
@@ -55,3 +58,37 @@ func testAccAutorotatePolicyResourceIdConfig(rotateDays int) string {
 		}
 	`, rotateDays)
 }
+
+func TestAccResourceAutorotatePolicy_update(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccSDDCManagerOrCloudBuilderPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAutorotatePolicyResourceIdConfig(credentials.AutorotateDays30),
+				Check: resource.TestCheckResourceAttr("vcf_credentials_auto_rotate_policy.vc_0_autorotate",
+					"auto_rotate_days", fmt.Sprint(credentials.AutorotateDays30)),
+			},
+			{
+				Config: testAccAutorotatePolicyResourceIdConfig(credentials.AutorotateDays60),
+				Check: resource.TestCheckResourceAttr("vcf_credentials_auto_rotate_policy.vc_0_autorotate",
+					"auto_rotate_days", fmt.Sprint(credentials.AutorotateDays60)),
+			},
+		},
+	})
+}
+
+func TestResourceCredentialsAutoRotatePolicyImport(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, ResourceCredentialsAutoRotatePolicy().Schema, map[string]interface{}{})
+	data.SetId("vcenter:sfo-m01-vc01.sfo.rainpole.io:administrator@vsphere.local")
+	imported, err := resourceCredentialsAutoRotatePolicyImport(context.Background(), data, nil)
+	assert.NoError(t, err)
+	assert.Len(t, imported, 1)
+	assert.Equal(t, "VCENTER", imported[0].Get("resource_type"))
+	assert.Equal(t, "sfo-m01-vc01.sfo.rainpole.io", imported[0].Get("resource_name"))
+	assert.Equal(t, "administrator@vsphere.local", imported[0].Get("user_name"))
+
+	data.SetId("VCENTER:sfo-m01-vc01.sfo.rainpole.io")
+	_, err = resourceCredentialsAutoRotatePolicyImport(context.Background(), data, nil)
+	assert.Error(t, err)
+}
@@ -22,6 +22,12 @@ func ResourceCredentialsRotate() *schema.Resource {
 		ReadContext:   resourceCredentialsPasswordRotationRead,
 		CreateContext: resourceCredentialsPasswordRotationCreate,
 		DeleteContext: resourceCredentialsPasswordRotationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"resource_name": {
 				Type:        schema.TypeString,
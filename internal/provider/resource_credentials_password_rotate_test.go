@@ -29,6 +29,7 @@ func testAccResourceCredentialsPasswordRotateConfig() string {
 		data "vcf_credentials" "sddc_creds" {
 			resource_type = "VCENTER"
 			account_type = "USER"
+			include_passwords = true
 		}
 
 		resource "vcf_credentials_rotate" "vc_0_rotate" {
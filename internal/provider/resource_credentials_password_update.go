@@ -6,15 +6,18 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func ResourceCredentialsUpdate() *schema.Resource {
@@ -22,6 +25,13 @@ func ResourceCredentialsUpdate() *schema.Resource {
 		ReadContext:   resourceCredentialsPasswordUpdateRead,
 		CreateContext: resourceCredentialsPasswordUpdateCreate,
 		DeleteContext: resourceCredentialsPasswordUpdateDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{validateCredentialsUpdatePasswords},
 		Schema: map[string]*schema.Schema{
 			"resource_name": {
 				Type:        schema.TypeString,
@@ -30,10 +40,11 @@ func ResourceCredentialsUpdate() *schema.Resource {
 				ForceNew:    true,
 			},
 			"resource_type": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The type of the resource which credentials will be updated",
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The type of the resource which credentials will be updated",
+				ValidateFunc: validation.StringInSlice(credentials.AllResourceTypes(), false),
+				ForceNew:     true,
 			},
 			"credentials": {
 				Type:        schema.TypeList,
@@ -56,14 +67,30 @@ func ResourceCredentialsUpdate() *schema.Resource {
 							Required:    true,
 						},
 						"password": {
-							Type:        schema.TypeString,
-							Description: "The password for the account.",
-							Required:    true,
-							Sensitive:   true,
+							Type: schema.TypeString,
+							Description: "The password for the account. Exactly one of password or password_wo has to be set. " +
+								"The passwords of ESXi, vCenter, PSC, NSX Manager and NSX edge accounts are checked against " +
+								"the password policy of the resource type and must not contain the host name of resource_name.",
+							Optional:  true,
+							Sensitive: true,
+						},
+						"password_wo": {
+							Type: schema.TypeString,
+							Description: "The password for the account as a write-only argument which is never stored in the state. " +
+								"Requires Terraform 1.11 or later. Exactly one of password or password_wo has to be set.",
+							Optional:  true,
+							Sensitive: true,
+							WriteOnly: true,
 						},
 					},
 				},
 			},
+			"password_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary version of the write-only passwords. Changing it triggers a new password update.",
+			},
 			"once_only": {
 				Type:        schema.TypeBool,
 				Default:     true,
@@ -74,12 +101,85 @@ func ResourceCredentialsUpdate() *schema.Resource {
 			"last_update_time": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The time of the last password rotation.",
+				Description: "The time of the last password update.",
 			},
 		},
 	}
 }
 
+// credentialsPasswordPolicies are the password policies of the resource types whose password rules are known.
+var credentialsPasswordPolicies = map[string]validationUtils.PasswordPolicy{
+	credentials.ResourceTypeEsxi:        validationUtils.EsxiPasswordPolicy,
+	credentials.ResourceTypeVcenter:     validationUtils.VcenterPasswordPolicy,
+	credentials.ResourceTypePsc:         validationUtils.SsoPasswordPolicy,
+	credentials.ResourceTypeNsxManager:  validationUtils.NsxPasswordPolicy,
+	credentials.ResourceTypeNsxtManager: validationUtils.NsxPasswordPolicy,
+	credentials.ResourceTypeNsxEdge:     validationUtils.NsxEdgePasswordPolicy,
+}
+
+// validateCredentialsUpdatePasswords checks that each credential sets exactly one of password or password_wo and
+// checks the new passwords, including the write-only ones the schema validations do not see, against the password
+// policy of the resource type. The values not known before the apply are skipped.
+func validateCredentialsUpdatePasswords(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	config := req.RawConfig
+	if !config.IsKnown() || config.IsNull() {
+		return
+	}
+	creds := config.GetAttr("credentials")
+	if !creds.IsKnown() || creds.IsNull() {
+		return
+	}
+
+	resourceType, resourceName := config.GetAttr("resource_type"), config.GetAttr("resource_name")
+	var policy validationUtils.PasswordPolicy
+	hasPolicy := false
+	if resourceType.IsKnown() && !resourceType.IsNull() {
+		policy, hasPolicy = credentialsPasswordPolicies[resourceType.AsString()]
+	}
+	var fqdns []string
+	if resourceName.IsKnown() && !resourceName.IsNull() {
+		fqdns = append(fqdns, resourceName.AsString())
+	}
+
+	for i, credential := range creds.AsValueSlice() {
+		if !credential.IsKnown() || credential.IsNull() {
+			continue
+		}
+		path := cty.GetAttrPath("credentials").IndexInt(i)
+		password, passwordWo := credential.GetAttr("password"), credential.GetAttr("password_wo")
+		if password.IsKnown() && passwordWo.IsKnown() {
+			hasPassword := !password.IsNull() && len(password.AsString()) > 0
+			hasPasswordWo := !passwordWo.IsNull() && len(passwordWo.AsString()) > 0
+			if hasPassword == hasPasswordWo {
+				resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "invalid credential",
+					Detail:        "exactly one of password or password_wo has to be set",
+					AttributePath: path,
+				})
+			}
+		}
+
+		if !hasPolicy {
+			continue
+		}
+		for _, key := range []string{"password", "password_wo"} {
+			password := credential.GetAttr(key)
+			if !password.IsKnown() || password.IsNull() || len(password.AsString()) == 0 {
+				continue
+			}
+			for _, err := range policy.Check(password.AsString(), fqdns...) {
+				resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       fmt.Sprintf("invalid %s password", resourceType.AsString()),
+					Detail:        err.Error(),
+					AttributePath: path.GetAttr(key),
+				})
+			}
+		}
+	}
+}
+
 func resourceCredentialsPasswordUpdateRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
 	creds, err := credentials.ReadCredentials(ctx, data, apiClient)
@@ -93,14 +193,16 @@ func resourceCredentialsPasswordUpdateRead(ctx context.Context, data *schema.Res
 		for _, cred := range creds {
 			username := entry["user_name"].(string)
 			credentialType := entry["credential_type"].(string)
-			if *cred.Username == username && *cred.CredentialType == credentialType {
+			// Credentials set through password_wo must not end up in the state
+			password, _ := entry["password"].(string)
+			if *cred.Username == username && *cred.CredentialType == credentialType && len(password) > 0 {
 				entry["password"] = cred.Password
 			}
 		}
 	}
 
 	_ = data.Set("credentials", dataCreds)
-	id, err := credentials.CreatePasswordChangeID(data, credentials.Rotate)
+	id, err := credentials.CreatePasswordChangeID(data, credentials.Update)
 	if err != nil {
 		return diag.Errorf("error during id generation %s", err)
 	}
@@ -112,7 +214,7 @@ func resourceCredentialsPasswordUpdateRead(ctx context.Context, data *schema.Res
 
 func resourceCredentialsPasswordUpdateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if d.Get("last_update_time").(string) != "" && d.Get("once_only").(bool) {
-		log.Print("[DEBUG] Skipping password rotation")
+		log.Print("[DEBUG] Skipping password update")
 		return nil
 	}
 
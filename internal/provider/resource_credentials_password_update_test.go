@@ -5,12 +5,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
 )
 
 func TestAccCredentialsResourcePasswordUpdate(t *testing.T) {
@@ -43,6 +49,7 @@ func testAccResourceCredentialsPasswordUpdateConfig(newPassword string) string {
 			resource_type = "ESXI"
 			account_type = "USER"
 			resource_name = %[1]q
+			include_passwords = true
 
 			depends_on = [
 				vcf_credentials_update.vc_0_update
@@ -51,3 +58,133 @@ func testAccResourceCredentialsPasswordUpdateConfig(newPassword string) string {
 
 `, os.Getenv("VCF_TEST_HOST1_FQDN"), newPassword)
 }
+
+func TestAccCredentialsResourcePasswordUpdate_writeOnly(t *testing.T) {
+	newPassword := fmt.Sprintf("%s$1A", acctest.RandString(7))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccSDDCManagerOrCloudBuilderPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{{
+			Config: testAccResourceCredentialsPasswordUpdateWriteOnlyConfig(newPassword),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("data.vcf_credentials.esx_creds", "credentials.0.password", newPassword),
+				resource.TestCheckNoResourceAttr("vcf_credentials_update.vc_0_update", "credentials.0.password_wo"),
+				resource.TestCheckResourceAttr("vcf_credentials_update.vc_0_update", "credentials.0.password", ""),
+			),
+		}},
+	})
+}
+
+func testAccResourceCredentialsPasswordUpdateWriteOnlyConfig(newPassword string) string {
+
+	return fmt.Sprintf(`
+		resource "vcf_credentials_update" "vc_0_update" {
+			resource_name = %[1]q
+			resource_type = "ESXI"
+			password_wo_version = 1
+			credentials {
+				credential_type = "SSH"
+				user_name = "root"
+				password_wo = %[2]q
+			}
+		}
+
+		data "vcf_credentials" "esx_creds" {
+			resource_type = "ESXI"
+			account_type = "USER"
+			resource_name = %[1]q
+			include_passwords = true
+
+			depends_on = [
+				vcf_credentials_update.vc_0_update
+			]
+		}
+
+`, os.Getenv("VCF_TEST_HOST1_FQDN"), newPassword)
+}
+
+func testCredentialsUpdateConfig(resourceType string, password, passwordWo cty.Value) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"resource_name": cty.StringVal("sfo01-w01-esx01.sfo.rainpole.io"),
+		"resource_type": cty.StringVal(resourceType),
+		"credentials": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"credential_type": cty.StringVal("SSH"),
+			"user_name":       cty.StringVal("root"),
+			"password":        password,
+			"password_wo":     passwordWo,
+		})}),
+	})
+}
+
+func TestValidateCredentialsUpdatePasswords(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		password     cty.Value
+		passwordWo   cty.Value
+		errors       []string
+	}{
+		{
+			name:         "password",
+			resourceType: credentials.ResourceTypeEsxi,
+			password:     cty.StringVal("VMw@re1!VMw@re1!"),
+			passwordWo:   cty.NullVal(cty.String),
+		},
+		{
+			name:         "write-only password",
+			resourceType: credentials.ResourceTypeEsxi,
+			password:     cty.NullVal(cty.String),
+			passwordWo:   cty.StringVal("VMw@re1!VMw@re1!"),
+		},
+		{
+			name:         "both passwords",
+			resourceType: credentials.ResourceTypeEsxi,
+			password:     cty.StringVal("VMw@re1!VMw@re1!"),
+			passwordWo:   cty.StringVal("VMw@re1!VMw@re1!"),
+			errors:       []string{"exactly one of password or password_wo has to be set"},
+		},
+		{
+			name:         "no password",
+			resourceType: credentials.ResourceTypeEsxi,
+			password:     cty.StringVal(""),
+			passwordWo:   cty.NullVal(cty.String),
+			errors:       []string{"exactly one of password or password_wo has to be set"},
+		},
+		{
+			name:         "no password of a resource type without policy",
+			resourceType: credentials.ResourceTypeBackup,
+			password:     cty.NullVal(cty.String),
+			passwordWo:   cty.NullVal(cty.String),
+			errors:       []string{"exactly one of password or password_wo has to be set"},
+		},
+		{
+			name:         "unknown write-only password",
+			resourceType: credentials.ResourceTypeEsxi,
+			password:     cty.NullVal(cty.String),
+			passwordWo:   cty.UnknownVal(cty.String),
+		},
+		{
+			name:         "password violating the policy",
+			resourceType: credentials.ResourceTypeEsxi,
+			password:     cty.NullVal(cty.String),
+			passwordWo:   cty.StringVal("VMw@re1!VMw@re1!VMw@re1!"),
+			errors:       []string{"the password must be at most 20 characters long"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			validateCredentialsUpdatePasswords(context.Background(), schema.ValidateResourceConfigFuncRequest{
+				RawConfig: testCredentialsUpdateConfig(test.resourceType, test.password, test.passwordWo),
+			}, resp)
+
+			var errors []string
+			for _, diagnostic := range resp.Diagnostics {
+				errors = append(errors, diagnostic.Detail)
+			}
+			assert.Equal(t, test.errors, errors)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func ResourceCredentialsRotation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialsRotationCreate,
+		ReadContext:   resourceCredentialsRotationRead,
+		DeleteContext: resourceCredentialsRotationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"resource": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MinItems:     1,
+				ExactlyOneOf: []string{"resource", "selector"},
+				Description:  "The resources which credentials will be rotated",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the resource which credentials will be rotated",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"resource_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The type of the resource which credentials will be rotated",
+							ValidateFunc: validation.StringInSlice(credentials.AllResourceTypes(), false),
+						},
+						"credentials": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "The accounts of the resource which passwords will be rotated",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"credential_type": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The type(s) of the account. One among: SSO, SSH, API, FTP, AUDIT",
+										ValidateFunc: validation.StringInSlice(
+											credentials.AllCredentialTypes(), true),
+									},
+									"user_name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  "The user name of the account",
+										ValidateFunc: validation.NoZeroValues,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"selector": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"resource", "selector"},
+				Description: "Selects the accounts to rotate by resource type instead of listing them, " +
+					"e.g. all NSX and vCenter accounts in all domains. The accounts are looked up when the rotation is performed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_types": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "The types of the resources which credentials will be rotated, e.g. VCENTER, NSXT_MANAGER",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(credentials.AllResourceTypes(), false),
+							},
+						},
+						"domain_names": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The domains which resources will be rotated. All domains if omitted",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"account_types": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The types of the accounts to rotate. One or more among USER, SYSTEM, SERVICE. All types if omitted",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(credentials.AllAccountTypes(), false),
+							},
+						},
+					},
+				},
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "The maximum number of accounts rotated by a single credentials task. The batches are rotated one " +
+					"after the other. All accounts are rotated in a single task if omitted",
+			},
+			"rotate_on": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "Arbitrary value that triggers a new rotation whenever it changes, " +
+					"e.g. the ID of a time_rotating resource",
+			},
+			"continue_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+				Description: "If set to true, the accounts which fail to rotate are skipped and the passwords of the remaining " +
+					"accounts are rotated. The skipped accounts are reported in failed_accounts",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the last credentials task that performed the rotation",
+			},
+			"task_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of all credentials tasks that performed the rotation, one per batch",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"last_rotate_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last password rotation",
+			},
+			"failed_accounts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The accounts which passwords failed to rotate when continue_on_failure is set, or in the failed batch",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the resource to which the account belongs",
+						},
+						"user_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The user name of the account",
+						},
+						"credential_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the credential",
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The reason of the failure",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCredentialsRotationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	resources := data.Get("resource").([]interface{})
+	if selectors := data.Get("selector").([]interface{}); len(selectors) > 0 {
+		selector := selectors[0].(map[string]interface{})
+		selectedResources, err := credentials.SelectResourceCredentials(ctx, vcfClient.ApiClient,
+			utils.ToStringSlice(selector["resource_types"].([]interface{})),
+			utils.ToStringSlice(selector["domain_names"].([]interface{})),
+			utils.ToStringSlice(selector["account_types"].([]interface{})))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(selectedResources) == 0 {
+			return diag.Errorf("no accounts match the selector")
+		}
+		resources = selectedResources
+	}
+
+	taskIds, failedAccounts, err := credentials.RotateResourceCredentials(ctx, resources, credentials.RotationOptions{
+		ContinueOnFailure: data.Get("continue_on_failure").(bool),
+		BatchSize:         data.Get("batch_size").(int),
+	}, vcfClient)
+	if err != nil {
+		return rotationFailure(data, taskIds, failedAccounts, err)
+	}
+
+	setRotationState(data, taskIds, failedAccounts)
+	diags := resourceCredentialsRotationRead(ctx, data, meta)
+	for _, account := range failedAccounts {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Password rotation failed for an account",
+			Detail:   account.String(),
+		})
+	}
+
+	return diags
+}
+
+// setRotationState records the tasks which rotated the passwords, the last one is the ID of the rotation.
+func setRotationState(data *schema.ResourceData, taskIds []string, failedAccounts []credentials.FailedAccount) {
+	taskId := taskIds[len(taskIds)-1]
+	data.SetId(taskId)
+	_ = data.Set("task_id", taskId)
+	_ = data.Set("task_ids", taskIds)
+	_ = data.Set("last_rotate_time", time.Now().Format(time.RFC3339))
+	_ = data.Set("failed_accounts", flattenFailedAccounts(failedAccounts))
+}
+
+// rotationFailure returns the error of a rotation. If the batches before the failed one rotated passwords, their
+// tasks and the accounts of the failed batch are recorded in the state, which Terraform keeps as tainted, so that
+// the state tells which passwords have changed.
+func rotationFailure(data *schema.ResourceData, taskIds []string, failedAccounts []credentials.FailedAccount,
+	err error) diag.Diagnostics {
+	diags := diag.FromErr(err)
+	if len(taskIds) == 0 {
+		return diags
+	}
+
+	var taskErr *credentials.TaskError
+	if errors.As(err, &taskErr) {
+		failedAccounts = append(failedAccounts, taskErr.FailedAccounts...)
+	}
+	setRotationState(data, taskIds, failedAccounts)
+	diags[0].Detail = fmt.Sprintf("The passwords of the batches before the failed one were rotated by the credentials "+
+		"tasks %s.", strings.Join(taskIds, ", "))
+	return diags
+}
+
+func flattenFailedAccounts(failedAccounts []credentials.FailedAccount) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(failedAccounts))
+	for _, account := range failedAccounts {
+		result = append(result, map[string]interface{}{
+			"resource_name":   account.ResourceName,
+			"user_name":       account.UserName,
+			"credential_type": account.CredentialType,
+			"error":           account.Message,
+		})
+	}
+
+	return result
+}
+
+func resourceCredentialsRotationRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The rotation is a one-off operation, there is no remote object to refresh
+	return nil
+}
+
+func resourceCredentialsRotationDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// Removing the resource does not revert the rotated passwords
+	return nil
+}
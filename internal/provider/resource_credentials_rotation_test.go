@@ -0,0 +1,88 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceVcfCredentialsRotation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceCredentialsRotationConfig("initial"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_credentials_rotation.vc_rotation", "id"),
+					resource.TestCheckResourceAttrSet("vcf_credentials_rotation.vc_rotation", "task_id"),
+					resource.TestCheckResourceAttrSet("vcf_credentials_rotation.vc_rotation", "last_rotate_time"),
+				),
+			},
+			{
+				// Changing the keeper value triggers another rotation
+				Config: testAccResourceCredentialsRotationConfig("rotated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_credentials_rotation.vc_rotation", "rotate_on", "rotated"),
+					resource.TestCheckResourceAttrSet("vcf_credentials_rotation.vc_rotation", "task_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceCredentialsRotationConfig(rotateOn string) string {
+	return fmt.Sprintf(`
+		data "vcf_credentials" "vc_creds" {
+			resource_type = "VCENTER"
+			account_type = "USER"
+		}
+
+		resource "vcf_credentials_rotation" "vc_rotation" {
+			resource {
+				resource_name = data.vcf_credentials.vc_creds.credentials[0].resource[0].name
+				resource_type = data.vcf_credentials.vc_creds.credentials[0].resource[0].type
+				credentials {
+					credential_type = data.vcf_credentials.vc_creds.credentials[0].credential_type
+					user_name = data.vcf_credentials.vc_creds.credentials[0].user_name
+				}
+			}
+			rotate_on = %q
+		}
+`, rotateOn)
+}
+
+func TestAccResourceVcfCredentialsRotation_selector(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceCredentialsRotationSelectorConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_credentials_rotation.campaign", "id"),
+					resource.TestCheckResourceAttrSet("vcf_credentials_rotation.campaign", "task_ids.0"),
+					resource.TestCheckResourceAttrSet("vcf_credentials_rotation.campaign", "last_rotate_time"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceCredentialsRotationSelectorConfig() string {
+	return `
+		resource "vcf_credentials_rotation" "campaign" {
+			selector {
+				resource_types = ["VCENTER", "NSXT_MANAGER"]
+				account_types = ["USER"]
+			}
+			batch_size = 2
+			continue_on_failure = true
+		}
+`
+}
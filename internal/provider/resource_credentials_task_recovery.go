@@ -0,0 +1,134 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func ResourceCredentialsTaskRecovery() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialsTaskRecoveryCreate,
+		ReadContext:   resourceCredentialsTaskRecoveryRead,
+		DeleteContext: resourceCredentialsTaskRecoveryDelete,
+		Description: "Cancels or retries a stuck or failed credentials task, which otherwise blocks all further " +
+			"credentials operations in SDDC Manager",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The action to perform on the credentials task. One among: CANCEL, RETRY",
+				ValidateFunc: validation.StringInSlice([]string{credentials.TaskActionCancel, credentials.TaskActionRetry}, false),
+			},
+			"task_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "The ID of the credentials task. If omitted, the most recent credentials task which is " +
+					"pending, in progress, failed or inconsistent is used",
+			},
+			"recover_on": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary value that triggers the action again whenever it changes",
+			},
+			"task_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the credentials task. One among: UPDATE, ROTATE, REMEDIATE, UPDATE_AUTO_ROTATE_POLICY",
+			},
+			"task_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the credentials task after the action",
+			},
+		},
+	}
+}
+
+func resourceCredentialsTaskRecoveryCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	taskId := data.Get("task_id").(string)
+	if len(taskId) == 0 {
+		blockingTask, err := credentials.FindBlockingTask(ctx, vcfClient.ApiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if blockingTask == nil {
+			id, err := utils.HashFields([]string{data.Get("action").(string), time.Now().Format(time.RFC3339)})
+			if err != nil {
+				return diag.Errorf("error during id generation %s", err)
+			}
+			data.SetId(id)
+
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "No credentials task to recover",
+				Detail:   "There is no pending, in progress, failed or inconsistent credentials task, nothing was done",
+			}}
+		}
+		taskId = blockingTask.ID
+	}
+
+	var err error
+	switch data.Get("action").(string) {
+	case credentials.TaskActionCancel:
+		log.Printf("[DEBUG] Cancelling credentials task %s", taskId)
+		err = credentials.CancelTask(ctx, taskId, vcfClient.ApiClient)
+	case credentials.TaskActionRetry:
+		log.Printf("[DEBUG] Retrying credentials task %s", taskId)
+		err = credentials.RetryTask(ctx, taskId, vcfClient)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(taskId)
+	_ = data.Set("task_id", taskId)
+
+	return resourceCredentialsTaskRecoveryRead(ctx, data, meta)
+}
+
+func resourceCredentialsTaskRecoveryRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taskId := data.Get("task_id").(string)
+	if len(taskId) == 0 {
+		return nil
+	}
+
+	task, err := credentials.GetCredentialsTask(ctx, taskId, meta.(*api_client.SddcManagerClient).ApiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = data.Set("task_type", task.Type)
+	_ = data.Set("task_status", task.Status)
+
+	return nil
+}
+
+func resourceCredentialsTaskRecoveryDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The action is a one-off operation, there is nothing to revert
+	return nil
+}
@@ -0,0 +1,33 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceVcfCredentialsTaskRecovery(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				// Cancels the most recent blocking credentials task, if there is one
+				Config: testAccResourceCredentialsTaskRecoveryConfig(),
+				Check:  resource.TestCheckResourceAttrSet("vcf_credentials_task_recovery.cancel", "id"),
+			},
+		},
+	})
+}
+
+func testAccResourceCredentialsTaskRecoveryConfig() string {
+	return `
+		resource "vcf_credentials_task_recovery" "cancel" {
+			action = "CANCEL"
+		}
+`
+}
@@ -34,73 +34,84 @@ func ResourceCsr() *schema.Resource {
 			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
-		Schema: map[string]*schema.Schema{
-			"domain_id": {
-				Type:         schema.TypeString,
-				Description:  "Domain Id or Name for which the CSRs should be generated",
-				Required:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"country": {
-				Type:         schema.TypeString,
-				Description:  "ISO 3166 country code where company is legally registered",
-				Required:     true,
-				ValidateFunc: validation.StringInSlice(constants.GetIso3166CountryCodes(), false),
-			},
-			"email": {
-				Type:         schema.TypeString,
-				Description:  "Contact email address",
-				Required:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"key_size": {
-				Type:         schema.TypeInt,
-				Description:  "Certificate public key size. One among: 2048, 3072, 4096",
-				Required:     true,
-				ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
-			},
-			"locality": {
-				Type:         schema.TypeString,
-				Description:  "The city or locality where company is legally registered",
-				Required:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"organization": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name under which your company is known. The listed organization must be the legal registrant of the domain name in the certificate request.",
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"organization_unit": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Organization with which the certificate is associated",
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"state": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Full name (do not abbreviate) of the state, province, region, or territory where your company is legally registered.",
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"resource": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Resources for which the CSRs are to be generated. One among: SDDC_MANAGER, PSC, VCENTER, NSX_MANAGER, NSXT_MANAGER, VROPS, VRSLCM, VXRAIL_MANAGER",
-				ValidateFunc: validation.StringInSlice([]string{"SDDC_MANAGER", "PSC", "VCENTER", "NSX_MANAGER", "NSXT_MANAGER", "VROPS", "VRSLCM", "VXRAIL_MANAGER"}, false),
-			},
-			"fqdn": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "FQDN of the resource",
-				ValidateFunc: validation.NoZeroValues,
-			},
-			"csr": {
-				Type:        schema.TypeList,
-				Computed:    true,
-				Description: "Resulting CSR",
-				Elem:        certificates.CsrSchema(),
-			},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{{
+			Version: 0,
+			Type:    (&schema.Resource{Schema: resourceCsrSchema()}).CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceCsrStateUpgradeV0,
+		}},
+		Schema: resourceCsrSchema(),
+	}
+}
+
+// resourceCsrSchema returns the schema of the resource, unchanged since version 0 but for the format of the ID.
+func resourceCsrSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"domain_id": {
+			Type:         schema.TypeString,
+			Description:  "Domain Id or Name for which the CSRs should be generated",
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"country": {
+			Type:         schema.TypeString,
+			Description:  "ISO 3166 country code where company is legally registered",
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(constants.GetIso3166CountryCodes(), false),
+		},
+		"email": {
+			Type:         schema.TypeString,
+			Description:  "Contact email address",
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"key_size": {
+			Type:         schema.TypeInt,
+			Description:  "Certificate public key size. One among: 2048, 3072, 4096",
+			Required:     true,
+			ValidateFunc: validation.IntInSlice([]int{2048, 3072, 4096}),
+		},
+		"locality": {
+			Type:         schema.TypeString,
+			Description:  "The city or locality where company is legally registered",
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"organization": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The name under which your company is known. The listed organization must be the legal registrant of the domain name in the certificate request.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"organization_unit": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Organization with which the certificate is associated",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"state": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Full name (do not abbreviate) of the state, province, region, or territory where your company is legally registered.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"resource": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Resources for which the CSRs are to be generated. One among: SDDC_MANAGER, PSC, VCENTER, NSX_MANAGER, NSXT_MANAGER, VROPS, VRSLCM, VXRAIL_MANAGER",
+			ValidateFunc: validation.StringInSlice([]string{"SDDC_MANAGER", "PSC", "VCENTER", "NSX_MANAGER", "NSXT_MANAGER", "VROPS", "VRSLCM", "VXRAIL_MANAGER"}, false),
+		},
+		"fqdn": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "FQDN of the resource",
+			ValidateFunc: validation.NoZeroValues,
+		},
+		"csr": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Resulting CSR",
+			Elem:        certificates.CsrSchema(),
 		},
 	}
 }
@@ -188,6 +199,32 @@ func resourceCsrDelete(_ context.Context, _ *schema.ResourceData, _ interface{})
 	return nil
 }
 
+// resourceCsrStateUpgradeV0 adds the FQDN of the resource to the ID of the CSRs generated before version 0.10.0
+// of the provider, so that the certificates referencing them keep working.
+func resourceCsrStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	csrId, _ := rawState["id"].(string)
+	resourceFqdn, _ := rawState["fqdn"].(string)
+	if resourceFqdn == "" {
+		// The attribute did not exist in the first versions, the FQDN is then the one of the generated CSR
+		if csrs, ok := rawState["csr"].([]interface{}); ok && len(csrs) > 0 {
+			if csr, ok := csrs[0].(map[string]interface{}); ok {
+				if resources, ok := csr["resource"].([]interface{}); ok && len(resources) > 0 {
+					if resource, ok := resources[0].(map[string]interface{}); ok {
+						resourceFqdn, _ = resource["fqdn"].(string)
+					}
+				}
+			}
+		}
+		rawState["fqdn"] = resourceFqdn
+	}
+	rawState["id"] = certificates.UpgradeCsrIdV0(csrId, resourceFqdn)
+
+	return rawState, nil
+}
+
 // getCsrByResourceFqdn SDDC Manager API doesn't return CSR resource type, just FQDN.
 func getCsrByResourceFqdn(resourceFqdn string, csrs []*models.CSR) *models.CSR {
 	if len(resourceFqdn) < 1 || len(csrs) < 1 {
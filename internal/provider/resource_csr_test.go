@@ -5,11 +5,13 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 )
@@ -85,3 +87,25 @@ func testAccVcfCsrConfig(domainID, resource, fqdn string) string {
 		domainID, resource, fqdn,
 	)
 }
+
+func TestResourceCsrStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":       "csr:domain-1:VCENTER:task-1",
+		"resource": "VCENTER",
+		"csr": []interface{}{map[string]interface{}{
+			"resource": []interface{}{map[string]interface{}{"fqdn": "sfo-m01-vc01.sfo.rainpole.io"}},
+		}},
+	}
+	upgraded, err := resourceCsrStateUpgradeV0(context.Background(), rawState, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "csr:domain-1:VCENTER:sfo-m01-vc01.sfo.rainpole.io:task-1", upgraded["id"])
+	assert.Equal(t, "sfo-m01-vc01.sfo.rainpole.io", upgraded["fqdn"])
+
+	rawState = map[string]interface{}{
+		"id":   "csr:domain-1:VCENTER:sfo-m01-vc01.sfo.rainpole.io:task-1",
+		"fqdn": "sfo-m01-vc01.sfo.rainpole.io",
+	}
+	upgraded, err = resourceCsrStateUpgradeV0(context.Background(), rawState, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "csr:domain-1:VCENTER:sfo-m01-vc01.sfo.rainpole.io:task-1", upgraded["id"])
+}
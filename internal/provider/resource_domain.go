@@ -6,6 +6,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -49,67 +50,141 @@ func ResourceDomain() *schema.Resource {
 			Update: schema.DefaultTimeout(4 * time.Hour),
 			Delete: schema.DefaultTimeout(1 * time.Hour),
 		},
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringLenBetween(3, 20),
-				Description:  "Name of the domain (from 3 to 20 characters)",
-			},
-			"org_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringLenBetween(3, 20),
-				Description:  "Organization name of the workload domain",
-			},
-			"vcenter_configuration": {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "Specification describing vCenter Server instance settings",
-				MinItems:    1,
-				MaxItems:    1,
-				Elem:        vcenter.VCSubresourceSchema(),
-			},
-			"nsx_configuration": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "Specification details for NSX configuration",
-				MaxItems:    1,
-				Elem:        network.NsxSchema(),
-			},
-			"cluster": {
-				Type:        schema.TypeList,
-				Required:    true,
-				Description: "Specification representing the clusters to be added to the workload domain",
-				MinItems:    1,
-				Elem:        clusterSubresourceSchema(),
-			},
-			"status": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Status of the workload domain",
-			},
-			"type": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Type of the workload domain",
-			},
-			"sso_id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "ID of the SSO domain associated with the workload domain",
-			},
-			"sso_name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Name of the SSO domain associated with the workload domain",
-			},
-			"is_management_sso_domain": {
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Shows whether the workload domain is joined to the management domain SSO",
-			},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{{
+			Version: 0,
+			Type:    resourceDomainV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceDomainStateUpgradeV0,
+		}},
+		Schema: resourceDomainSchema(),
+	}
+}
+
+// resourceDomainSchema returns the schema of the current version of the resource.
+func resourceDomainSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringLenBetween(3, 20),
+			Description:  "Name of the domain (from 3 to 20 characters)",
+		},
+		"org_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(3, 20),
+			Description:  "Organization name of the workload domain",
+		},
+		"vcenter_configuration": {
+			Type:        schema.TypeList,
+			Required:    true,
+			Description: "Specification describing vCenter Server instance settings",
+			MinItems:    1,
+			MaxItems:    1,
+			Elem:        vcenter.VCSubresourceSchema(),
+		},
+		"nsx_configuration": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Specification details for NSX configuration",
+			MaxItems:    1,
+			Elem:        network.NsxSchema(),
+		},
+		"cluster": {
+			Type:        schema.TypeList,
+			Required:    true,
+			Description: "Specification representing the clusters to be added to the workload domain",
+			MinItems:    1,
+			Elem:        clusterSubresourceSchema(),
 		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Status of the workload domain",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of the workload domain",
+		},
+		"sso_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the SSO domain associated with the workload domain",
+		},
+		"sso_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the SSO domain associated with the workload domain",
+		},
+		"is_management_sso_domain": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Shows whether the workload domain is joined to the management domain SSO",
+		},
+	}
+}
+
+// resourceDomainV0 returns the schema of the resource before version 0.4.0 of the provider, which named
+// the vCenter Server configuration "vcenter" and the FQDNs of the appliances "dns_name".
+func resourceDomainV0() *schema.Resource {
+	vcenterSchema := vcenter.VCSubresourceSchema()
+	vcenterSchema.Schema["dns_name"] = vcenterSchema.Schema["fqdn"]
+	delete(vcenterSchema.Schema, "fqdn")
+
+	nsxSchema := network.NsxSchema()
+	nsxManagerNodeSchema := network.NsxManagerNodeSchema()
+	nsxManagerNodeSchema.Schema["dns_name"] = nsxManagerNodeSchema.Schema["fqdn"]
+	delete(nsxManagerNodeSchema.Schema, "fqdn")
+	nsxSchema.Schema["nsx_manager_node"].Elem = nsxManagerNodeSchema
+
+	domainSchema := resourceDomainSchema()
+	domainSchema["vcenter"] = domainSchema["vcenter_configuration"]
+	domainSchema["vcenter"].Elem = vcenterSchema
+	delete(domainSchema, "vcenter_configuration")
+	domainSchema["nsx_configuration"].Elem = nsxSchema
+
+	return &schema.Resource{Schema: domainSchema}
+}
+
+// resourceDomainStateUpgradeV0 renames the attributes of a version 0 state, the values are kept as they are.
+func resourceDomainStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	if vcenterConfiguration, ok := rawState["vcenter"]; ok {
+		rawState["vcenter_configuration"] = vcenterConfiguration
+		delete(rawState, "vcenter")
+	}
+	renameStateListAttribute(rawState["vcenter_configuration"], "dns_name", "fqdn")
+
+	if nsxConfigurations, ok := rawState["nsx_configuration"].([]interface{}); ok {
+		for _, nsxConfiguration := range nsxConfigurations {
+			if nsxConfiguration, ok := nsxConfiguration.(map[string]interface{}); ok {
+				renameStateListAttribute(nsxConfiguration["nsx_manager_node"], "dns_name", "fqdn")
+			}
+		}
+	}
+
+	return rawState, nil
+}
+
+// renameStateListAttribute renames an attribute of all the blocks of a nested list in a raw state.
+func renameStateListAttribute(rawList interface{}, oldName, newName string) {
+	blocks, ok := rawList.([]interface{})
+	if !ok {
+		return
+	}
+	for _, block := range blocks {
+		attributes, ok := block.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := attributes[oldName]; ok {
+			attributes[newName] = value
+			delete(attributes, oldName)
+		}
 	}
 }
 
@@ -117,6 +192,16 @@ func resourceDomainCreate(ctx context.Context, data *schema.ResourceData, meta i
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
 
+	// The failed creation of a previous apply is not in the state, SDDC Manager keeps its progress
+	resumedDomainId, err := domain.ResumeDomainCreation(ctx, vcfClient, data.Get("name").(string))
+	if err != nil {
+		return creationTaskDiagnostics(err, "Domain")
+	}
+	if resumedDomainId != "" {
+		data.SetId(resumedDomainId)
+		return resourceDomainRead(ctx, data, meta)
+	}
+
 	domainCreationSpec, err := domain.CreateDomainCreationSpec(data)
 	if err != nil {
 		return diag.FromErr(err)
@@ -144,7 +229,7 @@ func resourceDomainCreate(ctx context.Context, data *schema.ResourceData, meta i
 	taskId := accepted.Payload.ID
 	err = vcfClient.WaitForTaskComplete(ctx, taskId, true)
 	if err != nil {
-		return diag.FromErr(err)
+		return creationTaskDiagnostics(err, "Domain")
 	}
 	domainId, err := vcfClient.GetResourceIdAssociatedWithTask(ctx, taskId, "Domain")
 	if err != nil {
@@ -162,6 +247,10 @@ func resourceDomainRead(ctx context.Context, data *schema.ResourceData, meta int
 
 	domainObj, err := domain.SetBasicDomainAttributes(ctx, data.Id(), data, apiClient)
 	if err != nil {
+		var notFound *domains.GetDomainNotFound
+		if errors.As(err, &notFound) {
+			return removeFromState(data, "Domain")
+		}
 		return diag.FromErr(err)
 	}
 
@@ -287,40 +376,8 @@ func handleClusterUpdateInDomain(ctx context.Context, newClustersStateList, oldC
 
 func resourceDomainDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
-	apiClient := vcfClient.ApiClient
-
-	markForDeleteUpdateSpec := domain.CreateDomainUpdateSpec(data, true)
-	domainUpdateParams := domains.NewUpdateDomainParamsWithContext(ctx).
-		WithTimeout(constants.DefaultVcfApiCallTimeout)
-	domainUpdateParams.DomainUpdateSpec = markForDeleteUpdateSpec
-	domainUpdateParams.ID = data.Id()
 
-	acceptedUpdateTask, _, err := apiClient.Domains.UpdateDomain(domainUpdateParams)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	taskId := acceptedUpdateTask.Payload.ID
-	err = vcfClient.WaitForTaskComplete(ctx, taskId, false)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	domainDeleteParams := domains.NewDeleteDomainParamsWithContext(ctx).
-		WithTimeout(constants.DefaultVcfApiCallTimeout)
-	domainDeleteParams.ID = data.Id()
-
-	acceptedDeleteTask, acceptedDeleteTask2, err := apiClient.Domains.DeleteDomain(domainDeleteParams)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if acceptedDeleteTask != nil {
-		taskId = acceptedDeleteTask.Payload.ID
-	}
-	if acceptedDeleteTask2 != nil {
-		taskId = acceptedDeleteTask2.Payload.ID
-	}
-	err = vcfClient.WaitForTaskComplete(ctx, taskId, true)
-	if err != nil {
+	if err := domain.DeleteDomain(ctx, vcfClient, data.Id()); err != nil {
 		return diag.FromErr(err)
 	}
 
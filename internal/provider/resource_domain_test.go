@@ -13,6 +13,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 	"github.com/vmware/vcf-sdk-go/client/domains"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
@@ -593,3 +594,33 @@ func domainImportStateCheck(states []*terraform.InstanceState) error {
 	}
 	return fmt.Errorf("domain InstanceState not found! Import failed")
 }
+
+func TestResourceDomainStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name": "sfo-w01",
+		"vcenter": []interface{}{map[string]interface{}{
+			"name":     "sfo-w01-vc01",
+			"dns_name": "sfo-w01-vc01.sfo.rainpole.io",
+		}},
+		"nsx_configuration": []interface{}{map[string]interface{}{
+			"vip_fqdn": "sfo-w01-nsx01.sfo.rainpole.io",
+			"nsx_manager_node": []interface{}{
+				map[string]interface{}{"name": "sfo-w01-nsx01a", "dns_name": "sfo-w01-nsx01a.sfo.rainpole.io"},
+				map[string]interface{}{"name": "sfo-w01-nsx01b", "dns_name": "sfo-w01-nsx01b.sfo.rainpole.io"},
+			},
+		}},
+	}
+
+	upgraded, err := resourceDomainStateUpgradeV0(context.Background(), rawState, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, upgraded, "vcenter")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"name": "sfo-w01-vc01",
+		"fqdn": "sfo-w01-vc01.sfo.rainpole.io",
+	}}, upgraded["vcenter_configuration"])
+	nsxManagerNodes := upgraded["nsx_configuration"].([]interface{})[0].(map[string]interface{})["nsx_manager_node"]
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "sfo-w01-nsx01a", "fqdn": "sfo-w01-nsx01a.sfo.rainpole.io"},
+		map[string]interface{}{"name": "sfo-w01-nsx01b", "fqdn": "sfo-w01-nsx01b.sfo.rainpole.io"},
+	}, nsxManagerNodes)
+}
@@ -6,7 +6,9 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -34,12 +36,15 @@ func ResourceEdgeCluster() *schema.Resource {
 		ReadContext:   resourceNsxEdgeClusterRead,
 		UpdateContext: resourceNsxEdgeClusterUpdate,
 		DeleteContext: resourceNsxEdgeClusterDelete,
+		CustomizeDiff: validateEdgeClusterRoutingUpdate,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(180 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(180 * time.Minute),
+			Delete: schema.DefaultTimeout(180 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -157,6 +162,33 @@ func ResourceEdgeCluster() *schema.Resource {
 	}
 }
 
+// validateEdgeClusterRoutingUpdate rejects routing changes on an existing edge cluster.
+// SDDC Manager only supports expanding and shrinking an edge cluster, so changes to the
+// ASN or to the uplinks and BGP peers of existing edge nodes would otherwise be stored in
+// the state without ever being applied.
+func validateEdgeClusterRoutingUpdate(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	// An imported edge cluster has no ASN in the state yet
+	if oldAsn, _ := diff.GetChange("asn"); diff.HasChange("asn") && oldAsn.(int) != 0 {
+		return fmt.Errorf("the ASN of an existing edge cluster cannot be updated through SDDC Manager, " +
+			"update the Tier-0 gateway in NSX instead")
+	}
+
+	if diff.HasChange("edge_node") {
+		oldNodesRaw, newNodesRaw := diff.GetChange("edge_node")
+		changedNodes := nsx_edge_cluster.GetNodesWithChangedUplinks(oldNodesRaw.([]interface{}), newNodesRaw.([]interface{}))
+		if len(changedNodes) > 0 {
+			return fmt.Errorf("the uplinks and BGP peers of existing edge nodes cannot be updated through SDDC Manager, "+
+				"update the Tier-0 gateway in NSX instead. Changed edge nodes: %s", strings.Join(changedNodes, ", "))
+		}
+	}
+
+	return nil
+}
+
 func resourceNsxEdgeClusterCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api_client.SddcManagerClient).ApiClient
 
@@ -5,11 +5,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 )
@@ -401,3 +404,53 @@ func getEdgeNodeChecks(i int) []resource.TestCheckFunc {
 		resource.TestCheckResourceAttrSet("vcf_edge_cluster.testCluster1", fmt.Sprintf("edge_node.%d.uplink.1.bgp_peer.0.asn", i)),
 	}
 }
+
+func TestValidateEdgeClusterRoutingUpdate(t *testing.T) {
+	tests := []struct {
+		name  string
+		state *terraform.InstanceState
+		err   string
+	}{
+		{name: "new edge cluster"},
+		{
+			// The ASN of an imported edge cluster is not read from SDDC Manager, the configuration sets it
+			name: "imported edge cluster",
+			state: &terraform.InstanceState{ID: "edge-cluster", Attributes: map[string]string{
+				"id":   "edge-cluster",
+				"name": edgeClusterName,
+			}},
+		},
+		{
+			name: "changed ASN",
+			state: &terraform.InstanceState{ID: "edge-cluster", Attributes: map[string]string{
+				"id":   "edge-cluster",
+				"name": edgeClusterName,
+				"asn":  "65004",
+			}},
+			err: "the ASN of an existing edge cluster cannot be updated through SDDC Manager",
+		},
+		{
+			name: "same ASN",
+			state: &terraform.InstanceState{ID: "edge-cluster", Attributes: map[string]string{
+				"id":   "edge-cluster",
+				"name": edgeClusterName,
+				"asn":  "65003",
+			}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ResourceEdgeCluster().Diff(context.Background(), test.state, terraform.NewResourceConfigRaw(
+				map[string]interface{}{
+					"name": edgeClusterName,
+					"asn":  65003,
+				}), nil)
+			if len(test.err) > 0 {
+				assert.ErrorContains(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
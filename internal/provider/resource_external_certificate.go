@@ -140,7 +140,7 @@ func resourceResourceExternalCertificateCreate(ctx context.Context, data *schema
 }
 
 func resourceResourceExternalCertificateRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+	vcfClient := meta.(*api_client.SddcManagerClient)
 
 	csrID := data.Get("csr_id").(string)
 	csrIdComponents := strings.Split(csrID, ":")
@@ -151,7 +151,7 @@ func resourceResourceExternalCertificateRead(ctx context.Context, data *schema.R
 	domainID := csrIdComponents[1]
 	resourceType := csrIdComponents[2]
 
-	cert, err := certificates.ReadCertificate(ctx, apiClient, domainID, resourceType)
+	cert, err := certificates.ReadCertificate(ctx, vcfClient, domainID, resourceType)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,160 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/fleet"
+)
+
+func ResourceFleetOperation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFleetOperationCreate,
+		ReadContext:   resourceFleetOperationRead,
+		DeleteContext: resourceFleetOperationDelete,
+		Description: "Runs an operation on several VCF instances of a VCF 9 fleet and waits for its completion. " +
+			"Requires the fleet management connection of the provider",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(4 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(4 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of the operation. One among: INVENTORY_SYNC, PRECHECK, HEALTH_CHECK",
+				ValidateFunc: validation.StringInSlice(fleet.OperationTypes(), false),
+			},
+			"instance_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The IDs of the instances the operation runs on, all the instances of the fleet if empty",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"run_on": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary value that triggers a new operation whenever it changes",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the operation",
+			},
+			"instance": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The status of the operation on each instance",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the instance",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the operation on the instance",
+						},
+						"error_message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The reason the operation failed on the instance, if it did",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceFleetOperationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := getFleetClient(meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	spec := &fleet.OperationSpec{Type: data.Get("type").(string)}
+	for _, instanceId := range data.Get("instance_ids").(*schema.Set).List() {
+		spec.InstanceIDs = append(spec.InstanceIDs, instanceId.(string))
+	}
+	sort.Strings(spec.InstanceIDs)
+
+	operation, err := fleet.StartOperation(ctx, client, spec)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	operation, err = fleet.WaitForOperation(ctx, client, operation.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, failed := range fleet.FailedInstanceOperations(operation) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("The %s operation %s on instance %s", spec.Type, failed.Status, failed.InstanceID),
+			Detail:   failed.ErrorMessage,
+		})
+	}
+	if diags.HasError() {
+		return diags
+	}
+	if operation.Status != fleet.OperationStatusSuccessful {
+		return diag.Errorf("the %s operation %s completed with status %s", spec.Type, operation.ID, operation.Status)
+	}
+
+	data.SetId(operation.ID)
+	setFleetOperation(data, operation)
+	return nil
+}
+
+func resourceFleetOperationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, diags := getFleetClient(meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	operation, err := fleet.GetOperation(ctx, client, data.Id())
+	if api_client.IsNotFound(err) {
+		// The fleet management keeps the operations for a limited time, the status read last is kept rather than
+		// running the operation again, run_on triggers a new one
+		tflog.Debug(ctx, fmt.Sprintf("Fleet operation %s not found, keeping its last status", data.Id()))
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setFleetOperation(data, operation)
+	return nil
+}
+
+func resourceFleetOperationDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The operation is a one-off operation, there is nothing to revert
+	return nil
+}
+
+func setFleetOperation(data *schema.ResourceData, operation *fleet.Operation) {
+	_ = data.Set("status", operation.Status)
+	_ = data.Set("instance", fleet.FlattenInstanceOperations(operation))
+}
@@ -0,0 +1,185 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	vcfclient "github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/users"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const groupUserType = "GROUP"
+
+func ResourceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Description: "Resource used to assign a role of SDDC Manager to a group of an SSO domain or of an Active Directory " +
+			"or OpenLDAP identity source. All members of the group are granted the role",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the group, e.g. gg-vcf-admins",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"domain": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The SSO domain or the domain of the identity source of the group, e.g. rainpole.io",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"role_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the role to assign to the group. Changing it updates the role in place",
+			},
+			"creation_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the group was added to SDDC Manager",
+			},
+		},
+	}
+}
+
+func resourceGroupCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	name := data.Get("name").(string)
+	domain := data.Get("domain").(string)
+	// Fail early on a typo, SDDC Manager would only reject the group when its members log in
+	if err := checkSsoGroupExists(ctx, client, name, domain); err != nil {
+		return diag.FromErr(err)
+	}
+	roleId, err := getRoleId(ctx, client, data.Get("role_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groupType := groupUserType
+	params := users.NewAddUsersParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithUsers([]*models.User{{
+			Name:   &name,
+			Domain: domain,
+			Type:   &groupType,
+			Role:   &models.RoleReference{ID: &roleId},
+		}})
+
+	_, created, err := client.Users.AddUsers(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if created == nil || created.Payload == nil || len(created.Payload.Elements) == 0 {
+		return diag.Errorf("the group %s was not added", name)
+	}
+	data.SetId(created.Payload.Elements[0].ID)
+
+	return resourceGroupRead(ctx, data, meta)
+}
+
+func resourceGroupRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	group, err := getUserOfType(ctx, client, data.Id(), groupUserType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if group == nil {
+		data.SetId("")
+		return nil
+	}
+
+	_ = data.Set("name", group.Name)
+	_ = data.Set("domain", group.Domain)
+	_ = data.Set("creation_timestamp", group.CreationTimestamp)
+	if group.Role != nil && group.Role.ID != nil {
+		roleName, err := getRoleName(ctx, client, *group.Role.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = data.Set("role_name", roleName)
+	}
+
+	return nil
+}
+
+func resourceGroupUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	if data.HasChange("role_name") {
+		roleId, err := getRoleId(ctx, client, data.Get("role_name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := updateUserRole(ctx, client, data.Id(), roleId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceGroupRead(ctx, data, meta)
+}
+
+func resourceGroupDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	params := users.NewRemoveUserParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Id())
+	if _, err := client.Users.RemoveUser(params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId("")
+	return nil
+}
+
+// checkSsoGroupExists checks that the SSO domain has a group with the name.
+func checkSsoGroupExists(ctx context.Context, client *vcfclient.VcfClient, name, domain string) error {
+	entitiesResponse, err := client.Users.GetSSODomainEntities(
+		users.NewGetSSODomainEntitiesParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithSSODomain(domain))
+	if err != nil {
+		return err
+	}
+	if entitiesResponse.Payload != nil {
+		for _, entity := range entitiesResponse.Payload.Elements {
+			if entity == nil || entity.Type == nil || *entity.Type != groupUserType {
+				continue
+			}
+			// The entities of an identity source may be qualified with the domain
+			if strings.EqualFold(entity.Name, name) || strings.EqualFold(entity.Name, name+"@"+domain) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("group %s not found in the SSO domain %s", name, domain)
+}
@@ -0,0 +1,79 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+)
+
+const testGroupName = "Administrators"
+
+func TestAccResourceVcfGroup(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		CheckDestroy:             testCheckVcfGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccVcfGroupConfig("no-such-group", "VIEWER"),
+				ExpectError: regexp.MustCompile("group no-such-group not found"),
+			},
+			{
+				Config: testAccVcfGroupConfig(testGroupName, "VIEWER"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_group.admins", "id"),
+					resource.TestCheckResourceAttrSet("vcf_group.admins", "creation_timestamp"),
+				),
+			},
+			{
+				Config: testAccVcfGroupConfig(testGroupName, "OPERATOR"),
+				Check:  resource.TestCheckResourceAttr("vcf_group.admins", "role_name", "OPERATOR"),
+			},
+			{
+				ResourceName:      "vcf_group.admins",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVcfGroupConfig(name, roleName string) string {
+	return fmt.Sprintf(`
+	resource "vcf_group" "admins" {
+		name      = %q
+		domain    = "vsphere.local"
+		role_name = %q
+	}
+`, name, roleName)
+}
+
+func testCheckVcfGroupDestroy(state *terraform.State) error {
+	client := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "vcf_group" {
+			continue
+		}
+
+		group, err := getUserOfType(context.Background(), client, rs.Primary.ID, groupUserType)
+		if err != nil {
+			return err
+		}
+		if group != nil {
+			return fmt.Errorf("found group %q", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
@@ -37,6 +37,8 @@ func ResourceHost() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(12 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Hour),
 			Delete: schema.DefaultTimeout(1 * time.Hour),
 		},
 		Schema: map[string]*schema.Schema{
@@ -168,6 +170,10 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	hostResponse, err := apiClient.Hosts.GetHost(getHostParams)
 	if err != nil {
+		var notFound *hosts.GetHostNotFound
+		if errors.As(err, &notFound) {
+			return removeFromState(d, "Host")
+		}
 		tflog.Error(ctx, err.Error())
 		return diag.FromErr(err)
 	}
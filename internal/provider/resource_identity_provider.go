@@ -0,0 +1,325 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	vcfclient "github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/identity_providers"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+const (
+	identityProviderTypeAdfs    = "Microsoft ADFS"
+	identityProviderTypeEntraId = "Microsoft Entra ID"
+	identityProviderTypeOkta    = "Okta"
+)
+
+func ResourceIdentityProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIdentityProviderCreate,
+		ReadContext:   resourceIdentityProviderRead,
+		UpdateContext: resourceIdentityProviderUpdate,
+		DeleteContext: resourceIdentityProviderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Description: "Resource used to federate the identity of the management vCenter with an external OIDC identity " +
+			"provider, Microsoft ADFS, Microsoft Entra ID or Okta. The users log in to vCenter and SDDC Manager with the provider",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the identity provider",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: fmt.Sprintf("The type of the identity provider. One of: %s, %s, %s",
+					identityProviderTypeAdfs, identityProviderTypeEntraId, identityProviderTypeOkta),
+				ValidateFunc: validation.StringInSlice([]string{
+					identityProviderTypeAdfs, identityProviderTypeEntraId, identityProviderTypeOkta}, false),
+			},
+			"client_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The identifier of the client application registered in the identity provider",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"client_secret": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The secret of the client application",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"discovery_endpoint": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The URL of the OpenID Connect metadata of the identity provider, e.g. " +
+					"https://login.microsoftonline.com/<tenant>/v2.0/.well-known/openid-configuration",
+				ValidateFunc: validation.IsURLWithHTTPS,
+			},
+			"cert_chain": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The PEM encoded certificates of the ADFS server or of the CA that issued them",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validationutils.ValidatePemCertificateSchema,
+				},
+			},
+			"directory": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The directory the users and groups are synchronized from. Required for Microsoft Entra ID and Okta",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the directory",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"default_domain": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The default domain of the users of the directory, e.g. rainpole.io",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"domains": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "The domains of the users and groups of the directory",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the directory",
+						},
+					},
+				},
+			},
+			"sync_client_token_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "The lifetime in seconds of the token of the SCIM client synchronizing the directory. Defaults to 3 days",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the identity provider, ACTIVE when the users log in with it",
+			},
+			"domain_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The domains of the identity provider",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The message of SDDC Manager about the configuration of the identity provider",
+			},
+		},
+		CustomizeDiff: func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+			federated := diff.Get("type").(string) != identityProviderTypeAdfs
+			hasDirectory := len(diff.Get("directory").([]interface{})) > 0
+			if federated && !hasDirectory {
+				return fmt.Errorf("directory must be set for %s", diff.Get("type").(string))
+			}
+			if !federated && hasDirectory {
+				return fmt.Errorf("directory is only supported for %s and %s", identityProviderTypeEntraId, identityProviderTypeOkta)
+			}
+			return nil
+		},
+	}
+}
+
+func resourceIdentityProviderCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	params := identity_providers.NewAddExternalIdentityProviderParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithIdentityProviderSpec(getIdentityProviderSpec(data))
+	if _, _, err := client.IdentityProviders.AddExternalIdentityProvider(params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The response has no ID, the provider is looked up by name
+	name := data.Get("name").(string)
+	identityProvider, err := findIdentityProvider(ctx, client, func(identityProvider *models.IdentityProvider) bool {
+		return identityProvider.Name == name
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if identityProvider == nil {
+		return diag.Errorf("identity provider %s not found after it was added", name)
+	}
+	data.SetId(identityProvider.ID)
+
+	return resourceIdentityProviderRead(ctx, data, meta)
+}
+
+func resourceIdentityProviderRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	identityProvider, err := findIdentityProvider(ctx, client, func(identityProvider *models.IdentityProvider) bool {
+		return identityProvider.ID == data.Id()
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if identityProvider == nil {
+		data.SetId("")
+		return nil
+	}
+
+	_ = data.Set("name", identityProvider.Name)
+	_ = data.Set("type", identityProvider.Type)
+	_ = data.Set("status", identityProvider.Status)
+	_ = data.Set("domain_names", identityProvider.DomainNames)
+	_ = data.Set("message", identityProvider.IdpMessage)
+
+	// The client secret is never returned
+	oidc := identityProvider.Oidc
+	if fedIdp := identityProvider.FedIdp; fedIdp != nil {
+		if fedIdp.OidcInfo != nil {
+			oidc = fedIdp.OidcInfo
+		}
+		if fedIdp.SyncClientTokenTTL > 0 {
+			_ = data.Set("sync_client_token_ttl", int(fedIdp.SyncClientTokenTTL))
+		}
+		if directory := fedIdp.DirectoryList; directory != nil {
+			directoryAttributes := map[string]interface{}{
+				"domains": directory.Domains,
+				"id":      directory.DirectoryID,
+			}
+			if directory.Name != nil {
+				directoryAttributes["name"] = *directory.Name
+			}
+			if directory.DefaultDomain != nil {
+				directoryAttributes["default_domain"] = *directory.DefaultDomain
+			}
+			_ = data.Set("directory", []map[string]interface{}{directoryAttributes})
+		}
+	}
+	if oidc != nil {
+		_ = data.Set("client_id", oidc.ClientID)
+		_ = data.Set("discovery_endpoint", oidc.DiscoveryEndpoint)
+	}
+
+	return nil
+}
+
+func resourceIdentityProviderUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	params := identity_providers.NewUpdateExternalIdentityProviderParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Id()).
+		WithIdentityProviderSpec(getIdentityProviderSpec(data))
+	if _, _, err := client.IdentityProviders.UpdateExternalIdentityProvider(params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceIdentityProviderRead(ctx, data, meta)
+}
+
+func resourceIdentityProviderDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	// The users log in with the embedded identity provider again
+	params := identity_providers.NewDeleteExternalIdentityProviderParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Id())
+	if _, _, err := client.IdentityProviders.DeleteExternalIdentityProvider(params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId("")
+	return nil
+}
+
+func getIdentityProviderSpec(data *schema.ResourceData) *models.IdentityProviderSpec {
+	name := data.Get("name").(string)
+	providerType := data.Get("type").(string)
+	clientId := data.Get("client_id").(string)
+	clientSecret := data.Get("client_secret").(string)
+	discoveryEndpoint := data.Get("discovery_endpoint").(string)
+	oidcSpec := &models.OidcSpec{
+		ClientID:          &clientId,
+		ClientSecret:      &clientSecret,
+		DiscoveryEndpoint: &discoveryEndpoint,
+	}
+
+	spec := &models.IdentityProviderSpec{
+		Name:      &name,
+		Type:      &providerType,
+		CertChain: utils.ToStringSlice(data.Get("cert_chain").([]interface{})),
+	}
+	directories := data.Get("directory").([]interface{})
+	if len(directories) == 0 {
+		spec.Oidc = oidcSpec
+		return spec
+	}
+
+	// Microsoft Entra ID and Okta federate through the vCenter identity broker, which synchronizes the directory
+	directory := directories[0].(map[string]interface{})
+	directoryName := directory["name"].(string)
+	defaultDomain := directory["default_domain"].(string)
+	spec.FedIdpSpec = &models.FederatedIdentityProviderSpec{
+		Name:     &name,
+		OidcSpec: oidcSpec,
+		Directory: &models.IdentityProviderDirectory{
+			Name:          &directoryName,
+			DefaultDomain: &defaultDomain,
+			Domains:       utils.ToStringSlice(directory["domains"].([]interface{})),
+		},
+		SyncClientTokenTTL: int64(data.Get("sync_client_token_ttl").(int)),
+	}
+	return spec
+}
+
+func findIdentityProvider(ctx context.Context, client *vcfclient.VcfClient, matches func(*models.IdentityProvider) bool) (*models.IdentityProvider, error) {
+	providersResponse, err := client.IdentityProviders.GetIdentityProviders(
+		identity_providers.NewGetIdentityProvidersParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if providersResponse.Payload != nil {
+		for _, identityProvider := range providersResponse.Payload.Elements {
+			if identityProvider != nil && matches(identityProvider) {
+				return identityProvider, nil
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,86 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceVcfIdentityProvider(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		CheckDestroy:             testCheckVcfIdentityProviderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccVcfIdentityProviderConfig(identityProviderTypeOkta),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("directory must be set for Okta"),
+			},
+			{
+				Config: testAccVcfIdentityProviderConfig(identityProviderTypeAdfs),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_identity_provider.adfs", "id"),
+					resource.TestCheckResourceAttrSet("vcf_identity_provider.adfs", "status"),
+					resource.TestCheckResourceAttr("vcf_identity_provider.adfs", "client_id", os.Getenv(constants.VcfTestOidcClientId)),
+				),
+			},
+			{
+				ResourceName:            "vcf_identity_provider.adfs",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_secret", "cert_chain"},
+			},
+		},
+	})
+}
+
+func testAccVcfIdentityProviderConfig(providerType string) string {
+	return fmt.Sprintf(`
+	resource "vcf_identity_provider" "adfs" {
+		name               = "terraform-acceptance-test"
+		type               = %q
+		client_id          = %q
+		client_secret      = %q
+		discovery_endpoint = %q
+	}`,
+		providerType,
+		os.Getenv(constants.VcfTestOidcClientId),
+		os.Getenv(constants.VcfTestOidcClientSecret),
+		os.Getenv(constants.VcfTestOidcDiscoveryEndpoint))
+}
+
+func testCheckVcfIdentityProviderDestroy(state *terraform.State) error {
+	client := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "vcf_identity_provider" {
+			continue
+		}
+
+		identityProvider, err := findIdentityProvider(context.Background(), client, func(identityProvider *models.IdentityProvider) bool {
+			return identityProvider.ID == rs.Primary.ID
+		})
+		if err != nil {
+			return err
+		}
+		if identityProvider != nil {
+			return fmt.Errorf("found identity provider %q", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
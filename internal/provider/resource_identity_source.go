@@ -0,0 +1,358 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	vcfclient "github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/identity_providers"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+// embeddedIdentityProviderType is the type of the vCenter Single Sign-On identity provider of the management domain.
+const embeddedIdentityProviderType = "Embedded"
+
+func ResourceIdentitySource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIdentitySourceCreate,
+		ReadContext:   resourceIdentitySourceRead,
+		UpdateContext: resourceIdentitySourceUpdate,
+		DeleteContext: resourceIdentitySourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Description: "Resource used to add an Active Directory or OpenLDAP identity source to the vCenter Single Sign-On " +
+			"identity provider of SDDC Manager. The ID of the resource is the domain name of the identity source",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the identity source",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"domain_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the domain, e.g. rainpole.io",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"domain_alias": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The alias of the domain, e.g. the NetBIOS name RAINPOLE",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ActiveDirectory",
+				Description:  "The type of the LDAP server. One of: ActiveDirectory, OpenLdap",
+				ValidateFunc: validation.StringInSlice([]string{"ActiveDirectory", "OpenLdap"}, false),
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The user name the identity provider binds to the LDAP server with, e.g. svc-sso@rainpole.io",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The password of the bind user",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"users_base_dn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The base distinguished name of the users, e.g. ou=Users,dc=rainpole,dc=io",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"groups_base_dn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The base distinguished name of the groups, e.g. ou=Groups,dc=rainpole,dc=io",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"server_endpoints": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Description: "The URLs of the LDAP servers, e.g. ldaps://dc01.rainpole.io:636. " +
+					"The identity provider fails over to the next server when one is unreachable",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsURLWithScheme([]string{"ldap", "ldaps"}),
+				},
+			},
+			"cert_chain": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "The PEM encoded certificates of the LDAPS servers or of the CA that issued them. " +
+					"Required when one of the server endpoints uses LDAPS. SDDC Manager trusts them for outbound connections " +
+					"unless trust_certificates is false",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validationutils.ValidatePemCertificateSchema,
+				},
+			},
+			"trust_certificates": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to add the certificates of cert_chain to the trust store of SDDC Manager before adding the identity source",
+			},
+			"certificate_aliases": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The aliases under which SDDC Manager trusts the certificates added by the resource. Certificates already trusted are not added, nor removed on destroy",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"identity_provider_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the vCenter Single Sign-On identity provider the identity source is added to",
+			},
+		},
+		CustomizeDiff: func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+			if requiresCertChain(utils.ToStringSlice(diff.Get("server_endpoints").([]interface{}))) &&
+				len(diff.Get("cert_chain").([]interface{})) == 0 {
+				return fmt.Errorf("cert_chain must be set when one of the server endpoints uses LDAPS")
+			}
+			if diff.Id() != "" && diff.HasChanges("cert_chain", "trust_certificates") {
+				return diff.SetNewComputed("certificate_aliases")
+			}
+			return nil
+		},
+	}
+}
+
+func resourceIdentitySourceCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	identityProvider, err := getEmbeddedIdentityProvider(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := trustIdentitySourceCertificates(ctx, data, client); diags != nil {
+		return diags
+	}
+
+	params := identity_providers.NewAddEmbeddedIdentitySourceParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(identityProvider.ID).
+		WithIdentitySourceSpec(getIdentitySourceSpec(data))
+	if _, _, err = client.IdentityProviders.AddEmbeddedIdentitySource(params); err != nil {
+		// Without an ID the aliases would not be kept in the state, the certificates are removed right away
+		return append(diag.FromErr(err), untrustIdentitySourceCertificates(ctx, data, data.Get("certificate_aliases"), client)...)
+	}
+	data.SetId(data.Get("domain_name").(string))
+
+	return resourceIdentitySourceRead(ctx, data, meta)
+}
+
+func resourceIdentitySourceRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	identityProvider, err := getEmbeddedIdentityProvider(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("identity_provider_id", identityProvider.ID)
+
+	identitySource := findIdentitySource(identityProvider, data.Id())
+	if identitySource == nil {
+		data.SetId("")
+		return nil
+	}
+	_ = data.Set("name", identitySource.Name)
+	_ = data.Set("domain_name", data.Id())
+
+	if ldap := identitySource.Ldap; ldap != nil {
+		_ = data.Set("domain_alias", ldap.DomainAlias)
+		_ = data.Set("type", ldap.Type)
+		_ = data.Set("username", ldap.Username)
+		// The password is never returned and the certificates are kept as configured, the API returns them re-encoded
+		if details := ldap.SourceDetails; details != nil {
+			_ = data.Set("server_endpoints", details.ServerEndpoints)
+			if details.UsersBaseDn != nil {
+				_ = data.Set("users_base_dn", *details.UsersBaseDn)
+			}
+			if details.GroupsBaseDn != nil {
+				_ = data.Set("groups_base_dn", *details.GroupsBaseDn)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceIdentitySourceUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	if data.HasChanges("cert_chain", "trust_certificates") {
+		// The aliases in the state, the plan marks them as unknown
+		oldAliases, _ := data.GetChange("certificate_aliases")
+		if diags := untrustIdentitySourceCertificates(ctx, data, oldAliases, client); diags != nil {
+			return diags
+		}
+		if diags := trustIdentitySourceCertificates(ctx, data, client); diags != nil {
+			return diags
+		}
+	}
+
+	// The identity source is replaced as a whole, the API requires the bind password on every update
+	params := identity_providers.NewUpdateEmbeddedIdentitySourceParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Get("identity_provider_id").(string)).
+		WithDomainName(data.Id()).
+		WithIdentitySourceSpec(getIdentitySourceSpec(data))
+	if _, _, err := client.IdentityProviders.UpdateEmbeddedIdentitySource(params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceIdentitySourceRead(ctx, data, meta)
+}
+
+func resourceIdentitySourceDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	params := identity_providers.NewDeleteIdentitySourceParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Get("identity_provider_id").(string)).
+		WithDomainName(data.Id())
+	if _, _, err := client.IdentityProviders.DeleteIdentitySource(params); err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := untrustIdentitySourceCertificates(ctx, data, data.Get("certificate_aliases"), client); diags != nil {
+		return diags
+	}
+
+	data.SetId("")
+	return nil
+}
+
+// trustIdentitySourceCertificates adds the certificates of cert_chain SDDC Manager does not trust yet to its trust store,
+// so that the connection to the LDAPS servers can be verified without a manual trust step.
+func trustIdentitySourceCertificates(ctx context.Context, data *schema.ResourceData, client *vcfclient.VcfClient) diag.Diagnostics {
+	aliases := make([]string, 0)
+	if data.Get("trust_certificates").(bool) {
+		for _, certificate := range utils.ToStringSlice(data.Get("cert_chain").([]interface{})) {
+			_, err := lcm.FindTrustedCertificateAlias(ctx, certificate, client)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, lcm.ErrCertificateNotTrusted) {
+				return diag.FromErr(err)
+			}
+			alias, err := lcm.TrustCertificate(ctx, certificate, client)
+			if err != nil {
+				_ = data.Set("certificate_aliases", aliases)
+				return diag.FromErr(err)
+			}
+			aliases = append(aliases, alias)
+		}
+	}
+	_ = data.Set("certificate_aliases", aliases)
+
+	return nil
+}
+
+// untrustIdentitySourceCertificates removes the certificates added by trustIdentitySourceCertificates from the trust store.
+func untrustIdentitySourceCertificates(ctx context.Context, data *schema.ResourceData, aliases interface{}, client *vcfclient.VcfClient) diag.Diagnostics {
+	for _, alias := range utils.ToStringSlice(aliases.([]interface{})) {
+		if err := lcm.DeleteTrustedCertificate(ctx, alias, client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	_ = data.Set("certificate_aliases", []string{})
+
+	return nil
+}
+
+func getIdentitySourceSpec(data *schema.ResourceData) *models.IdentitySourceSpec {
+	name := data.Get("name").(string)
+	domainName := data.Get("domain_name").(string)
+	ldapType := data.Get("type").(string)
+	username := data.Get("username").(string)
+	password := data.Get("password").(string)
+	usersBaseDn := data.Get("users_base_dn").(string)
+	groupsBaseDn := data.Get("groups_base_dn").(string)
+
+	return &models.IdentitySourceSpec{
+		Name: &name,
+		Ldap: &models.LdapSpec{
+			DomainName:  &domainName,
+			DomainAlias: data.Get("domain_alias").(string),
+			Type:        &ldapType,
+			Username:    &username,
+			Password:    &password,
+			SourceDetails: &models.SourceDetails{
+				ServerEndpoints: utils.ToStringSlice(data.Get("server_endpoints").([]interface{})),
+				CertChain:       utils.ToStringSlice(data.Get("cert_chain").([]interface{})),
+				UsersBaseDn:     &usersBaseDn,
+				GroupsBaseDn:    &groupsBaseDn,
+			},
+		},
+	}
+}
+
+// getEmbeddedIdentityProvider returns the vCenter Single Sign-On identity provider with its identity sources.
+func getEmbeddedIdentityProvider(ctx context.Context, client *vcfclient.VcfClient) (*models.IdentityProvider, error) {
+	identityProvider, err := findIdentityProvider(ctx, client, func(identityProvider *models.IdentityProvider) bool {
+		return identityProvider.Type == embeddedIdentityProviderType
+	})
+	if err == nil && identityProvider == nil {
+		err = fmt.Errorf("the %s identity provider was not found", embeddedIdentityProviderType)
+	}
+	return identityProvider, err
+}
+
+func findIdentitySource(identityProvider *models.IdentityProvider, domainName string) *models.VcIdentitySources {
+	for _, identitySource := range identityProvider.IdentitySources {
+		if identitySource == nil {
+			continue
+		}
+		if identitySource.Ldap != nil && identitySource.Ldap.DomainName == domainName {
+			return identitySource
+		}
+		for _, name := range identitySource.DomainNames {
+			if name == domainName {
+				return identitySource
+			}
+		}
+	}
+	return nil
+}
+
+func requiresCertChain(serverEndpoints []string) bool {
+	for _, serverEndpoint := range serverEndpoints {
+		if strings.HasPrefix(strings.ToLower(serverEndpoint), "ldaps://") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+func TestAccResourceVcfIdentitySource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		CheckDestroy:             testCheckVcfIdentitySourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfIdentitySourceConfig("cn=Users"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_identity_source.ad", "id", os.Getenv(constants.VcfTestLdapDomain)),
+					resource.TestCheckResourceAttrSet("vcf_identity_source.ad", "identity_provider_id"),
+					resource.TestCheckResourceAttr("vcf_identity_source.ad", "type", "ActiveDirectory"),
+					testCheckVcfIdentitySourceCertificateTrusted,
+				),
+			},
+			{
+				Config: testAccVcfIdentitySourceConfig("ou=Groups"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_identity_source.ad", "groups_base_dn",
+						"ou=Groups,"+os.Getenv(constants.VcfTestLdapBaseDn)),
+				),
+			},
+			{
+				ResourceName:            "vcf_identity_source.ad",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "cert_chain", "trust_certificates", "certificate_aliases"},
+			},
+		},
+	})
+}
+
+func testAccVcfIdentitySourceConfig(groupsContainer string) string {
+	certChain := ""
+	if certificate := os.Getenv(constants.VcfTestLdapCertificate); certificate != "" {
+		certChain = fmt.Sprintf("cert_chain = [%q]", certificate)
+	}
+	return fmt.Sprintf(`
+	resource "vcf_identity_source" "ad" {
+		name             = %q
+		domain_name      = %q
+		username         = %q
+		password         = %q
+		users_base_dn    = "cn=Users,%s"
+		groups_base_dn   = "%s,%s"
+		server_endpoints = [%q]
+		%s
+	}`,
+		os.Getenv(constants.VcfTestLdapDomain),
+		os.Getenv(constants.VcfTestLdapDomain),
+		os.Getenv(constants.VcfTestLdapUsername),
+		os.Getenv(constants.VcfTestLdapPassword),
+		os.Getenv(constants.VcfTestLdapBaseDn),
+		groupsContainer,
+		os.Getenv(constants.VcfTestLdapBaseDn),
+		os.Getenv(constants.VcfTestLdapServerEndpoint),
+		certChain)
+}
+
+// testCheckVcfIdentitySourceCertificateTrusted checks that SDDC Manager trusts the certificate of the LDAPS server.
+func testCheckVcfIdentitySourceCertificateTrusted(_ *terraform.State) error {
+	certificate := os.Getenv(constants.VcfTestLdapCertificate)
+	if certificate == "" {
+		return nil
+	}
+	client := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+
+	_, err := lcm.FindTrustedCertificateAlias(context.Background(), certificate, client)
+	return err
+}
+
+func testCheckVcfIdentitySourceDestroy(state *terraform.State) error {
+	client := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "vcf_identity_source" {
+			continue
+		}
+
+		identityProvider, err := getEmbeddedIdentityProvider(context.Background(), client)
+		if err != nil {
+			return err
+		}
+		if findIdentitySource(identityProvider, rs.Primary.ID) != nil {
+			return fmt.Errorf("found identity source %q", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
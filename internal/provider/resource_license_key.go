@@ -0,0 +1,225 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/client/license_keys"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+// licenseProductTypes are the products SDDC Manager manages license keys for.
+var licenseProductTypes = []string{"VCENTER", "VSAN", "SDDC_MANAGER", "ESXI", "NSXT", "NSXIO", "WCP", "HORIZON_VIEW"}
+
+func ResourceLicenseKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLicenseKeyCreate,
+		ReadContext:   resourceLicenseKeyRead,
+		DeleteContext: resourceLicenseKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLicenseKeyImport,
+		},
+		Description: "Resource used to add a license key to the inventory of SDDC Manager, from which the license keys of " +
+			"the domains, clusters and hosts are assigned",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"product_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The product of the license key. One among VCENTER, VSAN, SDDC_MANAGER, ESXI, NSXT, NSXIO, WCP, HORIZON_VIEW",
+				ValidateFunc: validation.StringInSlice(licenseProductTypes, false),
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				Description:  "The license key, 5 groups of 5 upper case letters or digits separated by hyphens",
+				ValidateFunc: validationutils.ValidateLicenseKey,
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The description of the license key",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"product_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the product of the license key",
+			},
+			"is_unlimited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the usage of the license key is unlimited",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the license key, e.g. ACTIVE or EXPIRED",
+			},
+			"expiry_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiry date of the license key, empty if it never expires",
+			},
+			"license_unit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unit the usage of the license key is counted in, e.g. CPU or CORE",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of units the license key allows",
+			},
+			"used": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of units in use",
+			},
+			"remaining": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of units left",
+			},
+		},
+	}
+}
+
+func resourceLicenseKeyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	productType := data.Get("product_type").(string)
+	key := data.Get("key").(string)
+	description := data.Get("description").(string)
+	params := license_keys.NewAddLicenseKeyParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithLicenseKey(&models.LicenseKey{
+			ProductType: &productType,
+			Key:         &key,
+			Description: &description,
+		})
+
+	ok, created, err := apiClient.LicenseKeys.AddLicenseKey(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var licenseKey *models.LicenseKey
+	if created != nil {
+		licenseKey = created.Payload
+	} else if ok != nil {
+		licenseKey = ok.Payload
+	}
+	if licenseKey != nil && len(licenseKey.ID) > 0 {
+		data.SetId(licenseKey.ID)
+	} else {
+		// The key is sensitive, it is not used as the ID as is
+		id, err := utils.HashFields([]string{key})
+		if err != nil {
+			return diag.Errorf("error during id generation %s", err)
+		}
+		data.SetId(id)
+	}
+
+	return resourceLicenseKeyRead(ctx, data, meta)
+}
+
+func resourceLicenseKeyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	params := license_keys.NewGetLicenseKeyParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithKey(data.Get("key").(string))
+	result, err := apiClient.LicenseKeys.GetLicenseKey(params)
+	if err != nil {
+		var notFound *license_keys.GetLicenseKeyNotFound
+		if errors.As(err, &notFound) {
+			log.Printf("License key %s not found, removing it from the state", data.Id())
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	licenseKey := result.Payload
+	if licenseKey == nil {
+		data.SetId("")
+		return nil
+	}
+
+	if len(licenseKey.ID) > 0 {
+		data.SetId(licenseKey.ID)
+	}
+	if licenseKey.ProductType != nil {
+		_ = data.Set("product_type", *licenseKey.ProductType)
+	}
+	if licenseKey.Description != nil {
+		_ = data.Set("description", *licenseKey.Description)
+	}
+	_ = data.Set("product_version", licenseKey.ProductVersion)
+	_ = data.Set("is_unlimited", licenseKey.IsUnlimited)
+	if licenseKey.LicenseKeyValidity != nil {
+		_ = data.Set("status", licenseKey.LicenseKeyValidity.LicenseKeyStatus)
+		_ = data.Set("expiry_date", licenseKey.LicenseKeyValidity.ExpiryDate)
+	}
+	if licenseKey.LicenseKeyUsage != nil {
+		_ = data.Set("license_unit", licenseKey.LicenseKeyUsage.LicenseUnit)
+		_ = data.Set("total", int(licenseKey.LicenseKeyUsage.Total))
+		_ = data.Set("used", int(licenseKey.LicenseKeyUsage.Used))
+		_ = data.Set("remaining", int(licenseKey.LicenseKeyUsage.Remaining))
+	}
+
+	return nil
+}
+
+func resourceLicenseKeyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	// SDDC Manager refuses to remove a license key which is assigned to a resource
+	params := license_keys.NewRemoveLicenseKeyParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithKey(data.Get("key").(string))
+	if _, _, err := apiClient.LicenseKeys.RemoveLicenseKey(params); err != nil {
+		var notFound *license_keys.RemoveLicenseKeyNotFound
+		if !errors.As(err, &notFound) {
+			return diag.FromErr(err)
+		}
+	}
+
+	data.SetId("")
+	return nil
+}
+
+// resourceLicenseKeyImport imports a license key by the key itself, which is the only way SDDC Manager looks
+// license keys up.
+func resourceLicenseKeyImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	_ = data.Set("key", data.Id())
+	if diags := resourceLicenseKeyRead(ctx, data, meta); diags.HasError() {
+		return nil, errors.New(diags[0].Summary)
+	}
+	if len(data.Id()) == 0 {
+		return nil, errors.New("license key not found")
+	}
+	return []*schema.ResourceData{data}, nil
+}
@@ -0,0 +1,79 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/vcf-sdk-go/client/license_keys"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceVcfLicenseKey(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		CheckDestroy:             testCheckVcfLicenseKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfLicenseKeyConfig(os.Getenv(constants.VcfTestLicenseKey)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_license_key.esxi", "id"),
+					resource.TestCheckResourceAttr("vcf_license_key.esxi", "product_type", "ESXI"),
+					resource.TestCheckResourceAttrSet("vcf_license_key.esxi", "status"),
+					resource.TestCheckResourceAttrSet("vcf_license_key.esxi", "license_unit"),
+				),
+			},
+			{
+				ResourceName:      "vcf_license_key.esxi",
+				ImportState:       true,
+				ImportStateId:     os.Getenv(constants.VcfTestLicenseKey),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVcfLicenseKeyConfig(key string) string {
+	return fmt.Sprintf(`
+	resource "vcf_license_key" "esxi" {
+		product_type = "ESXI"
+		key          = %q
+		description  = "ESXi license key added by the acceptance tests"
+	}
+`, key)
+}
+
+func testCheckVcfLicenseKeyDestroy(state *terraform.State) error {
+	client := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "vcf_license_key" {
+			continue
+		}
+
+		params := license_keys.NewGetLicenseKeyParamsWithContext(context.Background()).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithKey(rs.Primary.Attributes["key"])
+		_, err := client.LicenseKeys.GetLicenseKey(params)
+		if err == nil {
+			return fmt.Errorf("found license key %q", rs.Primary.ID)
+		}
+		var notFound *license_keys.GetLicenseKeyNotFound
+		if !errors.As(err, &notFound) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	vcfclient "github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/users"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+func ResourceLocalAccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLocalAccountCreate,
+		ReadContext:   resourceLocalAccountRead,
+		UpdateContext: resourceLocalAccountUpdate,
+		DeleteContext: resourceLocalAccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "Manages the password of the admin@local account of SDDC Manager, the break-glass API account " +
+			"which is available when the vCenter Single Sign-On domain is not",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"password_wo": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ValidateFunc: validationutils.ValidateLocalAccountPassword,
+				Description: "The new password of the account as a write-only argument which is never stored in the state. " +
+					"At least 12 characters long, with a special symbol among !%@$^#?*. Requires Terraform 1.11 or later",
+			},
+			"old_password_wo": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Description: "The current password of the account as a write-only argument, required when the account " +
+					"is already configured",
+			},
+			"password_wo_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "Arbitrary version of the write-only password. Changing it rotates the password, set " +
+					"old_password_wo to the previous password",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the account",
+			},
+			"is_configured": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the account has a password",
+			},
+			"last_update_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last password update",
+			},
+		},
+	}
+}
+
+func resourceLocalAccountCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := updateLocalAccountPassword(ctx, data, meta); diags.HasError() {
+		return diags
+	}
+
+	return resourceLocalAccountRead(ctx, data, meta)
+}
+
+func resourceLocalAccountRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	localAccount, err := getLocalAccount(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if localAccount.Name != nil {
+		_ = data.Set("name", *localAccount.Name)
+	}
+	_ = data.Set("is_configured", localAccount.IsConfigured)
+
+	return nil
+}
+
+func resourceLocalAccountUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if data.HasChange("password_wo_version") {
+		if diags := updateLocalAccountPassword(ctx, data, meta); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceLocalAccountRead(ctx, data, meta)
+}
+
+func resourceLocalAccountDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The account cannot be removed, removing the resource leaves the password in place
+	return nil
+}
+
+func getLocalAccount(ctx context.Context, apiClient *vcfclient.VcfClient) (*models.LocalUser, error) {
+	localAccountResponse, err := apiClient.Users.GetLocalAccount(
+		users.NewGetLocalAccountParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if localAccountResponse.Payload == nil {
+		return nil, fmt.Errorf("the local account of SDDC Manager was not found")
+	}
+	return localAccountResponse.Payload, nil
+}
+
+func updateLocalAccountPassword(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	// Write-only values are never stored in the state, so they have to be read from the configuration
+	password, diags := getWriteOnlyString(data, "password_wo")
+	if diags.HasError() {
+		return diags
+	}
+	oldPassword, diags := getWriteOnlyString(data, "old_password_wo")
+	if diags.HasError() {
+		return diags
+	}
+
+	localAccount, err := getLocalAccount(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if localAccount.IsConfigured && len(oldPassword) == 0 {
+		return diag.Errorf("old_password_wo must be set, the password of the local account is already configured")
+	}
+
+	params := users.NewUpdateLocalUserPasswordParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithLocaUserPassword(&models.LocalAccountPasswordInfo{
+			NewPassword: &password,
+			OldPassword: oldPassword,
+		})
+	if _, err := apiClient.Users.UpdateLocalUserPassword(params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// SDDC Manager has a single local account
+	if localAccount.Name != nil {
+		data.SetId(*localAccount.Name)
+	} else {
+		data.SetId("admin@local")
+	}
+	_ = data.Set("last_update_time", time.Now().Format(time.RFC3339))
+
+	return nil
+}
+
+func getWriteOnlyString(data *schema.ResourceData, key string) (string, diag.Diagnostics) {
+	value, diags := data.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return "", diags
+	}
+	if value.IsKnown() && !value.IsNull() {
+		return value.AsString(), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,51 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceLocalAccount(t *testing.T) {
+	password := os.Getenv(constants.VcfTestLocalAccountPassword)
+	rotatedPassword := password + "Rotated1!"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceLocalAccountConfig(rotatedPassword, password, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_local_account.admin", "name", "admin@local"),
+					resource.TestCheckResourceAttr("vcf_local_account.admin", "is_configured", "true"),
+					resource.TestCheckNoResourceAttr("vcf_local_account.admin", "password_wo"),
+					resource.TestCheckNoResourceAttr("vcf_local_account.admin", "old_password_wo"),
+				),
+			},
+			{
+				// Bumping the version rotates the password, back to the original one
+				Config: testAccResourceLocalAccountConfig(password, rotatedPassword, 2),
+				Check:  resource.TestCheckResourceAttr("vcf_local_account.admin", "password_wo_version", "2"),
+			},
+		},
+	})
+}
+
+func testAccResourceLocalAccountConfig(password, oldPassword string, version int) string {
+	return fmt.Sprintf(`
+		resource "vcf_local_account" "admin" {
+			password_wo = %q
+			old_password_wo = %q
+			password_wo_version = %d
+		}
+`, password, oldPassword, version)
+}
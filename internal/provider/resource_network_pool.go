@@ -6,7 +6,11 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/netip"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -26,6 +30,7 @@ import (
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 type IpPoolModel struct {
@@ -52,6 +57,53 @@ type ResourceNetworkPoolModel struct {
 
 type ResourceNetworkPool struct {
 	client *client.VcfClient
+	// plannedRanges are the IP ranges of the network pools planned by the provider instance
+	plannedRanges *networkPoolRanges
+	// defaultTimeouts are the timeouts of the provider configuration which replace the built-in defaults
+	defaultTimeouts *operationTimeouts
+}
+
+// networkPoolRanges holds the IP ranges of the network pools planned by a provider instance, so that the
+// overlaps between the network pools of a configuration are reported by the plan instead of the apply.
+type networkPoolRanges struct {
+	mutex  sync.Mutex
+	ranges map[string][]validationutils.IpRange
+}
+
+func newNetworkPoolRanges() *networkPoolRanges {
+	return &networkPoolRanges{ranges: make(map[string][]validationutils.IpRange)}
+}
+
+// register records the ranges of the network pool and returns the overlaps with the ranges of the other network pools.
+func (r *networkPoolRanges) register(name string, ranges []validationutils.IpRange) []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.ranges[name] = ranges
+	overlaps := make([]string, 0)
+	for otherName, otherRanges := range r.ranges {
+		if otherName == name {
+			continue
+		}
+		for _, ipRange := range ranges {
+			for _, otherRange := range otherRanges {
+				if ipRange.Overlaps(otherRange) {
+					overlaps = append(overlaps, fmt.Sprintf("the range %s overlaps the range %s of the network pool %s",
+						ipRange, otherRange, otherName))
+				}
+			}
+		}
+	}
+	sort.Strings(overlaps)
+
+	return overlaps
+}
+
+func (r *networkPoolRanges) unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.ranges, name)
 }
 
 func (r *ResourceNetworkPool) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
@@ -62,7 +114,13 @@ func (r *ResourceNetworkPool) Configure(ctx context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*api_client.SddcManagerClient).ApiClient
+	sddcManagerClient, ok := req.ProviderData.(*api_client.SddcManagerClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data",
+			fmt.Sprintf("vcf_network_pool requires a connection to SDDC Manager, got %T.", req.ProviderData))
+		return
+	}
+	r.client = sddcManagerClient.ApiClient
 }
 
 func (r *ResourceNetworkPool) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -74,6 +132,9 @@ func (r *ResourceNetworkPool) Schema(ctx context.Context, req resource.SchemaReq
 		Attributes: map[string]schema.Attribute{
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
 			}),
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -149,16 +210,127 @@ func (r *ResourceNetworkPool) Schema(ctx context.Context, req resource.SchemaReq
 	}
 }
 
+// ValidateConfig checks that the IP pools of each network are in its subnet, do not include its gateway and do not
+// overlap each other. The values unknown until the apply are not checked.
+func (r *ResourceNetworkPool) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var data ResourceNetworkPoolModel
+	res.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if res.Diagnostics.HasError() || data.Networks.IsNull() || data.Networks.IsUnknown() {
+		return
+	}
+
+	var networks []NetworkModel
+	res.Diagnostics.Append(data.Networks.ElementsAs(ctx, &networks, false)...)
+	rangesByNetwork := make([][]validationutils.IpRange, len(networks))
+	for i, network := range networks {
+		networkPath := path.Root("network").AtListIndex(i)
+		ranges, diags := networkIpRanges(ctx, network, networkPath)
+		res.Diagnostics.Append(diags...)
+		rangesByNetwork[i] = ranges
+
+		if network.Subnet.IsUnknown() || network.Mask.IsUnknown() || network.Subnet.IsNull() || network.Mask.IsNull() {
+			continue
+		}
+		subnet, err := validationutils.ParseSubnet(network.Subnet.ValueString(), network.Mask.ValueString())
+		if err != nil {
+			res.Diagnostics.AddAttributeError(networkPath.AtName("subnet"), "Invalid subnet", err.Error())
+			continue
+		}
+		var gateway netip.Addr
+		if !network.Gateway.IsUnknown() && !network.Gateway.IsNull() {
+			if gateway, err = netip.ParseAddr(network.Gateway.ValueString()); err != nil {
+				res.Diagnostics.AddAttributeError(networkPath.AtName("gateway"), "Invalid gateway", err.Error())
+			} else if err := validationutils.ValidateGatewayInSubnet(subnet, gateway); err != nil {
+				res.Diagnostics.AddAttributeError(networkPath.AtName("gateway"), "Invalid gateway", err.Error())
+			}
+		}
+		for _, err := range validationutils.ValidateIpRangesInSubnet(subnet, gateway, ranges) {
+			res.Diagnostics.AddAttributeError(networkPath.AtName("ip_pools"), "Invalid IP pool", err.Error())
+		}
+	}
+
+	// The networks of a pool may share a subnet, their ranges must not overlap then either
+	for i, ranges := range rangesByNetwork {
+		for j, otherRanges := range rangesByNetwork[:i] {
+			for _, ipRange := range ranges {
+				for _, other := range otherRanges {
+					if ipRange.Overlaps(other) {
+						res.Diagnostics.AddAttributeError(path.Root("network").AtListIndex(i), "Overlapping IP pools",
+							fmt.Sprintf("the range %s overlaps the range %s of network %d", ipRange, other, j))
+					}
+				}
+			}
+		}
+	}
+}
+
+// ModifyPlan reports the overlaps between the IP pools of the network pool and the ones of the other network pools
+// planned with it.
+func (r *ResourceNetworkPool) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	if r.plannedRanges == nil {
+		return
+	}
+	if req.Plan.Raw.IsNull() {
+		var state ResourceNetworkPoolModel
+		res.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		r.plannedRanges.unregister(state.Name.ValueString())
+		return
+	}
+
+	var data ResourceNetworkPoolModel
+	res.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if res.Diagnostics.HasError() || data.Name.IsUnknown() || data.Networks.IsUnknown() || data.Networks.IsNull() {
+		return
+	}
+
+	var networks []NetworkModel
+	res.Diagnostics.Append(data.Networks.ElementsAs(ctx, &networks, false)...)
+	ranges := make([]validationutils.IpRange, 0)
+	for i, network := range networks {
+		networkRanges, _ := networkIpRanges(ctx, network, path.Root("network").AtListIndex(i))
+		ranges = append(ranges, networkRanges...)
+	}
+	for _, overlap := range r.plannedRanges.register(data.Name.ValueString(), ranges) {
+		res.Diagnostics.AddAttributeError(path.Root("network"), "Overlapping network pools", overlap)
+	}
+}
+
+// networkIpRanges returns the known IP pools of the network, the invalid ones are reported in the diagnostics.
+func networkIpRanges(ctx context.Context, network NetworkModel, networkPath path.Path) ([]validationutils.IpRange, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ranges := make([]validationutils.IpRange, 0)
+	if network.IpPools.IsNull() || network.IpPools.IsUnknown() {
+		return ranges, diags
+	}
+
+	var ipPools []IpPoolModel
+	diags.Append(network.IpPools.ElementsAs(ctx, &ipPools, false)...)
+	for j, ipPool := range ipPools {
+		if ipPool.Start.IsUnknown() || ipPool.End.IsUnknown() || ipPool.Start.IsNull() || ipPool.End.IsNull() {
+			continue
+		}
+		ipRange, err := validationutils.ParseIpRange(ipPool.Start.ValueString(), ipPool.End.ValueString())
+		if err != nil {
+			diags.AddAttributeError(networkPath.AtName("ip_pools").AtListIndex(j), "Invalid IP pool", err.Error())
+			continue
+		}
+		ranges = append(ranges, ipRange)
+	}
+
+	return ranges, diags
+}
+
 func (r *ResourceNetworkPool) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
 	var data ResourceNetworkPoolModel
 
 	res.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 
-	timeout, diags := data.Timeouts.Create(ctx, 30*time.Minute)
+	timeout, diags := data.Timeouts.Create(ctx, orDefault(r.defaultTimeouts.Create, 30*time.Minute))
 	res.Diagnostics.Append(diags...)
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx = api_client.WithOperationContext(ctx)
 
 	createParams := network_pools.NewCreateNetworkPoolParamsWithContext(ctx).
 		WithTimeout(constants.DefaultVcfApiCallTimeout)
@@ -213,8 +385,15 @@ func (r *ResourceNetworkPool) Read(ctx context.Context, req resource.ReadRequest
 	var data ResourceNetworkPoolModel
 	res.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	timeout, diags := data.Timeouts.Read(ctx, orDefault(r.defaultTimeouts.Read, 20*time.Minute))
+	res.Diagnostics.Append(diags...)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	params := network_pools.NewGetNetworkPoolByIDParamsWithContext(ctx).
-		WithTimeout(constants.DefaultVcfApiCallTimeout)
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Id.ValueString())
 
 	networkPoolPayload, err := r.client.NetworkPools.GetNetworkPoolByID(params)
 	if err != nil {
@@ -225,6 +404,7 @@ func (r *ResourceNetworkPool) Read(ctx context.Context, req resource.ReadRequest
 	networkPool := networkPoolPayload.Payload
 	data.Id = types.StringValue(networkPool.ID)
 	data.Name = types.StringValue(networkPool.Name)
+	res.Diagnostics.Append(res.State.Set(ctx, &data)...)
 }
 
 func (r *ResourceNetworkPool) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
@@ -235,6 +415,13 @@ func (r *ResourceNetworkPool) Delete(ctx context.Context, req resource.DeleteReq
 	var data ResourceNetworkPoolModel
 	res.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
+	timeout, diags := data.Timeouts.Delete(ctx, orDefault(r.defaultTimeouts.Delete, 30*time.Minute))
+	res.Diagnostics.Append(diags...)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ctx = api_client.WithOperationContext(ctx)
+
 	params := network_pools.NewDeleteNetworkPoolParamsWithContext(ctx).
 		WithTimeout(constants.DefaultVcfApiCallTimeout)
 	params.ID = data.Id.ValueString()
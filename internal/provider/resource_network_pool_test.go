@@ -5,77 +5,109 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"regexp"
 	"testing"
 
+	frameworkresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
-	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
-func TestAccResourceVcfNetworkPool(t *testing.T) {
+func TestAccResourceVcfNetworkPoolOverlaps(t *testing.T) {
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: muxedFactories(),
-		CheckDestroy:             testCheckVcfNetworkPoolDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccVcfNetworkPoolConfig(constants.VcfTestNetworkPoolName),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttrSet("vcf_network_pool.test_pool", "id"),
-				),
+				Config:      testAccVcfNetworkPoolOverlapsConfig("192.168.4.5", "192.168.4.50", "192.168.4.40", "192.168.4.60", "192.168.6.5", "192.168.6.50"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("overlaps the range 192.168.4.5-192.168.4.50"),
+			},
+			{
+				Config:      testAccVcfNetworkPoolOverlapsConfig("192.168.4.1", "192.168.4.50", "192.168.4.60", "192.168.4.70", "192.168.6.5", "192.168.6.50"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("includes the gateway 192.168.4.1"),
+			},
+			{
+				Config:      testAccVcfNetworkPoolOverlapsConfig("192.168.4.5", "192.168.4.50", "192.168.4.60", "192.168.4.70", "192.168.4.10", "192.168.4.20"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("of the network pool terraform-acc-pool-"),
 			},
 		},
 	})
 }
 
-func testAccVcfNetworkPoolConfig(networkPoolName string) string {
+func testAccVcfNetworkPoolOverlapsConfig(start1, end1, start2, end2, otherStart, otherEnd string) string {
 	return fmt.Sprintf(`
-	resource "vcf_network_pool" "test_pool" {
-		name    = %q
+	resource "vcf_network_pool" "first" {
+		name = "terraform-acc-pool-1"
 		network {
-			gateway   = "192.168.4.1"
-			mask      = "255.255.255.0"
-			mtu       = 8940
-			subnet    = "192.168.4.0"
-			type      = "VSAN"
-			vlan_id   = 100
+			gateway = "192.168.4.1"
+			mask    = "255.255.255.0"
+			subnet  = "192.168.4.0"
+			type    = "VSAN"
+			vlan_id = 100
 			ip_pools {
-				start = "192.168.4.5"
-				end   = "192.168.4.50"
+				start = %q
+				end   = %q
+			}
+			ip_pools {
+				start = %q
+				end   = %q
 			}
 		}
+	}
+
+	resource "vcf_network_pool" "second" {
+		name = "terraform-acc-pool-2"
 		network {
-			gateway   = "192.168.5.1"
-			mask      = "255.255.255.0"
-			mtu       = 8940
-			subnet    = "192.168.5.0"
-			type      = "vMotion"
-			vlan_id   = 100
+			gateway = "192.168.6.1"
+			mask    = "255.255.252.0"
+			subnet  = "192.168.4.0"
+			type    = "vMotion"
+			vlan_id = 101
 			ip_pools {
-				start = "192.168.5.5"
-				end   = "192.168.5.50"
+				start = %q
+				end   = %q
 			}
 		}
-	}`, networkPoolName)
-}
-
-func testCheckVcfNetworkPoolDestroy(_ *terraform.State) error {
-	apiClient := (interface{}(testAccFrameworkProvider)).(*FrameworkProvider).SddcManagerClient.ApiClient
-
-	hosts, err := apiClient.NetworkPools.GetNetworkPool(nil)
-	if err != nil {
-		log.Println("error = ", err)
-		return err
 	}
+`, start1, end1, start2, end2, otherStart, otherEnd)
+}
 
-	for _, networkPool := range hosts.Payload.Elements {
-		if networkPool.Name == constants.VcfTestNetworkPoolName {
-			return fmt.Errorf("found networkPool %q", networkPool.ID)
+func TestNetworkPoolRangesRegister(t *testing.T) {
+	ipRange := func(start, end string) validationutils.IpRange {
+		result, err := validationutils.ParseIpRange(start, end)
+		if err != nil {
+			t.Fatal(err)
 		}
+		return result
 	}
+	planned := newNetworkPoolRanges()
+
+	assert.Empty(t, planned.register("pool-1", []validationutils.IpRange{ipRange("10.0.0.10", "10.0.0.20")}))
+	assert.Empty(t, planned.register("pool-2", []validationutils.IpRange{ipRange("10.0.0.21", "10.0.0.30")}))
+	// Planning a pool again replaces its ranges
+	assert.Empty(t, planned.register("pool-1", []validationutils.IpRange{ipRange("10.0.0.5", "10.0.0.15")}))
+	assert.Equal(t, []string{"the range 10.0.0.16-10.0.0.25 overlaps the range 10.0.0.21-10.0.0.30 of the network pool pool-2"},
+		planned.register("pool-3", []validationutils.IpRange{ipRange("10.0.0.16", "10.0.0.25")}))
+
+	planned.unregister("pool-2")
+	assert.Empty(t, planned.register("pool-3", []validationutils.IpRange{ipRange("10.0.0.16", "10.0.0.25")}))
+}
+
+func TestNetworkPoolConfigureRequiresSddcManager(t *testing.T) {
+	fleetClient := api_client.NewFleetClient("admin@local", "VMware123!VMware123!", "fleet.rainpole.io", true)
 
-	return nil
+	networkPool := &ResourceNetworkPool{}
+	res := &frameworkresource.ConfigureResponse{}
+	networkPool.Configure(context.Background(), frameworkresource.ConfigureRequest{ProviderData: fleetClient}, res)
+	assert.True(t, res.Diagnostics.HasError())
+	assert.Contains(t, res.Diagnostics[0].Detail(), "vcf_network_pool requires a connection to SDDC Manager")
+	assert.Nil(t, networkPool.client)
 }
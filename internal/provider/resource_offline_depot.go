@@ -0,0 +1,206 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+func ResourceOfflineDepot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOfflineDepotCreate,
+		ReadContext:   resourceOfflineDepotRead,
+		UpdateContext: resourceOfflineDepotUpdate,
+		DeleteContext: resourceOfflineDepotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "Configures SDDC Manager to download the LCM bundles from an offline depot, for sites without internet access",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The FQDN or IP address of the offline depot",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      443,
+				Description:  "The HTTPS port of the offline depot",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The username used to authenticate against the offline depot",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The password used to authenticate against the offline depot",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The certificate of the offline depot, or of the CA which issued it, in PEM format. SDDC Manager trusts it for outbound connections",
+			},
+			"certificate_alias": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The alias under which SDDC Manager trusts the certificate",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the connection to the offline depot",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The message explaining the status of the connection to the offline depot",
+			},
+		},
+	}
+}
+
+func resourceOfflineDepotCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	if certificate := data.Get("certificate").(string); len(certificate) > 0 {
+		alias, err := lcm.TrustCertificate(ctx, certificate, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = data.Set("certificate_alias", alias)
+	}
+
+	// SDDC Manager has a single depot configuration
+	data.SetId(data.Get("hostname").(string))
+
+	return updateOfflineDepot(ctx, data, apiClient)
+}
+
+func resourceOfflineDepotRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	settings, err := lcm.GetDepotSettings(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if settings.DepotConfiguration == nil || !settings.DepotConfiguration.IsOfflineDepot {
+		data.SetId("")
+		return nil
+	}
+
+	_ = data.Set("hostname", settings.DepotConfiguration.Hostname)
+	if settings.DepotConfiguration.Port > 0 {
+		_ = data.Set("port", int(settings.DepotConfiguration.Port))
+	}
+	setOfflineAccountAttributes(data, settings)
+
+	return nil
+}
+
+func resourceOfflineDepotUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	if data.HasChange("certificate") {
+		if alias := data.Get("certificate_alias").(string); len(alias) > 0 {
+			if err := lcm.DeleteTrustedCertificate(ctx, alias, apiClient); err != nil {
+				return diag.FromErr(err)
+			}
+			_ = data.Set("certificate_alias", "")
+		}
+		if certificate := data.Get("certificate").(string); len(certificate) > 0 {
+			alias, err := lcm.TrustCertificate(ctx, certificate, apiClient)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			_ = data.Set("certificate_alias", alias)
+		}
+	}
+
+	return updateOfflineDepot(ctx, data, apiClient)
+}
+
+func resourceOfflineDepotDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	settings := &lcm.DepotSettings{
+		DepotConfiguration: &lcm.DepotConfiguration{IsOfflineDepot: false},
+	}
+	if _, err := lcm.UpdateDepotSettings(ctx, settings, apiClient); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if alias := data.Get("certificate_alias").(string); len(alias) > 0 {
+		if err := lcm.DeleteTrustedCertificate(ctx, alias, apiClient); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func updateOfflineDepot(ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient) diag.Diagnostics {
+	username := data.Get("username").(string)
+	password := data.Get("password").(string)
+	settings := &lcm.DepotSettings{
+		OfflineAccount: &models.DepotAccount{
+			Username: &username,
+			Password: &password,
+		},
+		DepotConfiguration: &lcm.DepotConfiguration{
+			IsOfflineDepot: true,
+			Hostname:       data.Get("hostname").(string),
+			Port:           int32(data.Get("port").(int)),
+		},
+	}
+
+	result, err := lcm.UpdateDepotSettings(ctx, settings, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setOfflineAccountAttributes(data, result)
+
+	status := data.Get("status").(string)
+	if len(status) > 0 && status != lcm.DepotStatusConnectionSuccessful {
+		return diag.Errorf("SDDC Manager cannot connect to the offline depot %s: %s %s", settings.DepotConfiguration.Hostname,
+			status, data.Get("message").(string))
+	}
+
+	return nil
+}
+
+func setOfflineAccountAttributes(data *schema.ResourceData, settings *lcm.DepotSettings) {
+	if settings.OfflineAccount == nil {
+		return
+	}
+	if settings.OfflineAccount.Username != nil {
+		_ = data.Set("username", *settings.OfflineAccount.Username)
+	}
+	_ = data.Set("status", settings.OfflineAccount.Status)
+	_ = data.Set("message", settings.OfflineAccount.Message)
+}
@@ -0,0 +1,43 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceOfflineDepot(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceOfflineDepotConfig(
+					os.Getenv(constants.VcfTestOfflineDepotHostname),
+					os.Getenv(constants.VcfTestOfflineDepotUsername),
+					os.Getenv(constants.VcfTestOfflineDepotPassword)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_offline_depot.depot", "status", "DEPOT_CONNECTION_SUCCESSFUL"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceOfflineDepotConfig(hostname, username, password string) string {
+	return fmt.Sprintf(`
+		resource "vcf_offline_depot" "depot" {
+			hostname = %q
+			username = %q
+			password = %q
+		}
+`, hostname, username, password)
+}
@@ -0,0 +1,110 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+func ResourceSddcManagerUpgrade() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSddcManagerUpgradeCreate,
+		ReadContext:   resourceSddcManagerUpgradeRead,
+		DeleteContext: resourceSddcManagerUpgradeDelete,
+		Description: "Upgrades the SDDC Manager appliance to a target VCF release, which must precede the upgrade of the " +
+			"domains, and waits for the upgrade to complete",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(4 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(4 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"target_version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The VCF release to upgrade SDDC Manager to",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"run_prechecks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Run the upgrade precheck before the upgrade and stop if it does not pass",
+			},
+			"scheduled_start": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The time at which SDDC Manager starts the upgrade, in RFC 3339 format. If omitted, the upgrade starts immediately",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"upgrade_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the upgrade. Empty if SDDC Manager already ran the target release",
+			},
+			"current_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The VCF release SDDC Manager is currently running",
+			},
+		},
+	}
+}
+
+func resourceSddcManagerUpgradeCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	options := lcm.SddcManagerUpgradeOptions{
+		TargetVersion:  data.Get("target_version").(string),
+		RunPrechecks:   data.Get("run_prechecks").(bool),
+		ScheduledStart: data.Get("scheduled_start").(string),
+	}
+
+	upgradeId, err := lcm.UpgradeSddcManager(ctx, options, vcfClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := utils.HashFields([]string{options.TargetVersion})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+	_ = data.Set("upgrade_id", upgradeId)
+
+	return resourceSddcManagerUpgradeRead(ctx, data, meta)
+}
+
+func resourceSddcManagerUpgradeRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	release, err := lcm.GetSystemRelease(ctx, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if release.Version != nil {
+		_ = data.Set("current_version", *release.Version)
+	}
+
+	return nil
+}
+
+func resourceSddcManagerUpgradeDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// An upgrade cannot be reverted, SDDC Manager stays at the target release
+	return nil
+}
@@ -0,0 +1,39 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceSddcManagerUpgrade(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSddcManagerUpgradeConfig(os.Getenv(constants.VcfTestUpgradeTargetVersion)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_sddc_manager_upgrade.sddc_manager", "current_version",
+						os.Getenv(constants.VcfTestUpgradeTargetVersion)),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSddcManagerUpgradeConfig(targetVersion string) string {
+	return fmt.Sprintf(`
+		resource "vcf_sddc_manager_upgrade" "sddc_manager" {
+			target_version = %q
+		}
+`, targetVersion)
+}
@@ -0,0 +1,177 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	vcfclient "github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/users"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+const serviceAccountUserType = "SERVICE"
+
+func ResourceServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceAccountCreate,
+		ReadContext:   resourceServiceAccountRead,
+		UpdateContext: resourceServiceAccountUpdate,
+		DeleteContext: resourceServiceAccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Description: "Resource used to create a service account of SDDC Manager with a role. Unlike vcf_user, the API " +
+			"key of the account is not stored in the state, it is read with the vcf_service_account_token ephemeral resource",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the service account",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"role_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the role of the service account, e.g. VIEWER. Changing it updates the role in place, the API key is kept",
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The domain of the service account",
+			},
+			"creation_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the service account was created",
+			},
+		},
+	}
+}
+
+func resourceServiceAccountCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	roleId, err := getRoleId(ctx, client, data.Get("role_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := data.Get("name").(string)
+	userType := serviceAccountUserType
+	params := users.NewAddUsersParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithUsers([]*models.User{{
+			Name: &name,
+			Type: &userType,
+			Role: &models.RoleReference{ID: &roleId},
+		}})
+
+	_, created, err := client.Users.AddUsers(params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if created == nil || created.Payload == nil || len(created.Payload.Elements) == 0 {
+		return diag.Errorf("the service account %s was not created", name)
+	}
+	data.SetId(created.Payload.Elements[0].ID)
+
+	return resourceServiceAccountRead(ctx, data, meta)
+}
+
+func resourceServiceAccountRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	serviceAccount, err := getServiceAccount(ctx, client, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if serviceAccount == nil {
+		data.SetId("")
+		return nil
+	}
+
+	_ = data.Set("name", serviceAccount.Name)
+	_ = data.Set("domain", serviceAccount.Domain)
+	_ = data.Set("creation_timestamp", serviceAccount.CreationTimestamp)
+	if serviceAccount.Role != nil && serviceAccount.Role.ID != nil {
+		roleName, err := getRoleName(ctx, client, *serviceAccount.Role.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = data.Set("role_name", roleName)
+	}
+
+	return nil
+}
+
+func resourceServiceAccountUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	if data.HasChange("role_name") {
+		roleId, err := getRoleId(ctx, client, data.Get("role_name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := updateUserRole(ctx, client, data.Id(), roleId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceServiceAccountRead(ctx, data, meta)
+}
+
+func resourceServiceAccountDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	// Removing the service account revokes its API key
+	params := users.NewRemoveUserParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Id())
+	if _, err := client.Users.RemoveUser(params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId("")
+	return nil
+}
+
+// getServiceAccount returns the service account with the ID, nil if it does not exist.
+func getServiceAccount(ctx context.Context, client *vcfclient.VcfClient, id string) (*models.User, error) {
+	return getUserOfType(ctx, client, id, serviceAccountUserType)
+}
+
+// getUserOfType returns the user with the ID, nil if it does not exist, or an error if it is not of the type.
+func getUserOfType(ctx context.Context, client *vcfclient.VcfClient, id, userType string) (*models.User, error) {
+	usersResponse, err := client.Users.GetUsers(
+		users.NewGetUsersParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range usersResponse.Payload.Elements {
+		if user == nil || user.ID != id {
+			continue
+		}
+		if user.Type == nil || *user.Type != userType {
+			return nil, fmt.Errorf("user %s is not of type %s", id, userType)
+		}
+		return user, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+)
+
+const testServiceAccountName = "svc-terraform-pipeline1"
+
+func TestAccResourceVcfServiceAccount(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		CheckDestroy:             testCheckVcfServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfServiceAccountConfig("VIEWER"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_service_account.pipeline", "id"),
+					resource.TestCheckResourceAttrSet("vcf_service_account.pipeline", "creation_timestamp"),
+					// The API key is only available through the ephemeral resource
+					resource.TestCheckNoResourceAttr("vcf_service_account.pipeline", "api_key"),
+				),
+			},
+			{
+				Config: testAccVcfServiceAccountConfig("OPERATOR"),
+				Check:  resource.TestCheckResourceAttr("vcf_service_account.pipeline", "role_name", "OPERATOR"),
+			},
+			{
+				ResourceName:      "vcf_service_account.pipeline",
+				ImportState:       true,
+				ImportStateId:     testServiceAccountName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVcfServiceAccountConfig(roleName string) string {
+	return fmt.Sprintf(`
+	resource "vcf_service_account" "pipeline" {
+		name      = %q
+		role_name = %q
+	}
+
+	ephemeral "vcf_service_account_token" "pipeline" {
+		service_account_id = vcf_service_account.pipeline.id
+	}
+`, testServiceAccountName, roleName)
+}
+
+func testCheckVcfServiceAccountDestroy(state *terraform.State) error {
+	client := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "vcf_service_account" {
+			continue
+		}
+
+		serviceAccount, err := getServiceAccount(context.Background(), client, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if serviceAccount != nil {
+			return fmt.Errorf("found service account %q", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
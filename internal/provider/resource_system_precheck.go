@@ -0,0 +1,214 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+)
+
+const (
+	precheckFailOnFailure = "FAILURE"
+	precheckFailOnWarning = "WARNING"
+	precheckFailOnNone    = "NONE"
+)
+
+func ResourceSystemPrecheck() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSystemPrecheckCreate,
+		ReadContext:   resourceSystemPrecheckRead,
+		DeleteContext: resourceSystemPrecheckDelete,
+		Description:   "Runs the SDDC Manager precheck of workload domains or clusters and waits for its results",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"resource": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The resources to check",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The type of the resource. One among: DOMAIN, CLUSTER",
+							ValidateFunc: validation.StringInSlice([]string{lcm.PrecheckResourceTypeDomain, lcm.PrecheckResourceTypeCluster}, false),
+						},
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The ID of the resource",
+							ValidateFunc: validation.NoZeroValues,
+						},
+					},
+				},
+			},
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  lcm.PrecheckModeUpgrade,
+				Description: "The mode of the precheck. One among: UPGRADE, RECOVERY. UPGRADE checks the health of the resources, " +
+					"RECOVERY runs the inventory consistency checks",
+				ValidateFunc: validation.StringInSlice([]string{lcm.PrecheckModeUpgrade, lcm.PrecheckModeRecovery}, false),
+			},
+			"bundle_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of a bundle which applicability to the resources is checked as well",
+			},
+			"fail_on": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  precheckFailOnFailure,
+				Description: "The results which fail the resource creation. One among: FAILURE, WARNING, NONE. WARNING fails " +
+					"on failures and warnings",
+				ValidateFunc: validation.StringInSlice([]string{precheckFailOnFailure, precheckFailOnWarning, precheckFailOnNone}, false),
+			},
+			"run_on": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary value that triggers a new precheck whenever it changes",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the precheck task",
+			},
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The results of the individual checks",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the check",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the check",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The result of the check, e.g. SUCCESSFUL, WARNING, FAILED",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the checked resource",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the checked resource",
+						},
+						"errors": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The errors reported by the check, with their remediation",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSystemPrecheckCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sddcClient := meta.(*api_client.SddcManagerClient)
+
+	spec := &models.PrecheckSpec{
+		Mode:     data.Get("mode").(string),
+		BundleID: data.Get("bundle_id").(string),
+	}
+	for _, resourceRaw := range data.Get("resource").([]interface{}) {
+		resource := resourceRaw.(map[string]interface{})
+		resourceId := resource["id"].(string)
+		resourceType := resource["type"].(string)
+		spec.Resources = append(spec.Resources, &models.Resource{
+			ResourceID: &resourceId,
+			Type:       &resourceType,
+		})
+	}
+
+	task, err := lcm.RunSystemPrecheck(ctx, spec, sddcClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results := lcm.GetPrecheckResults(task)
+	failOn := data.Get("fail_on").(string)
+
+	var failingStatuses, warningStatuses []string
+	switch failOn {
+	case precheckFailOnFailure:
+		failingStatuses = []string{lcm.PrecheckStatusFailed}
+		warningStatuses = []string{lcm.PrecheckStatusWarning}
+	case precheckFailOnWarning:
+		failingStatuses = []string{lcm.PrecheckStatusFailed, lcm.PrecheckStatusWarning}
+	case precheckFailOnNone:
+		warningStatuses = []string{lcm.PrecheckStatusFailed, lcm.PrecheckStatusWarning}
+	}
+
+	var diags diag.Diagnostics
+	for _, result := range lcm.FilterPrecheckResults(results, failingStatuses...) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Precheck %s reported %s", result.Name, result.Status),
+			Detail:   result.String(),
+		})
+	}
+	if diags.HasError() {
+		return diags
+	}
+	for _, result := range lcm.FilterPrecheckResults(results, warningStatuses...) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Precheck %s reported %s", result.Name, result.Status),
+			Detail:   result.String(),
+		})
+	}
+
+	data.SetId(task.ID)
+	_ = data.Set("status", task.Status)
+	_ = data.Set("results", lcm.FlattenPrecheckResults(results))
+
+	return diags
+}
+
+func resourceSystemPrecheckRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The results are those of the precheck run at creation, run_on triggers a new one
+	return nil
+}
+
+func resourceSystemPrecheckDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The precheck is a one-off operation, there is nothing to revert
+	return nil
+}
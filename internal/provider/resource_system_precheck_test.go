@@ -0,0 +1,43 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceSystemPrecheck(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSystemPrecheckConfig(os.Getenv(constants.VcfTestDomainDataSourceId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_system_precheck.management", "status"),
+					resource.TestCheckResourceAttrSet("vcf_system_precheck.management", "results.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceSystemPrecheckConfig(domainId string) string {
+	return fmt.Sprintf(`
+		resource "vcf_system_precheck" "management" {
+			resource {
+				type = "DOMAIN"
+				id   = %q
+			}
+			fail_on = "NONE"
+		}
+`, domainId)
+}
@@ -0,0 +1,159 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/vcenter"
+)
+
+func ResourceTag() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTagCreate,
+		ReadContext:   resourceTagRead,
+		UpdateContext: resourceTagUpdate,
+		DeleteContext: resourceTagDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDomainScopedImport,
+		},
+		Description: "Resource used to manage a vSphere tag in the vCenter Server of a domain, e.g. to assign it to the " +
+			"domain, its clusters or its hosts",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the domain of the vCenter Server the tag is created in",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"category_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the category of the tag",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the tag, unique in its category",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the tag",
+			},
+		},
+	}
+}
+
+func resourceTagCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taggingClient, err := vcenter.NewTaggingClient(ctx, meta.(*api_client.SddcManagerClient).ApiClient,
+		data.Get("domain_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer taggingClient.Logout(ctx)
+
+	id, err := taggingClient.CreateTag(ctx, vcenter.Tag{
+		CategoryID:  data.Get("category_id").(string),
+		Name:        data.Get("name").(string),
+		Description: data.Get("description").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
+
+	return resourceTagRead(ctx, data, meta)
+}
+
+func resourceTagRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taggingClient, err := vcenter.NewTaggingClient(ctx, meta.(*api_client.SddcManagerClient).ApiClient,
+		data.Get("domain_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer taggingClient.Logout(ctx)
+
+	tag, err := taggingClient.GetTag(ctx, data.Id())
+	if err != nil {
+		if errors.Is(err, vcenter.ErrNotFound) {
+			log.Printf("Tag %s not found, removing it from the state", data.Id())
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = data.Set("category_id", tag.CategoryID)
+	_ = data.Set("name", tag.Name)
+	_ = data.Set("description", tag.Description)
+
+	return nil
+}
+
+func resourceTagUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taggingClient, err := vcenter.NewTaggingClient(ctx, meta.(*api_client.SddcManagerClient).ApiClient,
+		data.Get("domain_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer taggingClient.Logout(ctx)
+
+	if err := taggingClient.UpdateTag(ctx, data.Id(), data.Get("name").(string),
+		data.Get("description").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTagRead(ctx, data, meta)
+}
+
+func resourceTagDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taggingClient, err := vcenter.NewTaggingClient(ctx, meta.(*api_client.SddcManagerClient).ApiClient,
+		data.Get("domain_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer taggingClient.Logout(ctx)
+
+	if err := taggingClient.DeleteTag(ctx, data.Id()); err != nil && !errors.Is(err, vcenter.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	data.SetId("")
+	return nil
+}
+
+// resourceDomainScopedImport imports a vCenter Server object by the ID of its domain and its own ID,
+// e.g. <domain ID>:<tag ID>, the domain is needed to connect to the vCenter Server.
+func resourceDomainScopedImport(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	domainId, id, found := strings.Cut(data.Id(), ":")
+	if !found || len(domainId) == 0 || len(id) == 0 {
+		return nil, fmt.Errorf("invalid ID %q, expected <domain ID>:<ID>", data.Id())
+	}
+	_ = data.Set("domain_id", domainId)
+	data.SetId(id)
+
+	return []*schema.ResourceData{data}, nil
+}
@@ -0,0 +1,312 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/clusters"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+const (
+	tagResourceTypeDomain  = "DOMAIN"
+	tagResourceTypeCluster = "CLUSTER"
+	tagResourceTypeHost    = "HOST"
+)
+
+func ResourceTagAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTagAssignmentCreate,
+		ReadContext:   resourceTagAssignmentRead,
+		UpdateContext: resourceTagAssignmentUpdate,
+		DeleteContext: resourceTagAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTagAssignmentImport,
+		},
+		Description: "Resource used to assign vSphere tags to a domain, a cluster or a host through SDDC Manager. " +
+			"The resource manages all the tags of the entity",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of the entity to tag. One among DOMAIN, CLUSTER, HOST",
+				ValidateFunc: validation.StringInSlice([]string{tagResourceTypeDomain, tagResourceTypeCluster, tagResourceTypeHost}, false),
+			},
+			"resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the entity to tag",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"tag_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The IDs of the tags assigned to the entity. The tags assigned outside of Terraform are removed",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceTagAssignmentCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	resourceType := data.Get("resource_type").(string)
+	resourceId := data.Get("resource_id").(string)
+	data.SetId(resourceType + ":" + resourceId)
+
+	assigned, err := getAssignedTagIds(ctx, apiClient, resourceType, resourceId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	added, removed := diffTagIds(assigned, utils.ToStringSlice(data.Get("tag_ids").(*schema.Set).List()))
+	if err := updateAssignedTags(ctx, apiClient, resourceType, resourceId, added, removed); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTagAssignmentRead(ctx, data, meta)
+}
+
+func resourceTagAssignmentRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	assigned, err := getAssignedTagIds(ctx, apiClient, data.Get("resource_type").(string), data.Get("resource_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("tag_ids", assigned)
+
+	return nil
+}
+
+func resourceTagAssignmentUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	oldTagIds, newTagIds := data.GetChange("tag_ids")
+	added, removed := diffTagIds(utils.ToStringSlice(oldTagIds.(*schema.Set).List()),
+		utils.ToStringSlice(newTagIds.(*schema.Set).List()))
+	if err := updateAssignedTags(ctx, apiClient, data.Get("resource_type").(string), data.Get("resource_id").(string),
+		added, removed); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTagAssignmentRead(ctx, data, meta)
+}
+
+func resourceTagAssignmentDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	resourceType := data.Get("resource_type").(string)
+	resourceId := data.Get("resource_id").(string)
+	assigned, err := getAssignedTagIds(ctx, apiClient, resourceType, resourceId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateAssignedTags(ctx, apiClient, resourceType, resourceId, nil, assigned); err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId("")
+	return nil
+}
+
+// resourceTagAssignmentImport imports the tags of an entity by its type and ID, e.g. CLUSTER:<cluster ID>.
+func resourceTagAssignmentImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceType, resourceId, found := strings.Cut(data.Id(), ":")
+	if !found || len(resourceId) == 0 {
+		return nil, fmt.Errorf("invalid ID %q, expected <resource type>:<resource ID>", data.Id())
+	}
+	resourceType = strings.ToUpper(resourceType)
+	_ = data.Set("resource_type", resourceType)
+	_ = data.Set("resource_id", resourceId)
+	data.SetId(resourceType + ":" + resourceId)
+
+	return []*schema.ResourceData{data}, nil
+}
+
+// diffTagIds returns the tag IDs to assign and to remove to go from the current tag IDs to the desired ones.
+func diffTagIds(current, desired []string) (added, removed []string) {
+	for _, tagId := range desired {
+		if !slices.Contains(current, tagId) {
+			added = append(added, tagId)
+		}
+	}
+	for _, tagId := range current {
+		if !slices.Contains(desired, tagId) {
+			removed = append(removed, tagId)
+		}
+	}
+
+	return added, removed
+}
+
+func getAssignedTagIds(ctx context.Context, apiClient *client.VcfClient, resourceType, resourceId string) ([]string, error) {
+	var page *models.PageOfTag
+	switch resourceType {
+	case tagResourceTypeDomain:
+		params := domains.NewGetTagsAssignedToDomainParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+		params.ID = resourceId
+		result, err := apiClient.Domains.GetTagsAssignedToDomain(params)
+		if err != nil {
+			return nil, err
+		}
+		page = result.Payload
+	case tagResourceTypeCluster:
+		params := clusters.NewGetTagsAssignedToClusterParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+		params.ID = resourceId
+		result, err := apiClient.Clusters.GetTagsAssignedToCluster(params)
+		if err != nil {
+			return nil, err
+		}
+		page = result.Payload
+	case tagResourceTypeHost:
+		params := hosts.NewGetTagsAssignedToHostParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+		params.ID = resourceId
+		result, err := apiClient.Hosts.GetTagsAssignedToHost(params)
+		if err != nil {
+			return nil, err
+		}
+		page = result.Payload
+	default:
+		return nil, fmt.Errorf("unsupported resource type %s", resourceType)
+	}
+
+	tagIds := make([]string, 0)
+	if page != nil {
+		for _, tag := range page.Elements {
+			if tag != nil && len(tag.ID) > 0 {
+				tagIds = append(tagIds, tag.ID)
+			}
+		}
+	}
+
+	return tagIds, nil
+}
+
+// updateAssignedTags assigns the added tags to the entity and removes the removed ones from it.
+func updateAssignedTags(ctx context.Context, apiClient *client.VcfClient, resourceType, resourceId string,
+	added, removed []string) error {
+	if len(added) > 0 {
+		result, err := assignOrRemoveTags(ctx, apiClient, resourceType, resourceId, added, true)
+		if err != nil {
+			return err
+		}
+		if err := checkTagAssignmentResult(result); err != nil {
+			return fmt.Errorf("cannot assign tags to %s %s: %w", resourceType, resourceId, err)
+		}
+	}
+	if len(removed) > 0 {
+		result, err := assignOrRemoveTags(ctx, apiClient, resourceType, resourceId, removed, false)
+		if err != nil {
+			return err
+		}
+		if err := checkTagAssignmentResult(result); err != nil {
+			return fmt.Errorf("cannot remove tags from %s %s: %w", resourceType, resourceId, err)
+		}
+	}
+
+	return nil
+}
+
+func assignOrRemoveTags(ctx context.Context, apiClient *client.VcfClient, resourceType, resourceId string,
+	tagIds []string, assign bool) (*models.TagAssignmentResult, error) {
+	spec := &models.TagsSpec{TagIds: tagIds}
+	switch resourceType {
+	case tagResourceTypeDomain:
+		if assign {
+			params := domains.NewAssignTagsToDomainParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+			params.ID = resourceId
+			params.TagsSpec = spec
+			result, err := apiClient.Domains.AssignTagsToDomain(params)
+			if err != nil {
+				return nil, err
+			}
+			return result.Payload, nil
+		}
+		params := domains.NewRemoveTagsFromDomainParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+		params.ID = resourceId
+		params.TagsSpec = spec
+		result, err := apiClient.Domains.RemoveTagsFromDomain(params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Payload, nil
+	case tagResourceTypeCluster:
+		if assign {
+			params := clusters.NewAssignTagsToClusterParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+			params.ID = resourceId
+			params.TagsSpec = spec
+			result, err := apiClient.Clusters.AssignTagsToCluster(params)
+			if err != nil {
+				return nil, err
+			}
+			return result.Payload, nil
+		}
+		params := clusters.NewRemoveTagsFromClusterParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+		params.ID = resourceId
+		params.TagsSpec = spec
+		result, err := apiClient.Clusters.RemoveTagsFromCluster(params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Payload, nil
+	case tagResourceTypeHost:
+		if assign {
+			params := hosts.NewAssignTagsToHostParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+			params.ID = resourceId
+			params.TagsSpec = spec
+			result, err := apiClient.Hosts.AssignTagsToHost(params)
+			if err != nil {
+				return nil, err
+			}
+			return result.Payload, nil
+		}
+		params := hosts.NewRemoveTagsFromHostParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+		params.ID = resourceId
+		params.TagsSpec = spec
+		result, err := apiClient.Hosts.RemoveTagsFromHost(params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Payload, nil
+	default:
+		return nil, fmt.Errorf("unsupported resource type %s", resourceType)
+	}
+}
+
+func checkTagAssignmentResult(result *models.TagAssignmentResult) error {
+	if result == nil || result.Success {
+		return nil
+	}
+	if len(result.DefaultErrorMessages) == 0 {
+		return errors.New("the operation failed")
+	}
+	return errors.New(strings.Join(result.DefaultErrorMessages, ", "))
+}
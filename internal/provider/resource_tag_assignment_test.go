@@ -0,0 +1,106 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceVcfTagAssignment(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		CheckDestroy:             testCheckVcfTagAssignmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfTagAssignmentConfig(`[vcf_tag.gold.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_tag_assignment.cluster", "id",
+						"CLUSTER:"+os.Getenv(constants.VcfTestClusterId)),
+					resource.TestCheckResourceAttr("vcf_tag_assignment.cluster", "tag_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccVcfTagAssignmentConfig(`[vcf_tag.gold.id, vcf_tag.silver.id]`),
+				Check:  resource.TestCheckResourceAttr("vcf_tag_assignment.cluster", "tag_ids.#", "2"),
+			},
+			{
+				ResourceName:      "vcf_tag_assignment.cluster",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVcfTagAssignmentConfig(tagIds string) string {
+	return fmt.Sprintf(`
+	resource "vcf_tag_category" "tier" {
+		domain_id        = %q
+		name             = "terraform-acc-assignment-tier"
+		cardinality      = "MULTIPLE"
+		associable_types = ["ClusterComputeResource"]
+	}
+
+	resource "vcf_tag" "gold" {
+		domain_id   = vcf_tag_category.tier.domain_id
+		category_id = vcf_tag_category.tier.id
+		name        = "gold"
+	}
+
+	resource "vcf_tag" "silver" {
+		domain_id   = vcf_tag_category.tier.domain_id
+		category_id = vcf_tag_category.tier.id
+		name        = "silver"
+	}
+
+	resource "vcf_tag_assignment" "cluster" {
+		resource_type = "CLUSTER"
+		resource_id   = %q
+		tag_ids       = %s
+	}
+`, os.Getenv(constants.VcfTestDomainDataSourceId), os.Getenv(constants.VcfTestClusterId), tagIds)
+}
+
+func testCheckVcfTagAssignmentDestroy(state *terraform.State) error {
+	client := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "vcf_tag_assignment" {
+			continue
+		}
+
+		tagIds, err := getAssignedTagIds(context.Background(), client, rs.Primary.Attributes["resource_type"],
+			rs.Primary.Attributes["resource_id"])
+		if err != nil {
+			return err
+		}
+		if len(tagIds) > 0 {
+			return fmt.Errorf("found tags %v assigned to %q", tagIds, rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func TestDiffTagIds(t *testing.T) {
+	added, removed := diffTagIds([]string{"a", "b"}, []string{"b", "c"})
+	assert.Equal(t, []string{"c"}, added)
+	assert.Equal(t, []string{"a"}, removed)
+
+	added, removed = diffTagIds(nil, []string{"a"})
+	assert.Equal(t, []string{"a"}, added)
+	assert.Empty(t, removed)
+}
@@ -0,0 +1,155 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/terraform-provider-vcf/internal/vcenter"
+)
+
+func ResourceTagCategory() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTagCategoryCreate,
+		ReadContext:   resourceTagCategoryRead,
+		UpdateContext: resourceTagCategoryUpdate,
+		DeleteContext: resourceTagCategoryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDomainScopedImport,
+		},
+		Description: "Resource used to manage a vSphere tag category in the vCenter Server of a domain, with the SSO " +
+			"credentials SDDC Manager manages for it",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the domain of the vCenter Server the category is created in",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the category",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the category",
+			},
+			"cardinality": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "How many tags of the category can be assigned to an object. One among SINGLE, MULTIPLE",
+				ValidateFunc: validation.StringInSlice([]string{"SINGLE", "MULTIPLE"}, false),
+			},
+			"associable_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The types of the objects the tags of the category can be assigned to, e.g. ClusterComputeResource or HostSystem. All types if empty",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceTagCategoryCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taggingClient, err := vcenter.NewTaggingClient(ctx, meta.(*api_client.SddcManagerClient).ApiClient,
+		data.Get("domain_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer taggingClient.Logout(ctx)
+
+	id, err := taggingClient.CreateCategory(ctx, vcenter.TagCategory{
+		Name:            data.Get("name").(string),
+		Description:     data.Get("description").(string),
+		Cardinality:     data.Get("cardinality").(string),
+		AssociableTypes: utils.ToStringSlice(data.Get("associable_types").(*schema.Set).List()),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(id)
+
+	return resourceTagCategoryRead(ctx, data, meta)
+}
+
+func resourceTagCategoryRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taggingClient, err := vcenter.NewTaggingClient(ctx, meta.(*api_client.SddcManagerClient).ApiClient,
+		data.Get("domain_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer taggingClient.Logout(ctx)
+
+	category, err := taggingClient.GetCategory(ctx, data.Id())
+	if err != nil {
+		if errors.Is(err, vcenter.ErrNotFound) {
+			log.Printf("Tag category %s not found, removing it from the state", data.Id())
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = data.Set("name", category.Name)
+	_ = data.Set("description", category.Description)
+	_ = data.Set("cardinality", category.Cardinality)
+	_ = data.Set("associable_types", category.AssociableTypes)
+
+	return nil
+}
+
+func resourceTagCategoryUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taggingClient, err := vcenter.NewTaggingClient(ctx, meta.(*api_client.SddcManagerClient).ApiClient,
+		data.Get("domain_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer taggingClient.Logout(ctx)
+
+	if err := taggingClient.UpdateCategory(ctx, data.Id(), data.Get("name").(string),
+		data.Get("description").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceTagCategoryRead(ctx, data, meta)
+}
+
+func resourceTagCategoryDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	taggingClient, err := vcenter.NewTaggingClient(ctx, meta.(*api_client.SddcManagerClient).ApiClient,
+		data.Get("domain_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer taggingClient.Logout(ctx)
+
+	// vCenter Server deletes the tags of the category with it
+	if err := taggingClient.DeleteCategory(ctx, data.Id()); err != nil && !errors.Is(err, vcenter.ErrNotFound) {
+		return diag.FromErr(err)
+	}
+
+	data.SetId("")
+	return nil
+}
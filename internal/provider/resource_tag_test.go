@@ -0,0 +1,135 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/vcenter"
+)
+
+func TestAccResourceVcfTag(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		CheckDestroy:             testCheckVcfTagDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfTagConfig("Created by the acceptance tests"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_tag_category.tier", "id"),
+					resource.TestCheckResourceAttr("vcf_tag_category.tier", "associable_types.#", "2"),
+					resource.TestCheckResourceAttrPair("vcf_tag.gold", "category_id", "vcf_tag_category.tier", "id"),
+				),
+			},
+			{
+				Config: testAccVcfTagConfig("Updated by the acceptance tests"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_tag_category.tier", "description", "Updated by the acceptance tests"),
+					resource.TestCheckResourceAttr("vcf_tag.gold", "description", "Updated by the acceptance tests"),
+				),
+			},
+			{
+				ResourceName:      "vcf_tag_category.tier",
+				ImportState:       true,
+				ImportStateIdFunc: testAccDomainScopedImportId("vcf_tag_category.tier"),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "vcf_tag.gold",
+				ImportState:       true,
+				ImportStateIdFunc: testAccDomainScopedImportId("vcf_tag.gold"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceDomainScopedImport(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, ResourceTag().Schema, map[string]interface{}{})
+	data.SetId("domain-1:urn:vmomi:InventoryServiceTag:tag-1:GLOBAL")
+	imported, err := resourceDomainScopedImport(context.Background(), data, nil)
+	assert.NoError(t, err)
+	assert.Len(t, imported, 1)
+	assert.Equal(t, "domain-1", imported[0].Get("domain_id"))
+	assert.Equal(t, "urn:vmomi:InventoryServiceTag:tag-1:GLOBAL", imported[0].Id())
+
+	for _, id := range []string{"tag-1", ":tag-1", "domain-1:"} {
+		data.SetId(id)
+		_, err = resourceDomainScopedImport(context.Background(), data, nil)
+		assert.Error(t, err, id)
+	}
+}
+
+// testAccDomainScopedImportId returns the <domain ID>:<ID> import ID of a tag or a tag category.
+func testAccDomainScopedImportId(resourceName string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("%s not found in the state", resourceName)
+		}
+		return rs.Primary.Attributes["domain_id"] + ":" + rs.Primary.ID, nil
+	}
+}
+
+func testAccVcfTagConfig(description string) string {
+	return fmt.Sprintf(`
+	resource "vcf_tag_category" "tier" {
+		domain_id        = %q
+		name             = "terraform-acc-tier"
+		description      = %q
+		cardinality      = "SINGLE"
+		associable_types = ["ClusterComputeResource", "HostSystem"]
+	}
+
+	resource "vcf_tag" "gold" {
+		domain_id   = vcf_tag_category.tier.domain_id
+		category_id = vcf_tag_category.tier.id
+		name        = "gold"
+		description = %q
+	}
+`, os.Getenv(constants.VcfTestDomainDataSourceId), description, description)
+}
+
+func testCheckVcfTagDestroy(state *terraform.State) error {
+	client := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+	ctx := context.Background()
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "vcf_tag" && rs.Type != "vcf_tag_category" {
+			continue
+		}
+
+		taggingClient, err := vcenter.NewTaggingClient(ctx, client, rs.Primary.Attributes["domain_id"])
+		if err != nil {
+			return err
+		}
+		if rs.Type == "vcf_tag" {
+			_, err = taggingClient.GetTag(ctx, rs.Primary.ID)
+		} else {
+			_, err = taggingClient.GetCategory(ctx, rs.Primary.ID)
+		}
+		taggingClient.Logout(ctx)
+		if err == nil {
+			return fmt.Errorf("found %s %q", rs.Type, rs.Primary.ID)
+		}
+		if !errors.Is(err, vcenter.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
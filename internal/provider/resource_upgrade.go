@@ -0,0 +1,380 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/lcm"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+func ResourceUpgrade() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUpgradeCreate,
+		ReadContext:   resourceUpgradeRead,
+		UpdateContext: resourceUpgradeUpdate,
+		DeleteContext: resourceUpgradeDelete,
+		CustomizeDiff: resourceUpgradeCustomizeDiff,
+		Description: "Upgrades a workload domain to a target VCF release, through the intermediate releases if needed, " +
+			"and waits for the upgrade to complete",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(12 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(12 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the workload domain to upgrade",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"target_version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The VCF release to upgrade the domain to",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"component_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Description: "Upgrade only the components of the given types rather than the whole domain. One or more among " +
+					"NSX_T_MANAGER, VCENTER, HOST",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(lcm.UpgradeComponentTypes(), false),
+				},
+			},
+			"precheck_on_plan": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Run the upgrade precheck of the domain when planning the upgrade and fail the plan if a check " +
+					"fails. The precheck does not change the environment but it can take several minutes",
+			},
+			"run_prechecks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Run the upgrade precheck before every upgrade and stop if it does not pass",
+			},
+			"scheduled_start": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "The time at which SDDC Manager starts the upgrades, in RFC 3339 format. If omitted, the upgrades start immediately",
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"maintenance_window"},
+			},
+			"maintenance_window": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ForceNew:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"scheduled_start"},
+				Description: "The time range in which SDDC Manager may start the upgrades. An upgrade requested before the window " +
+					"is scheduled at its start, no upgrade is started once the window has ended",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The start of the window, in RFC 3339 format",
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+						"end": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							Description:  "The end of the window, in RFC 3339 format",
+							ValidateFunc: validation.IsRFC3339Time,
+						},
+					},
+				},
+			},
+			"wait_for_completion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+				Description: "Wait for the upgrades to complete. If false, only the next upgrade is started or scheduled and " +
+					"the resource is created immediately",
+			},
+			"vcenter_temporary_network": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "The temporary network used by the vCenter upgrades which migrate to a new appliance",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "IPv4 address of the temporary vCenter appliance",
+							ValidateFunc: validationutils.ValidateIPv4AddressSchema,
+						},
+						"subnet_mask": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "IPv4 subnet mask of the temporary network",
+							ValidateFunc: validationutils.ValidateIPv4AddressSchema,
+						},
+						"gateway": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "IPv4 gateway of the temporary network",
+							ValidateFunc: validationutils.ValidateIPv4AddressSchema,
+						},
+					},
+				},
+			},
+			"upgrade_path": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The releases the domain was upgraded through, in order, ending with the target release",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"upgrade_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The IDs of the upgrades performed, in the order they were applied",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"current_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current VCF release of the domain",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the last upgrade performed",
+			},
+		},
+	}
+}
+
+func resourceUpgradeCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	options := lcm.UpgradeOptions{
+		DomainId:          data.Get("domain_id").(string),
+		TargetVersion:     data.Get("target_version").(string),
+		RunPrechecks:      data.Get("run_prechecks").(bool),
+		ScheduledStart:    data.Get("scheduled_start").(string),
+		WaitForCompletion: data.Get("wait_for_completion").(bool),
+		ComponentTypes:    utils.ToStringSlice(data.Get("component_types").([]interface{})),
+	}
+	if windows := data.Get("maintenance_window").([]interface{}); len(windows) > 0 && windows[0] != nil {
+		window, err := toMaintenanceWindow(windows[0].(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		options.MaintenanceWindow = window
+	}
+	if networks := data.Get("vcenter_temporary_network").([]interface{}); len(networks) > 0 && networks[0] != nil {
+		network := networks[0].(map[string]interface{})
+		ipAddress := network["ip_address"].(string)
+		subnetMask := network["subnet_mask"].(string)
+		gateway := network["gateway"].(string)
+		options.VcenterTemporaryNetwork = &models.TemporaryNetwork{
+			IPAddress:  &ipAddress,
+			SubnetMask: &subnetMask,
+			Gateway:    &gateway,
+		}
+	}
+
+	path := []string{options.TargetVersion}
+	if len(options.ComponentTypes) == 0 {
+		var err error
+		path, err = lcm.PlanUpgradePath(ctx, options.DomainId, options.TargetVersion, vcfClient.ApiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	options.Path = path
+
+	// The components already upgraded are no longer upgradable, so applying again after a failure
+	// continues with the remaining ones
+	upgradeIds, err := lcm.UpgradeDomain(ctx, options, vcfClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := utils.HashFields([]string{options.DomainId, options.TargetVersion, strings.Join(options.ComponentTypes, ",")})
+	if err != nil {
+		return diag.Errorf("error during id generation %s", err)
+	}
+	data.SetId(id)
+	_ = data.Set("upgrade_path", path)
+	_ = data.Set("upgrade_ids", upgradeIds)
+
+	return resourceUpgradeRead(ctx, data, meta)
+}
+
+func resourceUpgradeRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	release, err := lcm.GetDomainRelease(ctx, data.Get("domain_id").(string), apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if release.Version != nil {
+		_ = data.Set("current_version", *release.Version)
+	}
+
+	upgradeIds := utils.ToStringSlice(data.Get("upgrade_ids").([]interface{}))
+	if len(upgradeIds) == 0 {
+		return nil
+	}
+	lastUpgradeId := upgradeIds[len(upgradeIds)-1]
+	upgrade, err := lcm.GetUpgrade(ctx, lastUpgradeId, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	status := ""
+	if upgrade.Status != nil {
+		status = *upgrade.Status
+	}
+	_ = data.Set("status", status)
+
+	if data.Get("wait_for_completion").(bool) || status != lcm.UpgradeStatusCompletedWithSuccess {
+		return nil
+	}
+	remaining, err := hasRemainingUpgrades(ctx, data, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if remaining {
+		// The upgrade started without waiting has completed, the next apply continues with the remaining upgrades
+		log.Printf("[INFO] Upgrade %s completed, removing it from the state to continue the upgrade of domain %s to %s",
+			lastUpgradeId, data.Get("domain_id").(string), data.Get("target_version").(string))
+		data.SetId("")
+	}
+
+	return nil
+}
+
+// hasRemainingUpgrades tells whether upgrades remain once the last upgrade started without waiting has completed.
+// The release of the domain does not change when only some of its components are upgraded, so the upgrades of a
+// scoped resource remain as long as the domain has upgradables of its component types.
+func hasRemainingUpgrades(ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient) (bool, error) {
+	domainId := data.Get("domain_id").(string)
+	targetVersion := data.Get("target_version").(string)
+	componentTypes := utils.ToStringSlice(data.Get("component_types").([]interface{}))
+	if len(componentTypes) == 0 {
+		return data.Get("current_version").(string) != targetVersion, nil
+	}
+
+	return lcm.HasRemainingUpgrades(ctx, domainId, targetVersion, componentTypes, apiClient)
+}
+
+func resourceUpgradeUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only precheck_on_plan can change, it is used only when planning
+	return resourceUpgradeRead(ctx, data, meta)
+}
+
+// resourceUpgradeCustomizeDiff runs the upgrade precheck when an upgrade is planned and precheck_on_plan is set,
+// so that a plan reviewed before it is applied reflects whether the domain can be upgraded. The plans of an upgrade
+// already in the state, e.g. when only precheck_on_plan changes, do not run the precheck.
+func resourceUpgradeCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.Get("precheck_on_plan").(bool) || meta == nil {
+		return nil
+	}
+	if !(diff.Id() == "" || diff.HasChanges("domain_id", "target_version", "component_types")) {
+		return nil
+	}
+	if !diff.NewValueKnown("domain_id") || !diff.NewValueKnown("target_version") {
+		log.Printf("[DEBUG] Skipping the upgrade precheck, the domain or the target release is not known yet")
+		return nil
+	}
+
+	domainId := diff.Get("domain_id").(string)
+	targetVersion := diff.Get("target_version").(string)
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	key := upgradePrecheckKey{client: vcfClient, domainId: domainId, targetVersion: targetVersion}
+	if outcome, ok := upgradePrechecks.Load(key); ok {
+		// The diff of a creation or a replacement is customized twice
+		err, _ := outcome.(error)
+		return err
+	}
+	err := precheckUpgradePlan(ctx, domainId, targetVersion, vcfClient)
+	upgradePrechecks.Store(key, err)
+
+	return err
+}
+
+// upgradePrecheckKey identifies the upgrade precheck of a plan, the provider instance of the client lives for a
+// single plan.
+type upgradePrecheckKey struct {
+	client        *api_client.SddcManagerClient
+	domainId      string
+	targetVersion string
+}
+
+// upgradePrechecks are the outcomes of the upgrade prechecks run during the plans, the errors are nil when the
+// precheck passed.
+var upgradePrechecks sync.Map
+
+func precheckUpgradePlan(ctx context.Context, domainId, targetVersion string, vcfClient *api_client.SddcManagerClient) error {
+	failures, err := lcm.PrecheckDomainUpgrade(ctx, domainId, targetVersion, vcfClient)
+	if err != nil {
+		return fmt.Errorf("cannot run the upgrade precheck of domain %s: %w", domainId, err)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		descriptions = append(descriptions, failure.String())
+	}
+
+	return fmt.Errorf("the upgrade precheck of domain %s reported %d critical issue(s): %s", domainId, len(failures),
+		strings.Join(descriptions, "; "))
+}
+
+func toMaintenanceWindow(window map[string]interface{}) (*lcm.MaintenanceWindow, error) {
+	start, err := time.Parse(time.RFC3339, window["start"].(string))
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse(time.RFC3339, window["end"].(string))
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("the end of the maintenance window %s must be after its start %s",
+			window["end"].(string), window["start"].(string))
+	}
+
+	return &lcm.MaintenanceWindow{Start: start, End: end}, nil
+}
+
+func resourceUpgradeDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// An upgrade cannot be reverted, the domain stays at the target release
+	return nil
+}
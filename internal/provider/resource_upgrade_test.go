@@ -0,0 +1,43 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+func TestAccResourceUpgrade(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUpgradeConfig(os.Getenv(constants.VcfTestDomainDataSourceId),
+					os.Getenv(constants.VcfTestUpgradeTargetVersion)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_upgrade.domain", "current_version",
+						os.Getenv(constants.VcfTestUpgradeTargetVersion)),
+					resource.TestCheckResourceAttrSet("vcf_upgrade.domain", "upgrade_ids.#"),
+					resource.TestCheckResourceAttrSet("vcf_upgrade.domain", "upgrade_path.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceUpgradeConfig(domainId, targetVersion string) string {
+	return fmt.Sprintf(`
+		resource "vcf_upgrade" "domain" {
+			domain_id      = %q
+			target_version = %q
+		}
+`, domainId, targetVersion)
+}
@@ -6,13 +6,19 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	vcfclient "github.com/vmware/vcf-sdk-go/client"
 	"github.com/vmware/vcf-sdk-go/client/users"
 	"github.com/vmware/vcf-sdk-go/models"
 
@@ -20,16 +26,23 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 )
 
+// noAccessRoleName is the role of SDDC Manager without any privilege.
+const noAccessRoleName = "NO ACCESS"
+
 func ResourceUser() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceUserCreate,
 		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceUserImport,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -58,8 +71,14 @@ func ResourceUser() *schema.Resource {
 			"role_name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
-				Description: "The name of the role to assign to the user",
+				Description: "The name of the role to assign to the user. Changing it updates the role in place",
+			},
+			"deactivate_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether destroying the resource assigns the NO ACCESS role to the user instead of removing it, " +
+					"which keeps the user and its audit history in SDDC Manager",
 			},
 			"api_key": {
 				Type:        schema.TypeString,
@@ -97,27 +116,24 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	if roleName, ok := d.GetOk("role_name"); ok {
-		roleNameVal := roleName.(string)
-
-		roleResult, err := client.Users.GetRoles(nil)
+		roleId, err := getRoleId(ctx, client, roleName.(string))
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		user.Role = &models.RoleReference{ID: &roleId}
+	}
+	params.Users = []*models.User{&user}
 
-		roleFound := false
-		for _, role := range roleResult.Payload.Elements {
-			if *role.Name == roleNameVal {
-				user.Role = &models.RoleReference{ID: role.ID}
-				roleFound = true
-				break
-			}
-		}
-
-		if !roleFound {
-			return diag.Errorf("role not found: %s", roleNameVal)
+	// A user deactivated on destroy is still known to SDDC Manager, it is reactivated with the role
+	if deactivatedUser, err := findDeactivatedUser(ctx, client, user); err != nil {
+		return diag.FromErr(err)
+	} else if deactivatedUser != nil {
+		if err := updateUserRole(ctx, client, deactivatedUser.ID, *user.Role.ID); err != nil {
+			return diag.FromErr(err)
 		}
+		d.SetId(deactivatedUser.ID)
+		return resourceUserRead(ctx, d, meta)
 	}
-	params.Users = []*models.User{&user}
 
 	_, created, err := client.Users.AddUsers(params)
 	if err != nil {
@@ -143,6 +159,16 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	// Check if the resource with the known id exists
 	for _, user := range ok.Payload.Elements {
 		if user.ID == id {
+			_ = d.Set("name", user.Name)
+			_ = d.Set("domain", user.Domain)
+			_ = d.Set("type", user.Type)
+			if user.Role != nil && user.Role.ID != nil {
+				roleName, err := getRoleName(ctx, client, *user.Role.ID)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				_ = d.Set("role_name", roleName)
+			}
 			_ = d.Set("api_key", user.APIKey)
 			_ = d.Set("creation_timestamp", user.CreationTimestamp)
 			return nil
@@ -152,9 +178,150 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	return nil
 }
 
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	if d.HasChange("role_name") {
+		roleId, err := getRoleId(ctx, client, d.Get("role_name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// Replacing the user would revoke the API key of a service account
+		if err := updateUserRole(ctx, client, d.Id(), roleId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceUserRead(ctx, d, meta)
+}
+
+// resourceUserImport imports a user or a service account by ID or by name.
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*api_client.SddcManagerClient).ApiClient
+
+	ok, err := client.Users.GetUsers(
+		users.NewGetUsersParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range ok.Payload.Elements {
+		if user.ID == d.Id() || (user.Name != nil && *user.Name == d.Id()) {
+			d.SetId(user.ID)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user not found: %s", d.Id())
+}
+
+// findDeactivatedUser returns the user with the same name, domain and type which has the NO ACCESS role, nil if none.
+func findDeactivatedUser(ctx context.Context, client *vcfclient.VcfClient, user models.User) (*models.User, error) {
+	if user.Name == nil || user.Role == nil {
+		return nil, nil
+	}
+	ok, err := client.Users.GetUsers(
+		users.NewGetUsersParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return nil, err
+	}
+	for _, existingUser := range ok.Payload.Elements {
+		if existingUser == nil || existingUser.Name == nil || *existingUser.Name != *user.Name ||
+			!strings.EqualFold(existingUser.Domain, user.Domain) || existingUser.Role == nil || existingUser.Role.ID == nil {
+			continue
+		}
+		if existingUser.Type != nil && user.Type != nil && !strings.EqualFold(*existingUser.Type, *user.Type) {
+			continue
+		}
+		roleName, err := getRoleName(ctx, client, *existingUser.Role.ID)
+		if err != nil {
+			return nil, err
+		}
+		if roleName == noAccessRoleName {
+			return existingUser, nil
+		}
+	}
+	return nil, nil
+}
+
+func getRoleId(ctx context.Context, client *vcfclient.VcfClient, roleName string) (string, error) {
+	roleResult, err := client.Users.GetRoles(
+		users.NewGetRolesParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return "", err
+	}
+	for _, role := range roleResult.Payload.Elements {
+		if role.Name != nil && *role.Name == roleName && role.ID != nil {
+			return *role.ID, nil
+		}
+	}
+	return "", fmt.Errorf("role not found: %s", roleName)
+}
+
+func getRoleName(ctx context.Context, client *vcfclient.VcfClient, roleId string) (string, error) {
+	roleResult, err := client.Users.GetRoles(
+		users.NewGetRolesParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout))
+	if err != nil {
+		return "", err
+	}
+	for _, role := range roleResult.Payload.Elements {
+		if role.ID != nil && *role.ID == roleId && role.Name != nil {
+			return *role.Name, nil
+		}
+	}
+	return "", fmt.Errorf("role not found: %s", roleId)
+}
+
+// updateUserRole assigns the role to the user in place. The SDK has no operation for it, the request is submitted
+// with the authenticated transport of the SDK client.
+func updateUserRole(ctx context.Context, client *vcfclient.VcfClient, userId, roleId string) error {
+	params := runtime.ClientRequestWriterFunc(func(request runtime.ClientRequest, _ strfmt.Registry) error {
+		if err := request.SetTimeout(constants.DefaultVcfApiCallTimeout); err != nil {
+			return err
+		}
+		if err := request.SetPathParam("id", userId); err != nil {
+			return err
+		}
+		return request.SetBodyParam(map[string]interface{}{"role": map[string]interface{}{"id": roleId}})
+	})
+	reader := runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+		if response.Code() >= 200 && response.Code() < 300 {
+			return nil, nil
+		}
+		content, _ := io.ReadAll(response.Body())
+		return nil, fmt.Errorf("PATCH /v1/users/%s returned %d: %s", userId, response.Code(), string(content))
+	})
+
+	_, err := client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "updateUser",
+		Method:             http.MethodPatch,
+		PathPattern:        "/v1/users/{id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             reader,
+		Context:            ctx,
+	})
+	return err
+}
+
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api_client.SddcManagerClient).ApiClient
 
+	if d.Get("deactivate_on_destroy").(bool) {
+		// SDDC Manager cannot disable a user, the role without privileges is assigned instead
+		roleId, err := getRoleId(ctx, client, noAccessRoleName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := updateUserRole(ctx, client, d.Id(), roleId); err != nil {
+			return diag.FromErr(err)
+		}
+		log.Printf("%s: Deactivated, the user is kept with the %s role", d.Id(), noAccessRoleName)
+		d.SetId("")
+		return nil
+	}
+
 	params := users.NewRemoveUserParamsWithContext(ctx).
 		WithTimeout(constants.DefaultVcfApiCallTimeout)
 	params.ID = d.Id()
@@ -7,6 +7,7 @@ package provider
 import (
 	"fmt"
 	"log"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -18,9 +19,11 @@ import (
 const (
 	testUserName1 = "testuser1@vrack.vsphere.local"
 	testUserName2 = "serviceuser1"
+	testUserName3 = "testuser3@vrack.vsphere.local"
 )
 
 func TestAccResourceVcfUser(t *testing.T) {
+	var serviceUserApiKey string
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: muxedFactories(),
@@ -34,6 +37,29 @@ func TestAccResourceVcfUser(t *testing.T) {
 					resource.TestCheckResourceAttrSet("vcf_user.serviceuser1", "id"),
 					resource.TestCheckResourceAttrSet("vcf_user.serviceuser1", "api_key"),
 					resource.TestCheckResourceAttrSet("vcf_user.serviceuser1", "creation_timestamp"),
+					func(state *terraform.State) error {
+						serviceUserApiKey = state.RootModule().Resources["vcf_user.serviceuser1"].Primary.Attributes["api_key"]
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "vcf_user.serviceuser1",
+				ImportState:             true,
+				ImportStateId:           testUserName2,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"type", "deactivate_on_destroy"},
+			},
+			{
+				Config: strings.Replace(testAccVcfUserConfig(), `role_name = "VIEWER"
+	}
+`, `role_name = "OPERATOR"
+	}
+`, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcf_user.serviceuser1", "role_name", "OPERATOR"),
+					// The service account is updated in place, its API key is kept
+					resource.TestCheckResourceAttrPtr("vcf_user.serviceuser1", "api_key", &serviceUserApiKey),
 				),
 			},
 		},
@@ -58,6 +84,45 @@ func testAccVcfUserConfig() string {
 `, testUserName1, testUserName2)
 }
 
+func TestAccResourceVcfUserDeactivateOnDestroy(t *testing.T) {
+	userConfig := fmt.Sprintf(`
+	resource "vcf_user" "testuser3" {
+		name                  = %q
+		domain                = "vrack.vsphere.local"
+		type                  = "USER"
+		role_name             = "OPERATOR"
+		deactivate_on_destroy = true
+	}
+`, testUserName3)
+	lookupConfig := fmt.Sprintf(`
+	data "vcf_user" "testuser3" {
+		name   = %q
+		domain = "vrack.vsphere.local"
+	}
+`, testUserName3)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: userConfig,
+				Check:  resource.TestCheckResourceAttr("vcf_user.testuser3", "role_name", "OPERATOR"),
+			},
+			{
+				// Removing the resource keeps the user without privileges
+				Config: lookupConfig,
+				Check:  resource.TestCheckResourceAttr("data.vcf_user.testuser3", "role_name", noAccessRoleName),
+			},
+			{
+				// The deactivated user is reactivated instead of added again
+				Config: userConfig,
+				Check:  resource.TestCheckResourceAttr("vcf_user.testuser3", "role_name", "OPERATOR"),
+			},
+		},
+	})
+}
+
 func testCheckVcfUserDestroy(_ *terraform.State) error {
 	apiClient := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
 
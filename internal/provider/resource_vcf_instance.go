@@ -6,24 +6,37 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	sddc_api "github.com/vmware/vcf-sdk-go/client/sddc"
 	"github.com/vmware/vcf-sdk-go/models"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/datastores"
+	"github.com/vmware/terraform-provider-vcf/internal/domain"
 	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	"github.com/vmware/terraform-provider-vcf/internal/sddc"
 	validation_utils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
-var dvSwitchVersions = []string{"7.0.0", "7.0.2", "7.0.3", "8.0.0"}
+var dvSwitchVersions = []string{"7.0.0", "7.0.2", "7.0.3", "8.0.0", sddc.DvSwitchVersionVcf52}
+var excludedComponentValues = []string{"NSX", "VSAN", "EsxThumbprintValidation", "CEIP", "Backup"}
+
+const defaultBringupTaskName = "workflowconfig/workflowspec-ems.json"
 
 func ResourceVcfInstance() *schema.Resource {
 	return &schema.Resource{
@@ -31,20 +44,41 @@ func ResourceVcfInstance() *schema.Resource {
 		ReadContext:   resourceVcfInstanceRead,
 		UpdateContext: resourceVcfInstanceUpdate,
 		DeleteContext: resourceVcfInstanceDelete,
+		CustomizeDiff: validateVcfInstanceSpec,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 		Schema: resourceVcfInstanceSchema(),
 	}
 }
 
-// TODO add support for "subscriptionLicensing" property in future releases.
+// sddcSpecRequirements are the arguments required for bring-up, with the check whether a spec decoded from
+// spec_json provides them instead.
+var sddcSpecRequirements = []struct {
+	key   string
+	isSet func(sddcSpec *models.SDDCSpec) bool
+}{
+	{"instance_id", func(sddcSpec *models.SDDCSpec) bool { return sddcSpec.SDDCID != nil && len(*sddcSpec.SDDCID) > 0 }},
+	{"cluster", func(sddcSpec *models.SDDCSpec) bool { return sddcSpec.ClusterSpec != nil }},
+	{"dns", func(sddcSpec *models.SDDCSpec) bool { return sddcSpec.DNSSpec != nil }},
+	{"dv_switch_version", func(sddcSpec *models.SDDCSpec) bool { return len(sddcSpec.DvSwitchVersion) > 0 }},
+	{"dvs", func(sddcSpec *models.SDDCSpec) bool { return len(sddcSpec.DvsSpecs) > 0 }},
+	{"host", func(sddcSpec *models.SDDCSpec) bool { return len(sddcSpec.HostSpecs) > 0 }},
+	{"management_pool_name", func(sddcSpec *models.SDDCSpec) bool { return len(sddcSpec.ManagementPoolName) > 0 }},
+	{"network", func(sddcSpec *models.SDDCSpec) bool { return len(sddcSpec.NetworkSpecs) > 0 }},
+	{"ntp_servers", func(sddcSpec *models.SDDCSpec) bool { return len(sddcSpec.NtpServers) > 0 }},
+	{"vcenter", func(sddcSpec *models.SDDCSpec) bool { return sddcSpec.VcenterSpec != nil }},
+}
+
 func resourceVcfInstanceSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"instance_id": {
 			Type:         schema.TypeString,
 			Description:  "Client string that identifies an SDDC by name or instance name. Used for management domain name. Can contain only letters, numbers and the following symbols: '-'. Example: \"sfo01-m01\", Length 3-20 characters",
-			Required:     true,
+			Optional:     true,
 			ValidateFunc: validation_utils.ValidateSddcId,
 		},
 		"status": {
@@ -57,6 +91,11 @@ func resourceVcfInstanceSchema() map[string]*schema.Schema {
 			Description: "SDDC Task creation timestamp",
 			Computed:    true,
 		},
+		"current_stage": {
+			Type:        schema.TypeString,
+			Description: "The step of the bring-up in progress, or the failed step, empty once the bring-up succeeded",
+			Computed:    true,
+		},
 		"sddc_manager_fqdn": {
 			Type:        schema.TypeString,
 			Description: "FQDN of the resulting SDDC Manager",
@@ -87,10 +126,19 @@ func resourceVcfInstanceSchema() map[string]*schema.Schema {
 		"dvs":     sddc.GetDvsSchema(),
 		"dv_switch_version": {
 			Type:         schema.TypeString,
-			Description:  "The version of the distributed virtual switches to be used. One among: 7.0.0, 7.0.2, 7.0.3, 8.0.0",
-			Required:     true,
+			Description:  "The version of the distributed virtual switches to be used. One among: 7.0.0, 7.0.2, 7.0.3, 8.0.0, 8.0.3",
+			Optional:     true,
 			ValidateFunc: validation.StringInSlice(dvSwitchVersions, false),
 		},
+		"excluded_components": {
+			Type:        schema.TypeList,
+			Description: "Components to be excluded from the bring-up. One or more among: NSX, VSAN, EsxThumbprintValidation, CEIP, Backup",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(excludedComponentValues, false),
+			},
+		},
 		"esx_license": {
 			Type:      schema.TypeString,
 			Sensitive: true,
@@ -100,43 +148,181 @@ func resourceVcfInstanceSchema() map[string]*schema.Schema {
 		"management_pool_name": {
 			Type:        schema.TypeString,
 			Description: "A string identifying the network pool associated with the management domain",
-			Required:    true,
+			Optional:    true,
 		},
 		"network": sddc.GetNetworkSpecsSchema(),
 		"nsx":     sddc.GetNsxSpecSchema(),
 		"ntp_servers": {
 			Type:        schema.TypeList,
 			Description: "List of NTP servers",
-			Required:    true,
+			Optional:    true,
 			Elem: &schema.Schema{
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringIsNotEmpty,
 			},
 		},
+		"proxy":        sddc.GetProxySchema(),
 		"psc":          sddc.GetPscSchema(),
 		"sddc_manager": sddc.GetSddcManagerSchema(),
 		"security":     sddc.GetSecuritySchema(),
 		"skip_esx_thumbprint_validation": {
 			Type:        schema.TypeBool,
 			Description: "Skip ESXi thumbprint validation",
-			Required:    true,
+			Optional:    true,
 		},
-		"task_name": {
+		"subscription_licensing": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Deploy the instance with subscription licensing, without license keys. Requires VCF 5.2, " +
+				"dv_switch_version 8.0.3, or later. The license keys cannot be set then",
+		},
+		"skip_gateway_ping_validation": {
+			Type:        schema.TypeBool,
+			Description: "Skip the validation that the gateways of the networks respond to ping",
+			Optional:    true,
+		},
+		"spec_json": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			Description: "The bring-up spec in the JSON format accepted by Cloud Builder. The arguments set in the configuration " +
+				"take precedence over the properties of the spec",
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: structure.SuppressJsonDiff,
+		},
+		"validate_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: true,
+			Default:  false,
+			Description: "Only validate the bring-up spec with Cloud Builder, without deploying the instance. Changes of the " +
+				"spec are validated again. Setting it to false starts the bring-up",
+		},
+		"version": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+\.\d+(\.\d+)*$`), "must be a version, e.g. 9.0.0.0"),
+			Description: "The VCF version of the instance. From 9.0 the instance is deployed with the VCF Installer " +
+				"appliance, configured as the Cloud Builder of the provider, otherwise with Cloud Builder",
+		},
+		"revalidate_on_resume": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+			Description: "Validate the bring-up spec again before resuming a failed bring-up. Cloud Builder retries " +
+				"the failed bring-up from the failed step",
+		},
+		"validation_id": {
+			Type:        schema.TypeString,
+			Description: "ID of the last validation of the bring-up spec, when validate_only is set",
+			Computed:    true,
+		},
+		"validation_status": {
+			Type:        schema.TypeString,
+			Description: "Result of the last validation of the bring-up spec, when validate_only is set",
+			Computed:    true,
+		},
+		"validation_checks": {
+			Type:        schema.TypeList,
+			Description: "Checks of the last validation of the bring-up spec, when validate_only is set",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"description": {
+						Type:        schema.TypeString,
+						Description: "Description of the check",
+						Computed:    true,
+					},
+					"result_status": {
+						Type:        schema.TypeString,
+						Description: "Result of the check",
+						Computed:    true,
+					},
+					"severity": {
+						Type:        schema.TypeString,
+						Description: "Severity of the check result, e.g. WARNING",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"import_management_domain": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "Connect to the SDDC Manager deployed by the bring-up and read the management domain into the " +
+				"management_domain_id, management_domain_name, management_vcenter_fqdn and management_nsx_vip_fqdn attributes",
+		},
+		"sddc_manager_api_username": {
 			Type:     schema.TypeString,
 			Optional: true,
-			Default:  "workflowconfig/workflowspec-ems.json",
+			Description: "The user connecting to SDDC Manager when import_management_domain is set. Defaults to the SSO " +
+				"administrator of the bring-up spec, e.g. administrator@vsphere.local",
+			RequiredWith: []string{"sddc_manager_api_username", "sddc_manager_api_password"},
+		},
+		"sddc_manager_api_password": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The password of sddc_manager_api_username. Defaults to the SSO administrator password of the bring-up spec",
+		},
+		"management_domain_id": {
+			Type:        schema.TypeString,
+			Description: "ID of the management domain, when import_management_domain is set",
+			Computed:    true,
+		},
+		"management_domain_name": {
+			Type:        schema.TypeString,
+			Description: "Name of the management domain, when import_management_domain is set",
+			Computed:    true,
+		},
+		"management_vcenter_fqdn": {
+			Type:        schema.TypeString,
+			Description: "FQDN of the vCenter Server of the management domain, when import_management_domain is set",
+			Computed:    true,
+		},
+		"management_nsx_vip_fqdn": {
+			Type:        schema.TypeString,
+			Description: "FQDN of the virtual IP of the NSX Manager cluster of the management domain, when import_management_domain is set",
+			Computed:    true,
+		},
+		"task_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The name of the workflow to execute, workflowconfig/workflowspec-ems.json unless set in spec_json",
 		},
 		"vcenter":    sddc.GetVcenterSchema(),
 		"vsan":       sddc.GetVsanSchema(),
 		"vx_manager": sddc.GetVxManagerSchema(),
+		"nfs_datastore": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"vsan", "vmfs_datastore"},
+			Description:   "NFS v3 principal storage of the management domain instead of vSAN. Requires version 9.0 or later",
+			Elem:          datastores.NfsDatastoreSchema(),
+		},
+		"vmfs_datastore": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"vsan", "nfs_datastore"},
+			Description:   "VMFS on FC principal storage of the management domain instead of vSAN. Requires version 9.0 or later",
+			Elem:          datastores.VmfsDatastoreSchema(),
+		},
 	}
 }
 
-func buildSddcSpec(data *schema.ResourceData) *models.SDDCSpec {
-	sddcSpec := &models.SDDCSpec{}
-	if rawCeipEnabled, ok := data.GetOk("ceip_enabled"); ok {
-		ceipEnabled := rawCeipEnabled.(bool)
-		sddcSpec.CEIPEnabled = ceipEnabled
+func buildSddcSpec(data *schema.ResourceData) (*models.SDDCSpec, error) {
+	// The arguments set in the configuration take precedence over the properties of spec_json
+	sddcSpec, err := sddc.GetSddcSpecFromJson(data.Get("spec_json").(string))
+	if err != nil {
+		return nil, err
+	}
+	if isArgumentConfigured(data, "ceip_enabled") {
+		sddcSpec.CEIPEnabled = data.Get("ceip_enabled").(bool)
 	}
 	if clusterSpec, ok := data.GetOk("cluster"); ok {
 		sddcSpec.ClusterSpec = sddc.GetSddcClusterSpecFromSchema(clusterSpec.([]interface{}))
@@ -150,12 +336,14 @@ func buildSddcSpec(data *schema.ResourceData) *models.SDDCSpec {
 	if dvSwitchVersion, ok := data.GetOk("dv_switch_version"); ok {
 		sddcSpec.DvSwitchVersion = dvSwitchVersion.(string)
 	}
+	if excludedComponents, ok := data.GetOk("excluded_components"); ok {
+		sddcSpec.ExcludedComponents = utils.ToStringSlice(excludedComponents.([]interface{}))
+	}
 	if esxLicense, ok := data.GetOk("esx_license"); ok {
 		sddcSpec.EsxLicense = esxLicense.(string)
 	}
-	if rawFipsEnabled, ok := data.GetOk("fips_enabled"); ok {
-		fipsEnabled := rawFipsEnabled.(bool)
-		sddcSpec.FIPSEnabled = fipsEnabled
+	if isArgumentConfigured(data, "fips_enabled") {
+		sddcSpec.FIPSEnabled = data.Get("fips_enabled").(bool)
 	}
 	if hostSpecs, ok := data.GetOk("host"); ok {
 		sddcSpec.HostSpecs = sddc.GetSddcHostSpecsFromSchema(hostSpecs.([]interface{}))
@@ -172,6 +360,9 @@ func buildSddcSpec(data *schema.ResourceData) *models.SDDCSpec {
 	if ntpServers, ok := data.GetOk("ntp_servers"); ok {
 		sddcSpec.NtpServers = utils.ToStringSlice(ntpServers.([]interface{}))
 	}
+	if proxySpec, ok := data.GetOk("proxy"); ok {
+		sddcSpec.ProxySpec = sddc.GetProxySpecFromSchema(proxySpec.([]interface{}))
+	}
 	if pscSpecs, ok := data.GetOk("psc"); ok {
 		sddcSpec.PscSpecs = sddc.GetPscSpecsFromSchema(pscSpecs.([]interface{}))
 	}
@@ -184,11 +375,16 @@ func buildSddcSpec(data *schema.ResourceData) *models.SDDCSpec {
 	if securitySpec, ok := data.GetOk("security"); ok {
 		sddcSpec.SecuritySpec = sddc.GetSecuritySpecSchema(securitySpec.([]interface{}))
 	}
-	if skipEsxThumbPrintValidation, ok := data.GetOk("skip_esx_thumbprint_validation"); ok {
-		sddcSpec.SkipEsxThumbprintValidation = skipEsxThumbPrintValidation.(bool)
+	if isArgumentConfigured(data, "skip_esx_thumbprint_validation") {
+		sddcSpec.SkipEsxThumbprintValidation = data.Get("skip_esx_thumbprint_validation").(bool)
+	}
+	if isArgumentConfigured(data, "skip_gateway_ping_validation") {
+		sddcSpec.SkipGatewayPingValidation = data.Get("skip_gateway_ping_validation").(bool)
 	}
 	if taskName, ok := data.GetOk("task_name"); ok {
 		sddcSpec.TaskName = utils.ToStringPointer(taskName)
+	} else if sddcSpec.TaskName == nil || len(*sddcSpec.TaskName) == 0 {
+		sddcSpec.TaskName = utils.ToStringPointer(defaultBringupTaskName)
 	}
 	if vcenterSpec, ok := data.GetOk("vcenter"); ok {
 		sddcSpec.VcenterSpec = sddc.GetVcenterSpecFromSchema(vcenterSpec.([]interface{}))
@@ -199,13 +395,263 @@ func buildSddcSpec(data *schema.ResourceData) *models.SDDCSpec {
 	if vxManagerSpec, ok := data.GetOk("vx_manager"); ok {
 		sddcSpec.VxManagerSpec = sddc.GetVxManagerSpecFromSchema(vxManagerSpec.([]interface{}))
 	}
-	return sddcSpec
+	return sddcSpec, nil
+}
+
+// buildSddcSpecProperties builds the bring-up spec as a JSON document. Unlike the SDDCSpec model of the SDK, it keeps
+// the properties of spec_json the SDK has no model for and carries subscriptionLicensing.
+func buildSddcSpecProperties(data *schema.ResourceData) (map[string]interface{}, error) {
+	sddcSpec, err := buildSddcSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	specProperties := make(map[string]interface{})
+	if specJson := data.Get("spec_json").(string); len(specJson) > 0 {
+		if err := json.Unmarshal([]byte(specJson), &specProperties); err != nil {
+			return nil, fmt.Errorf("cannot decode the bring-up spec: %w", err)
+		}
+	}
+
+	modelledSpec, err := json.Marshal(sddcSpec)
+	if err != nil {
+		return nil, err
+	}
+	modelledProperties := make(map[string]interface{})
+	if err := json.Unmarshal(modelledSpec, &modelledProperties); err != nil {
+		return nil, err
+	}
+	for key, value := range modelledProperties {
+		if value != nil {
+			specProperties[key] = value
+		}
+	}
+	if isArgumentConfigured(data, "subscription_licensing") {
+		specProperties["subscriptionLicensing"] = data.Get("subscription_licensing").(bool)
+	}
+
+	return specProperties, nil
+}
+
+// withSpecBody sends the given document as the body of a bring-up request instead of the SDDCSpec of the parameters.
+func withSpecBody(specProperties map[string]interface{}) sddc_api.ClientOption {
+	return func(operation *runtime.ClientOperation) {
+		params := operation.Params
+		operation.Params = runtime.ClientRequestWriterFunc(func(request runtime.ClientRequest, registry strfmt.Registry) error {
+			if err := params.WriteToRequest(request, registry); err != nil {
+				return err
+			}
+			return request.SetBodyParam(specProperties)
+		})
+	}
+}
+
+// isArgumentConfigured tells whether the argument is set in the configuration, including to its zero value.
+func isArgumentConfigured(data *schema.ResourceData, key string) bool {
+	if _, ok := data.GetOk(key); ok {
+		return true
+	}
+	value, diags := data.GetRawConfigAt(cty.GetAttrPath(key))
+	return !diags.HasError() && !value.IsNull()
+}
+
+// validateVcfInstanceSpec verifies at plan time that the arguments required for bring-up are set either in the
+// configuration or in spec_json.
+func validateVcfInstanceSpec(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("spec_json") {
+		return nil
+	}
+	sddcSpec, err := sddc.GetSddcSpecFromJson(diff.Get("spec_json").(string))
+	if err != nil {
+		return err
+	}
+
+	useInstaller := isInstallerVersion(diff.Get("version").(string))
+	if err := validatePrincipalStorage(diff, sddcSpec, useInstaller); err != nil {
+		return err
+	}
+	missing := make([]string, 0)
+	for _, requirement := range sddcSpecRequirements {
+		if _, ok := diff.GetOk(requirement.key); ok || !diff.NewValueKnown(requirement.key) || requirement.isSet(sddcSpec) {
+			continue
+		}
+		if useInstaller && requirement.key == "dv_switch_version" {
+			// The VCF Installer deploys the switch version of the release
+			continue
+		}
+		missing = append(missing, requirement.key)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the following arguments must be set in the configuration or in spec_json: %s",
+			strings.Join(missing, ", "))
+	}
+
+	if !diff.NewValueKnown("dv_switch_version") || !diff.NewValueKnown("dvs") {
+		return nil
+	}
+	dvSwitchVersion := sddcSpec.DvSwitchVersion
+	if rawDvSwitchVersion, ok := diff.GetOk("dv_switch_version"); ok {
+		dvSwitchVersion = rawDvSwitchVersion.(string)
+	}
+	if useInstaller && len(dvSwitchVersion) == 0 {
+		dvSwitchVersion = sddc.DvSwitchVersionVcf52
+	}
+	if err := validateSubscriptionLicensing(diff, sddcSpec, dvSwitchVersion); err != nil {
+		return err
+	}
+	dvsSpecs := sddcSpec.DvsSpecs
+	if rawDvsSpecs, ok := diff.GetOk("dvs"); ok {
+		dvsSpecs = sddc.GetDvsSpecsFromSchema(rawDvsSpecs.([]interface{}))
+	}
+
+	return sddc.ValidateDvsSpecs(dvSwitchVersion, dvsSpecs)
+}
+
+// validateSubscriptionLicensing verifies that the VCF version supports subscription licensing, if enabled in the
+// configuration or in spec_json, and that no license key is set then.
+func validateSubscriptionLicensing(diff *schema.ResourceDiff, sddcSpec *models.SDDCSpec, dvSwitchVersion string) error {
+	enabled := diff.Get("subscription_licensing").(bool)
+	if value, diags := diff.GetRawConfigAt(cty.GetAttrPath("subscription_licensing")); diags.HasError() || value.IsNull() {
+		specProperties := make(map[string]interface{})
+		_ = json.Unmarshal([]byte(diff.Get("spec_json").(string)), &specProperties)
+		enabled, _ = specProperties["subscriptionLicensing"].(bool)
+	}
+	if !enabled {
+		return nil
+	}
+
+	switchVersion, err := version.NewVersion(dvSwitchVersion)
+	if err == nil && switchVersion.LessThan(version.Must(version.NewVersion(sddc.DvSwitchVersionVcf52))) {
+		return fmt.Errorf("subscription_licensing requires VCF 5.2 or later, dv_switch_version %s", sddc.DvSwitchVersionVcf52)
+	}
+
+	if rawVcenterSpec, ok := diff.GetOk("vcenter"); ok {
+		sddcSpec.VcenterSpec = sddc.GetVcenterSpecFromSchema(rawVcenterSpec.([]interface{}))
+	}
+	if rawNsxSpec, ok := diff.GetOk("nsx"); ok {
+		sddcSpec.NSXTSpec = sddc.GetNsxSpecFromSchema(rawNsxSpec.([]interface{}))
+	}
+	if rawVsanSpec, ok := diff.GetOk("vsan"); ok {
+		sddcSpec.VSANSpec = sddc.GetVsanSpecFromSchema(rawVsanSpec.([]interface{}))
+	}
+	licensed := make([]string, 0)
+	if _, ok := diff.GetOk("esx_license"); ok || len(sddcSpec.EsxLicense) > 0 {
+		licensed = append(licensed, "esx_license")
+	}
+	if sddcSpec.VcenterSpec != nil && len(sddcSpec.VcenterSpec.LicenseFile) > 0 {
+		licensed = append(licensed, "vcenter.license")
+	}
+	if sddcSpec.NSXTSpec != nil && len(sddcSpec.NSXTSpec.NSXTLicense) > 0 {
+		licensed = append(licensed, "nsx.license")
+	}
+	if sddcSpec.VSANSpec != nil && len(sddcSpec.VSANSpec.LicenseFile) > 0 {
+		licensed = append(licensed, "vsan.license")
+	}
+	if len(licensed) > 0 {
+		return fmt.Errorf("license keys cannot be set with subscription_licensing: %s", strings.Join(licensed, ", "))
+	}
+
+	return nil
+}
+
+// sddcSpecAttributePaths indexes the addresses and names configured for the instance by the path of their argument,
+// the validation errors of Cloud Builder mention the offending values rather than the properties of the spec.
+func sddcSpecAttributePaths(data *schema.ResourceData) map[string]cty.Path {
+	paths := make(map[string]cty.Path)
+	addPath := func(value interface{}, path cty.Path) {
+		if stringValue, ok := value.(string); ok && len(stringValue) > 0 {
+			if _, exists := paths[stringValue]; !exists {
+				paths[stringValue] = path
+			}
+		}
+	}
+	addBlockPaths := func(key string, attributes ...string) {
+		for i, rawBlock := range data.Get(key).([]interface{}) {
+			block, ok := rawBlock.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for _, attribute := range attributes {
+				addPath(block[attribute], cty.GetAttrPath(key).IndexInt(i).GetAttr(attribute))
+			}
+		}
+	}
+
+	addBlockPaths("host", "hostname")
+	for i, rawHost := range data.Get("host").([]interface{}) {
+		host, ok := rawHost.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, rawIpAddress := range host["ip_address_private"].([]interface{}) {
+			if ipAddress, ok := rawIpAddress.(map[string]interface{}); ok {
+				addPath(ipAddress["ip_address"], cty.GetAttrPath("host").IndexInt(i).
+					GetAttr("ip_address_private").IndexInt(0).GetAttr("ip_address"))
+			}
+		}
+	}
+	addBlockPaths("vcenter", "vcenter_hostname", "vcenter_ip")
+	addBlockPaths("sddc_manager", "hostname", "ip_address")
+	addBlockPaths("dns", "name_server", "secondary_name_server")
+	addBlockPaths("network", "subnet", "gateway")
+	for i, ntpServer := range data.Get("ntp_servers").([]interface{}) {
+		addPath(ntpServer, cty.GetAttrPath("ntp_servers").IndexInt(i))
+	}
+
+	return paths
+}
+
+// validatePrincipalStorage verifies the principal storage of the management domain, which is vSAN unless the
+// VCF Installer deploys it on NFS or VMFS on FC.
+func validatePrincipalStorage(diff *schema.ResourceDiff, sddcSpec *models.SDDCSpec, useInstaller bool) error {
+	for _, key := range []string{"nfs_datastore", "vmfs_datastore"} {
+		if _, ok := diff.GetOk(key); ok && !useInstaller {
+			return fmt.Errorf("%s requires version 9.0 or later, Cloud Builder deploys the management domain on vSAN", key)
+		}
+	}
+
+	vsanSpec := sddcSpec.VSANSpec
+	if rawVsanSpec, ok := diff.GetOk("vsan"); ok {
+		vsanSpec = sddc.GetVsanSpecFromSchema(rawVsanSpec.([]interface{}))
+	}
+	if vsanSpec != nil && vsanSpec.EsaConfig != nil && vsanSpec.EsaConfig.Enabled != nil && *vsanSpec.EsaConfig.Enabled &&
+		vsanSpec.VSANDedup {
+		return fmt.Errorf("vsan_dedup is not supported with vSAN ESA, which compresses the data without deduplication")
+	}
+
+	return nil
+}
+
+// missingSddcSpecArguments returns the arguments required for bring-up that the spec does not provide.
+func missingSddcSpecArguments(sddcSpec *models.SDDCSpec, useInstaller bool) []string {
+	missing := make([]string, 0)
+	for _, requirement := range sddcSpecRequirements {
+		if useInstaller && requirement.key == "dv_switch_version" {
+			continue
+		}
+		if !requirement.isSet(sddcSpec) {
+			missing = append(missing, requirement.key)
+		}
+	}
+
+	return missing
 }
 
 func resourceVcfInstanceCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api_client.CloudBuilderClient)
 
-	sddcSpec := buildSddcSpec(data)
+	sddcSpec, err := buildSddcSpec(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("task_name", *sddcSpec.TaskName)
+
+	if data.Get("validate_only").(bool) {
+		return validateVcfInstanceOnly(ctx, data, client, sddcSpec)
+	}
+
+	if isInstallerVersion(data.Get("version").(string)) {
+		return resourceVcfInstanceInstallerCreate(ctx, data, meta)
+	}
 
 	bringUpInfo, err := getLastBringUp(ctx, client)
 	if err != nil {
@@ -213,12 +659,19 @@ func resourceVcfInstanceCreate(ctx context.Context, data *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	bringUpID, diags := invokeBringupWorkflow(ctx, client, sddcSpec, bringUpInfo)
+	specProperties, err := buildSddcSpecProperties(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	bringUpID, diags := invokeBringupWorkflow(ctx, client, sddcSpec, bringUpInfo, data.Get("revalidate_on_resume").(bool),
+		withSpecBody(specProperties))
 	if diags != nil {
-		return diags
+		return validation_utils.AttachAttributePaths(diags, sddcSpecAttributePaths(data))
 	}
+	// Keep the execution in the state, a failed bring-up taints the resource and is resumed by the next apply
+	data.SetId(bringUpID)
 
-	diags = waitForBringupProcess(ctx, bringUpID, client)
+	diags = waitForBringupProcess(ctx, data, bringUpID, client)
 	if diags != nil {
 		return diags
 	}
@@ -227,9 +680,21 @@ func resourceVcfInstanceCreate(ctx context.Context, data *schema.ResourceData, m
 }
 
 func resourceVcfInstanceRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if data.Get("validate_only").(bool) {
+		// Nothing is deployed, the results of the last validation are kept
+		return nil
+	}
 	client := meta.(*api_client.CloudBuilderClient)
+	installerClient := client.NewInstallerClient()
+	useInstaller := isInstallerVersion(data.Get("version").(string))
 
-	bringUpInfo, err := getLastBringUp(ctx, client)
+	var bringUpInfo *models.SDDCTask
+	var err error
+	if useInstaller {
+		bringUpInfo, err = getLastInstallerDeployment(ctx, installerClient)
+	} else {
+		bringUpInfo, err = getLastBringUp(ctx, client)
+	}
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		return diag.FromErr(err)
@@ -239,8 +704,14 @@ func resourceVcfInstanceRead(ctx context.Context, data *schema.ResourceData, met
 	data.SetId(bringupId)
 	_ = data.Set("status", bringUpInfo.Status)
 	_ = data.Set("creation_timestamp", bringUpInfo.CreationTimestamp)
+	_ = data.Set("current_stage", bringupStage(bringUpInfo))
 
-	sddcManagerInfo, err := getSddcManagerInfo(ctx, bringupId, client)
+	var sddcManagerInfo *models.SDDCManagerInfo
+	if useInstaller {
+		sddcManagerInfo, err = getInstallerSddcManagerInfo(ctx, bringupId, installerClient)
+	} else {
+		sddcManagerInfo, err = getSddcManagerInfo(ctx, bringupId, client)
+	}
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		return diag.FromErr(err)
@@ -250,9 +721,26 @@ func resourceVcfInstanceRead(ctx context.Context, data *schema.ResourceData, met
 	_ = data.Set("sddc_manager_id", sddcManagerInfo.ID)
 	_ = data.Set("sddc_manager_version", sddcManagerInfo.Version)
 
+	if data.Get("import_management_domain").(bool) {
+		if err := readManagementDomain(ctx, data, client); err != nil {
+			if data.IsNewResource() {
+				return diag.FromErr(err)
+			}
+			tflog.Warn(ctx, fmt.Sprintf("Cannot read the management domain, keeping the last known values: %s", err))
+		}
+	}
+
 	return nil
 }
 func resourceVcfInstanceUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if data.Get("validate_only").(bool) {
+		sddcSpec, err := buildSddcSpec(data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = data.Set("task_name", *sddcSpec.TaskName)
+		return validateVcfInstanceOnly(ctx, data, meta.(*api_client.CloudBuilderClient), sddcSpec)
+	}
 	// no op
 	return resourceVcfInstanceRead(ctx, data, meta)
 }
@@ -261,18 +749,117 @@ func resourceVcfInstanceDelete(_ context.Context, _ *schema.ResourceData, _ inte
 	return nil
 }
 
-func invokeBringupWorkflow(ctx context.Context, client *api_client.CloudBuilderClient, sddcSpec *models.SDDCSpec, lastBringup *models.SDDCTask) (string, diag.Diagnostics) {
+// readManagementDomain connects to the SDDC Manager instance deployed by the bring-up and records the
+// endpoints of the management domain.
+func readManagementDomain(ctx context.Context, data *schema.ResourceData, client *api_client.CloudBuilderClient) error {
+	username := data.Get("sddc_manager_api_username").(string)
+	password := data.Get("sddc_manager_api_password").(string)
+	if len(username) == 0 {
+		// The SSO administrator of the management domain, as set in the bring-up spec
+		sddcSpec, err := buildSddcSpec(data)
+		if err != nil {
+			return err
+		}
+		ssoDomain := "vsphere.local"
+		if len(sddcSpec.PscSpecs) > 0 && sddcSpec.PscSpecs[0] != nil {
+			if sddcSpec.PscSpecs[0].PscSSOSpec != nil && len(sddcSpec.PscSpecs[0].PscSSOSpec.SSODomain) > 0 {
+				ssoDomain = sddcSpec.PscSpecs[0].PscSSOSpec.SSODomain
+			}
+			if len(password) == 0 && sddcSpec.PscSpecs[0].AdminUserSSOPassword != nil {
+				password = *sddcSpec.PscSpecs[0].AdminUserSSOPassword
+			}
+		}
+		username = "administrator@" + ssoDomain
+	}
+
+	sddcManagerFqdn := data.Get("sddc_manager_fqdn").(string)
+	sddcManagerClient := client.NewSddcManagerClient(username, password, sddcManagerFqdn)
+	if err := sddcManagerClient.Connect(); err != nil {
+		return fmt.Errorf("cannot connect to SDDC Manager %s as %s: %w", sddcManagerFqdn, username, err)
+	}
+
+	managementDomain, err := domain.GetManagementDomain(ctx, sddcManagerClient.ApiClient)
+	if err != nil {
+		return err
+	}
+	_ = data.Set("management_domain_id", managementDomain.ID)
+	_ = data.Set("management_domain_name", managementDomain.Name)
+	vcenterFqdn := ""
+	if len(managementDomain.VCENTERS) > 0 && managementDomain.VCENTERS[0] != nil {
+		vcenterFqdn = managementDomain.VCENTERS[0].Fqdn
+	}
+	_ = data.Set("management_vcenter_fqdn", vcenterFqdn)
+	nsxVipFqdn := ""
+	if managementDomain.NSXTCluster != nil {
+		nsxVipFqdn = managementDomain.NSXTCluster.VipFqdn
+	}
+	_ = data.Set("management_nsx_vip_fqdn", nsxVipFqdn)
+
+	return nil
+}
+
+// validateVcfInstanceOnly validates the bring-up spec with Cloud Builder and records the results, without deploying.
+func validateVcfInstanceOnly(ctx context.Context, data *schema.ResourceData, client *api_client.CloudBuilderClient, sddcSpec *models.SDDCSpec) diag.Diagnostics {
+	var validationResult *models.Validation
+	var diags diag.Diagnostics
+	if isInstallerVersion(data.Get("version").(string)) {
+		installerSpec, err := buildInstallerSpec(data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		validationResult, diags = validateInstallerSpec(ctx, client.NewInstallerClient(), installerSpec)
+	} else {
+		specProperties, err := buildSddcSpecProperties(data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		validationResult, diags = validateBringupSpec(ctx, client, sddcSpec, withSpecBody(specProperties))
+	}
+	if diags != nil {
+		return validation_utils.AttachAttributePaths(diags, sddcSpecAttributePaths(data))
+	}
+	tflog.Info(ctx, fmt.Sprintf("Bring-Up spec validation with ID %s is in state %s", validationResult.ID, validationResult.ResultStatus))
+
+	data.SetId(validationResult.ID)
+	_ = data.Set("validation_id", validationResult.ID)
+	_ = data.Set("validation_status", validationResult.ResultStatus)
+
+	checks := make([]map[string]interface{}, 0, len(validationResult.ValidationChecks))
+	for _, check := range validationResult.ValidationChecks {
+		if check == nil {
+			continue
+		}
+		checks = append(checks, map[string]interface{}{
+			"description":   check.Description,
+			"result_status": check.ResultStatus,
+			"severity":      check.Severity,
+		})
+	}
+	_ = data.Set("validation_checks", checks)
+
+	return nil
+}
+
+// invokeBringupWorkflow starts the bring-up, or resumes the last bring-up if it did not complete successfully: a failed
+// bring-up is retried from the failed step and a running one is waited for.
+func invokeBringupWorkflow(ctx context.Context, client *api_client.CloudBuilderClient, sddcSpec *models.SDDCSpec, lastBringup *models.SDDCTask, revalidate bool, opts ...sddc_api.ClientOption) (string, diag.Diagnostics) {
 	var bringUpID string
+	if lastBringup != nil && lastBringup.Status == "IN_PROGRESS" {
+		tflog.Info(ctx, fmt.Sprintf("Bring-Up workflow with ID %s is in progress, waiting for it", lastBringup.ID))
+		return lastBringup.ID, nil
+	}
 	if lastBringup != nil && lastBringup.Status != "COMPLETED_WITH_SUCCESS" {
 		bringUpID = lastBringup.ID
-		diags := validateBringupSpec(ctx, client, sddcSpec)
-		if diags != nil {
-			return bringUpID, diags
+		if revalidate {
+			_, diags := validateBringupSpec(ctx, client, sddcSpec, opts...)
+			if diags != nil {
+				return bringUpID, diags
+			}
 		}
 
 		retryBringupParams := sddc_api.NewRetrySDDCParamsWithContext(ctx).
 			WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(bringUpID).WithSDDCSpec(sddcSpec)
-		okResponse, acceptedResponse, err := client.ApiClient.SDDC.RetrySDDC(retryBringupParams)
+		okResponse, acceptedResponse, err := client.ApiClient.SDDC.RetrySDDC(retryBringupParams, opts...)
 		if okResponse != nil {
 			bringUpID = okResponse.Payload.ID
 		}
@@ -280,10 +867,12 @@ func invokeBringupWorkflow(ctx context.Context, client *api_client.CloudBuilderC
 			bringUpID = acceptedResponse.Payload.ID
 		}
 		if err != nil {
-			return "", diag.FromErr(err)
+			return "", validation_utils.ConvertVcfErrorToDiag(err)
 		}
+
+		tflog.Info(ctx, fmt.Sprintf("Bring-Up workflow with ID %s in state %s has been resumed", bringUpID, lastBringup.Status))
 	} else {
-		diags := validateBringupSpec(ctx, client, sddcSpec)
+		_, diags := validateBringupSpec(ctx, client, sddcSpec, opts...)
 		if diags != nil {
 			return bringUpID, diags
 		}
@@ -291,7 +880,7 @@ func invokeBringupWorkflow(ctx context.Context, client *api_client.CloudBuilderC
 		bringupParams := sddc_api.NewStartBringupParamsWithContext(ctx).
 			WithTimeout(constants.DefaultVcfApiCallTimeout).WithSDDCSpec(sddcSpec)
 
-		okResponse, acceptedResponse, err := client.ApiClient.SDDC.StartBringup(bringupParams)
+		okResponse, acceptedResponse, err := client.ApiClient.SDDC.StartBringup(bringupParams, opts...)
 		if okResponse != nil {
 			bringUpID = okResponse.Payload.ID
 		}
@@ -307,12 +896,14 @@ func invokeBringupWorkflow(ctx context.Context, client *api_client.CloudBuilderC
 	return bringUpID, nil
 }
 
-func waitForBringupProcess(ctx context.Context, bringUpID string, client *api_client.CloudBuilderClient) diag.Diagnostics {
+func waitForBringupProcess(ctx context.Context, data *schema.ResourceData, bringUpID string, client *api_client.CloudBuilderClient) diag.Diagnostics {
+	lastStage := ""
 	for {
 		task, err := getBringUp(ctx, bringUpID, client)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		lastStage = reportBringupProgress(ctx, data, task, lastStage)
 
 		if task.Status == "IN_PROGRESS" {
 			time.Sleep(20 * time.Second)
@@ -328,6 +919,46 @@ func waitForBringupProcess(ctx context.Context, bringUpID string, client *api_cl
 	}
 }
 
+// bringupStage returns the description of the failed step of the bring-up or of the step in progress, with the
+// number of steps completed.
+func bringupStage(task *models.SDDCTask) string {
+	var current *models.SDDCSubTask
+	completed := 0
+	for _, subTask := range task.SDDCSubTasks {
+		if subTask == nil {
+			continue
+		}
+		switch subTask.Status {
+		case "COMPLETED_WITH_SUCCESS":
+			completed++
+		case "COMPLETED_WITH_FAILURE":
+			current = subTask
+		case "IN_PROGRESS":
+			if current == nil {
+				current = subTask
+			}
+		}
+	}
+	if current == nil {
+		return ""
+	}
+	description := current.Description
+	if len(description) == 0 {
+		description = current.Name
+	}
+	return fmt.Sprintf("%s (%d of %d steps completed)", description, completed, len(task.SDDCSubTasks))
+}
+
+// reportBringupProgress records the current stage of the bring-up and logs it when it changed since lastStage.
+func reportBringupProgress(ctx context.Context, data *schema.ResourceData, task *models.SDDCTask, lastStage string) string {
+	stage := bringupStage(task)
+	_ = data.Set("current_stage", stage)
+	if stage != lastStage && len(stage) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Bring-Up workflow with ID %s is in state %s: %s", task.ID, task.Status, stage))
+	}
+	return stage
+}
+
 func getLastBringUp(ctx context.Context, client *api_client.CloudBuilderClient) (*models.SDDCTask, error) {
 	retrieveAllSddcsResp, err := client.ApiClient.SDDC.GetBringupTasks(
 		sddc_api.NewGetBringupTasksParamsWithTimeout(constants.DefaultVcfApiCallTimeout).WithContext(ctx))
@@ -340,12 +971,12 @@ func getLastBringUp(ctx context.Context, client *api_client.CloudBuilderClient)
 	return nil, nil
 }
 
-func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderClient, sddcSpec *models.SDDCSpec) diag.Diagnostics {
+func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderClient, sddcSpec *models.SDDCSpec, opts ...sddc_api.ClientOption) (*models.Validation, diag.Diagnostics) {
 	validateSddcSpec := sddc_api.NewValidateBringupSpecParams().WithContext(ctx).
 		WithTimeout(constants.DefaultVcfApiCallTimeout).WithSDDCSpec(sddcSpec).WithRedo(utils.ToBoolPointer(true))
 
 	var validationResponse *models.Validation
-	okResponse, acceptedResponse, err := client.ApiClient.SDDC.ValidateBringupSpec(validateSddcSpec)
+	okResponse, acceptedResponse, err := client.ApiClient.SDDC.ValidateBringupSpec(validateSddcSpec, opts...)
 	if okResponse != nil {
 		validationResponse = okResponse.Payload
 	}
@@ -353,10 +984,10 @@ func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderCli
 		validationResponse = acceptedResponse.Payload
 	}
 	if err != nil {
-		return validation_utils.ConvertVcfErrorToDiag(err)
+		return nil, validation_utils.ConvertVcfErrorToDiag(err)
 	}
 	if validation_utils.HasValidationFailed(validationResponse) {
-		return validation_utils.ConvertValidationResultToDiag(validationResponse)
+		return validationResponse, validation_utils.ConvertValidationResultToDiag(validationResponse)
 	}
 	validationId := validationResponse.ID
 	for {
@@ -365,7 +996,7 @@ func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderCli
 		getSddcValidationParams.SetID(validationId)
 		getValidationResponse, err := client.ApiClient.SDDC.GetBringupValidation(getSddcValidationParams)
 		if err != nil {
-			return validation_utils.ConvertVcfErrorToDiag(err)
+			return nil, validation_utils.ConvertVcfErrorToDiag(err)
 		}
 		validationResponse = getValidationResponse.Payload
 		if validation_utils.HaveValidationChecksFinished(validationResponse.ValidationChecks) {
@@ -374,13 +1005,13 @@ func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderCli
 		time.Sleep(10 * time.Second)
 	}
 	if err != nil {
-		return validation_utils.ConvertVcfErrorToDiag(err)
+		return nil, validation_utils.ConvertVcfErrorToDiag(err)
 	}
 	if validation_utils.HasValidationFailed(validationResponse) {
-		return validation_utils.ConvertValidationResultToDiag(validationResponse)
+		return validationResponse, validation_utils.ConvertValidationResultToDiag(validationResponse)
 	}
 
-	return nil
+	return validationResponse, nil
 }
 
 func getBringUp(ctx context.Context, bringupId string, client *api_client.CloudBuilderClient) (*models.SDDCTask, error) {
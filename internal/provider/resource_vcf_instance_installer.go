@@ -0,0 +1,214 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/datastores"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validation_utils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+// installerMinimumVersion is the first VCF version deployed with the VCF Installer instead of Cloud Builder.
+var installerMinimumVersion = version.Must(version.NewVersion("9.0"))
+
+// isInstallerVersion tells whether the instance of the VCF version is deployed with the VCF Installer.
+func isInstallerVersion(vcfVersion string) bool {
+	if len(vcfVersion) == 0 {
+		return false
+	}
+	parsedVersion, err := version.NewVersion(vcfVersion)
+	return err == nil && parsedVersion.GreaterThanOrEqual(installerMinimumVersion)
+}
+
+// buildInstallerSpec builds the deployment spec of the VCF Installer. The properties of spec_json the SDK has no model
+// for, e.g. the VCF Operations and VCF Automation specs of VCF 9, are kept as is.
+func buildInstallerSpec(data *schema.ResourceData) (map[string]interface{}, error) {
+	installerSpec, err := buildSddcSpecProperties(data)
+	if err != nil {
+		return nil, err
+	}
+	installerSpec["version"] = data.Get("version").(string)
+
+	datastoreSpec, err := getPrincipalStorageSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	if datastoreSpec != nil {
+		installerSpec["datastoreSpec"] = datastoreSpec
+		delete(installerSpec, "vsanSpec")
+	}
+
+	return installerSpec, nil
+}
+
+// getPrincipalStorageSpec returns the NFS or VMFS on FC principal storage of the management domain, nil for vSAN.
+func getPrincipalStorageSpec(data *schema.ResourceData) (*models.DatastoreSpec, error) {
+	if nfsDatastore, ok := data.GetOk("nfs_datastore"); ok {
+		nfsDatastoreSpec, err := datastores.TryConvertToNfsDatastoreSpec(nfsDatastore.([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		return &models.DatastoreSpec{NfsDatastoreSpecs: []*models.NfsDatastoreSpec{nfsDatastoreSpec}}, nil
+	}
+	if vmfsDatastore, ok := data.GetOk("vmfs_datastore"); ok {
+		vmfsDatastoreObject := vmfsDatastore.([]interface{})[0].(map[string]interface{})
+		vmfsDatastoreSpec, err := datastores.TryConvertToVmfsDatastoreSpec(map[string]interface{}{
+			"datastore_names": utils.ToStringSlice(vmfsDatastoreObject["datastore_names"].([]interface{})),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &models.DatastoreSpec{VmfsDatastoreSpec: vmfsDatastoreSpec}, nil
+	}
+	return nil, nil
+}
+
+func resourceVcfInstanceInstallerCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api_client.CloudBuilderClient).NewInstallerClient()
+
+	installerSpec, err := buildInstallerSpec(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.Connect(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	lastDeployment, err := getLastInstallerDeployment(ctx, client)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		return diag.FromErr(err)
+	}
+
+	deploymentID, diags := invokeInstallerWorkflow(ctx, client, installerSpec, lastDeployment, data.Get("revalidate_on_resume").(bool))
+	if diags != nil {
+		return validation_utils.AttachAttributePaths(diags, sddcSpecAttributePaths(data))
+	}
+	data.SetId(deploymentID)
+
+	diags = waitForInstallerDeployment(ctx, data, deploymentID, client)
+	if diags != nil {
+		return diags
+	}
+
+	return resourceVcfInstanceRead(ctx, data, meta)
+}
+
+func getLastInstallerDeployment(ctx context.Context, client *api_client.InstallerClient) (*models.SDDCTask, error) {
+	deployments := &models.PageOfSDDCTask{}
+	if err := client.Do(ctx, http.MethodGet, "/v1/sddcs", nil, deployments); err != nil {
+		return nil, err
+	}
+	if len(deployments.Elements) > 0 {
+		return deployments.Elements[0], nil
+	}
+	return nil, nil
+}
+
+func validateInstallerSpec(ctx context.Context, client *api_client.InstallerClient, installerSpec map[string]interface{}) (*models.Validation, diag.Diagnostics) {
+	validationResponse := &models.Validation{}
+	if err := client.Do(ctx, http.MethodPost, "/v1/sddcs/validations", installerSpec, validationResponse); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if validation_utils.HasValidationFailed(validationResponse) {
+		return validationResponse, validation_utils.ConvertValidationResultToDiag(validationResponse)
+	}
+	path := fmt.Sprintf("/v1/sddcs/validations/%s", validationResponse.ID)
+	for {
+		validationResponse = &models.Validation{}
+		if err := client.Do(ctx, http.MethodGet, path, nil, validationResponse); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if validation_utils.HaveValidationChecksFinished(validationResponse.ValidationChecks) {
+			break
+		}
+		time.Sleep(10 * time.Second)
+	}
+	if validation_utils.HasValidationFailed(validationResponse) {
+		return validationResponse, validation_utils.ConvertValidationResultToDiag(validationResponse)
+	}
+
+	return validationResponse, nil
+}
+
+// invokeInstallerWorkflow starts the deployment with the VCF Installer, or resumes the last deployment as
+// invokeBringupWorkflow does with Cloud Builder.
+func invokeInstallerWorkflow(ctx context.Context, client *api_client.InstallerClient, installerSpec map[string]interface{}, lastDeployment *models.SDDCTask, revalidate bool) (string, diag.Diagnostics) {
+	if lastDeployment != nil && lastDeployment.Status == "IN_PROGRESS" {
+		tflog.Info(ctx, fmt.Sprintf("Deployment with ID %s is in progress, waiting for it", lastDeployment.ID))
+		return lastDeployment.ID, nil
+	}
+
+	resume := lastDeployment != nil && lastDeployment.Status != "COMPLETED_WITH_SUCCESS"
+	if !resume || revalidate {
+		if _, diags := validateInstallerSpec(ctx, client, installerSpec); diags != nil {
+			return "", diags
+		}
+	}
+
+	deployment := &models.SDDCTask{}
+	if resume {
+		path := fmt.Sprintf("/v1/sddcs/%s", lastDeployment.ID)
+		if err := client.Do(ctx, http.MethodPatch, path, installerSpec, deployment); err != nil {
+			return "", diag.FromErr(err)
+		}
+		if len(deployment.ID) == 0 {
+			deployment.ID = lastDeployment.ID
+		}
+		tflog.Info(ctx, fmt.Sprintf("Deployment with ID %s in state %s has been resumed", deployment.ID, lastDeployment.Status))
+	} else {
+		if err := client.Do(ctx, http.MethodPost, "/v1/sddcs", installerSpec, deployment); err != nil {
+			return "", diag.FromErr(err)
+		}
+		tflog.Info(ctx, fmt.Sprintf("Deployment with ID %s has started", deployment.ID))
+	}
+
+	return deployment.ID, nil
+}
+
+func waitForInstallerDeployment(ctx context.Context, data *schema.ResourceData, deploymentID string, client *api_client.InstallerClient) diag.Diagnostics {
+	path := fmt.Sprintf("/v1/sddcs/%s", deploymentID)
+	lastStage := ""
+	for {
+		task := &models.SDDCTask{}
+		if err := client.Do(ctx, http.MethodGet, path, nil, task); err != nil {
+			return diag.FromErr(err)
+		}
+		lastStage = reportBringupProgress(ctx, data, task, lastStage)
+
+		if task.Status == "IN_PROGRESS" {
+			time.Sleep(20 * time.Second)
+			continue
+		}
+
+		if task.Status == "COMPLETED_WITH_FAILURE" {
+			err := fmt.Errorf("Task with ID = %s , Name: %q is in state %s", deploymentID, task.Name, task.Status)
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+}
+
+func getInstallerSddcManagerInfo(ctx context.Context, deploymentID string, client *api_client.InstallerClient) (*models.SDDCManagerInfo, error) {
+	sddcManagerInfo := &models.SDDCManagerInfo{}
+	path := fmt.Sprintf("/v1/sddcs/%s/sddc-manager", deploymentID)
+	if err := client.Do(ctx, http.MethodGet, path, nil, sddcManagerInfo); err != nil {
+		return nil, err
+	}
+	return sddcManagerInfo, nil
+}
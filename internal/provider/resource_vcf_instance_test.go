@@ -8,16 +8,22 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
 
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/terraform-provider-vcf/internal/sddc"
+	validation_utils "github.com/vmware/terraform-provider-vcf/internal/validation"
 )
 
 func TestAccResourceVcfSddcBasic(t *testing.T) {
@@ -41,6 +47,24 @@ func TestAccResourceVcfSddcBasic(t *testing.T) {
 	})
 }
 
+func TestAccResourceVcfSddcValidateOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: muxedFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVcfSddcConfigValidateOnly(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_instance.sddc_1", "validation_id"),
+					resource.TestCheckResourceAttr("vcf_instance.sddc_1", "validation_status", "SUCCEEDED"),
+					resource.TestCheckResourceAttrSet("vcf_instance.sddc_1", "validation_checks.#"),
+					resource.TestCheckNoResourceAttr("vcf_instance.sddc_1", "sddc_manager_id"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSddcResourceExists() resource.TestCheckFunc {
 	return func(state *terraform.State) error {
 		for _, rs := range state.RootModule().Resources {
@@ -65,6 +89,12 @@ func testAccCheckSddcResourceExists() resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckVcfSddcConfigValidateOnly() string {
+	return strings.Replace(testAccCheckVcfSddcConfigBasic(), `instance_id = "sddcId-1001"`,
+		`instance_id = "sddcId-1001"
+	  validate_only = true`, 1)
+}
+
 func testAccCheckVcfSddcConfigBasic() string {
 	return fmt.Sprintf(
 		`resource "vcf_instance" "sddc_1" {
@@ -479,7 +509,8 @@ func TestVcfInstanceSchemaParse(t *testing.T) {
 		},
 	}
 	var testResourceData = schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), input)
-	sddcSpec := buildSddcSpec(testResourceData)
+	sddcSpec, err := buildSddcSpec(testResourceData)
+	assert.NoError(t, err)
 	assert.Equal(t, *sddcSpec.SDDCID, "sddcId-1001")
 	assert.Equal(t, sddcSpec.DvSwitchVersion, "7.0.0")
 	assert.Equal(t, sddcSpec.SkipEsxThumbprintValidation, true)
@@ -514,7 +545,7 @@ func TestVcfInstanceSchemaParse(t *testing.T) {
 	assert.Equal(t, *sddcSpec.NSXTSpec.Vip, "10.0.0.30")
 	assert.Equal(t, *sddcSpec.NSXTSpec.VipFqdn, "vip-nsx-mgmt")
 	assert.Equal(t, sddcSpec.NSXTSpec.NSXTLicense, "XXX")
-	assert.Equal(t, sddcSpec.NSXTSpec.TransportVlanID, int32(0))
+	assert.Equal(t, sddcSpec.NSXTSpec.TransportVlanID, utils.ToInt32Pointer(0))
 	assert.Equal(t, *sddcSpec.NSXTSpec.OverLayTransportZone.ZoneName, "overlay-tz")
 	assert.Equal(t, *sddcSpec.NSXTSpec.OverLayTransportZone.NetworkName, "net-overlay")
 	assert.Equal(t, sddcSpec.VSANSpec.LicenseFile, "XXX")
@@ -544,7 +575,7 @@ func TestVcfInstanceSchemaParse(t *testing.T) {
 	assert.Equal(t, *sddcSpec.ClusterSpec.ResourcePoolSpecs[1].MemoryReservationExpandable, false)
 	assert.Equal(t, sddcSpec.ClusterSpec.ResourcePoolSpecs[1].MemoryReservationMb, int64(1000))
 	assert.Equal(t, sddcSpec.ClusterSpec.ResourcePoolSpecs[1].MemorySharesLevel, "normal")
-	assert.Equal(t, sddcSpec.ClusterSpec.ResourcePoolSpecs[1].MemorySharesValue, int32(10))
+	assert.Equal(t, sddcSpec.ClusterSpec.ResourcePoolSpecs[1].MemorySharesValue, utils.ToInt32Pointer(10))
 	assert.Equal(t, *sddcSpec.PscSpecs[0].AdminUserSSOPassword, "TestTest123!")
 	assert.Equal(t, sddcSpec.PscSpecs[0].PscSSOSpec.SSODomain, "vsphere.local")
 	assert.Equal(t, sddcSpec.VcenterSpec.VcenterIP, "10.0.0.6")
@@ -562,3 +593,168 @@ func TestVcfInstanceSchemaParse(t *testing.T) {
 	assert.Equal(t, sddcSpec.HostSpecs[0].IPAddressPrivate.Cidr, "")
 	assert.Equal(t, sddcSpec.HostSpecs[0].IPAddressPrivate.Gateway, "10.0.0.250")
 }
+
+func TestVcfInstanceSpecJsonMerge(t *testing.T) {
+	input := map[string]interface{}{
+		"instance_id": "sddcId-1002",
+		"ntp_servers": []interface{}{"10.0.0.251"},
+		"spec_json": `{
+			"sddcId": "sddcId-1001",
+			"managementPoolName": "sfo-m01-np01",
+			"ntpServers": ["10.0.0.250"],
+			"skipGatewayPingValidation": true,
+			"dnsSpec": {"domain": "vsphere.local", "nameserver": "10.0.0.250"}
+		}`,
+	}
+	var testResourceData = schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), input)
+	sddcSpec, err := buildSddcSpec(testResourceData)
+	assert.NoError(t, err)
+	assert.Equal(t, *sddcSpec.SDDCID, "sddcId-1002")
+	assert.Equal(t, sddcSpec.NtpServers, []string{"10.0.0.251"})
+	assert.Equal(t, sddcSpec.ManagementPoolName, "sfo-m01-np01")
+	assert.Equal(t, sddcSpec.SkipGatewayPingValidation, true)
+	assert.Equal(t, *sddcSpec.DNSSpec.Domain, "vsphere.local")
+	assert.Equal(t, sddcSpec.DNSSpec.Nameserver, "10.0.0.250")
+	assert.Equal(t, *sddcSpec.TaskName, "workflowconfig/workflowspec-ems.json")
+}
+
+func TestVcfInstanceDvsNsxtSwitchConfig(t *testing.T) {
+	input := map[string]interface{}{
+		"dv_switch_version": "8.0.3",
+		"dvs": []interface{}{
+			map[string]interface{}{
+				"dvs_name": "sfo-m01-cl01-vds01",
+				"networks": []interface{}{"MANAGEMENT", "VMOTION", "VSAN"},
+				"nsxt_switch_config": []interface{}{
+					map[string]interface{}{
+						"host_switch_operational_mode": "STANDARD",
+						"transport_zone": []interface{}{
+							map[string]interface{}{
+								"name":           "sfo-m01-tz-overlay01",
+								"transport_type": "OVERLAY",
+							},
+						},
+					},
+				},
+				"vmnics_to_uplinks": []interface{}{
+					map[string]interface{}{
+						"vds_uplink_name": "uplink1",
+						"nsx_uplink_name": "uplink-1",
+					},
+				},
+			},
+		},
+	}
+	var testResourceData = schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), input)
+	sddcSpec, err := buildSddcSpec(testResourceData)
+	assert.NoError(t, err)
+	assert.Equal(t, sddcSpec.DvsSpecs[0].NSXTSwitchConfig.HostSwitchOperationalMode, "STANDARD")
+	assert.Equal(t, sddcSpec.DvsSpecs[0].NSXTSwitchConfig.TransportZones[0].Name, "sfo-m01-tz-overlay01")
+	assert.Equal(t, *sddcSpec.DvsSpecs[0].NSXTSwitchConfig.TransportZones[0].TransportType, "OVERLAY")
+	assert.Equal(t, *sddcSpec.DvsSpecs[0].VmnicsToUplinks[0].VdsUplinkName, "uplink1")
+	assert.Equal(t, *sddcSpec.DvsSpecs[0].VmnicsToUplinks[0].NsxUplinkName, "uplink-1")
+	assert.NoError(t, sddc.ValidateDvsSpecs(sddcSpec.DvSwitchVersion, sddcSpec.DvsSpecs))
+	assert.Error(t, sddc.ValidateDvsSpecs("8.0.0", sddcSpec.DvsSpecs))
+}
+
+func TestVcfInstanceInstallerSpec(t *testing.T) {
+	input := map[string]interface{}{
+		"version":     "9.0.0.0",
+		"instance_id": "sddcId-1002",
+		"spec_json": `{
+			"sddcId": "sddcId-1001",
+			"vcfOperationsSpec": {"nodes": [{"hostname": "vcf-ops-1"}]}
+		}`,
+	}
+	var testResourceData = schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), input)
+	assert.True(t, isInstallerVersion(testResourceData.Get("version").(string)))
+	installerSpec, err := buildInstallerSpec(testResourceData)
+	assert.NoError(t, err)
+	assert.Equal(t, installerSpec["sddcId"], "sddcId-1002")
+	assert.Equal(t, installerSpec["version"], "9.0.0.0")
+	assert.Contains(t, installerSpec, "vcfOperationsSpec")
+	assert.False(t, isInstallerVersion("5.2.1"))
+}
+
+func TestVcfInstanceInstallerNfsPrincipalStorage(t *testing.T) {
+	input := map[string]interface{}{
+		"version":   "9.0.0.0",
+		"spec_json": `{"vsanSpec": {"datastoreName": "sfo-m01-cl01-ds-vsan01"}}`,
+		"nfs_datastore": []interface{}{
+			map[string]interface{}{
+				"datastore_name": "sfo-m01-cl01-ds-nfs01",
+				"path":           "/nfs_mount/mgmt",
+				"read_only":      false,
+				"server_name":    "10.0.0.250",
+			},
+		},
+	}
+	var testResourceData = schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), input)
+	installerSpec, err := buildInstallerSpec(testResourceData)
+	assert.NoError(t, err)
+	assert.NotContains(t, installerSpec, "vsanSpec")
+	datastoreSpec := installerSpec["datastoreSpec"].(*models.DatastoreSpec)
+	assert.Equal(t, *datastoreSpec.NfsDatastoreSpecs[0].DatastoreName, "sfo-m01-cl01-ds-nfs01")
+	assert.Equal(t, *datastoreSpec.NfsDatastoreSpecs[0].NasVolume.Path, "/nfs_mount/mgmt")
+}
+
+func TestVcfInstanceValidationAttributePaths(t *testing.T) {
+	input := map[string]interface{}{
+		"ntp_servers": []interface{}{"10.0.0.250"},
+		"host": []interface{}{
+			map[string]interface{}{
+				"hostname": "esxi-1",
+				"ip_address_private": []interface{}{
+					map[string]interface{}{
+						"ip_address": "10.0.0.100",
+					},
+				},
+			},
+			map[string]interface{}{
+				"hostname": "esxi-2",
+			},
+		},
+	}
+	var testResourceData = schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), input)
+	diags := validation_utils.AttachAttributePaths(diag.Diagnostics{
+		{Severity: diag.Error, Summary: "ESXi Host Readiness Check", Detail: "Cannot connect to esxi-2"},
+		{Severity: diag.Error, Summary: "Network Connectivity Validation", Detail: "Host 10.0.0.100 is not reachable"},
+		{Severity: diag.Error, Summary: "NTP Server Validation", Detail: "NTP server 10.0.0.250 does not respond"},
+	}, sddcSpecAttributePaths(testResourceData))
+	assert.Equal(t, cty.GetAttrPath("host").IndexInt(1).GetAttr("hostname"), diags[0].AttributePath)
+	assert.Equal(t, cty.GetAttrPath("host").IndexInt(0).GetAttr("ip_address_private").IndexInt(0).GetAttr("ip_address"),
+		diags[1].AttributePath)
+	assert.Equal(t, cty.GetAttrPath("ntp_servers").IndexInt(0), diags[2].AttributePath)
+}
+
+func TestVcfInstanceSubscriptionLicensing(t *testing.T) {
+	input := map[string]interface{}{
+		"subscription_licensing": true,
+		"spec_json":              `{"sddcId": "sddcId-1001", "newerCloudBuilderProperty": "value"}`,
+	}
+	var testResourceData = schema.TestResourceDataRaw(t, resourceVcfInstanceSchema(), input)
+	specProperties, err := buildSddcSpecProperties(testResourceData)
+	assert.NoError(t, err)
+	assert.Equal(t, specProperties["subscriptionLicensing"], true)
+	assert.Equal(t, specProperties["newerCloudBuilderProperty"], "value")
+	assert.Equal(t, specProperties["sddcId"], "sddcId-1001")
+}
+
+func TestVcfInstanceBringupStage(t *testing.T) {
+	task := &models.SDDCTask{
+		SDDCSubTasks: []*models.SDDCSubTask{
+			{Name: "DeployVcenter", Description: "Deploy vCenter Server", Status: "COMPLETED_WITH_SUCCESS"},
+			{Name: "DeployNsx", Description: "Deploy NSX Manager", Status: "IN_PROGRESS"},
+			{Name: "ConfigureVsan", Status: "INITIALIZED"},
+		},
+	}
+	assert.Equal(t, "Deploy NSX Manager (1 of 3 steps completed)", bringupStage(task))
+
+	task.SDDCSubTasks[1].Status = "COMPLETED_WITH_FAILURE"
+	task.SDDCSubTasks[2].Status = "IN_PROGRESS"
+	assert.Equal(t, "Deploy NSX Manager (1 of 3 steps completed)", bringupStage(task))
+
+	task.SDDCSubTasks[1].Status = "COMPLETED_WITH_SUCCESS"
+	task.SDDCSubTasks[2].Status = "COMPLETED_WITH_SUCCESS"
+	assert.Equal(t, "", bringupStage(task))
+}
@@ -0,0 +1,134 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/certificates"
+	"github.com/vmware/terraform-provider-vcf/internal/cluster"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/domain"
+	"github.com/vmware/terraform-provider-vcf/internal/network"
+)
+
+// The names of the resources created by the acceptance tests, only those are deleted by the sweepers.
+var (
+	sweepDomainNames      = []string{"sfo-w01-vc01"}
+	sweepClusterNames     = []string{"sfo-m01-cl01", "sfo-w02-cl02"}
+	sweepNetworkPoolNames = []string{"engineering-pool", "cluster-pool", "terraform-acc-pool-1", "terraform-acc-pool-2"}
+	sweepOpenSslCaNames   = []string{"test.openssl.eng.vmware.com"}
+	sweepHostFqdnEnvVars  = []string{
+		constants.VcfTestHost1Fqdn,
+		constants.VcfTestHost2Fqdn,
+		constants.VcfTestHost3Fqdn,
+		constants.VcfTestHost4Fqdn,
+		constants.VcfTestHost5Fqdn,
+		constants.VcfTestHost6Fqdn,
+		constants.VcfTestHost7Fqdn,
+		constants.VcfTestHost8Fqdn,
+	}
+)
+
+// TestMain runs the sweepers instead of the tests when the -sweep flag is set, e.g.
+// go test ./internal/provider -v -sweep=all.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// The sweepers run in the reverse order of the creation of the resources: the domains first, which deletes their
+// clusters, then the other clusters, which releases their hosts, then the hosts, which releases their network pools.
+func init() {
+	resource.AddTestSweepers("vcf_domain", &resource.Sweeper{
+		Name: "vcf_domain",
+		F: func(_ string) error {
+			vcfClient, err := sweepSddcManagerClient()
+			if err != nil {
+				return err
+			}
+			return domain.SweepDomains(context.Background(), vcfClient, sweepDomainNames)
+		},
+	})
+	resource.AddTestSweepers("vcf_cluster", &resource.Sweeper{
+		Name:         "vcf_cluster",
+		Dependencies: []string{"vcf_domain"},
+		F: func(_ string) error {
+			vcfClient, err := sweepSddcManagerClient()
+			if err != nil {
+				return err
+			}
+			return cluster.SweepClusters(context.Background(), vcfClient, sweepClusterNames)
+		},
+	})
+	resource.AddTestSweepers("vcf_host", &resource.Sweeper{
+		Name:         "vcf_host",
+		Dependencies: []string{"vcf_cluster"},
+		F: func(_ string) error {
+			vcfClient, err := sweepSddcManagerClient()
+			if err != nil {
+				return err
+			}
+			return cluster.SweepHosts(context.Background(), vcfClient, sweepEnvValues(sweepHostFqdnEnvVars...))
+		},
+	})
+	resource.AddTestSweepers("vcf_network_pool", &resource.Sweeper{
+		Name:         "vcf_network_pool",
+		Dependencies: []string{"vcf_host"},
+		F: func(_ string) error {
+			vcfClient, err := sweepSddcManagerClient()
+			if err != nil {
+				return err
+			}
+			return network.SweepNetworkPools(context.Background(), vcfClient.ApiClient, sweepNetworkPoolNames)
+		},
+	})
+	resource.AddTestSweepers("vcf_certificate_authority", &resource.Sweeper{
+		Name: "vcf_certificate_authority",
+		F: func(_ string) error {
+			vcfClient, err := sweepSddcManagerClient()
+			if err != nil {
+				return err
+			}
+			identifiers := append(sweepEnvValues(constants.VcfTestMsftCaServerUrl), sweepOpenSslCaNames...)
+			return certificates.SweepCertificateAuthorities(context.Background(), vcfClient.ApiClient, identifiers)
+		},
+	})
+}
+
+// sweepSddcManagerClient connects to the SDDC Manager instance of the acceptance tests.
+func sweepSddcManagerClient() (*api_client.SddcManagerClient, error) {
+	url := os.Getenv(constants.VcfTestUrl)
+	username := os.Getenv(constants.VcfTestUsername)
+	password := os.Getenv(constants.VcfTestPassword)
+	if url == "" || username == "" || password == "" {
+		return nil, fmt.Errorf("%s, %s and %s must be set for the sweepers",
+			constants.VcfTestUrl, constants.VcfTestUsername, constants.VcfTestPassword)
+	}
+	allowUnverifiedTls, _ := strconv.ParseBool(os.Getenv(constants.VcfTestAllowUnverifiedTls))
+
+	vcfClient := api_client.NewSddcManagerClient(username, password, url, allowUnverifiedTls)
+	if err := vcfClient.Connect(); err != nil {
+		return nil, err
+	}
+	return vcfClient, nil
+}
+
+// sweepEnvValues returns the values of the environment variables which are set.
+func sweepEnvValues(envVars ...string) []string {
+	values := make([]string, 0, len(envVars))
+	for _, envVar := range envVars {
+		if value := os.Getenv(envVar); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -4,6 +4,16 @@
 
 package resource_utils
 
+import (
+	"context"
+	md52 "crypto/md5"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
 func ToBoolPointer(object interface{}) *bool {
 	if object == nil {
 		return nil
@@ -39,6 +49,19 @@ func ToStringSlice(params []interface{}) []string {
 }
 
 // CreateIdToObjectMap Creates a Map with string ID index to Object.
+// HashFields returns the MD5 hash of the joined fields, used as the ID of the data sources and resources which have
+// no ID of their own.
+func HashFields(fields []string) (string, error) {
+	md5 := md52.New()
+	_, err := io.WriteString(md5, strings.Join(fields, ""))
+
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(md5.Sum(nil)), nil
+}
+
 func CreateIdToObjectMap(objectsList []interface{}) map[string]interface{} {
 	// crete a map of new host id -> host
 	result := make(map[string]interface{})
@@ -79,3 +102,26 @@ func CalculateAddedRemovedResources(newResourcesList, oldResourcesList []interfa
 
 	return addedResources, removedResources
 }
+
+// ParallelMap calls fn for every item, at most workers calls at a time, and returns the results in the order of the
+// items. The first error cancels the context of the other calls and is returned.
+func ParallelMap[T, R any](ctx context.Context, items []T, workers int,
+	fn func(context.Context, T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for i, item := range items {
+		group.Go(func() error {
+			result, err := fn(groupCtx, item)
+			if err != nil {
+				return err
+			}
+			results[i] = result
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
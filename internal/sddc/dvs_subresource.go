@@ -5,6 +5,9 @@
 package sddc
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/vcf-sdk-go/models"
@@ -13,11 +16,17 @@ import (
 )
 
 var trafficTypeValues = []string{"VSAN", "VMOTION", "VIRTUALMACHINE", "MANAGEMENT", "NFS", "VDP", "HBR", "FAULTTOLERANCE", "ISCSI"}
+var hostSwitchOperationalModeValues = []string{"STANDARD", "ENS", "ENS_INTERRUPT"}
+var transportTypeValues = []string{"VLAN", "OVERLAY"}
+
+// DvSwitchVersionVcf52 is the version of the distributed switches of VCF 5.2, the first release which accepts
+// the NSX switch configuration and the uplink mapping of the distributed switches.
+const DvSwitchVersionVcf52 = "8.0.3"
 
 func GetDvsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
-		Required: true,
+		Optional: true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"dvs_name": {
@@ -27,7 +36,7 @@ func GetDvsSchema() *schema.Schema {
 				},
 				"is_used_by_nsxt": {
 					Type:        schema.TypeBool,
-					Description: "Flag indicating whether the DVS is used by NSX",
+					Description: "Flag indicating whether the DVS is used by NSX. Deprecated in favor of nsxt_switch_config from VCF 5.2",
 					Optional:    true,
 				},
 				"mtu": {
@@ -45,13 +54,35 @@ func GetDvsSchema() *schema.Schema {
 						Type: schema.TypeString,
 					},
 				},
-				"nioc": getNiocSchema(),
+				"nioc":               getNiocSchema(),
+				"nsxt_switch_config": getNsxtSwitchConfigSchema(),
 				"vmnics": {
 					Type:        schema.TypeList,
-					Description: "Vmnics to be attached to the DVS",
-					Required:    true,
+					Description: "Vmnics to be attached to the DVS. Either vmnics or vmnics_to_uplinks has to be set",
+					Optional:    true,
 					Elem:        &schema.Schema{Type: schema.TypeString},
 				},
+				"vmnics_to_uplinks": {
+					Type:        schema.TypeList,
+					Description: "The mapping of the DVS uplinks to the NSX switch uplinks. Requires VCF 5.2",
+					Optional:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"vds_uplink_name": {
+								Type:         schema.TypeString,
+								Description:  "The uplink name of the DVS",
+								Required:     true,
+								ValidateFunc: validation.NoZeroValues,
+							},
+							"nsx_uplink_name": {
+								Type:         schema.TypeString,
+								Description:  "The uplink name of the NSX switch",
+								Required:     true,
+								ValidateFunc: validation.NoZeroValues,
+							},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -81,6 +112,82 @@ func getNiocSchema() *schema.Schema {
 	}
 }
 
+func getNsxtSwitchConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The NSX configuration of the DVS. Requires VCF 5.2",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"host_switch_operational_mode": {
+					Type:         schema.TypeString,
+					Description:  "The operational mode of the NSX host switch. One among: STANDARD, ENS, ENS_INTERRUPT",
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(hostSwitchOperationalModeValues, false),
+				},
+				"transport_zone": {
+					Type:        schema.TypeList,
+					Description: "The transport zones associated with the DVS",
+					Required:    true,
+					MinItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Description: "The name of the transport zone",
+								Optional:    true,
+							},
+							"transport_type": {
+								Type:         schema.TypeString,
+								Description:  "The type of the transport zone. One among: VLAN, OVERLAY",
+								Required:     true,
+								ValidateFunc: validation.StringInSlice(transportTypeValues, false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateDvsSpecs verifies the distributed switch specs against the version of the distributed switches,
+// which identifies the VCF release. The version is not verified if empty.
+func ValidateDvsSpecs(dvSwitchVersion string, dvsSpecs []*models.DvsSpec) error {
+	supportsNsxtSwitchConfig := true
+	if len(dvSwitchVersion) > 0 {
+		current, err := version.NewVersion(dvSwitchVersion)
+		if err != nil {
+			return err
+		}
+		supportsNsxtSwitchConfig = current.GreaterThanOrEqual(version.Must(version.NewVersion(DvSwitchVersionVcf52)))
+	}
+
+	for _, dvsSpec := range dvsSpecs {
+		if dvsSpec == nil {
+			continue
+		}
+		dvsName := ""
+		if dvsSpec.DvsName != nil {
+			dvsName = *dvsSpec.DvsName
+		}
+		usesVcf52Fields := dvsSpec.NSXTSwitchConfig != nil || len(dvsSpec.VmnicsToUplinks) > 0
+		if usesVcf52Fields && !supportsNsxtSwitchConfig {
+			return fmt.Errorf("DVS %q: nsxt_switch_config and vmnics_to_uplinks require dv_switch_version %s (VCF 5.2) or later, got %s",
+				dvsName, DvSwitchVersionVcf52, dvSwitchVersion)
+		}
+		if dvsSpec.NSXTSwitchConfig != nil && dvsSpec.IsUsedByNSXT {
+			return fmt.Errorf("DVS %q: is_used_by_nsxt cannot be set together with nsxt_switch_config", dvsName)
+		}
+		if len(dvsSpec.Vmnics) == 0 && len(dvsSpec.VmnicsToUplinks) == 0 {
+			return fmt.Errorf("DVS %q: either vmnics or vmnics_to_uplinks has to be set", dvsName)
+		}
+	}
+
+	return nil
+}
+
 func GetDvsSpecsFromSchema(rawData []interface{}) []*models.DvsSpec {
 	var dvsSpecs []*models.DvsSpec
 	for _, dvsSpecListEntry := range rawData {
@@ -103,6 +210,12 @@ func GetDvsSpecsFromSchema(rawData []interface{}) []*models.DvsSpec {
 		if vmnicsData, ok := dvsSpecRaw["vmnics"].([]interface{}); ok {
 			dvsSpec.Vmnics = utils.ToStringSlice(vmnicsData)
 		}
+		if switchConfigData, ok := dvsSpecRaw["nsxt_switch_config"].([]interface{}); ok && len(switchConfigData) > 0 {
+			dvsSpec.NSXTSwitchConfig = getNsxtSwitchConfigFromSchema(switchConfigData)
+		}
+		if uplinksData, ok := dvsSpecRaw["vmnics_to_uplinks"].([]interface{}); ok {
+			dvsSpec.VmnicsToUplinks = getUplinkMappingsFromSchema(uplinksData)
+		}
 		dvsSpecs = append(dvsSpecs, dvsSpec)
 	}
 	return dvsSpecs
@@ -123,3 +236,33 @@ func getNiocSpecsFromSchema(rawData []interface{}) []*models.NiocSpec {
 	}
 	return niocSpecBindingsList
 }
+
+func getNsxtSwitchConfigFromSchema(rawData []interface{}) *models.NSXTSwitchConfig {
+	if rawData[0] == nil {
+		return &models.NSXTSwitchConfig{}
+	}
+	switchConfigRaw := rawData[0].(map[string]interface{})
+	switchConfig := &models.NSXTSwitchConfig{
+		HostSwitchOperationalMode: switchConfigRaw["host_switch_operational_mode"].(string),
+	}
+	for _, transportZoneListEntry := range switchConfigRaw["transport_zone"].([]interface{}) {
+		transportZoneRaw := transportZoneListEntry.(map[string]interface{})
+		switchConfig.TransportZones = append(switchConfig.TransportZones, &models.TransportZone{
+			Name:          transportZoneRaw["name"].(string),
+			TransportType: utils.ToStringPointer(transportZoneRaw["transport_type"]),
+		})
+	}
+	return switchConfig
+}
+
+func getUplinkMappingsFromSchema(rawData []interface{}) []*models.UplinkMapping {
+	var uplinkMappings []*models.UplinkMapping
+	for _, uplinkMappingListEntry := range rawData {
+		uplinkMappingRaw := uplinkMappingListEntry.(map[string]interface{})
+		uplinkMappings = append(uplinkMappings, &models.UplinkMapping{
+			VdsUplinkName: utils.ToStringPointer(uplinkMappingRaw["vds_uplink_name"]),
+			NsxUplinkName: utils.ToStringPointer(uplinkMappingRaw["nsx_uplink_name"]),
+		})
+	}
+	return uplinkMappings
+}
@@ -23,7 +23,7 @@ func GetPscSchema() *schema.Schema {
 					Type:         schema.TypeString,
 					Description:  "Admin user sso password. Password needs to be a strong password with at least one Uppercase alphabet, one lowercase alphabet, one digit and one special character specified in braces [!$%^] and 8-20 characters in length,and 3 maximum identical adjacent characters!",
 					Required:     true,
-					ValidateFunc: validation.ValidatePassword,
+					ValidateFunc: validation.SsoPasswordPolicy.ValidateFunc(),
 				},
 				"psc_sso_domain": {
 					Type:        schema.TypeString,
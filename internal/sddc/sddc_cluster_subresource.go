@@ -19,7 +19,7 @@ var resourcePoolTypeValues = []string{"management", "compute", "network"}
 func GetSddcClusterSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
-		Required: true,
+		Optional: true,
 		MaxItems: 1,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
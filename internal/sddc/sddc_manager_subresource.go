@@ -36,7 +36,7 @@ func GetSddcManagerSchema() *schema.Schema {
 					Type:         schema.TypeString,
 					Description:  "The local account is a built-in admin account (password for the break glass user admin@local) in VCF that can be used in emergency scenarios. The password of this account must be at least 12 characters long. It also must contain at-least 1 uppercase, 1 lowercase, 1 special character specified in braces [!%@$^#?] and 1 digit. In addition, a character cannot be repeated more than 3 times consecutively.",
 					Optional:     true,
-					ValidateFunc: validation_utils.ValidatePassword,
+					ValidateFunc: validation_utils.ValidateLocalAccountPassword,
 				},
 				"root_user_credentials":   getCredentialsSchema(),
 				"second_user_credentials": getCredentialsSchema(),
@@ -17,7 +17,7 @@ var teamingPolicies = []string{"loadbalance_loadbased", "loadbalance_ip", "loadb
 func GetNetworkSpecsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
-		Required: true,
+		Optional: true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"network_type": {
@@ -33,10 +33,10 @@ func GetNsxSpecSchema() *schema.Schema {
 				},
 				"root_nsx_manager_password": {
 					Type:         schema.TypeString,
-					Description:  "NSX Manager root password. Password should have 1) At least eight characters, 2) At least one lower-case letter, 3) At least one upper-case letter 4) At least one digit 5) At least one special character, 6) At least five different characters , 7) No dictionary words, 6) No palindromes",
+					Description:  "NSX Manager root password. Password should have 1) At least twelve characters, 2) At least one lower-case letter, 3) At least one upper-case letter 4) At least one digit 5) At least one special character, 6) At least five different characters , 7) No dictionary words, 6) No palindromes",
 					Required:     true,
 					Sensitive:    true,
-					ValidateFunc: validation_utils.ValidatePassword,
+					ValidateFunc: validation_utils.NsxPasswordPolicy.ValidateFunc(),
 				},
 				"ip_address_pool": {
 					Type:        schema.TypeList,
@@ -50,14 +50,14 @@ func GetNsxSpecSchema() *schema.Schema {
 					Description:  "NSX admin password. The password must be at least 12 characters long. Must contain at-least 1 uppercase, 1 lowercase, 1 special character and 1 digit. In addition, a character cannot be repeated 3 or more times consecutively.",
 					Optional:     true,
 					Sensitive:    true,
-					ValidateFunc: validation_utils.ValidatePassword,
+					ValidateFunc: validation_utils.NsxPasswordPolicy.ValidateFunc(),
 				},
 				"nsx_audit_password": {
 					Type:         schema.TypeString,
 					Description:  "NSX audit password. The password must be at least 12 characters long. Must contain at-least 1 uppercase, 1 lowercase, 1 special character and 1 digit. In addition, a character cannot be repeated 3 or more times consecutively.",
 					Optional:     true,
 					Sensitive:    true,
-					ValidateFunc: validation_utils.ValidatePassword,
+					ValidateFunc: validation_utils.NsxPasswordPolicy.ValidateFunc(),
 				},
 				"license": {
 					Type:        schema.TypeString,
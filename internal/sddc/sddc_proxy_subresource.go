@@ -0,0 +1,48 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package sddc
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+func GetProxySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The proxy server used by Cloud Builder and SDDC Manager to reach the internet",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"host": {
+					Type:         schema.TypeString,
+					Description:  "IP address or FQDN of the proxy server",
+					Required:     true,
+					ValidateFunc: validation.NoZeroValues,
+				},
+				"port": {
+					Type:         schema.TypeInt,
+					Description:  "Port of the proxy server",
+					Required:     true,
+					ValidateFunc: validation.IsPortNumber,
+				},
+			},
+		},
+	}
+}
+
+func GetProxySpecFromSchema(rawData []interface{}) *models.ProxySpec {
+	if len(rawData) <= 0 {
+		return nil
+	}
+	data := rawData[0].(map[string]interface{})
+
+	return &models.ProxySpec{
+		Host: data["host"].(string),
+		Port: int32(data["port"].(int)),
+	}
+}
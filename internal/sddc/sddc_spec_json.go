@@ -0,0 +1,25 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package sddc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// GetSddcSpecFromJson decodes a bring-up spec in the JSON format accepted by Cloud Builder.
+func GetSddcSpecFromJson(specJson string) (*models.SDDCSpec, error) {
+	sddcSpec := &models.SDDCSpec{}
+	if len(specJson) == 0 {
+		return sddcSpec, nil
+	}
+	if err := json.Unmarshal([]byte(specJson), sddcSpec); err != nil {
+		return nil, fmt.Errorf("cannot decode the bring-up spec: %w", err)
+	}
+
+	return sddcSpec, nil
+}
@@ -19,7 +19,7 @@ var storageSizes = []string{"lstorage", "xlstorage"}
 func GetVcenterSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
-		Required: true,
+		Optional: true,
 		MaxItems: 1,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
@@ -33,7 +33,7 @@ func GetVcenterSchema() *schema.Schema {
 					Description:  "vCenter root password. The password must be between 8 characters and 20 characters long. It must also contain at least one uppercase and lowercase letter, one number, and one character from '! \" # $ % & ' ( ) * + , - . / : ; < = > ? @ [ \\ ] ^ _ ` { &Iota; } ~' and all characters must be ASCII. Space is not allowed in password.",
 					Required:     true,
 					Sensitive:    true,
-					ValidateFunc: validation_utils.ValidatePassword,
+					ValidateFunc: validation_utils.VcenterPasswordPolicy.ValidateFunc(),
 				},
 				"ssh_thumbprint": {
 					Type:        schema.TypeString,
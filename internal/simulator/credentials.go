@@ -0,0 +1,125 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// The simulator updates and rotates the passwords of any account, the operations of the accounts made to fail with
+// FailCredentialOperation fail and make their credentials task fail. A credentials task completes when it is started,
+// it is recorded both as a credentials task and as a task with the same ID, as SDDC Manager does. As SDDC Manager, the
+// simulator rejects the password operations while a failed credentials task is neither cancelled nor retried.
+
+// FailCredentialOperation makes the password operations of the account of the resource fail with the message.
+func (s *Simulator) FailCredentialOperation(resourceName, userName, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failedCredentials[resourceName+"/"+userName] = message
+}
+
+func (s *Simulator) updateOrRotatePasswords(w http.ResponseWriter, r *http.Request) {
+	spec := &models.CredentialsUpdateSpec{}
+	if !readBody(w, r, spec) {
+		return
+	}
+	if spec.OperationType == nil || len(spec.Elements) == 0 {
+		writeError(w, http.StatusBadRequest, "PASSWORD_MANAGER_INVALID_SPEC", "operationType and elements are required")
+		return
+	}
+
+	for _, task := range s.credentialsTasks {
+		if task.Status == "FAILED" {
+			writeError(w, http.StatusBadRequest, "PASSWORD_MANAGER_OPERATION_IN_PROGRESS",
+				"the credentials task "+task.ID+" has failed, cancel or retry it")
+			return
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	credentialsTask := &models.CredentialsTask{
+		ID:                s.newId(),
+		Name:              "Password " + strings.ToLower(*spec.OperationType) + " operation",
+		Type:              *spec.OperationType,
+		Status:            "SUCCESSFUL",
+		CreationTimestamp: now,
+	}
+	for _, element := range spec.Elements {
+		for _, credential := range element.Credentials {
+			subTask := &models.CredentialsSubTask{
+				ID:                s.newId(),
+				ResourceName:      element.ResourceName,
+				EntityType:        stringValue(element.ResourceType),
+				Username:          stringValue(credential.Username),
+				CredentialType:    credential.CredentialType,
+				Status:            "SUCCESSFUL",
+				CreationTimestamp: now,
+			}
+			if message, failed := s.failedCredentials[element.ResourceName+"/"+subTask.Username]; failed {
+				subTask.Status = "FAILED"
+				subTask.Errors = []*models.Error{{ErrorCode: "PASSWORD_MANAGER_OPERATION_FAILED", Message: message}}
+				credentialsTask.Status = "FAILED"
+			}
+			credentialsTask.SubTasks = append(credentialsTask.SubTasks, subTask)
+		}
+	}
+	s.credentialsTasks[credentialsTask.ID] = credentialsTask
+
+	task := &models.Task{
+		ID:                  credentialsTask.ID,
+		Name:                credentialsTask.Name,
+		Type:                "CREDENTIALS_" + credentialsTask.Type,
+		Status:              "Successful",
+		CreationTimestamp:   now,
+		CompletionTimestamp: now,
+	}
+	if credentialsTask.Status == "FAILED" {
+		task.Status = "Failed"
+	}
+	s.tasks[task.ID] = task
+	writeJSON(w, http.StatusAccepted, task)
+}
+
+func (s *Simulator) getCredentialsTasks(w http.ResponseWriter, r *http.Request) {
+	result := make([]*models.CredentialsTask, 0, len(s.credentialsTasks))
+	for _, task := range s.credentialsTasks {
+		result = append(result, task)
+	}
+	slices.SortFunc(result, func(a, b *models.CredentialsTask) int {
+		return strings.Compare(b.ID, a.ID)
+	})
+	writeJSON(w, http.StatusOK, &models.PageOfCredentialsTask{Elements: result, PageMetadata: pageMetadata(len(result))})
+}
+
+func (s *Simulator) getCredentialsTask(w http.ResponseWriter, r *http.Request) {
+	if task, ok := s.credentialsTasks[r.PathValue("id")]; ok {
+		writeJSON(w, http.StatusOK, task)
+		return
+	}
+	writeNotFound(w, "credentials task", r.PathValue("id"))
+}
+
+// cancelCredentialsTask cancels a failed credentials task, SDDC Manager cannot cancel the other tasks.
+func (s *Simulator) cancelCredentialsTask(w http.ResponseWriter, r *http.Request) {
+	credentialsTask, ok := s.credentialsTasks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "credentials task", r.PathValue("id"))
+		return
+	}
+	if credentialsTask.Status != "FAILED" {
+		writeError(w, http.StatusBadRequest, "PASSWORD_MANAGER_TASK_NOT_CANCELLABLE",
+			"only a failed credentials task can be cancelled")
+		return
+	}
+	credentialsTask.Status = "USER_CANCELLED"
+	task := s.tasks[credentialsTask.ID]
+	task.Status = "Cancelled"
+	writeJSON(w, http.StatusOK, task)
+}
@@ -0,0 +1,111 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/vmware/terraform-provider-vcf/internal/fleet"
+)
+
+// The simulator also serves the fleet management API of VCF 9, for a fleet of the simulated instance and of a second
+// instance. The operations complete as soon as they are started, they fail on the disconnected instances.
+
+// FleetInstanceId is the ID of the second instance of the simulated fleet, the simulated instance has the ID of its
+// SDDC Manager.
+const FleetInstanceId = "a3e8b6d1-7f2c-4b9a-8e5d-simulator009"
+
+func newFleetInstances() []*fleet.Instance {
+	return []*fleet.Instance{
+		{
+			ID:              SddcManagerId,
+			Name:            "sfo-vcf01",
+			Version:         VcfVersion,
+			SddcManagerFqdn: SddcManagerFqdn,
+			Status:          "ACTIVE",
+		},
+		{
+			ID:              FleetInstanceId,
+			Name:            "lax-vcf01",
+			Version:         "9.0.0.0",
+			SddcManagerFqdn: "lax-vcf01.lax.rainpole.io",
+			Status:          "ACTIVE",
+		},
+	}
+}
+
+// DisconnectFleetInstance makes the instance of the fleet disconnected, the operations on it fail.
+func (s *Simulator) DisconnectFleetInstance(id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, instance := range s.inventory.fleetInstances {
+		if instance.ID == id {
+			instance.Status = "DISCONNECTED"
+		}
+	}
+}
+
+func (s *Simulator) getFleetInstances(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &fleet.PageOfInstance{Elements: s.inventory.fleetInstances})
+}
+
+func (s *Simulator) startFleetOperation(w http.ResponseWriter, r *http.Request) {
+	spec := &fleet.OperationSpec{}
+	if !readBody(w, r, spec) {
+		return
+	}
+	if !slices.Contains(fleet.OperationTypes(), spec.Type) {
+		writeError(w, http.StatusBadRequest, "FLEET_OPERATION_TYPE_INVALID",
+			fmt.Sprintf("%q is not a type of fleet operation", spec.Type))
+		return
+	}
+
+	instances := s.inventory.fleetInstances
+	if len(spec.InstanceIDs) > 0 {
+		instances = nil
+		for _, id := range spec.InstanceIDs {
+			index := slices.IndexFunc(s.inventory.fleetInstances, func(instance *fleet.Instance) bool {
+				return instance.ID == id
+			})
+			if index < 0 {
+				writeError(w, http.StatusBadRequest, "FLEET_INSTANCE_NOT_FOUND",
+					fmt.Sprintf("instance %s is not in the fleet", id))
+				return
+			}
+			instances = append(instances, s.inventory.fleetInstances[index])
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	operation := &fleet.Operation{
+		ID:                  s.newId(),
+		Type:                spec.Type,
+		Status:              fleet.OperationStatusSuccessful,
+		CreationTimestamp:   now,
+		CompletionTimestamp: now,
+	}
+	for _, instance := range instances {
+		instanceOperation := &fleet.InstanceOperation{InstanceID: instance.ID, Status: fleet.OperationStatusSuccessful}
+		if instance.Status != "ACTIVE" {
+			instanceOperation.Status = fleet.OperationStatusFailed
+			instanceOperation.ErrorMessage = fmt.Sprintf("instance %s is %s", instance.Name, instance.Status)
+			operation.Status = fleet.OperationStatusFailed
+		}
+		operation.InstanceOperations = append(operation.InstanceOperations, instanceOperation)
+	}
+	s.fleetOperations[operation.ID] = operation
+	writeJSON(w, http.StatusAccepted, operation)
+}
+
+func (s *Simulator) getFleetOperation(w http.ResponseWriter, r *http.Request) {
+	if operation, ok := s.fleetOperations[r.PathValue("id")]; ok {
+		writeJSON(w, http.StatusOK, operation)
+		return
+	}
+	writeNotFound(w, "fleet operation", r.PathValue("id"))
+}
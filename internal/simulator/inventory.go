@@ -0,0 +1,200 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/fleet"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+// The IDs of the canned inventory, stable so that the examples and the tests can refer to them.
+const (
+	ManagementDomainId  = "2f8f9c28-9e0c-4b3a-8d0c-simulator001"
+	ManagementClusterId = "5b2c3b8e-7c1a-4d5e-9f1a-simulator002"
+	ManagementPoolId    = "8d6a1f3c-2b4e-4c7d-a9e8-simulator003"
+	SddcManagerId       = "c1f0e3a2-6d5b-4e8f-b7a9-simulator004"
+
+	// SddcManagerFqdn is the FQDN the simulator reports for the SDDC Manager appliance.
+	SddcManagerFqdn = "sfo-vcf01.sfo.rainpole.io"
+	// VcfVersion is the version of VCF the simulator reports.
+	VcfVersion = "5.2.1.0"
+	// NsxVersion is the version of NSX the simulator reports, the one of VCF 5.2.1.
+	NsxVersion = "4.2.1.0.0-24304122"
+)
+
+// inventory is the state of the simulated SDDC Manager. It starts with a bring-up: a management domain with
+// a cluster of four hosts, its network pool, two spare commissioned hosts and the license keys.
+type inventory struct {
+	domains      []*models.Domain
+	clusters     []*models.Cluster
+	hosts        []*models.Host
+	networkPools []*models.NetworkPool
+	licenseKeys  []*models.LicenseKey
+	sddcManager  *models.SDDCManager
+	release      *models.Release
+	ceip         *models.CEIP
+	// fleetInstances are the instances of the simulated VCF 9 fleet
+	fleetInstances []*fleet.Instance
+}
+
+func newInventory() *inventory {
+	managementDomain := &models.Domain{
+		ID:                    ManagementDomainId,
+		Name:                  "sfo-m01",
+		Type:                  "MANAGEMENT",
+		Status:                "ACTIVE",
+		SSOID:                 "b8d2e7a4-3f1c-4a6b-9e2d-simulator005",
+		SSOName:               "vsphere.local",
+		IsManagementSSODomain: true,
+		Clusters:              []*models.ClusterReference{{ID: utils.ToStringPointer(ManagementClusterId)}},
+		VCENTERS: []*models.VcenterReference{{
+			ID:   utils.ToStringPointer("e4a7c2b9-1d3f-4e5a-8b6c-simulator006"),
+			Fqdn: "sfo-m01-vc01.sfo.rainpole.io",
+		}},
+		NSXTCluster: &models.NsxTClusterReference{
+			ID:      "f3b9d1e6-5a2c-4f7b-8d4e-simulator007",
+			Vip:     "10.11.10.65",
+			VipFqdn: "sfo-m01-nsx01.sfo.rainpole.io",
+		},
+	}
+
+	inv := &inventory{
+		domains:        []*models.Domain{managementDomain},
+		fleetInstances: newFleetInstances(),
+		networkPools: []*models.NetworkPool{{
+			ID:   ManagementPoolId,
+			Name: "sfo-m01-np01",
+			Networks: []*models.Network{
+				newNetwork("VSAN", "172.16.13", 1613),
+				newNetwork("VMOTION", "172.16.12", 1612),
+			},
+		}},
+		sddcManager: &models.SDDCManager{
+			ID:        SddcManagerId,
+			Fqdn:      SddcManagerFqdn,
+			IPAddress: "10.11.10.60",
+			Version:   VcfVersion + "-24307856",
+			Domain:    &models.DomainReference{ID: utils.ToStringPointer(ManagementDomainId), Name: managementDomain.Name},
+		},
+		release: &models.Release{
+			Product:                 utils.ToStringPointer("VCF"),
+			Version:                 utils.ToStringPointer(VcfVersion),
+			Description:             utils.ToStringPointer("VMware Cloud Foundation " + VcfVersion),
+			ReleaseDate:             utils.ToStringPointer("2024-10-09"),
+			MinCompatibleVcfVersion: utils.ToStringPointer("4.5.0.0"),
+			IsApplicable:            true,
+		},
+		ceip: &models.CEIP{
+			InstanceID: "a9c4e2f7-8b1d-4c3e-b6a5-simulator008",
+			Status:     utils.ToStringPointer("ENABLED"),
+		},
+		licenseKeys: []*models.LicenseKey{
+			newLicenseKey("ESXI", "00000-00000-00000-00000-00001", "CPU", 16, 8),
+			newLicenseKey("VSAN", "00000-00000-00000-00000-00002", "CPU", 16, 8),
+			newLicenseKey("NSXT", "00000-00000-00000-00000-00003", "CPU", 16, 8),
+		},
+	}
+
+	managementCluster := &models.Cluster{
+		ID:                   ManagementClusterId,
+		Name:                 "sfo-m01-cl01",
+		IsDefault:            true,
+		PrimaryDatastoreName: "sfo-m01-cl01-ds-vsan01",
+		PrimaryDatastoreType: "VSAN",
+	}
+	for i := 1; i <= 6; i++ {
+		host := &models.Host{
+			ID:                    fmt.Sprintf("d7e1a3b5-4c2f-4a8e-9b6d-simulator1%02d", i),
+			Fqdn:                  fmt.Sprintf("sfo01-m01-esx%02d.sfo.rainpole.io", i),
+			EsxiVersion:           "8.0.3-24022510",
+			HardwareVendor:        "Dell Inc.",
+			HardwareModel:         "PowerEdge R650",
+			CompatibleStorageType: "VSAN",
+			Networkpool:           &models.NetworkPoolReference{ID: utils.ToStringPointer(ManagementPoolId), Name: "sfo-m01-np01"},
+			IPAddresses:           []*models.IPAddress{{IPAddress: fmt.Sprintf("10.11.10.%d", 100+i), Type: "MANAGEMENT"}},
+		}
+		if i <= 4 {
+			host.Status = "ASSIGNED"
+			host.Domain = &models.DomainReference{ID: utils.ToStringPointer(ManagementDomainId)}
+			host.Cluster = &models.ClusterReference{ID: utils.ToStringPointer(ManagementClusterId)}
+			managementCluster.Hosts = append(managementCluster.Hosts, &models.HostReference{
+				ID:        host.ID,
+				Fqdn:      host.Fqdn,
+				IPAddress: host.IPAddresses[0].IPAddress,
+			})
+		} else {
+			host.Status = "UNASSIGNED_USEABLE"
+		}
+		inv.hosts = append(inv.hosts, host)
+	}
+	inv.clusters = []*models.Cluster{managementCluster}
+
+	return inv
+}
+
+// newNetwork returns a network of the management network pool in the /24 subnet with the given first three octets.
+func newNetwork(networkType, subnetPrefix string, vlanId int32) *models.Network {
+	return &models.Network{
+		ID:      ManagementPoolId + "-" + networkType,
+		Type:    networkType,
+		Subnet:  subnetPrefix + ".0",
+		Mask:    "255.255.255.0",
+		Gateway: subnetPrefix + ".1",
+		Mtu:     9000,
+		VlanID:  vlanId,
+		IPPools: []*models.IPPool{{Start: subnetPrefix + ".10", End: subnetPrefix + ".50"}},
+	}
+}
+
+func newLicenseKey(productType, key, unit string, total, used int32) *models.LicenseKey {
+	return &models.LicenseKey{
+		ID:             "license-" + key[len(key)-5:],
+		ProductType:    utils.ToStringPointer(productType),
+		Key:            utils.ToStringPointer(key),
+		Description:    utils.ToStringPointer("Simulated " + productType + " license key"),
+		ProductVersion: "8",
+		LicenseKeyValidity: &models.LicenseKeyValidity{
+			LicenseKeyStatus: "NEVER_EXPIRES",
+		},
+		LicenseKeyUsage: &models.LicenseKeyUsage{
+			LicenseUnit: unit,
+			Total:       total,
+			Used:        used,
+			Remaining:   total - used,
+		},
+	}
+}
+
+// newCertificate returns the certificate of a resource of the domain, issued by the certificate authority of the
+// simulated Active Directory. The certificate is valid for a year from now so that it never expires in the tests.
+func newCertificate(domain *models.Domain, fqdn string) *models.Certificate {
+	now := time.Now().UTC().Truncate(time.Hour)
+	return &models.Certificate{
+		Domain:                 utils.ToStringPointer(domain.Name),
+		ExpirationStatus:       utils.ToStringPointer("ACTIVE"),
+		IsInstalled:            utils.ToBoolPointer(true),
+		IssuedBy:               utils.ToStringPointer("CN=rainpole-RPL-AD01-CA, DC=rainpole, DC=io"),
+		IssuedTo:               utils.ToStringPointer(fqdn),
+		KeySize:                utils.ToStringPointer("3072"),
+		NotBefore:              utils.ToStringPointer(now.AddDate(-1, 0, 0).Format(time.RFC3339)),
+		NotAfter:               utils.ToStringPointer(now.AddDate(0, 0, 365).Format(time.RFC3339)),
+		NumberOfDaysToExpire:   utils.ToInt32Pointer(365),
+		PemEncoded:             utils.ToStringPointer("-----BEGIN CERTIFICATE-----\nsimulated\n-----END CERTIFICATE-----"),
+		PublicKey:              utils.ToStringPointer("simulated"),
+		PublicKeyAlgorithm:     utils.ToStringPointer("RSA"),
+		SerialNumber:           utils.ToStringPointer("1a:2b:3c:4d"),
+		SignatureAlgorithm:     utils.ToStringPointer("SHA256withRSA"),
+		Subject:                utils.ToStringPointer("CN=" + fqdn + ", OU=VCF, O=VMware Inc., L=Palo Alto, ST=California, C=US"),
+		SubjectAlternativeName: []string{fqdn},
+		Thumbprint:             utils.ToStringPointer("AA:BB:CC:DD"),
+		ThumbprintAlgorithm:    utils.ToStringPointer("SHA-256"),
+		Version:                utils.ToStringPointer("V3"),
+	}
+}
@@ -0,0 +1,698 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package simulator serves a fake SDDC Manager API, so that the provider can plan and apply configurations
+// without a VCF instance, e.g. in unit tests and demos. The simulator answers with the vcf-sdk-go models of a
+// canned inventory. Network pools, license keys and CEIP can be changed, host commission specs can be validated, the
+// passwords of the accounts can be updated and rotated, the SoS health checks can be run, tasks can be retried and
+// cancelled, the upgrades of the domains can be read and prechecked and operations can be run on the instances of a
+// VCF 9 fleet, the requests the simulator does not support fail with the SIMULATOR_UNSUPPORTED error code.
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/fleet"
+)
+
+// UnsupportedErrorCode is the error code of the responses to the requests the simulator does not support.
+const UnsupportedErrorCode = "SIMULATOR_UNSUPPORTED"
+
+// Simulator is a fake SDDC Manager serving the API over TLS with a self-signed certificate.
+type Simulator struct {
+	server *httptest.Server
+
+	mutex     sync.Mutex
+	inventory *inventory
+	tasks     map[string]*models.Task
+	// validations are the results of the host commission validations, by ID
+	validations map[string]*models.Validation
+	// fleetOperations are the operations run on the instances of the simulated fleet, by ID
+	fleetOperations map[string]*fleet.Operation
+	// healthChecks are the runs of the SoS health checks, by ID
+	healthChecks map[string]*sosHealthCheck
+	// failedHealthChecks are the messages of the components whose health checks are RED
+	failedHealthChecks map[string]string
+	// credentialsTasks are the password updates and rotations, by ID
+	credentialsTasks map[string]*models.CredentialsTask
+	// failedCredentials are the messages of the accounts whose password operations fail, by resource and user name
+	failedCredentials map[string]string
+	lastId            int
+	// requests counts the requests served by method and path, e.g. "GET /v1/domains"
+	requests map[string]int
+	// lockedChanges is the number of the next changes rejected as if another workflow held a lock
+	lockedChanges int
+	// failedRetries are the numbers of the next retries of the failed tasks which fail again, by ID
+	failedRetries map[string]int
+	// domainReleases are the VCF releases of the domains which differ from the release of the system, by domain ID
+	domainReleases map[string]string
+	// upgradables are the bundles available to upgrade the domains, by domain ID
+	upgradables map[string][]*models.Upgradable
+	bundles     map[string]*models.Bundle
+	upgrades    map[string]*models.Upgrade
+	// prechecks are the runs of the system prechecks, by ID
+	prechecks map[string]*models.Task
+	// failedPrechecks are the messages of the checks which fail, by name
+	failedPrechecks map[string]string
+}
+
+var (
+	shared     *Simulator
+	sharedOnce sync.Once
+)
+
+// New starts a simulator with the canned inventory. The simulator must be stopped with Close.
+func New() *Simulator {
+	simulator := &Simulator{
+		inventory: newInventory(),
+		tasks:     make(map[string]*models.Task),
+		requests:  make(map[string]int),
+
+		failedRetries: make(map[string]int),
+
+		domainReleases:  make(map[string]string),
+		upgradables:     make(map[string][]*models.Upgradable),
+		bundles:         make(map[string]*models.Bundle),
+		upgrades:        make(map[string]*models.Upgrade),
+		prechecks:       make(map[string]*models.Task),
+		failedPrechecks: make(map[string]string),
+
+		validations: make(map[string]*models.Validation),
+
+		fleetOperations: make(map[string]*fleet.Operation),
+
+		healthChecks:       make(map[string]*sosHealthCheck),
+		failedHealthChecks: make(map[string]string),
+
+		credentialsTasks:  make(map[string]*models.CredentialsTask),
+		failedCredentials: make(map[string]string),
+	}
+	simulator.server = httptest.NewTLSServer(simulator.routes())
+	return simulator
+}
+
+// Shared returns the simulator of the process, started on the first call. The SDKv2 and the framework
+// providers are configured with the same simulator, so that they share the inventory.
+func Shared() *Simulator {
+	sharedOnce.Do(func() {
+		shared = New()
+		log.Printf("[INFO] SDDC Manager simulator listening on %s", shared.Host())
+	})
+	return shared
+}
+
+// Host returns the address and port of the simulator, to be used as the host of SDDC Manager.
+func (s *Simulator) Host() string {
+	return strings.TrimPrefix(s.server.URL, "https://")
+}
+
+// RequestCount returns the number of requests with the method and the path the simulator has served.
+func (s *Simulator) RequestCount(method, path string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests[method+" "+path]
+}
+
+// RejectChangesAsLocked makes the simulator reject the next count changes, i.e. the requests other than GET, with
+// the error SDDC Manager answers while another workflow holds the lock of a resource.
+func (s *Simulator) RejectChangesAsLocked(count int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.lockedChanges = count
+}
+
+// SetVersion changes the version SDDC Manager reports, with the build number, e.g. 4.5.2.0-22223457, to simulate the
+// API of another version of VCF.
+func (s *Simulator) SetVersion(sddcManagerVersion string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.inventory.sddcManager.Version = sddcManagerVersion
+}
+
+// SetDomainStatus changes the status of the domain, e.g. to simulate a domain whose creation is in progress.
+func (s *Simulator) SetDomainStatus(id, status string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, domain := range s.inventory.domains {
+		if domain.ID == id {
+			domain.Status = status
+		}
+	}
+}
+
+// Close stops the simulator.
+func (s *Simulator) Close() {
+	s.server.Close()
+}
+
+func (s *Simulator) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/tokens", s.createToken)
+	mux.HandleFunc("GET /v1/domains", s.getDomains)
+	mux.HandleFunc("GET /v1/domains/{id}", s.getDomain)
+	mux.HandleFunc("GET /v1/nsxt-clusters/{id}", s.getNsxCluster)
+	mux.HandleFunc("GET /v1/domains/{id}/resource-certificates", s.getCertificatesOfDomain)
+	mux.HandleFunc("GET /v1/domains/{id}/certificates", s.getLegacyCertificatesOfDomain)
+	mux.HandleFunc("GET /v1/clusters", s.getClusters)
+	mux.HandleFunc("GET /v1/clusters/{id}", s.getCluster)
+	mux.HandleFunc("GET /v1/hosts", s.getHosts)
+	mux.HandleFunc("GET /v1/hosts/{id}", s.getHost)
+	mux.HandleFunc("POST /v1/hosts/validations", s.validateHostCommissionSpecs)
+	mux.HandleFunc("GET /v1/hosts/validations/{id}", s.getHostCommissionValidation)
+	mux.HandleFunc("GET /v1/network-pools", s.getNetworkPools)
+	mux.HandleFunc("POST /v1/network-pools", s.createNetworkPool)
+	mux.HandleFunc("GET /v1/network-pools/{id}", s.getNetworkPool)
+	mux.HandleFunc("PATCH /v1/network-pools/{id}", s.updateNetworkPool)
+	mux.HandleFunc("DELETE /v1/network-pools/{id}", s.deleteNetworkPool)
+	mux.HandleFunc("GET /v1/network-pools/{id}/networks", s.getNetworksOfNetworkPool)
+	mux.HandleFunc("GET /v1/license-keys", s.getLicenseKeys)
+	mux.HandleFunc("POST /v1/license-keys", s.addLicenseKey)
+	mux.HandleFunc("GET /v1/license-keys/{key}", s.getLicenseKey)
+	mux.HandleFunc("DELETE /v1/license-keys/{key}", s.removeLicenseKey)
+	mux.HandleFunc("GET /v1/sddc-managers", s.getSddcManagers)
+	mux.HandleFunc("GET /v1/sddc-managers/{id}", s.getSddcManager)
+	mux.HandleFunc("GET /v1/releases", s.getReleases)
+	mux.HandleFunc("GET /v1/releases/system", s.getSystemRelease)
+	mux.HandleFunc("GET /v1/upgradables/domains/{id}", s.getUpgradablesOfDomain)
+	mux.HandleFunc("GET /v1/bundles/{id}", s.getBundle)
+	mux.HandleFunc("GET /v1/upgrades/{id}", s.getUpgrade)
+	mux.HandleFunc("POST /v1/system/prechecks", s.startPrecheck)
+	mux.HandleFunc("GET /v1/system/prechecks/tasks/{id}", s.getPrecheck)
+	mux.HandleFunc("GET /v1/system/ceip", s.getCeip)
+	mux.HandleFunc("PATCH /v1/system/ceip", s.setCeip)
+	mux.HandleFunc("POST /v1/system/health-summary", s.startHealthCheck)
+	mux.HandleFunc("GET /v1/system/health-summary/{id}", s.getHealthCheckStatus)
+	mux.HandleFunc("GET /v1/system/health-summary/{id}/data", s.exportHealthCheck)
+	mux.HandleFunc("PATCH /v1/credentials", s.updateOrRotatePasswords)
+	mux.HandleFunc("GET /v1/credentials/tasks", s.getCredentialsTasks)
+	mux.HandleFunc("GET /v1/credentials/tasks/{id}", s.getCredentialsTask)
+	mux.HandleFunc("DELETE /v1/credentials/tasks/{id}", s.cancelCredentialsTask)
+	mux.HandleFunc("GET /v1/tasks", s.getTasks)
+	mux.HandleFunc("GET /v1/tasks/{id}", s.getTask)
+	mux.HandleFunc("PATCH /v1/tasks/{id}", s.retryTask)
+	mux.HandleFunc("DELETE /v1/tasks/{id}", s.cancelTask)
+	mux.HandleFunc("GET /v1/fleet/instances", s.getFleetInstances)
+	mux.HandleFunc("POST /v1/fleet/operations", s.startFleetOperation)
+	mux.HandleFunc("GET /v1/fleet/operations/{id}", s.getFleetOperation)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotImplemented, UnsupportedErrorCode,
+			fmt.Sprintf("%s %s is not supported by the SDDC Manager simulator", r.Method, r.URL.Path))
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.requests[r.Method+" "+r.URL.Path]++
+		if r.Method != http.MethodGet && r.URL.Path != "/v1/tokens" && s.lockedChanges > 0 {
+			s.lockedChanges--
+			writeError(w, http.StatusConflict, "RESOURCE_LOCKED", "Unable to acquire resource level lock(s)")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Simulator) createToken(w http.ResponseWriter, r *http.Request) {
+	spec := &models.TokenCreationSpec{}
+	if !readBody(w, r, spec) {
+		return
+	}
+	if len(spec.Username) == 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_CREDENTIALS", "the username is required")
+		return
+	}
+	writeJSON(w, http.StatusOK, &models.TokenPair{
+		AccessToken:  "simulator-access-token",
+		RefreshToken: &models.RefreshToken{ID: s.newId()},
+	})
+}
+
+func (s *Simulator) getDomains(w http.ResponseWriter, r *http.Request) {
+	domainType := r.URL.Query().Get("type")
+	result := make([]*models.Domain, 0)
+	for _, domain := range s.inventory.domains {
+		if len(domainType) == 0 || domain.Type == domainType {
+			result = append(result, domain)
+		}
+	}
+	writeJSON(w, http.StatusOK, &models.PageOfDomain{Elements: result, PageMetadata: pageMetadata(len(result))})
+}
+
+func (s *Simulator) getDomain(w http.ResponseWriter, r *http.Request) {
+	for _, domain := range s.inventory.domains {
+		if domain.ID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, domain)
+			return
+		}
+	}
+	writeNotFound(w, "domain", r.PathValue("id"))
+}
+
+// getNsxCluster returns the NSX Manager cluster of a domain, which is ACTIVE.
+func (s *Simulator) getNsxCluster(w http.ResponseWriter, r *http.Request) {
+	for _, domain := range s.inventory.domains {
+		if domain.NSXTCluster == nil || domain.NSXTCluster.ID != r.PathValue("id") {
+			continue
+		}
+		writeJSON(w, http.StatusOK, &models.NsxTCluster{
+			ID:      domain.NSXTCluster.ID,
+			Vip:     domain.NSXTCluster.Vip,
+			VipFqdn: domain.NSXTCluster.VipFqdn,
+			Status:  "ACTIVE",
+			Version: NsxVersion,
+			Domains: []*models.DomainReference{{ID: &domain.ID, Name: domain.Name}},
+		})
+		return
+	}
+	writeNotFound(w, "NSX cluster", r.PathValue("id"))
+}
+
+// getCertificatesOfDomain returns the certificates of the vCenter Server and the NSX Manager cluster of the domain,
+// and of SDDC Manager for the management domain.
+func (s *Simulator) getCertificatesOfDomain(w http.ResponseWriter, r *http.Request) {
+	for _, domain := range s.inventory.domains {
+		if domain.ID != r.PathValue("id") {
+			continue
+		}
+		var result []*models.Certificate
+		for _, vcenter := range domain.VCENTERS {
+			result = append(result, newCertificate(domain, vcenter.Fqdn))
+		}
+		if domain.NSXTCluster != nil {
+			result = append(result, newCertificate(domain, domain.NSXTCluster.VipFqdn))
+		}
+		if domain.Type == "MANAGEMENT" {
+			result = append(result, newCertificate(domain, s.inventory.sddcManager.Fqdn))
+		}
+		writeJSON(w, http.StatusOK, &models.PageOfCertificate{
+			Elements:     result,
+			PageMetadata: pageMetadata(len(result)),
+		})
+		return
+	}
+	writeNotFound(w, "domain", r.PathValue("id"))
+}
+
+// getLegacyCertificatesOfDomain returns the certificates of the domain like VCF 4.x does: without their expiration
+// status and the number of days to their expiration.
+func (s *Simulator) getLegacyCertificatesOfDomain(w http.ResponseWriter, r *http.Request) {
+	for _, domain := range s.inventory.domains {
+		if domain.ID != r.PathValue("id") {
+			continue
+		}
+		var result []*models.Certificate
+		for _, vcenter := range domain.VCENTERS {
+			cert := newCertificate(domain, vcenter.Fqdn)
+			cert.ExpirationStatus = nil
+			cert.NumberOfDaysToExpire = nil
+			cert.IsInstalled = nil
+			result = append(result, cert)
+		}
+		writeJSON(w, http.StatusOK, &models.PageOfCertificate{
+			Elements:     result,
+			PageMetadata: pageMetadata(len(result)),
+		})
+		return
+	}
+	writeNotFound(w, "domain", r.PathValue("id"))
+}
+
+func (s *Simulator) getClusters(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &models.PageOfCluster{
+		Elements:     s.inventory.clusters,
+		PageMetadata: pageMetadata(len(s.inventory.clusters)),
+	})
+}
+
+func (s *Simulator) getCluster(w http.ResponseWriter, r *http.Request) {
+	for _, cluster := range s.inventory.clusters {
+		if cluster.ID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, cluster)
+			return
+		}
+	}
+	writeNotFound(w, "cluster", r.PathValue("id"))
+}
+
+func (s *Simulator) getHosts(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	result := make([]*models.Host, 0)
+	for _, host := range s.inventory.hosts {
+		if len(status) == 0 || host.Status == status {
+			result = append(result, host)
+		}
+	}
+	writeJSON(w, http.StatusOK, &models.PageOfHost{Elements: result, PageMetadata: pageMetadata(len(result))})
+}
+
+func (s *Simulator) getHost(w http.ResponseWriter, r *http.Request) {
+	for _, host := range s.inventory.hosts {
+		if host.ID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, host)
+			return
+		}
+	}
+	writeNotFound(w, "host", r.PathValue("id"))
+}
+
+// validateHostCommissionSpecs validates the hosts to commission in the background, the validation is in progress
+// until it is read. A host fails the validation if it is already commissioned or if its network pool does not exist.
+func (s *Simulator) validateHostCommissionSpecs(w http.ResponseWriter, r *http.Request) {
+	var hostSpecs []*models.HostCommissionSpec
+	if !readBody(w, r, &hostSpecs) {
+		return
+	}
+
+	validation := &models.Validation{
+		ID:              s.newId(),
+		Description:     "Validating the host commission specs",
+		ExecutionStatus: "COMPLETED",
+		ResultStatus:    "SUCCEEDED",
+	}
+	for _, hostSpec := range hostSpecs {
+		fqdn := stringValue(hostSpec.Fqdn)
+		check := &models.ValidationCheck{
+			Description:  fmt.Sprintf("Validating host %s", fqdn),
+			ResultStatus: "SUCCEEDED",
+			Severity:     "INFO",
+		}
+		var message string
+		if slices.ContainsFunc(s.inventory.hosts, func(host *models.Host) bool { return host.Fqdn == fqdn }) {
+			message = fmt.Sprintf("host %s is already commissioned", fqdn)
+		} else if s.findNetworkPool(stringValue(hostSpec.NetworkPoolID)) == nil {
+			message = fmt.Sprintf("network pool %s not found", stringValue(hostSpec.NetworkPoolID))
+		}
+		if message != "" {
+			check.ResultStatus = "FAILED"
+			check.Severity = "ERROR"
+			check.ErrorResponse = &models.Error{ErrorCode: "HOST_VALIDATION_FAILED", Message: message}
+			validation.ResultStatus = "FAILED"
+		}
+		validation.ValidationChecks = append(validation.ValidationChecks, check)
+	}
+	s.validations[validation.ID] = validation
+
+	inProgress := &models.Validation{ID: validation.ID, Description: validation.Description, ExecutionStatus: "IN_PROGRESS"}
+	for _, check := range validation.ValidationChecks {
+		inProgress.ValidationChecks = append(inProgress.ValidationChecks,
+			&models.ValidationCheck{Description: check.Description, ResultStatus: "IN_PROGRESS"})
+	}
+	writeJSON(w, http.StatusAccepted, inProgress)
+}
+
+func (s *Simulator) getHostCommissionValidation(w http.ResponseWriter, r *http.Request) {
+	if validation, ok := s.validations[r.PathValue("id")]; ok {
+		writeJSON(w, http.StatusOK, validation)
+		return
+	}
+	writeNotFound(w, "validation", r.PathValue("id"))
+}
+
+func (s *Simulator) getNetworkPools(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &models.PageOfNetworkPool{
+		Elements:     s.inventory.networkPools,
+		PageMetadata: pageMetadata(len(s.inventory.networkPools)),
+	})
+}
+
+func (s *Simulator) createNetworkPool(w http.ResponseWriter, r *http.Request) {
+	pool := &models.NetworkPool{}
+	if !readBody(w, r, pool) {
+		return
+	}
+	for _, existing := range s.inventory.networkPools {
+		if existing.Name == pool.Name {
+			writeError(w, http.StatusBadRequest, "NETWORK_POOL_ALREADY_EXISTS",
+				fmt.Sprintf("a network pool named %s already exists", pool.Name))
+			return
+		}
+	}
+	pool.ID = s.newId()
+	for _, network := range pool.Networks {
+		network.ID = s.newId()
+	}
+	s.inventory.networkPools = append(s.inventory.networkPools, pool)
+	writeJSON(w, http.StatusCreated, pool)
+}
+
+func (s *Simulator) getNetworkPool(w http.ResponseWriter, r *http.Request) {
+	if pool := s.findNetworkPool(r.PathValue("id")); pool != nil {
+		writeJSON(w, http.StatusOK, pool)
+		return
+	}
+	writeNotFound(w, "network pool", r.PathValue("id"))
+}
+
+func (s *Simulator) updateNetworkPool(w http.ResponseWriter, r *http.Request) {
+	pool := s.findNetworkPool(r.PathValue("id"))
+	if pool == nil {
+		writeNotFound(w, "network pool", r.PathValue("id"))
+		return
+	}
+	spec := &models.NetworkPoolUpdateSpec{}
+	if !readBody(w, r, spec) {
+		return
+	}
+	pool.Name = spec.Name
+	writeJSON(w, http.StatusOK, pool)
+}
+
+func (s *Simulator) deleteNetworkPool(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	for _, host := range s.inventory.hosts {
+		if host.Networkpool != nil && host.Networkpool.ID != nil && *host.Networkpool.ID == id {
+			writeError(w, http.StatusBadRequest, "NETWORK_POOL_IN_USE",
+				fmt.Sprintf("the network pool %s is used by the host %s", id, host.Fqdn))
+			return
+		}
+	}
+	for i, pool := range s.inventory.networkPools {
+		if pool.ID == id {
+			s.inventory.networkPools = append(s.inventory.networkPools[:i], s.inventory.networkPools[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeNotFound(w, "network pool", id)
+}
+
+func (s *Simulator) getNetworksOfNetworkPool(w http.ResponseWriter, r *http.Request) {
+	pool := s.findNetworkPool(r.PathValue("id"))
+	if pool == nil {
+		writeNotFound(w, "network pool", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, &models.PageOfNetwork{Elements: pool.Networks, PageMetadata: pageMetadata(len(pool.Networks))})
+}
+
+func (s *Simulator) findNetworkPool(id string) *models.NetworkPool {
+	for _, pool := range s.inventory.networkPools {
+		if pool.ID == id {
+			return pool
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) getLicenseKeys(w http.ResponseWriter, r *http.Request) {
+	productType := r.URL.Query().Get("productType")
+	result := make([]*models.LicenseKey, 0)
+	for _, licenseKey := range s.inventory.licenseKeys {
+		if len(productType) == 0 || strings.EqualFold(*licenseKey.ProductType, productType) {
+			result = append(result, licenseKey)
+		}
+	}
+	writeJSON(w, http.StatusOK, &models.PageOfLicenseKey{Elements: result, PageMetadata: pageMetadata(len(result))})
+}
+
+func (s *Simulator) addLicenseKey(w http.ResponseWriter, r *http.Request) {
+	licenseKey := &models.LicenseKey{}
+	if !readBody(w, r, licenseKey) {
+		return
+	}
+	if licenseKey.Key == nil || licenseKey.ProductType == nil {
+		writeError(w, http.StatusBadRequest, "LICENSE_KEY_INVALID", "the key and the product type are required")
+		return
+	}
+	if s.findLicenseKey(*licenseKey.Key) != nil {
+		writeError(w, http.StatusBadRequest, "LICENSE_KEY_ALREADY_EXISTS", "the license key already exists")
+		return
+	}
+	added := newLicenseKey(*licenseKey.ProductType, *licenseKey.Key, "CPU", 16, 0)
+	added.ID = s.newId()
+	added.Description = licenseKey.Description
+	s.inventory.licenseKeys = append(s.inventory.licenseKeys, added)
+	writeJSON(w, http.StatusCreated, added)
+}
+
+func (s *Simulator) getLicenseKey(w http.ResponseWriter, r *http.Request) {
+	if licenseKey := s.findLicenseKey(r.PathValue("key")); licenseKey != nil {
+		writeJSON(w, http.StatusOK, licenseKey)
+		return
+	}
+	writeNotFound(w, "license key", r.PathValue("key"))
+}
+
+func (s *Simulator) removeLicenseKey(w http.ResponseWriter, r *http.Request) {
+	for i, licenseKey := range s.inventory.licenseKeys {
+		if *licenseKey.Key == r.PathValue("key") {
+			s.inventory.licenseKeys = append(s.inventory.licenseKeys[:i], s.inventory.licenseKeys[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeNotFound(w, "license key", r.PathValue("key"))
+}
+
+func (s *Simulator) findLicenseKey(key string) *models.LicenseKey {
+	for _, licenseKey := range s.inventory.licenseKeys {
+		if licenseKey.Key != nil && *licenseKey.Key == key {
+			return licenseKey
+		}
+	}
+	return nil
+}
+
+func (s *Simulator) getSddcManagers(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &models.PageOfSDDCManager{
+		Elements:     []*models.SDDCManager{s.inventory.sddcManager},
+		PageMetadata: pageMetadata(1),
+	})
+}
+
+func (s *Simulator) getSddcManager(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("id") != s.inventory.sddcManager.ID {
+		writeNotFound(w, "SDDC Manager", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.inventory.sddcManager)
+}
+
+func (s *Simulator) getSystemRelease(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.inventory.release)
+}
+
+func (s *Simulator) getCeip(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.inventory.ceip)
+}
+
+// setCeip applies the CEIP change at once, the task of the change is complete when the provider reads it.
+func (s *Simulator) setCeip(w http.ResponseWriter, r *http.Request) {
+	spec := &models.CEIPUpdateSpec{}
+	if !readBody(w, r, spec) {
+		return
+	}
+	if spec.Status == nil || (*spec.Status != "ENABLE" && *spec.Status != "DISABLE") {
+		writeError(w, http.StatusBadRequest, "CEIP_STATUS_INVALID", "the status must be ENABLE or DISABLE")
+		return
+	}
+	status := *spec.Status + "D"
+	s.inventory.ceip.Status = &status
+	writeJSON(w, http.StatusAccepted, s.completedTask("Updating CEIP status", "CEIP_UPDATE"))
+}
+
+func (s *Simulator) getTasks(w http.ResponseWriter, r *http.Request) {
+	resourceType := r.URL.Query().Get("resourceType")
+	resourceId := r.URL.Query().Get("resourceId")
+	result := make([]*models.Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		if (resourceType != "" || resourceId != "") && !taskHasResource(task, resourceType, resourceId) {
+			continue
+		}
+		result = append(result, task)
+	}
+	writeJSON(w, http.StatusOK, &models.PageOfTask{Elements: result, PageMetadata: pageMetadata(len(result))})
+}
+
+func (s *Simulator) getTask(w http.ResponseWriter, r *http.Request) {
+	if task, ok := s.tasks[r.PathValue("id")]; ok {
+		writeJSON(w, http.StatusOK, task)
+		advanceTask(task)
+		return
+	}
+	writeNotFound(w, "task", r.PathValue("id"))
+}
+
+// taskHasResource returns whether the task concerns a resource of the type and the ID, either can be empty.
+func taskHasResource(task *models.Task, resourceType, resourceId string) bool {
+	for _, resource := range task.Resources {
+		if (resourceType == "" || (resource.Type != nil && strings.EqualFold(*resource.Type, resourceType))) &&
+			(resourceId == "" || (resource.ResourceID != nil && *resource.ResourceID == resourceId)) {
+			return true
+		}
+	}
+	return false
+}
+
+// completedTask records a task which completed successfully.
+func (s *Simulator) completedTask(name, taskType string) *models.Task {
+	now := time.Now().UTC().Format(time.RFC3339)
+	task := &models.Task{
+		ID:                  s.newId(),
+		Name:                name,
+		Type:                taskType,
+		Status:              "Successful",
+		CreationTimestamp:   now,
+		CompletionTimestamp: now,
+	}
+	s.tasks[task.ID] = task
+	return task
+}
+
+// newId returns an ID for a created resource, unique within the simulator.
+func (s *Simulator) newId() string {
+	s.lastId++
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", s.lastId)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func pageMetadata(totalElements int) *models.PageMetadata {
+	return &models.PageMetadata{
+		PageNumber:    0,
+		PageSize:      int32(totalElements),
+		TotalElements: int32(totalElements),
+		TotalPages:    1,
+	}
+}
+
+func readBody(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("[WARN] Cannot write the response of the SDDC Manager simulator: %s", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, errorCode, message string) {
+	writeJSON(w, status, &models.Error{ErrorCode: errorCode, ErrorType: "ERROR", Message: message})
+}
+
+func writeNotFound(w http.ResponseWriter, kind, id string) {
+	writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", fmt.Sprintf("%s %s not found", kind, url.PathEscape(id)))
+}
@@ -0,0 +1,155 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// The simulator runs the compute, NTP, services and certificate health checks of SoS on its inventory. A run is in
+// progress until its status is read, then its results are exported as an archive holding a health-results.json file.
+
+// sosHealthCheck is a run of the SoS health checks and the archive of its results.
+type sosHealthCheck struct {
+	summary *models.HealthSummary
+	archive []byte
+}
+
+// FailHealthCheck makes the health checks of the component, e.g. the FQDN of a host, RED with the message.
+func (s *Simulator) FailHealthCheck(component, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failedHealthChecks[component] = message
+}
+
+func (s *Simulator) startHealthCheck(w http.ResponseWriter, r *http.Request) {
+	spec := &models.HealthSummarySpec{}
+	if !readBody(w, r, spec) {
+		return
+	}
+
+	archive, err := s.newHealthResultsArchive(spec.HealthChecks)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "SOS_HEALTH_CHECK_FAILED", err.Error())
+		return
+	}
+	summary := &models.HealthSummary{
+		ID:                s.newId(),
+		Description:       "SoS health check",
+		Status:            "IN_PROGRESS",
+		CreationTimestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+	s.healthChecks[summary.ID] = &sosHealthCheck{summary: summary, archive: archive}
+	writeJSON(w, http.StatusAccepted, summary)
+}
+
+func (s *Simulator) getHealthCheckStatus(w http.ResponseWriter, r *http.Request) {
+	healthCheck, ok := s.healthChecks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "health check", r.PathValue("id"))
+		return
+	}
+	if healthCheck.summary.Status == "IN_PROGRESS" {
+		healthCheck.summary.Status = "COMPLETED_WITH_SUCCESS"
+		healthCheck.summary.CompletionTimestamp = time.Now().UTC().Format(time.RFC3339)
+		healthCheck.summary.BundleName = "healthcheck-" + healthCheck.summary.ID + ".tar.gz"
+		healthCheck.summary.BundleAvailable = "Yes"
+	}
+	writeJSON(w, http.StatusOK, healthCheck.summary)
+}
+
+func (s *Simulator) exportHealthCheck(w http.ResponseWriter, r *http.Request) {
+	healthCheck, ok := s.healthChecks[r.PathValue("id")]
+	if !ok || healthCheck.summary.Status == "IN_PROGRESS" {
+		writeNotFound(w, "health check bundle", r.PathValue("id"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(healthCheck.archive)
+}
+
+// newHealthResultsArchive returns the tar.gz archive of the results of the health checks on the inventory.
+func (s *Simulator) newHealthResultsArchive(healthChecks *models.HealthChecks) ([]byte, error) {
+	if healthChecks == nil {
+		healthChecks = &models.HealthChecks{}
+	}
+	results := make(map[string]interface{})
+	addResults := func(category, check string, components []string, message string) {
+		checks, ok := results[category].(map[string]interface{})
+		if !ok {
+			checks = make(map[string]interface{})
+			results[category] = checks
+		}
+		componentResults := make(map[string]interface{})
+		for _, component := range components {
+			result := map[string]string{"state": "GREEN", "message": message}
+			if failure, failed := s.failedHealthChecks[component]; failed {
+				result = map[string]string{"state": "RED", "message": failure}
+			}
+			componentResults[component] = result
+		}
+		checks[check] = componentResults
+	}
+
+	var hostFqdns, vcenterFqdns []string
+	for _, host := range s.inventory.hosts {
+		if host.Domain != nil {
+			hostFqdns = append(hostFqdns, host.Fqdn)
+		}
+	}
+	for _, domain := range s.inventory.domains {
+		for _, vcenter := range domain.VCENTERS {
+			vcenterFqdns = append(vcenterFqdns, vcenter.Fqdn)
+		}
+	}
+	if healthChecks.ComputeHealth {
+		addResults("Compute Health", "ESXi Core Dump Config", hostFqdns, "Core dump is configured")
+	}
+	if healthChecks.NtpHealth {
+		addResults("NTP Health", "ESXi Time", hostFqdns, "Time is in sync")
+		addResults("NTP Health", "vCenter Time", vcenterFqdns, "Time is in sync")
+	}
+	if healthChecks.ServicesHealth {
+		addResults("Services Health", "SDDC Manager Services", []string{SddcManagerFqdn}, "All the services are UP")
+	}
+	if healthChecks.CertificateHealth {
+		addResults("Certificate Health", "Certificate Expiry", append(vcenterFqdns, SddcManagerFqdn),
+			"The certificate is valid")
+	}
+
+	document, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err = tarWriter.WriteHeader(&tar.Header{
+		Name:     "health-check/health-results.json",
+		Mode:     0o644,
+		Size:     int64(len(document)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return nil, err
+	}
+	if _, err = tarWriter.Write(document); err != nil {
+		return nil, err
+	}
+	if err = tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,129 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// The changes of the simulator complete their tasks at once, the tasks added with StartTask run instead. A running
+// task completes its next stage, i.e. subtask, each time it is read and completes with its last stage, a task without
+// stages runs until it is cancelled. A task made to fail with FailTask can be retried, as SDDC Manager does, the
+// retries of the task fail as many times as requested before the task completes.
+
+// StartTask records a task in progress of the resource, running the stages one after the other, and returns its ID.
+func (s *Simulator) StartTask(name string, resource *models.Resource, cancellable bool, stages ...string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	task := &models.Task{
+		ID:                s.newId(),
+		Name:              name,
+		Type:              "SIMULATED_WORKFLOW",
+		Status:            "In Progress",
+		CreationTimestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		IsCancellable:     cancellable,
+	}
+	if resource != nil {
+		task.Resources = []*models.Resource{resource}
+	}
+	for i, stage := range stages {
+		status := "PENDING"
+		if i == 0 {
+			status = "IN_PROGRESS"
+		}
+		task.SubTasks = append(task.SubTasks, &models.SubTask{Name: stage, Status: status})
+	}
+	s.tasks[task.ID] = task
+	return task.ID
+}
+
+// FailTask makes the task fail at its running stage. A retryable task fails again on the next failedRetries retries,
+// then its retry completes it.
+func (s *Simulator) FailTask(id string, retryable bool, failedRetries int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	task := s.tasks[id]
+	task.Status = "Failed"
+	task.IsRetryable = retryable
+	for _, subTask := range task.SubTasks {
+		if subTask.Status == "IN_PROGRESS" {
+			subTask.Status = "FAILED"
+			subTask.Errors = []*models.Error{{ErrorCode: "SIMULATED_FAILURE", Message: "the stage failed"}}
+		}
+	}
+	s.failedRetries[id] = failedRetries
+}
+
+// TaskStatus returns the status of the task, e.g. Cancelled.
+func (s *Simulator) TaskStatus(id string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.tasks[id].Status
+}
+
+// advanceTask completes the running stage of a running task and starts the next one, the task completes with its
+// last stage.
+func advanceTask(task *models.Task) {
+	if task.Status != "In Progress" || len(task.SubTasks) == 0 {
+		return
+	}
+	for i, subTask := range task.SubTasks {
+		if subTask.Status != "IN_PROGRESS" {
+			continue
+		}
+		subTask.Status = "SUCCESSFUL"
+		if i+1 < len(task.SubTasks) {
+			task.SubTasks[i+1].Status = "IN_PROGRESS"
+			return
+		}
+	}
+	task.Status = "Successful"
+	task.CompletionTimestamp = time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+func (s *Simulator) retryTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.tasks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "task", r.PathValue("id"))
+		return
+	}
+	if task.Status != "Failed" || !task.IsRetryable {
+		writeError(w, http.StatusConflict, "TASK_NOT_RETRYABLE", "the task "+task.ID+" cannot be retried")
+		return
+	}
+
+	if s.failedRetries[task.ID] > 0 {
+		s.failedRetries[task.ID]--
+	} else {
+		task.Status = "Successful"
+		task.CompletionTimestamp = time.Now().UTC().Format(time.RFC3339Nano)
+		for _, subTask := range task.SubTasks {
+			subTask.Status = "SUCCESSFUL"
+			subTask.Errors = nil
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Simulator) cancelTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.tasks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "task", r.PathValue("id"))
+		return
+	}
+	if task.Status != "In Progress" || !task.IsCancellable {
+		writeError(w, http.StatusConflict, "TASK_NOT_CANCELLABLE", "the task "+task.ID+" cannot be cancelled")
+		return
+	}
+
+	task.Status = "Cancelled"
+	task.CompletionTimestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,144 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package simulator
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/models"
+
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+)
+
+// The domains of the simulator are at the release of the system until SetDomainRelease changes it. The bundles added
+// with AddUpgradable upgrade the components of a domain and the upgrades added with AddUpgrade are only read, the
+// simulator does not perform them. The system prechecks complete at once and fail the checks set with FailPrecheck.
+
+// SetDomainRelease changes the VCF release of the domain, e.g. 5.2.1.0.
+func (s *Simulator) SetDomainRelease(domainId, version string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.domainReleases[domainId] = version
+}
+
+// AddUpgradable makes the bundle available to upgrade the domain.
+func (s *Simulator) AddUpgradable(domainId string, bundle *models.Bundle) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.bundles[bundle.ID] = bundle
+	resourceType := "DOMAIN"
+	s.upgradables[domainId] = append(s.upgradables[domainId], &models.Upgradable{
+		BundleID:   bundle.ID,
+		BundleType: stringValue(bundle.Type),
+		Status:     "AVAILABLE",
+		Resource:   &models.Resource{ResourceID: &domainId, Type: &resourceType},
+	})
+}
+
+// AddUpgrade records an upgrade of the domain with the bundle in the status, e.g. COMPLETED_WITH_SUCCESS, and returns
+// its ID.
+func (s *Simulator) AddUpgrade(domainId, bundleId, status string) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := s.newId()
+	s.upgrades[id] = &models.Upgrade{
+		ID:           &id,
+		BundleID:     &bundleId,
+		ResourceType: utils.ToStringPointer("DOMAIN"),
+		ResourceUpgradeSpecs: []*models.ResourceUpgradeSpec{{
+			ResourceID: &domainId,
+		}},
+		Status: &status,
+	}
+	return id
+}
+
+// FailPrecheck makes the check with the name fail in the next system prechecks with the message.
+func (s *Simulator) FailPrecheck(name, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failedPrechecks[name] = message
+}
+
+func (s *Simulator) getReleases(w http.ResponseWriter, r *http.Request) {
+	release := *s.inventory.release
+	if version, ok := s.domainReleases[r.URL.Query().Get("domainId")]; ok {
+		release.Version = &version
+	}
+	result := []*models.Release{&release}
+	writeJSON(w, http.StatusOK, &models.PageOfRelease{Elements: result, PageMetadata: pageMetadata(len(result))})
+}
+
+func (s *Simulator) getUpgradablesOfDomain(w http.ResponseWriter, r *http.Request) {
+	result := s.upgradables[r.PathValue("id")]
+	if result == nil {
+		result = []*models.Upgradable{}
+	}
+	writeJSON(w, http.StatusOK, &models.PageOfUpgradable{Elements: result, PageMetadata: pageMetadata(len(result))})
+}
+
+func (s *Simulator) getBundle(w http.ResponseWriter, r *http.Request) {
+	bundle, ok := s.bundles[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "bundle", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, bundle)
+}
+
+func (s *Simulator) getUpgrade(w http.ResponseWriter, r *http.Request) {
+	upgrade, ok := s.upgrades[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "upgrade", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, upgrade)
+}
+
+// startPrecheck runs the checks at once, the precheck task is complete when the provider reads it.
+func (s *Simulator) startPrecheck(w http.ResponseWriter, r *http.Request) {
+	spec := &models.PrecheckSpec{}
+	if !readBody(w, r, spec) {
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	task := &models.Task{
+		ID:                  s.newId(),
+		Name:                "System precheck",
+		Type:                "PRECHECK",
+		Status:              "SUCCESSFUL",
+		CreationTimestamp:   now,
+		CompletionTimestamp: now,
+		Resources:           spec.Resources,
+	}
+	names := []string{"Inventory consistency"}
+	for name := range s.failedPrechecks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		subTask := &models.SubTask{Name: name, Status: "SUCCESSFUL", Resources: spec.Resources}
+		if message, ok := s.failedPrechecks[name]; ok {
+			subTask.Status = "FAILED"
+			subTask.Errors = []*models.Error{{ErrorCode: "PRECHECK_FAILED", Message: message}}
+			task.Status = "FAILED"
+		}
+		task.SubTasks = append(task.SubTasks, subTask)
+	}
+	s.prechecks[task.ID] = task
+	writeJSON(w, http.StatusAccepted, task)
+}
+
+func (s *Simulator) getPrecheck(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.prechecks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "precheck", r.PathValue("id"))
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
@@ -0,0 +1,241 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package sos
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vmware/vcf-sdk-go/client/sos"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// The health checks of the Supportability and Serviceability (SoS) utility of SDDC Manager. SoS runs the checks in
+// the background, then archives their results, which are exported as a tar.gz file.
+const (
+	HealthCheckCertificate           = "CERTIFICATE"
+	HealthCheckComposability         = "COMPOSABILITY"
+	HealthCheckCompute               = "COMPUTE"
+	HealthCheckConnectivity          = "CONNECTIVITY"
+	HealthCheckDns                   = "DNS"
+	HealthCheckGeneral               = "GENERAL"
+	HealthCheckHardwareCompatibility = "HARDWARE_COMPATIBILITY"
+	HealthCheckNtp                   = "NTP"
+	HealthCheckPassword              = "PASSWORD"
+	HealthCheckServices              = "SERVICES"
+	HealthCheckStorage               = "STORAGE"
+	HealthCheckVersion               = "VERSION"
+)
+
+const (
+	HealthSummaryStatusPending              = "PENDING"
+	HealthSummaryStatusInProgress           = "IN_PROGRESS"
+	HealthSummaryStatusCompletedWithFailure = "COMPLETED_WITH_FAILURE"
+)
+
+// The states of the results of the health checks, GRAY if a check does not apply to a component.
+const (
+	StateGreen  = "GREEN"
+	StateYellow = "YELLOW"
+	StateRed    = "RED"
+	StateGray   = "GRAY"
+)
+
+// healthResultsFile is the file of the archive which holds the results of the health checks, by category, check and
+// component, e.g. {"Compute Health": {"ESXi Time": {"esx01.rainpole.io": {"state": "GREEN", "message": "..."}}}}.
+const healthResultsFile = "health-results.json"
+
+// HealthCheckNames returns the names of the health checks SoS runs.
+func HealthCheckNames() []string {
+	return []string{HealthCheckCertificate, HealthCheckComposability, HealthCheckCompute, HealthCheckConnectivity,
+		HealthCheckDns, HealthCheckGeneral, HealthCheckHardwareCompatibility, HealthCheckNtp, HealthCheckPassword,
+		HealthCheckServices, HealthCheckStorage, HealthCheckVersion}
+}
+
+// Result is the result of a health check for a component, e.g. the time of an ESXi host.
+type Result struct {
+	Category  string
+	Check     string
+	Component string
+	State     string
+	Message   string
+}
+
+// NewHealthSummarySpec returns the spec of a run of the health checks, all of them if checks is empty, on the
+// domains, all of them if domainNames is empty.
+func NewHealthSummarySpec(checks, domainNames []string, includeFreeHosts, force bool) *models.HealthSummarySpec {
+	if len(checks) == 0 {
+		checks = HealthCheckNames()
+	}
+	healthChecks := &models.HealthChecks{
+		CertificateHealth:           slices.Contains(checks, HealthCheckCertificate),
+		ComposabilityHealth:         slices.Contains(checks, HealthCheckComposability),
+		ComputeHealth:               slices.Contains(checks, HealthCheckCompute),
+		ConnectivityHealth:          slices.Contains(checks, HealthCheckConnectivity),
+		DNSHealth:                   slices.Contains(checks, HealthCheckDns),
+		GeneralHealth:               slices.Contains(checks, HealthCheckGeneral),
+		HardwareCompatibilityHealth: slices.Contains(checks, HealthCheckHardwareCompatibility),
+		NtpHealth:                   slices.Contains(checks, HealthCheckNtp),
+		PasswordHealth:              slices.Contains(checks, HealthCheckPassword),
+		ServicesHealth:              slices.Contains(checks, HealthCheckServices),
+		StorageHealth:               slices.Contains(checks, HealthCheckStorage),
+		VersionHealth:               slices.Contains(checks, HealthCheckVersion),
+	}
+
+	scope := &models.HealthSummaryScope{IncludeAllDomains: len(domainNames) == 0, IncludeFreeHosts: includeFreeHosts}
+	for _, domainName := range domainNames {
+		// The clusters of the domain are all checked when none is listed
+		scope.Domains = append(scope.Domains, &models.Domains{DomainName: domainName, ClusterNames: []string{}})
+	}
+
+	return &models.HealthSummarySpec{
+		HealthChecks: healthChecks,
+		Options:      &models.HealthSummaryOption{Config: &models.HealthSummaryConfig{Force: force}},
+		Scope:        scope,
+	}
+}
+
+// RunHealthCheck runs the health checks of the spec, waits for them every poll interval of the client and returns
+// the results exported by SDDC Manager.
+func RunHealthCheck(ctx context.Context, vcfClient *api_client.SddcManagerClient, spec *models.HealthSummarySpec) (*models.HealthSummary, []Result, error) {
+	apiClient := vcfClient.ApiClient
+	ok, accepted, err := apiClient.SOS.StartHealthCheck(sos.NewStartHealthCheckParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithHealthsummaryspec(spec))
+	if err != nil {
+		return nil, nil, err
+	}
+	var summary *models.HealthSummary
+	if accepted != nil {
+		summary = accepted.Payload
+	} else if ok != nil {
+		summary = ok.Payload
+	}
+	if summary == nil || summary.ID == "" {
+		return nil, nil, errors.New("the health check did not return an ID")
+	}
+
+	for summary.Status == HealthSummaryStatusPending || summary.Status == HealthSummaryStatusInProgress {
+		select {
+		case <-time.After(vcfClient.PollInterval()):
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("health check %s did not complete: %w", summary.ID, ctx.Err())
+		}
+		statusResult, err := apiClient.SOS.GetHealthCheckStatus(sos.NewGetHealthCheckStatusParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(summary.ID))
+		if err != nil {
+			return nil, nil, err
+		}
+		summary = statusResult.Payload
+	}
+	if summary.Status == HealthSummaryStatusCompletedWithFailure {
+		return summary, nil, fmt.Errorf("health check %s failed to complete: %s", summary.ID, summary.Status)
+	}
+
+	exportResult, err := apiClient.SOS.ExportHealthCheckByID(sos.NewExportHealthCheckByIDParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(summary.ID))
+	if err != nil {
+		return summary, nil, err
+	}
+	results, err := ParseHealthResults(exportResult.Payload)
+	if err != nil {
+		return summary, nil, fmt.Errorf("cannot read the results of health check %s: %w", summary.ID, err)
+	}
+	return summary, results, nil
+}
+
+// ParseHealthResults reads the results of the health checks from the tar.gz archive SDDC Manager exports. Each
+// object of the results file with a state is the result of a check, the keys leading to it are its category, its
+// check and its component. The results are sorted by category, check and component.
+func ParseHealthResults(archive []byte) ([]Result, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("the archive has no %s", healthResultsFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || path.Base(header.Name) != healthResultsFile {
+			continue
+		}
+
+		var document map[string]interface{}
+		if err = json.NewDecoder(tarReader).Decode(&document); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", header.Name, err)
+		}
+		results := make([]Result, 0)
+		collectResults(document, nil, &results)
+		slices.SortFunc(results, func(a, b Result) int {
+			return strings.Compare(a.Category+"\x00"+a.Check+"\x00"+a.Component,
+				b.Category+"\x00"+b.Check+"\x00"+b.Component)
+		})
+		return results, nil
+	}
+}
+
+// collectResults appends the results found in the object of the results file reached through the keys.
+func collectResults(object map[string]interface{}, keys []string, results *[]Result) {
+	if state, ok := object["state"].(string); ok && len(keys) > 0 {
+		result := Result{Category: keys[0], Component: keys[len(keys)-1], State: strings.ToUpper(state)}
+		if len(keys) > 2 {
+			result.Check = strings.Join(keys[1:len(keys)-1], " / ")
+		}
+		result.Message, _ = object["message"].(string)
+		*results = append(*results, result)
+		return
+	}
+	for key, value := range object {
+		if nested, ok := value.(map[string]interface{}); ok {
+			collectResults(nested, append(slices.Clone(keys), key), results)
+		}
+	}
+}
+
+// FailedResults returns the results in the RED state.
+func FailedResults(results []Result) []Result {
+	failed := make([]Result, 0)
+	for _, result := range results {
+		if result.State == StateRed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// FlattenResults returns the results as the blocks of the vcf_health_check data source.
+func FlattenResults(results []Result) []map[string]interface{} {
+	flattened := make([]map[string]interface{}, 0, len(results))
+	for _, result := range results {
+		flattened = append(flattened, map[string]interface{}{
+			"category":  result.Category,
+			"check":     result.Check,
+			"component": result.Component,
+			"state":     result.State,
+			"message":   result.Message,
+		})
+	}
+	return flattened
+}
@@ -0,0 +1,122 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+// IpRange is a range of IPv4 addresses, both ends included.
+type IpRange struct {
+	Start netip.Addr
+	End   netip.Addr
+}
+
+// ParseIpRange parses the start and end IPv4 addresses of a range, the start must not be after the end.
+func ParseIpRange(start, end string) (IpRange, error) {
+	startAddr, err := netip.ParseAddr(start)
+	if err != nil || !startAddr.Is4() {
+		return IpRange{}, fmt.Errorf("invalid start IPv4 address %q", start)
+	}
+	endAddr, err := netip.ParseAddr(end)
+	if err != nil || !endAddr.Is4() {
+		return IpRange{}, fmt.Errorf("invalid end IPv4 address %q", end)
+	}
+	if endAddr.Less(startAddr) {
+		return IpRange{}, fmt.Errorf("the start address %s of the range is after its end address %s", start, end)
+	}
+
+	return IpRange{Start: startAddr, End: endAddr}, nil
+}
+
+func (r IpRange) String() string {
+	return r.Start.String() + "-" + r.End.String()
+}
+
+// Contains returns whether the address is in the range.
+func (r IpRange) Contains(addr netip.Addr) bool {
+	return !addr.Less(r.Start) && !r.End.Less(addr)
+}
+
+// Overlaps returns whether the ranges have at least one address in common.
+func (r IpRange) Overlaps(other IpRange) bool {
+	return !r.End.Less(other.Start) && !other.End.Less(r.Start)
+}
+
+// ParseSubnet parses a subnet given by its address and its mask, e.g. 192.168.10.0 and 255.255.255.0.
+func ParseSubnet(subnet, mask string) (netip.Prefix, error) {
+	subnetAddr, err := netip.ParseAddr(subnet)
+	if err != nil || !subnetAddr.Is4() {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet IPv4 address %q", subnet)
+	}
+	maskIp := net.ParseIP(mask).To4()
+	if maskIp == nil {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet mask %q", mask)
+	}
+	ones, bits := net.IPMask(maskIp).Size()
+	if bits == 0 {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet mask %q", mask)
+	}
+	prefix := netip.PrefixFrom(subnetAddr, ones)
+	if prefix.Masked().Addr() != subnetAddr {
+		return netip.Prefix{}, fmt.Errorf("%s is not the address of the subnet %s", subnet, prefix.Masked())
+	}
+
+	return prefix, nil
+}
+
+// ValidateIpRangesInSubnet checks that the ranges are in the subnet, do not include its network and broadcast
+// addresses or the gateway, and do not overlap each other. The gateway is not checked if it is not valid.
+func ValidateIpRangesInSubnet(subnet netip.Prefix, gateway netip.Addr, ranges []IpRange) []error {
+	errs := make([]error, 0)
+	networkAddr := subnet.Masked().Addr()
+	broadcastAddr := lastAddrOfSubnet(subnet)
+	for i, ipRange := range ranges {
+		if !subnet.Contains(ipRange.Start) || !subnet.Contains(ipRange.End) {
+			errs = append(errs, fmt.Errorf("the range %s is not in the subnet %s", ipRange, subnet.Masked()))
+		} else if ipRange.Contains(networkAddr) {
+			errs = append(errs, fmt.Errorf("the range %s includes the network address %s", ipRange, networkAddr))
+		} else if ipRange.Contains(broadcastAddr) {
+			errs = append(errs, fmt.Errorf("the range %s includes the broadcast address %s", ipRange, broadcastAddr))
+		}
+		if gateway.IsValid() && ipRange.Contains(gateway) {
+			errs = append(errs, fmt.Errorf("the range %s includes the gateway %s", ipRange, gateway))
+		}
+		for _, other := range ranges[:i] {
+			if ipRange.Overlaps(other) {
+				errs = append(errs, fmt.Errorf("the range %s overlaps the range %s", ipRange, other))
+			}
+		}
+	}
+
+	return errs
+}
+
+// ValidateGatewayInSubnet checks that the gateway is a host address of the subnet.
+func ValidateGatewayInSubnet(subnet netip.Prefix, gateway netip.Addr) error {
+	if !subnet.Contains(gateway) {
+		return fmt.Errorf("the gateway %s is not in the subnet %s", gateway, subnet.Masked())
+	}
+	if gateway == subnet.Masked().Addr() || gateway == lastAddrOfSubnet(subnet) {
+		return errors.New("the gateway cannot be the network or the broadcast address of the subnet")
+	}
+
+	return nil
+}
+
+func lastAddrOfSubnet(subnet netip.Prefix) netip.Addr {
+	bytes := subnet.Masked().Addr().As4()
+	hostBits := 32 - subnet.Bits()
+	for i := 3; i >= 0 && hostBits > 0; i-- {
+		bits := min(hostBits, 8)
+		bytes[i] |= byte(1<<bits - 1)
+		hostBits -= bits
+	}
+
+	return netip.AddrFrom4(bytes)
+}
@@ -0,0 +1,95 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestParseIpRange(t *testing.T) {
+	var rangeTests = []struct {
+		start       string
+		end         string
+		expectedErr string
+	}{
+		{"192.168.10.10", "192.168.10.20", ""},
+		{"192.168.10.10", "192.168.10.10", ""},
+		{"192.168.10.20", "192.168.10.10", "is after its end address"},
+		{"192.168.10", "192.168.10.10", "invalid start IPv4 address"},
+		{"192.168.10.10", "fe80::1", "invalid end IPv4 address"},
+	}
+
+	for _, tt := range rangeTests {
+		_, err := ParseIpRange(tt.start, tt.end)
+		if tt.expectedErr == "" && err != nil {
+			t.Errorf("ParseIpRange(%q, %q) returned unexpected error %v", tt.start, tt.end, err)
+		}
+		if tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+			t.Errorf("ParseIpRange(%q, %q) = %v, expected error containing %q", tt.start, tt.end, err, tt.expectedErr)
+		}
+	}
+}
+
+func TestParseSubnet(t *testing.T) {
+	prefix, err := ParseSubnet("192.168.8.0", "255.255.252.0")
+	if err != nil || prefix.String() != "192.168.8.0/22" {
+		t.Errorf("ParseSubnet returned %s, %v, expected 192.168.8.0/22", prefix, err)
+	}
+	if _, err := ParseSubnet("192.168.10.1", "255.255.255.0"); err == nil {
+		t.Errorf("ParseSubnet accepted a host address as subnet")
+	}
+	if _, err := ParseSubnet("192.168.10.0", "255.0.255.0"); err == nil {
+		t.Errorf("ParseSubnet accepted a non contiguous mask")
+	}
+}
+
+func TestValidateIpRangesInSubnet(t *testing.T) {
+	subnet := netip.MustParsePrefix("192.168.10.0/24")
+	gateway := netip.MustParseAddr("192.168.10.1")
+	ipRange := func(start, end string) IpRange {
+		result, err := ParseIpRange(start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	var rangesTests = []struct {
+		ranges      []IpRange
+		expectedErr string
+	}{
+		{[]IpRange{ipRange("192.168.10.10", "192.168.10.20"), ipRange("192.168.10.21", "192.168.10.30")}, ""},
+		{[]IpRange{ipRange("192.168.10.10", "192.168.10.20"), ipRange("192.168.10.20", "192.168.10.30")}, "overlaps the range 192.168.10.10-192.168.10.20"},
+		{[]IpRange{ipRange("192.168.10.0", "192.168.10.0")}, "includes the network address"},
+		{[]IpRange{ipRange("192.168.10.200", "192.168.10.255")}, "includes the broadcast address"},
+		{[]IpRange{ipRange("192.168.10.1", "192.168.10.20")}, "includes the gateway"},
+		{[]IpRange{ipRange("192.168.10.200", "192.168.11.10")}, "is not in the subnet"},
+	}
+
+	for _, tt := range rangesTests {
+		errs := ValidateIpRangesInSubnet(subnet, gateway, tt.ranges)
+		if tt.expectedErr == "" && len(errs) > 0 {
+			t.Errorf("ValidateIpRangesInSubnet(%v) returned unexpected errors %v", tt.ranges, errs)
+		}
+		if tt.expectedErr != "" && (len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.expectedErr)) {
+			t.Errorf("ValidateIpRangesInSubnet(%v) = %v, expected an error containing %q", tt.ranges, errs, tt.expectedErr)
+		}
+	}
+}
+
+func TestValidateGatewayInSubnet(t *testing.T) {
+	subnet := netip.MustParsePrefix("192.168.10.0/24")
+	if err := ValidateGatewayInSubnet(subnet, netip.MustParseAddr("192.168.10.1")); err != nil {
+		t.Errorf("ValidateGatewayInSubnet returned unexpected error %v", err)
+	}
+	if err := ValidateGatewayInSubnet(subnet, netip.MustParseAddr("192.168.11.1")); err == nil {
+		t.Errorf("ValidateGatewayInSubnet accepted a gateway outside of the subnet")
+	}
+	if err := ValidateGatewayInSubnet(subnet, netip.MustParseAddr("192.168.10.255")); err == nil {
+		t.Errorf("ValidateGatewayInSubnet accepted the broadcast address as gateway")
+	}
+}
@@ -0,0 +1,145 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// PasswordPolicy is the password complexity rule of a VCF component, the passwords SDDC Manager sets which violate it
+// fail the workflow setting them.
+type PasswordPolicy struct {
+	// Name identifies the policy in the password_violations function
+	Name      string
+	MinLength int
+	// MaxLength is the maximum length of the passwords, no limit if zero
+	MaxLength int
+	// SpecialSymbols are the special symbols the passwords must contain one of
+	SpecialSymbols []rune
+	// RejectDictionaryWords rejects the passwords made of well known words, e.g. password
+	RejectDictionaryWords bool
+}
+
+var (
+	defaultSpecialSymbols = []rune{'\'', '!', '"', '#', '$', '%', '&', '(', ')', '*', '+', '-', '.', '/', ':', ';', '<', '=', '>', '?', '@', '[', '\\', ']', '^', '_', '`', '{', '|', '}', '~'}
+
+	DefaultPasswordPolicy = PasswordPolicy{Name: "default", MinLength: 8, SpecialSymbols: defaultSpecialSymbols}
+	// EsxiPasswordPolicy is the rule of the root account of the ESXi hosts
+	EsxiPasswordPolicy = PasswordPolicy{Name: "esxi", MinLength: 8, MaxLength: 20, SpecialSymbols: defaultSpecialSymbols}
+	// VcenterPasswordPolicy is the rule of the root account of the vCenter Server appliances
+	VcenterPasswordPolicy = PasswordPolicy{Name: "vcenter", MinLength: 8, MaxLength: 20, SpecialSymbols: defaultSpecialSymbols}
+	// SsoPasswordPolicy is the rule of the administrator of the SSO domains
+	SsoPasswordPolicy = PasswordPolicy{Name: "sso", MinLength: 8, MaxLength: 20, SpecialSymbols: defaultSpecialSymbols}
+	// NsxPasswordPolicy is the rule of the root, admin and audit accounts of the NSX Manager appliances
+	NsxPasswordPolicy = PasswordPolicy{Name: "nsx", MinLength: 12, SpecialSymbols: defaultSpecialSymbols,
+		RejectDictionaryWords: true}
+	// NsxEdgePasswordPolicy is the rule of the root, admin and audit accounts of the NSX edge nodes
+	NsxEdgePasswordPolicy = PasswordPolicy{Name: "nsx_edge", MinLength: 12,
+		SpecialSymbols: []rune{'!', '@', '^', '=', '*', '+'}, RejectDictionaryWords: true}
+	// LocalAccountPasswordPolicy is the rule of the admin@local account of SDDC Manager
+	LocalAccountPasswordPolicy = PasswordPolicy{Name: "sddc_manager_local", MinLength: 12,
+		SpecialSymbols: []rune{'!', '%', '@', '$', '^', '#', '?', '*'}}
+)
+
+// dictionaryWords are the well known words NSX rejects in a password, whatever their case.
+var dictionaryWords = []string{"password", "passw0rd", "changeme", "welcome", "qwerty", "letmein", "secret"}
+
+// minHostNameLength is the length from which the host name of an FQDN is rejected in a password, shorter host names
+// are too likely to appear by chance.
+const minHostNameLength = 3
+
+// PasswordPolicies returns the password policies by name.
+func PasswordPolicies() map[string]PasswordPolicy {
+	policies := make(map[string]PasswordPolicy)
+	for _, policy := range []PasswordPolicy{DefaultPasswordPolicy, EsxiPasswordPolicy, VcenterPasswordPolicy,
+		SsoPasswordPolicy, NsxPasswordPolicy, NsxEdgePasswordPolicy, LocalAccountPasswordPolicy} {
+		policies[policy.Name] = policy
+	}
+	return policies
+}
+
+// PasswordPolicyNames returns the sorted names of the password policies.
+func PasswordPolicyNames() []string {
+	names := make([]string, 0)
+	for name := range PasswordPolicies() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Check returns the violations of the policy by the password. The password must not contain the host names of the
+// FQDNs either, i.e. their first labels, e.g. the one of the appliance the password is set on. The labels of the
+// domains are allowed, they are shared by all the appliances, e.g. "corp" of esx01.corp.com.
+func (policy PasswordPolicy) Check(password string, fqdns ...string) []error {
+	var errors []error
+	var containsUpperCase, containsLowerCase, containsDigit, containsSymbol bool
+	for _, char := range password {
+		if !containsLowerCase && unicode.IsLower(char) {
+			containsLowerCase = true
+		} else if !containsUpperCase && unicode.IsUpper(char) {
+			containsUpperCase = true
+		} else if !containsDigit && unicode.IsDigit(char) {
+			containsDigit = true
+		}
+	}
+	for _, symbol := range policy.SpecialSymbols {
+		if strings.ContainsRune(password, symbol) {
+			containsSymbol = true
+			break
+		}
+	}
+	if len(password) < policy.MinLength {
+		errors = append(errors, fmt.Errorf("the password must be at least %d characters long", policy.MinLength))
+	}
+	if policy.MaxLength > 0 && len(password) > policy.MaxLength {
+		errors = append(errors, fmt.Errorf("the password must be at most %d characters long", policy.MaxLength))
+	}
+	if !containsLowerCase {
+		errors = append(errors, fmt.Errorf("the password must contain at least one lower case letter"))
+	}
+	if !containsUpperCase {
+		errors = append(errors, fmt.Errorf("the password must contain at least one upper case letter"))
+	}
+	if !containsDigit {
+		errors = append(errors, fmt.Errorf("the password must contain at least one digit"))
+	}
+	if !containsSymbol {
+		errors = append(errors, fmt.Errorf("the password must contain at least one special symbol"))
+	}
+
+	lowerCasePassword := strings.ToLower(password)
+	if policy.RejectDictionaryWords {
+		for _, word := range dictionaryWords {
+			if strings.Contains(lowerCasePassword, word) {
+				errors = append(errors, fmt.Errorf("the password must not contain the dictionary word %q", word))
+			}
+		}
+	}
+	for _, fqdn := range fqdns {
+		hostName, _, _ := strings.Cut(strings.ToLower(fqdn), ".")
+		if len(hostName) >= minHostNameLength && strings.Contains(lowerCasePassword, hostName) {
+			errors = append(errors, fmt.Errorf("the password must not contain the host name %q of the FQDN %s", hostName,
+				fqdn))
+		}
+	}
+	return errors
+}
+
+// ValidateFunc returns the schema validation of the passwords against the policy.
+func (policy PasswordPolicy) ValidateFunc() schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (warnings []string, errors []error) {
+		password, ok := v.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected not nil and type of %q to be string", k)}
+		}
+		return nil, policy.Check(password)
+	}
+}
@@ -0,0 +1,62 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	t.Run("check password policy", func(t *testing.T) {
+		var policyTests = []struct {
+			policy      PasswordPolicy
+			password    string
+			fqdns       []string
+			expectedErr string
+		}{
+			{VcenterPasswordPolicy, "VMware123!VMware123!x", nil, "the password must be at most 20 characters long"},
+			{NsxPasswordPolicy, "VMware123!", nil, "the password must be at least 12 characters long"},
+			{NsxPasswordPolicy, "MyPassword123!", nil, "the password must not contain the dictionary word \"password\""},
+			{NsxEdgePasswordPolicy, "ChangeMe1234!", nil, "the password must not contain the dictionary word \"changeme\""},
+			{EsxiPasswordPolicy, "Esxi01-VMware1!", []string{"sfo01-m01-esx01.rainpole.io"}, ""},
+			{EsxiPasswordPolicy, "Sfo01-M01-Esx01!", []string{"sfo01-m01-esx01.rainpole.io"},
+				"the password must not contain the host name \"sfo01-m01-esx01\" of the FQDN sfo01-m01-esx01.rainpole.io"},
+			{EsxiPasswordPolicy, "VMware1!io", []string{"esx01.rainpole.io"}, ""},
+			// The labels of the domain are shared by all the hosts, SDDC Manager accepts them
+			{EsxiPasswordPolicy, "Rainpole123!", []string{"sfo01-m01-esx01.rainpole.io"}, ""},
+			{EsxiPasswordPolicy, "Compl3x!Pass", []string{"esx01.corp.com"}, ""},
+			{EsxiPasswordPolicy, "Compl3x!Pass", []string{"10.0.0.101"}, ""},
+			{EsxiPasswordPolicy, "VMware123|", nil, ""},
+			{NsxPasswordPolicy, "VMware123|VMware", nil, ""},
+			// The Greek capital letter iota looks like the vertical bar but is not a special symbol
+			{EsxiPasswordPolicy, "VMware123Ι", nil, "the password must contain at least one special symbol"},
+		}
+
+		for _, policyTest := range policyTests {
+			errs := policyTest.policy.Check(policyTest.password, policyTest.fqdns...)
+			if policyTest.expectedErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("%s: unexpected errors for password %s: %v", policyTest.policy.Name, policyTest.password, errs)
+				}
+				continue
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), policyTest.expectedErr) {
+				t.Errorf("%s: expected error %q for password %s, got %v", policyTest.policy.Name, policyTest.expectedErr,
+					policyTest.password, errs)
+			}
+		}
+	})
+}
+
+func TestPasswordPolicyNames(t *testing.T) {
+	t.Run("password policy names", func(t *testing.T) {
+		for _, name := range PasswordPolicyNames() {
+			if policy := PasswordPolicies()[name]; policy.Name != name {
+				t.Errorf("expected policy %s, got %s", name, policy.Name)
+			}
+		}
+	})
+}
@@ -0,0 +1,222 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/clusters"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/client/nsxt_edge_clusters"
+	"github.com/vmware/vcf-sdk-go/models"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+)
+
+// The types of the specs SDDC Manager validates, the spec of each type is the body of its validation API.
+const (
+	// SpecTypeHostCommission is a list of hosts to commission, validated with /v1/hosts/validations
+	SpecTypeHostCommission = "HOST_COMMISSION"
+	// SpecTypeClusterCreation is a cluster to add to a domain, validated with /v1/clusters/validations
+	SpecTypeClusterCreation = "CLUSTER_CREATION"
+	// SpecTypeDomainCreation is a workload domain to deploy, validated with /v1/domains/validations
+	SpecTypeDomainCreation = "DOMAIN_CREATION"
+	// SpecTypeEdgeClusterCreation is an NSX edge cluster to deploy, validated with /v1/edge-clusters/validations
+	SpecTypeEdgeClusterCreation = "EDGE_CLUSTER_CREATION"
+)
+
+// SpecTypes returns the types of the specs SDDC Manager validates.
+func SpecTypes() []string {
+	return []string{SpecTypeHostCommission, SpecTypeClusterCreation, SpecTypeDomainCreation, SpecTypeEdgeClusterCreation}
+}
+
+// validatableModel is a model of the SDK which checks its required properties and their formats.
+type validatableModel interface {
+	Validate(formats strfmt.Registry) error
+}
+
+// ValidateSpec validates the spec of the type, a JSON document in the format of the API, with SDDC Manager. The
+// properties the model of the spec does not have and the missing required properties are reported before the spec
+// is sent. The validations SDDC Manager runs in the background are polled every poll interval until their checks
+// have finished.
+func ValidateSpec(ctx context.Context, apiClient *client.VcfClient, specType, spec string, pollInterval time.Duration) (*models.Validation, error) {
+	switch specType {
+	case SpecTypeHostCommission:
+		var hostSpecs []*models.HostCommissionSpec
+		if err := decodeSpec(spec, &hostSpecs); err != nil {
+			return nil, err
+		}
+		for i, hostSpec := range hostSpecs {
+			if err := hostSpec.Validate(strfmt.Default); err != nil {
+				return nil, fmt.Errorf("invalid spec of host %d: %w", i, err)
+			}
+		}
+		return validateHostCommissionSpecs(ctx, apiClient, hostSpecs, pollInterval)
+	case SpecTypeClusterCreation:
+		clusterSpec := &models.ClusterCreationSpec{}
+		if err := decodeModelSpec(spec, clusterSpec); err != nil {
+			return nil, err
+		}
+		params := clusters.NewValidateClusterCreationSpecParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithClusterCreationSpec(clusterSpec)
+		result, err := apiClient.Clusters.ValidateClusterCreationSpec(params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Payload, nil
+	case SpecTypeDomainCreation:
+		domainSpec := &models.DomainCreationSpec{}
+		if err := decodeModelSpec(spec, domainSpec); err != nil {
+			return nil, err
+		}
+		params := domains.NewValidateDomainCreationSpecParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithDomainCreationSpec(domainSpec)
+		result, err := apiClient.Domains.ValidateDomainCreationSpec(params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Payload, nil
+	case SpecTypeEdgeClusterCreation:
+		edgeClusterSpec := &models.EdgeClusterCreationSpec{}
+		if err := decodeModelSpec(spec, edgeClusterSpec); err != nil {
+			return nil, err
+		}
+		return validateEdgeClusterSpec(ctx, apiClient, edgeClusterSpec, pollInterval)
+	}
+	return nil, fmt.Errorf("unknown spec type %s", specType)
+}
+
+func validateHostCommissionSpecs(ctx context.Context, apiClient *client.VcfClient, hostSpecs []*models.HostCommissionSpec,
+	pollInterval time.Duration) (*models.Validation, error) {
+	params := hosts.NewValidateHostCommissionSpecParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithHostCommissionSpecs(hostSpecs)
+	ok, accepted, err := apiClient.Hosts.ValidateHostCommissionSpec(params)
+	if err != nil {
+		return nil, err
+	}
+	var validation *models.Validation
+	if accepted != nil {
+		validation = accepted.Payload
+	} else if ok != nil {
+		validation = ok.Payload
+	}
+	return waitForValidation(ctx, validation, pollInterval, func(id string) (*models.Validation, error) {
+		result, err := apiClient.Hosts.GetHostCommissionValidationByID(
+			hosts.NewGetHostCommissionValidationByIDParamsWithContext(ctx).
+				WithTimeout(constants.DefaultVcfApiCallTimeout).
+				WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		return result.Payload, nil
+	})
+}
+
+func validateEdgeClusterSpec(ctx context.Context, apiClient *client.VcfClient, edgeClusterSpec *models.EdgeClusterCreationSpec,
+	pollInterval time.Duration) (*models.Validation, error) {
+	params := nsxt_edge_clusters.NewValidateEdgeClusterCreationSpecParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithEdgeCreationSpec(edgeClusterSpec)
+	ok, accepted, err := apiClient.NSXTEdgeClusters.ValidateEdgeClusterCreationSpec(params)
+	if err != nil {
+		return nil, err
+	}
+	var validation *models.Validation
+	if accepted != nil {
+		validation = accepted.Payload
+	} else if ok != nil {
+		validation = ok.Payload
+	}
+	return waitForValidation(ctx, validation, pollInterval, func(id string) (*models.Validation, error) {
+		result, err := apiClient.NSXTEdgeClusters.GetEdgeClusterValidationByID(
+			nsxt_edge_clusters.NewGetEdgeClusterValidationByIDParamsWithContext(ctx).
+				WithTimeout(constants.DefaultVcfApiCallTimeout).
+				WithID(id))
+		if err != nil {
+			return nil, err
+		}
+		return result.Payload, nil
+	})
+}
+
+// waitForValidation reads the validation every poll interval until its execution and its checks have finished.
+func waitForValidation(ctx context.Context, validation *models.Validation, pollInterval time.Duration,
+	getValidation func(id string) (*models.Validation, error)) (*models.Validation, error) {
+	if validation == nil {
+		return nil, fmt.Errorf("the validation did not return a result")
+	}
+	for validation.ExecutionStatus == "IN_PROGRESS" || !HaveValidationChecksFinished(validation.ValidationChecks) {
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("validation %s did not complete: %w", validation.ID, ctx.Err())
+		}
+		var err error
+		if validation, err = getValidation(validation.ID); err != nil {
+			return nil, err
+		}
+	}
+	return validation, nil
+}
+
+// decodeModelSpec decodes the spec into the model and checks its required properties.
+func decodeModelSpec(spec string, model validatableModel) error {
+	if err := decodeSpec(spec, model); err != nil {
+		return err
+	}
+	if err := model.Validate(strfmt.Default); err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+	return nil
+}
+
+// decodeSpec decodes the JSON spec, the properties the model does not have are rejected, e.g. a misspelled one.
+func decodeSpec(spec string, model interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(spec)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(model); err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+	return nil
+}
+
+// FlattenValidationChecks returns the checks of the validation and their nested checks, in the order they are run.
+func FlattenValidationChecks(validationChecks []*models.ValidationCheck) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(validationChecks))
+	for _, validationCheck := range validationChecks {
+		if validationCheck == nil {
+			continue
+		}
+		var errorMessages []string
+		if validationCheck.ErrorResponse != nil {
+			if validationCheck.ErrorResponse.Message != "" {
+				errorMessages = append(errorMessages, validationCheck.ErrorResponse.Message)
+			}
+			for _, nestedError := range validationCheck.ErrorResponse.NestedErrors {
+				if nestedError != nil && nestedError.Message != "" {
+					errorMessages = append(errorMessages, nestedError.Message)
+				}
+			}
+		}
+		result = append(result, map[string]interface{}{
+			"description":    validationCheck.Description,
+			"result_status":  validationCheck.ResultStatus,
+			"severity":       validationCheck.Severity,
+			"error_messages": errorMessages,
+		})
+		result = append(result, FlattenValidationChecks(validationCheck.NestedValidationChecks)...)
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+func TestDecodeModelSpec(t *testing.T) {
+	t.Run("decode model spec", func(t *testing.T) {
+		var decodeTests = []struct {
+			spec        string
+			expectedErr string
+		}{
+			{`{"domainName": "sfo-w01", "vcenterSpecs": {}, "computeSpec": {}}`, `unknown field "vcenterSpecs"`},
+			{`{"domainName": "sfo-w01"`, "unexpected EOF"},
+			{`{"domainName": "sfo-w01"}`, "computeSpec in body is required"},
+		}
+
+		for _, decodeTest := range decodeTests {
+			err := decodeModelSpec(decodeTest.spec, &models.DomainCreationSpec{})
+			if err == nil || !strings.Contains(err.Error(), decodeTest.expectedErr) {
+				t.Errorf("%s: expected error %q, got %v", decodeTest.spec, decodeTest.expectedErr, err)
+			}
+		}
+	})
+}
+
+func TestFlattenValidationChecks(t *testing.T) {
+	t.Run("flatten validation checks", func(t *testing.T) {
+		checks := []*models.ValidationCheck{{
+			Description:  "Validate the hosts",
+			ResultStatus: "FAILED",
+			Severity:     "ERROR",
+			NestedValidationChecks: []*models.ValidationCheck{{
+				Description:  "Validate esxi-1",
+				ResultStatus: "FAILED",
+				Severity:     "ERROR",
+				ErrorResponse: &models.Error{
+					Message:      "esxi-1 is not reachable",
+					NestedErrors: []*models.Error{{Message: "Cannot resolve esxi-1"}},
+				},
+			}},
+		}}
+
+		flattened := FlattenValidationChecks(checks)
+		if len(flattened) != 2 {
+			t.Fatalf("expected 2 checks, got %d", len(flattened))
+		}
+		if flattened[0]["description"] != "Validate the hosts" || len(flattened[0]["error_messages"].([]string)) > 0 {
+			t.Errorf("unexpected first check %v", flattened[0])
+		}
+		expectedMessages := []string{"esxi-1 is not reachable", "Cannot resolve esxi-1"}
+		if !reflect.DeepEqual(flattened[1]["error_messages"], expectedMessages) {
+			t.Errorf("expected messages %v, got %v", expectedMessages, flattened[1]["error_messages"])
+		}
+	})
+}
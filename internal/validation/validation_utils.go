@@ -5,66 +5,37 @@
 package validation
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net/netip"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/vmware/vcf-sdk-go/client/clusters"
 	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/client/nsxt_edge_clusters"
 	"github.com/vmware/vcf-sdk-go/models"
 )
 
 func ValidatePassword(v interface{}, k string) (warnings []string, errors []error) {
-	var specialSymbols = []rune{'\'', '!', '"', '#', '$', '%', '&', '(', ')', '*', '+', '-', '.', '/', ':', ';', '<', '=', '>', '?', '@', '[', '\\', ']', '^', '_', '`', '{', 'Ι', '}', '~'}
-	return validatePasswordInternal(v, k, specialSymbols, 8)
+	return DefaultPasswordPolicy.ValidateFunc()(v, k)
 }
 
-func ValidateNsxEdgePassword(v interface{}, k string) (warnings []string, errors []error) {
-	var specialSymbols = []rune{'!', '@', '^', '=', '*', '+'}
-	return validatePasswordInternal(v, k, specialSymbols, 12)
+// ValidateLocalAccountPassword checks the password of the admin@local account of SDDC Manager, at least 12 characters long.
+func ValidateLocalAccountPassword(v interface{}, k string) (warnings []string, errors []error) {
+	return LocalAccountPasswordPolicy.ValidateFunc()(v, k)
 }
 
-func validatePasswordInternal(v interface{}, k string, specialSymbols []rune, minLength int) (warnings []string, errors []error) {
-	password, ok := v.(string)
-	if !ok {
-		errors = append(errors, fmt.Errorf("expected not nil and type of %q to be string", k))
-		return
-	}
-	var containsUpperCase, containsLowerCase, containsDigit, containsSymbol bool
-	for _, char := range password {
-		if !containsLowerCase && unicode.IsLower(char) {
-			containsLowerCase = true
-		} else if !containsUpperCase && unicode.IsUpper(char) {
-			containsUpperCase = true
-		} else if !containsDigit && unicode.IsDigit(char) {
-			containsDigit = true
-		}
-	}
-	for _, symbol := range specialSymbols {
-		if strings.ContainsRune(password, symbol) {
-			containsSymbol = true
-			break
-		}
-	}
-	if len(password) < minLength {
-		errors = append(errors, fmt.Errorf("the password must be at least %d characters long", minLength))
-	}
-	if !containsLowerCase {
-		errors = append(errors, fmt.Errorf("the password must contain at least one lower case letter"))
-	}
-	if !containsUpperCase {
-		errors = append(errors, fmt.Errorf("the password must contain at least one upper case letter"))
-	}
-	if !containsDigit {
-		errors = append(errors, fmt.Errorf("the password must contain at least one digit"))
-	}
-	if !containsSymbol {
-		errors = append(errors, fmt.Errorf("the password must contain at least one special symbol"))
-	}
-	return
+func ValidateNsxEdgePassword(v interface{}, k string) (warnings []string, errors []error) {
+	return NsxEdgePasswordPolicy.ValidateFunc()(v, k)
 }
 
 func ValidateSddcId(v interface{}, k string) (warnings []string, errors []error) {
@@ -86,6 +57,23 @@ func ValidateSddcId(v interface{}, k string) (warnings []string, errors []error)
 	return
 }
 
+func ValidatePositiveDuration(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected not nil and type of %q to be string", k))
+		return
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid duration, e.g. 24h or 90m", value))
+		return
+	}
+	if duration <= 0 {
+		errors = append(errors, fmt.Errorf("the duration must be positive"))
+	}
+	return
+}
+
 func ValidateParsingFloatToInt(v interface{}, k string) (warnings []string, errors []error) {
 	floatNum := v.(float64)
 	var intNum = int(floatNum)
@@ -157,6 +145,41 @@ func ValidateCidrIPv4AddressSchema(i interface{}, k string) (_ []string, errors
 	}
 }
 
+// ValidatePemCertificateSchema checks that the value is a PEM encoded X.509 certificate.
+func ValidatePemCertificateSchema(i interface{}, k string) (_ []string, errors []error) {
+	certificate, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return nil, errors
+	}
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, []error{fmt.Errorf("expected %s to be a PEM encoded certificate", k)}
+	}
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return nil, []error{fmt.Errorf("cannot parse the certificate of %s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// licenseKeyPattern matches the license keys of the VCF products, 5 groups of 5 alphanumeric characters,
+// e.g. XX0XX-XX0XX-XX0XX-XX0XX-XX0XX.
+var licenseKeyPattern = regexp.MustCompile(`^[A-Z0-9]{5}(-[A-Z0-9]{5}){4}$`)
+
+// ValidateLicenseKey checks that the value has the format of a license key.
+func ValidateLicenseKey(i interface{}, k string) (_ []string, errors []error) {
+	licenseKey, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return nil, errors
+	}
+	if !licenseKeyPattern.MatchString(licenseKey) {
+		return nil, []error{fmt.Errorf("expected %s to be a license key of 5 groups of 5 upper case letters or digits "+
+			"separated by hyphens", k)}
+	}
+	return nil, nil
+}
+
 func ConvertVcfErrorToDiag(err interface{}) diag.Diagnostics {
 	if err == nil {
 		return nil
@@ -173,6 +196,14 @@ func ConvertVcfErrorToDiag(err interface{}) diag.Diagnostics {
 	if ok {
 		return convertVcfErrorsToDiagErrors(createDomainBadRequest.Payload)
 	}
+	hostsBadRequest, ok := err.(*hosts.ValidateHostCommissionSpecBadRequest)
+	if ok {
+		return convertVcfErrorsToDiagErrors(hostsBadRequest.Payload)
+	}
+	edgeClustersBadRequest, ok := err.(*nsxt_edge_clusters.ValidateEdgeClusterCreationSpecBadRequest)
+	if ok {
+		return convertVcfErrorsToDiagErrors(edgeClustersBadRequest.Payload)
+	}
 
 	return diag.FromErr(err.(error))
 }
@@ -231,7 +262,9 @@ func convertValidationChecksToDiagErrors(validationChecks []*models.ValidationCh
 	for _, validationCheck := range validationChecks {
 		if validationCheck.Severity == "ERROR" || validationCheck.ResultStatus != "SUCCEEDED" {
 			var validationErrorDetail string
-			if len(validationCheck.ErrorResponse.NestedErrors) > 0 {
+			if validationCheck.ErrorResponse == nil {
+				validationErrorDetail = validationCheck.ResultStatus
+			} else if len(validationCheck.ErrorResponse.NestedErrors) > 0 {
 				for _, nestedError := range validationCheck.ErrorResponse.NestedErrors {
 					validationErrorDetail += nestedError.Message + "\n"
 				}
@@ -251,6 +284,68 @@ func convertValidationChecksToDiagErrors(validationChecks []*models.ValidationCh
 	return result
 }
 
+// AttachAttributePaths sets the attribute path of the diagnostics without one to the path of the configured value
+// their summary or detail mentions, e.g. the hostname of a host, so that the offending argument is shown with the error.
+// The longest value is matched first, a value only matches as a whole word.
+func AttachAttributePaths(diags diag.Diagnostics, paths map[string]cty.Path) diag.Diagnostics {
+	values := make([]string, 0, len(paths))
+	for value := range paths {
+		if len(value) > 0 {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+
+	for i := range diags {
+		if len(diags[i].AttributePath) > 0 {
+			continue
+		}
+		text := diags[i].Summary + "\n" + diags[i].Detail
+		for _, value := range values {
+			if containsWord(text, value) {
+				diags[i].AttributePath = paths[value]
+				break
+			}
+		}
+	}
+	return diags
+}
+
+func containsWord(text, word string) bool {
+	isWordCharacter := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' || r == '_'
+	}
+	for offset := 0; offset < len(text); {
+		index := strings.Index(text[offset:], word)
+		if index < 0 {
+			return false
+		}
+		start := offset + index
+		end := start + len(word)
+		before, after := ' ', ' '
+		if start > 0 {
+			before = rune(text[start-1])
+		}
+		if end < len(text) {
+			after = rune(text[end])
+		}
+		// A trailing period ends a sentence rather than continuing a hostname or an address
+		if after == '.' && (end+1 == len(text) || unicode.IsSpace(rune(text[end+1]))) {
+			after = ' '
+		}
+		if !isWordCharacter(before) && !isWordCharacter(after) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
 func ConvertCertificateValidationsResultToDiag(validationTask *models.CertificateValidationTask) diag.Diagnostics {
 	if validationTask == nil || validationTask.Validations == nil {
 		return diag.FromErr(fmt.Errorf("provided certificate validation task is nil"))
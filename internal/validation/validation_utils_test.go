@@ -5,9 +5,19 @@
 package validation
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 func TestValidatePassword(t *testing.T) {
@@ -86,6 +96,34 @@ func TestValidateNsxEdgePassword(t *testing.T) {
 	})
 }
 
+func TestValidateLocalAccountPassword(t *testing.T) {
+	var passwordTests = []struct {
+		password    string
+		expectedErr string
+	}{
+		{"VMware1!", "the password must be at least 12 characters long"},
+		{"VMware123!VMware", ""},
+		{"VMware123-VMware", "the password must contain at least one special symbol"},
+	}
+
+	for _, passTest := range passwordTests {
+		_, err := ValidateLocalAccountPassword(passTest.password, "")
+		if len(passTest.expectedErr) == 0 {
+			if len(err) > 0 {
+				t.Errorf("failed. Unexpected error for password %s : %s", passTest.password, err[0].Error())
+			}
+			continue
+		}
+		if len(err) == 0 {
+			t.Errorf("failed. expected one error for password %s, but got zero", passTest.password)
+			continue
+		}
+		if !strings.Contains(err[0].Error(), passTest.expectedErr) {
+			t.Errorf("failed. Unexpected error for password %s : %s, expected %s", passTest.password, err[0].Error(), passTest.expectedErr)
+		}
+	}
+}
+
 func TestValidateSddcId(t *testing.T) {
 	t.Run("Validate sddc Id", func(t *testing.T) {
 		var sddcIdTests = []struct {
@@ -122,6 +160,36 @@ func TestValidateSddcId(t *testing.T) {
 	})
 }
 
+func TestValidatePositiveDuration(t *testing.T) {
+	var durationTests = []struct {
+		duration    string
+		expectedErr string
+	}{
+		{"24h", ""},
+		{"90m", ""},
+		{"1d", "is not a valid duration"},
+		{"-1h", "the duration must be positive"},
+		{"0s", "the duration must be positive"},
+	}
+
+	for _, durationTest := range durationTests {
+		_, err := ValidatePositiveDuration(durationTest.duration, "")
+		if len(durationTest.expectedErr) == 0 {
+			if len(err) > 0 {
+				t.Errorf("failed. Unexpected error for duration %s : %s", durationTest.duration, err[0].Error())
+			}
+			continue
+		}
+		if len(err) == 0 {
+			t.Errorf("failed. expected one error for duration %s, but got zero", durationTest.duration)
+			continue
+		}
+		if !strings.Contains(err[0].Error(), durationTest.expectedErr) {
+			t.Errorf("failed. Unexpected error for duration %s : %s, expected %s", durationTest.duration, err[0].Error(), durationTest.expectedErr)
+		}
+	}
+}
+
 func TestValidateParsingFloatToInt(t *testing.T) {
 	var testFloatNotInt = 3.14
 	var testFloatInt float64 = 3
@@ -176,6 +244,69 @@ func TestValidateCidrIPv4AddressSchema(t *testing.T) {
 	})
 }
 
+func TestValidatePemCertificateSchema(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	var certificateTests = []struct {
+		certificate interface{}
+		expectError bool
+	}{
+		{certificate, false},
+		{"random text", true},
+		{privateKey, true},
+		{"-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n", true},
+		{42, true},
+	}
+
+	for _, certificateTest := range certificateTests {
+		_, errs := ValidatePemCertificateSchema(certificateTest.certificate, "cert_chain.0")
+		if certificateTest.expectError && len(errs) == 0 {
+			t.Errorf("expected an error for %v", certificateTest.certificate)
+		}
+		if !certificateTest.expectError && len(errs) > 0 {
+			t.Errorf("unexpected error %v", errs)
+		}
+	}
+}
+
+func TestValidateLicenseKey(t *testing.T) {
+	var licenseKeyTests = []struct {
+		licenseKey  interface{}
+		expectError bool
+	}{
+		{"AB1CD-2EF3G-H4IJ5-K6LM7-N8OP9", false},
+		{"ab1cd-2ef3g-h4ij5-k6lm7-n8op9", true},
+		{"AB1CD-2EF3G-H4IJ5-K6LM7", true},
+		{"AB1CD2EF3GH4IJ5K6LM7N8OP9", true},
+		{"AB1CD-2EF3G-H4IJ5-K6LM7-N8OP9 ", true},
+		{42, true},
+	}
+
+	for _, licenseKeyTest := range licenseKeyTests {
+		_, errs := ValidateLicenseKey(licenseKeyTest.licenseKey, "key")
+		if licenseKeyTest.expectError && len(errs) == 0 {
+			t.Errorf("expected an error for license key %v", licenseKeyTest.licenseKey)
+		}
+		if !licenseKeyTest.expectError && len(errs) > 0 {
+			t.Errorf("unexpected errors for license key %v: %v", licenseKeyTest.licenseKey, errs)
+		}
+	}
+}
+
 func TestValidateIpv4Address(t *testing.T) {
 	t.Run("Validate ipv4 address", func(t *testing.T) {
 		var ipTests = []struct {
@@ -227,3 +358,31 @@ func TestIsEmpty(t *testing.T) {
 		}
 	})
 }
+
+func TestAttachAttributePaths(t *testing.T) {
+	t.Run("attach attribute paths", func(t *testing.T) {
+		paths := map[string]cty.Path{
+			"10.0.0.1":   cty.GetAttrPath("network").IndexInt(0).GetAttr("gateway"),
+			"10.0.0.10":  cty.GetAttrPath("host").IndexInt(1).GetAttr("ip_address_private").IndexInt(0).GetAttr("ip_address"),
+			"esxi-1":     cty.GetAttrPath("host").IndexInt(0).GetAttr("hostname"),
+			"esxi-1.lab": cty.GetAttrPath("host").IndexInt(2).GetAttr("hostname"),
+		}
+		var attachTests = []struct {
+			detail       string
+			expectedPath cty.Path
+		}{
+			{"ESXi host 10.0.0.10 is not reachable", paths["10.0.0.10"]},
+			{"Gateway 10.0.0.1 does not respond.", paths["10.0.0.1"]},
+			{"Cannot resolve esxi-1.lab", paths["esxi-1.lab"]},
+			{"Cannot resolve esxi-1.", paths["esxi-1"]},
+			{"Cannot resolve esxi-11", nil},
+		}
+
+		for _, attachTest := range attachTests {
+			diags := AttachAttributePaths(diag.Diagnostics{{Severity: diag.Error, Summary: "check", Detail: attachTest.detail}}, paths)
+			if !reflect.DeepEqual(diags[0].AttributePath, attachTest.expectedPath) {
+				t.Errorf("%q: expected path %v, got %v", attachTest.detail, attachTest.expectedPath, diags[0].AttributePath)
+			}
+		}
+	})
+}
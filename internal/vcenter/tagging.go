@@ -0,0 +1,194 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: MPL-2.0
+
+package vcenter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+)
+
+const (
+	sessionPath     = "/api/session"
+	categoriesPath  = "/api/cis/tagging/category"
+	tagsPath        = "/api/cis/tagging/tag"
+	sessionIdHeader = "vmware-api-session-id"
+)
+
+// ErrNotFound is returned when the tag or category does not exist in vCenter Server.
+var ErrNotFound = errors.New("not found")
+
+// TagCategory is a tag category of the vCenter Server tagging API.
+type TagCategory struct {
+	ID              string   `json:"id,omitempty"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Cardinality     string   `json:"cardinality"`
+	AssociableTypes []string `json:"associable_types"`
+}
+
+// Tag is a tag of the vCenter Server tagging API.
+type Tag struct {
+	ID          string `json:"id,omitempty"`
+	CategoryID  string `json:"category_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TaggingClient calls the tagging API of the vCenter Server of a domain in a session of the SSO administrator
+// SDDC Manager manages the credential of.
+type TaggingClient struct {
+	host      string
+	sessionId string
+}
+
+// NewTaggingClient opens a session on the vCenter Server of the domain. The session must be closed with Logout.
+func NewTaggingClient(ctx context.Context, apiClient *client.VcfClient, domainId string) (*TaggingClient, error) {
+	params := domains.NewGetDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	params.ID = domainId
+	domainResult, err := apiClient.Domains.GetDomain(params)
+	if err != nil {
+		return nil, err
+	}
+	domain := domainResult.Payload
+	if domain == nil || len(domain.VCENTERS) == 0 || len(domain.VCENTERS[0].Fqdn) == 0 {
+		return nil, fmt.Errorf("vcenter for domain %s not found", domainId)
+	}
+	host := domain.VCENTERS[0].Fqdn
+
+	credential, err := credentials.GetResourceCredential(ctx, apiClient, credentials.ResourceTypePsc, host,
+		"administrator@"+domain.SSOName, "SSO")
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+sessionPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.SetBasicAuth(*credential.Username, credential.Password)
+	taggingClient := &TaggingClient{host: host}
+	var sessionId string
+	if err := taggingClient.send(request, &sessionId); err != nil {
+		return nil, fmt.Errorf("cannot log in vCenter Server %s: %w", host, err)
+	}
+	taggingClient.sessionId = sessionId
+
+	return taggingClient, nil
+}
+
+// Logout closes the session, a failure is only logged.
+func (c *TaggingClient) Logout(ctx context.Context) {
+	if err := c.do(ctx, http.MethodDelete, sessionPath, nil, nil); err != nil {
+		log.Printf("[WARN] Cannot log out of vCenter Server %s: %s", c.host, err)
+	}
+}
+
+func (c *TaggingClient) CreateCategory(ctx context.Context, category TagCategory) (string, error) {
+	var id string
+	err := c.do(ctx, http.MethodPost, categoriesPath, category, &id)
+	return id, err
+}
+
+func (c *TaggingClient) GetCategory(ctx context.Context, id string) (*TagCategory, error) {
+	category := &TagCategory{}
+	if err := c.do(ctx, http.MethodGet, categoriesPath+"/"+url.PathEscape(id), nil, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory updates the name and the description of the category.
+func (c *TaggingClient) UpdateCategory(ctx context.Context, id, name, description string) error {
+	return c.do(ctx, http.MethodPatch, categoriesPath+"/"+url.PathEscape(id),
+		map[string]string{"name": name, "description": description}, nil)
+}
+
+func (c *TaggingClient) DeleteCategory(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, categoriesPath+"/"+url.PathEscape(id), nil, nil)
+}
+
+func (c *TaggingClient) CreateTag(ctx context.Context, tag Tag) (string, error) {
+	var id string
+	err := c.do(ctx, http.MethodPost, tagsPath, tag, &id)
+	return id, err
+}
+
+func (c *TaggingClient) GetTag(ctx context.Context, id string) (*Tag, error) {
+	tag := &Tag{}
+	if err := c.do(ctx, http.MethodGet, tagsPath+"/"+url.PathEscape(id), nil, tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// UpdateTag updates the name and the description of the tag.
+func (c *TaggingClient) UpdateTag(ctx context.Context, id, name, description string) error {
+	return c.do(ctx, http.MethodPatch, tagsPath+"/"+url.PathEscape(id),
+		map[string]string{"name": name, "description": description}, nil)
+}
+
+func (c *TaggingClient) DeleteTag(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, tagsPath+"/"+url.PathEscape(id), nil, nil)
+}
+
+func (c *TaggingClient) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(content)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, "https://"+c.host+path, payload)
+	if err != nil {
+		return err
+	}
+	request.Header.Set(sessionIdHeader, c.sessionId)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.send(request, result)
+}
+
+func (c *TaggingClient) send(request *http.Request, result interface{}) error {
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s %s: %w", request.Method, request.URL.Path, ErrNotFound)
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s: %s", request.Method, request.URL.Path, response.Status, string(content))
+	}
+	if result != nil && len(content) > 0 {
+		return json.Unmarshal(content, result)
+	}
+
+	return nil
+}
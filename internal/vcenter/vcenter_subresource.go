@@ -48,7 +48,7 @@ func VCSubresourceSchema() *schema.Resource {
 				Required:     true,
 				Sensitive:    true,
 				Description:  "root password for the vCenter Server Appliance (8-20 characters)",
-				ValidateFunc: validationUtils.ValidatePassword,
+				ValidateFunc: validationUtils.VcenterPasswordPolicy.ValidateFunc(),
 			},
 			"vm_size": {
 				Type:        schema.TypeString,